@@ -1,82 +1,119 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 
 	"github.com/delivery/internal/generated/servers"
 	_ "github.com/lib/pq"
 
 	"github.com/delivery/cmd"
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
 	"github.com/delivery/internal/adapters/out/postgres/courierrepo"
+	"github.com/delivery/internal/adapters/out/postgres/inboxrepo"
+	"github.com/delivery/internal/adapters/out/postgres/offerrepo"
+	"github.com/delivery/internal/adapters/out/postgres/ordereventrepo"
 	"github.com/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/delivery/internal/adapters/out/postgres/viewrepo"
+	"github.com/delivery/internal/adapters/out/postgres/zonerepo"
+	"github.com/delivery/internal/core/application/usecases/commands"
 	"github.com/delivery/internal/pkg/errs"
-	"github.com/joho/godotenv"
+	"github.com/delivery/internal/pkg/lifecycle"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/robfig/cron/v3"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
 func main() {
-	config := getConfigs()
+	if len(os.Args) > 2 && os.Args[1] == "config" && os.Args[2] == "print" {
+		printConfig(os.Args[3:])
+		return
+	}
+
+	config := getConfigs(os.Args[1:])
+	dbPort := strconv.Itoa(config.Db.Port)
 
 	connectionString, err := makeConnectionString(
-		config.DbHost,
-		config.DbPort,
-		config.DbUser,
-		config.DbPassword,
-		config.DbName,
-		config.DbSslMode)
+		config.Db.Host,
+		dbPort,
+		config.Db.User,
+		config.Db.Password,
+		config.Db.Name,
+		config.Db.SslMode)
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
-	crateDbIfNotExists(config.DbHost,
-		config.DbPort,
-		config.DbUser,
-		config.DbPassword,
-		config.DbName,
-		config.DbSslMode)
-	gormDb := mustGormOpen(connectionString)
+	crateDbIfNotExists(config.Db.Host,
+		dbPort,
+		config.Db.User,
+		config.Db.Password,
+		config.Db.Name,
+		config.Db.SslMode)
+	gormDb := mustGormOpen(connectionString, config.Db)
+	viewsExisted := gormDb.Migrator().HasTable(&viewrepo.CourierViewDTO{})
 	mustAutoMigrate(gormDb)
 
 	compositionRoot := cmd.NewCompositionRoot(
 		config,
 		gormDb,
 	)
+	if !viewsExisted {
+		mustRebuildReadModels(compositionRoot)
+	}
+
+	manager := lifecycle.NewManager(config.Shutdown.Timeout, config.Shutdown.CloseGrace)
 
 	startKafkaConsumer(compositionRoot)
-	startCronJobs(compositionRoot)
-	startWebServer(compositionRoot, config.HttpPort)
-}
+	scheduler := startCronJobs(compositionRoot, config.Jobs)
+	e := startWebServer(compositionRoot, config.Http.Port, manager)
+
+	// the order matters: stop producing work first, then drain it, then release the infrastructure
+	manager.OnStop("cron jobs", func(ctx context.Context) error {
+		return stopCronJobs(ctx, scheduler)
+	})
+	manager.OnClose("job locker", compositionRoot.Jobs.Locker.Close)
+	manager.OnClose("kafka consumer", compositionRoot.KafkaConsumer.Close)
+	manager.OnStop("http server", e.Shutdown)
+	manager.OnStop("domain event handlers", compositionRoot.Mediatr.Close)
+	manager.OnClose("kafka producer", compositionRoot.KafkaProducer.Close)
+	manager.OnClose("order assigned producer", compositionRoot.OrderAssignedProducer.Close)
+	manager.OnClose("courier location producer", compositionRoot.CourierLocationProducer.Close)
+	manager.OnClose("supply demand producer", compositionRoot.SupplyDemandProducer.Close)
+	manager.OnClose("kafka broker checker", compositionRoot.Clients.KafkaBrokerChecker.Close)
+	manager.OnClose("geo client", compositionRoot.Clients.GeoClient.Close)
+	manager.OnClose("database", func() error {
+		return closeDb(gormDb)
+	})
 
-func getConfigs() *cmd.Config {
-	return &cmd.Config{
-		HttpPort:                  goDotEnvVariable("HTTP_PORT"),
-		DbHost:                    goDotEnvVariable("DB_HOST"),
-		DbPort:                    goDotEnvVariable("DB_PORT"),
-		DbUser:                    goDotEnvVariable("DB_USER"),
-		DbPassword:                goDotEnvVariable("DB_PASSWORD"),
-		DbName:                    goDotEnvVariable("DB_NAME"),
-		DbSslMode:                 goDotEnvVariable("DB_SSLMODE"),
-		GeoServiceGrpcHost:        goDotEnvVariable("GEO_SERVICE_GRPC_HOST"),
-		KafkaHost:                 goDotEnvVariable("KAFKA_HOST"),
-		KafkaConsumerGroup:        goDotEnvVariable("KAFKA_CONSUMER_GROUP"),
-		KafkaBasketConfirmedTopic: goDotEnvVariable("KAFKA_BASKET_CONFIRMED_TOPIC"),
-		KafkaOrderChangedTopic:    goDotEnvVariable("KAFKA_ORDER_CHANGED_TOPIC"),
+	if err := manager.Run(context.Background()); err != nil {
+		log.Fatalf("Shutdown finished with errors: %v", err)
 	}
+	log.Println("Shutdown completed")
 }
 
-func goDotEnvVariable(key string) string {
-	err := godotenv.Load(".env")
+func getConfigs(args []string) *cmd.Config {
+	config, err := cmd.LoadConfig(args)
 	if err != nil {
-		log.Fatalf("Error loading .env file")
+		log.Fatalf("Invalid configuration:\n%v", err)
+	}
+	return config
+}
+
+// printConfig implements `delivery config print`
+func printConfig(args []string) {
+	config := getConfigs(args)
+	if err := cmd.WriteConfig(os.Stdout, *config); err != nil {
+		log.Fatalf("failed to print config: %v", err)
 	}
-	return os.Getenv(key)
 }
 
 func crateDbIfNotExists(host string, port string, user string,
@@ -126,7 +163,7 @@ func makeConnectionString(host string, port string, user string,
 		sslMode), nil
 }
 
-func mustGormOpen(connectionString string) *gorm.DB {
+func mustGormOpen(connectionString string, dbConfig cmd.DbConfig) *gorm.DB {
 	pgGorm, err := gorm.Open(postgres.New(
 		postgres.Config{
 			DSN:                  connectionString,
@@ -136,6 +173,15 @@ func mustGormOpen(connectionString string) *gorm.DB {
 	if err != nil {
 		log.Fatalf("connection to postgres through gorm\n: %s", err)
 	}
+
+	sqlDb, err := pgGorm.DB()
+	if err != nil {
+		log.Fatalf("getting postgres connection pool: %s", err)
+	}
+	sqlDb.SetMaxOpenConns(dbConfig.MaxOpenConns)
+	sqlDb.SetMaxIdleConns(dbConfig.MaxIdleConns)
+	sqlDb.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+
 	return pgGorm
 }
 
@@ -155,38 +201,121 @@ func mustAutoMigrate(db *gorm.DB) {
 		log.Fatalf("Ошибка миграции: %v", err)
 	}
 
+	err = db.AutoMigrate(&offerrepo.OfferDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&zonerepo.ZoneDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&inboxrepo.ProcessedMessageDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&auditrepo.EntryDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&ordereventrepo.EventDTO{}, &ordereventrepo.SnapshotDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+	err = db.AutoMigrate(&viewrepo.CourierViewDTO{}, &viewrepo.OrderViewDTO{})
+	if err != nil {
+		log.Fatalf("Ошибка миграции: %v", err)
+	}
+
+}
+
+// mustRebuildReadModels fills the views just created from the orders and couriers stored before them
+func mustRebuildReadModels(compositionRoot cmd.CompositionRoot) {
+	command, err := commands.NewRebuildReadModelsCommand()
+	if err != nil {
+		log.Fatalf("failed to create rebuild read models command: %v", err)
+	}
+	err = compositionRoot.CommandHandlers.RebuildReadModelsCommandHandler.Handle(context.Background(), command)
+	if err != nil {
+		log.Fatalf("failed to rebuild read models: %v", err)
+	}
 }
 
-func startWebServer(compositionRoot cmd.CompositionRoot, port string) {
+func startWebServer(compositionRoot cmd.CompositionRoot, port int, manager *lifecycle.Manager) *echo.Echo {
 	e := echo.New()
+	e.HTTPErrorHandler = compositionRoot.Servers.ErrorHandler
+	e.Use(middleware.RequestID())
 
-	e.GET("/health", func(c echo.Context) error {
-		return c.String(http.StatusOK, "Healthy")
-	})
+	healthHandler := compositionRoot.Servers.HealthHandler
+	e.GET("/health", healthHandler.Live)
+	e.GET("/health/live", healthHandler.Live)
+	e.GET("/health/ready", healthHandler.Ready)
 
+	e.Use(compositionRoot.Servers.Authorizer)
+	e.Use(compositionRoot.Servers.AuditContext)
+	e.Use(compositionRoot.Servers.RequestValidator)
 	servers.RegisterHandlers(e, compositionRoot.Servers.HttpServer)
 
-	e.Logger.Fatal(e.Start(fmt.Sprintf("0.0.0.0:%s", port)))
+	manager.Go("http server", func() error {
+		if err := e.Start(fmt.Sprintf("0.0.0.0:%d", port)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	})
+
+	return e
 }
 
-func startCronJobs(compositionRoot cmd.CompositionRoot) {
+func startCronJobs(compositionRoot cmd.CompositionRoot, jobsConfig cmd.JobsConfig) *cron.Cron {
 	c := cron.New(cron.WithSeconds())
-	_, err := c.AddJob("* * * * * *", &compositionRoot.Jobs.AssignOrderJob)
+	_, err := c.AddJob(jobsConfig.AssignOrderSchedule, compositionRoot.Jobs.AssignOrderJob)
 	if err != nil {
 		log.Fatalf("failed to add assign order job: %v", err)
 	}
-	_, err = c.AddJob("* * * * * *", &compositionRoot.Jobs.MoveCourierJob)
+	_, err = c.AddJob(jobsConfig.MoveCourierSchedule, compositionRoot.Jobs.MoveCourierJob)
 	if err != nil {
 		log.Fatalf("failed to add move courier job: %v", err)
 	}
+	_, err = c.AddJob(jobsConfig.ReassignStalledSchedule, compositionRoot.Jobs.ReassignStalledOrdersJob)
+	if err != nil {
+		log.Fatalf("failed to add reassign stalled orders job: %v", err)
+	}
+	_, err = c.AddJob(jobsConfig.SupplyDemandSchedule, compositionRoot.Jobs.SupplyDemandJob)
+	if err != nil {
+		log.Fatalf("failed to add supply demand job: %v", err)
+	}
 
 	c.Start()
+	return c
+}
+
+// stopCronJobs prevents new runs and waits for the running ones to finish
+func stopCronJobs(ctx context.Context, c *cron.Cron) error {
+	select {
+	case <-c.Stop().Done():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func startKafkaConsumer(compositionRoot cmd.CompositionRoot) {
 	go func() {
+		// consumer failure is reported through the health checks instead of killing the process
 		if err := compositionRoot.KafkaConsumer.Consume(); err != nil {
-			log.Fatalf("Kafka consumer error: %v", err)
+			log.Printf("Kafka consumer error: %v", err)
 		}
 	}()
 }
+
+func closeDb(gormDb *gorm.DB) error {
+	sqlDb, err := gormDb.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDb.Close()
+}
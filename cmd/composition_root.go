@@ -2,102 +2,175 @@ package cmd
 
 import (
 	"log"
-	"time"
 
 	"github.com/delivery/internal/adapters/in/http"
+	"github.com/delivery/internal/adapters/in/http/auth"
 	"github.com/delivery/internal/adapters/in/jobs"
 	consumer "github.com/delivery/internal/adapters/in/kafka"
+	"github.com/delivery/internal/adapters/out/clock"
+	"github.com/delivery/internal/adapters/out/courierindex"
 	"github.com/delivery/internal/adapters/out/grpc/geo"
 	producer "github.com/delivery/internal/adapters/out/kafka"
 	"github.com/delivery/internal/adapters/out/postgres"
+	"github.com/delivery/internal/adapters/out/postgres/viewrepo"
 	"github.com/delivery/internal/core/application/eventhandlers"
 	"github.com/delivery/internal/core/application/usecases/commands"
 	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/offer"
 	"github.com/delivery/internal/core/domain/model/order"
 	"github.com/delivery/internal/core/domain/service"
 	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/generated/servers"
 	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/health"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
 )
 
 type CompositionRoot struct {
-	config          *Config
-	gormDb          *gorm.DB
-	DomainServices  DomainServices
-	Repositories    Repositories
-	CommandHandlers CommandHandlers
-	QueryHandlers   QueryHandlers
-	Servers         Servers
-	Jobs            Jobs
-	KafkaConsumer   consumer.BasketConfirmedConsumer
-	KafkaProducer   ports.OrderProducer
-	EventHandler    ddd.EventHandler
-	Mediatr         ddd.Mediatr
+	config                  *Config
+	gormDb                  *gorm.DB
+	DomainServices          DomainServices
+	Repositories            Repositories
+	CommandHandlers         CommandHandlers
+	QueryHandlers           QueryHandlers
+	Servers                 Servers
+	Jobs                    Jobs
+	Clients                 Clients
+	KafkaConsumer           consumer.BasketConfirmedConsumer
+	KafkaProducer           ports.OrderProducer
+	OrderAssignedProducer   ports.OrderProducer
+	CourierLocationProducer ports.CourierProducer
+	SupplyDemandProducer    ports.SupplyDemandProducer
+	Clock                   ports.Clock
+	EventHandler            ddd.EventHandler
+	Mediatr                 ddd.Mediatr
+	Health                  health.Service
 }
 
 type DomainServices struct {
 	DispatchService service.DispatchService
+	EtaService      service.EtaService
 }
 
 type Repositories struct {
-	UnitOfWork        ports.UnitOfWork
-	OrderRepository   ports.OrderRepository
-	CourierRepository ports.CourierRepository
+	UnitOfWork          ports.UnitOfWork
+	OrderRepository     ports.OrderRepository
+	CourierRepository   ports.CourierRepository
+	ReadModelRepository ports.ReadModelRepository
+	ReadModelProjector  ports.ReadModelProjector
 }
 
 type CommandHandlers struct {
-	AssignOrderCommandHandler   commands.AssignOrderHandler
-	CreateOrderCommandHandler   commands.CreateOrderHandler
-	CreateCourierCommandHandler commands.CreateCourierHandler
-	MoveCourierCommandHandler   commands.MoveCourierHandler
+	AssignOrderCommandHandler           commands.AssignOrderHandler
+	ExpireOffersCommandHandler          commands.ExpireOffersHandler
+	CreateOrderCommandHandler           commands.CreateOrderHandler
+	CreateCourierCommandHandler         commands.CreateCourierHandler
+	MoveCourierCommandHandler           commands.MoveCourierHandler
+	UnassignOrderCommandHandler         commands.UnassignOrderHandler
+	ReassignOrderCommandHandler         commands.ReassignOrderHandler
+	ReassignStalledOrdersCommandHandler commands.ReassignStalledOrdersHandler
+	RebuildReadModelsCommandHandler     commands.RebuildReadModelsHandler
 }
 
 type QueryHandlers struct {
 	GetAllCouriersQueryHandler        queries.GetAllCouriersHandler
 	GetNotCompletedOrdersQueryHandler queries.GetAllUncompletedOrdersHandler
+	GetSupplyDemandQueryHandler       queries.GetSupplyDemandHandler
+	GetOrderTimelineQueryHandler      queries.GetOrderTimelineHandler
+	GetCourierPathQueryHandler        queries.GetCourierPathHandler
 }
 
 type Servers struct {
-	HttpServer *http.Server
+	HttpServer       *http.Server
+	HealthHandler    *http.HealthHandler
+	Authorizer       echo.MiddlewareFunc
+	AuditContext     echo.MiddlewareFunc
+	RequestValidator echo.MiddlewareFunc
+	ErrorHandler     echo.HTTPErrorHandler
+}
+
+type Clients struct {
+	GeoClient          *geo.Client
+	KafkaBrokerChecker *producer.BrokerChecker
 }
 
 type Jobs struct {
-	AssignOrderJob jobs.AssignOrderJob
-	MoveCourierJob jobs.MoveCourierJob
+	AssignOrderJob           *jobs.ExclusiveJob
+	MoveCourierJob           *jobs.ExclusiveJob
+	ReassignStalledOrdersJob *jobs.ExclusiveJob
+	SupplyDemandJob          *jobs.ExclusiveJob
+	Locker                   ports.JobLocker
 }
 
 func NewCompositionRoot(config *Config, gormDb *gorm.DB) CompositionRoot {
-	mediatr := ddd.NewMediatr()
-	unitOfWork, err := postgres.NewUnitOfWork(gormDb, mediatr)
+	mediatr, err := ddd.NewAsyncMediatr(ddd.AsyncMediatrConfig{
+		Workers:   config.Events.Workers,
+		QueueSize: config.Events.QueueSize,
+		Retry: ddd.RetryPolicy{
+			MaxAttempts: config.Events.MaxAttempts,
+			Backoff:     config.Events.RetryBackoff,
+		},
+	})
+	if err != nil {
+		log.Fatalf("failed to create mediatr: %v", err)
+	}
+	unitOfWork, err := newUnitOfWork(config.Orders, gormDb, mediatr)
 	if err != nil {
 		log.Fatalf("failed to create unit of work: %v", err)
 	}
 
 	// Services
-	dispatchService := service.NewDispatchService()
+	systemClock := clock.NewSystemClock()
+	dispatchService, err := service.NewZonedDispatchService(config.Dispatch.ZoneRules(), config.Dispatch.OfferTimeout)
+	if err != nil {
+		log.Fatalf("failed to create dispatch service: %v", err)
+	}
+	etaService, err := service.NewEtaService(config.Jobs.MoveCourierInterval())
+	if err != nil {
+		log.Fatalf("failed to create eta service: %v", err)
+	}
 
 	// Repositories
 	orderRepository := unitOfWork.OrderRepository()
 	courierRepository := unitOfWork.CourierRepository()
+	readModelRepository, err := viewrepo.NewRepository(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create read model repository: %v", err)
+	}
+	readModelProjector, err := viewrepo.NewProjector(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create read model projector: %v", err)
+	}
 
 	// Clients
-	geoClient, err := geo.NewGeoClient(config.GeoServiceGrpcHost, 5*time.Second)
+	geoClient, err := geo.NewGeoClient(config.Geo.GrpcHost, config.Geo.Timeout)
 	if err != nil {
 		log.Fatalf("failed to create geo service client: %v", err)
 	}
 
 	// Command Handlers
-	createOrderCommandHandler, err := commands.NewAddCreateOrderHandler(unitOfWork, geoClient)
+	createOrderCommandHandler, err := commands.NewAddCreateOrderHandler(unitOfWork, geoClient, systemClock)
 	if err != nil {
 		log.Fatalf("failed to create create order command handler: %v", err)
 	}
 
-	assignOrderCommandHandler, err := commands.NewAssignOrderHandler(unitOfWork, dispatchService)
+	courierIndex := service.NewCourierIndex()
+	assignOrderCommandHandler, err := newAssignOrderHandler(config.Dispatch, unitOfWork, dispatchService, etaService,
+		courierIndex, systemClock)
 	if err != nil {
 		log.Fatalf("failed to create assign order command handler: %v", err)
 	}
 
-	moveCourierCommandHandler, err := commands.NewMoveCourierHandler(unitOfWork)
+	expireOffersCommandHandler, err := commands.NewExpireOffersHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create expire offers command handler: %v", err)
+	}
+
+	moveCourierCommandHandler, err := commands.NewMoveCourierHandler(unitOfWork, etaService,
+		config.Jobs.MoveCourierInterval())
 	if err != nil {
 		log.Fatalf("failed to create move courier command handler: %v", err)
 	}
@@ -107,33 +180,141 @@ func NewCompositionRoot(config *Config, gormDb *gorm.DB) CompositionRoot {
 		log.Fatalf("failed to create create courier command handler: %v", err)
 	}
 
+	unassignOrderCommandHandler, err := commands.NewUnassignOrderHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create unassign order command handler: %v", err)
+	}
+
+	reassignOrderCommandHandler, err := commands.NewReassignOrderHandler(unitOfWork, etaService, config.Dispatch.OfferTimeout)
+	if err != nil {
+		log.Fatalf("failed to create reassign order command handler: %v", err)
+	}
+
+	acceptOfferCommandHandler, err := commands.NewAcceptOfferHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create accept offer command handler: %v", err)
+	}
+
+	declineOfferCommandHandler, err := commands.NewDeclineOfferHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create decline offer command handler: %v", err)
+	}
+
+	pickUpOrderCommandHandler, err := commands.NewPickUpOrderHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create pick up order command handler: %v", err)
+	}
+
+	deliverOrderCommandHandler, err := commands.NewDeliverOrderHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create deliver order command handler: %v", err)
+	}
+
+	createZoneCommandHandler, err := commands.NewCreateZoneHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create create zone command handler: %v", err)
+	}
+
+	assignHomeZoneCommandHandler, err := commands.NewAssignHomeZoneHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create assign home zone command handler: %v", err)
+	}
+
+	reassignStalledOrdersCommandHandler, err := commands.NewReassignStalledOrdersHandler(unitOfWork,
+		dispatchService, etaService, config.Jobs.StallTimeout())
+	if err != nil {
+		log.Fatalf("failed to create reassign stalled orders command handler: %v", err)
+	}
+
+	rebuildReadModelsCommandHandler, err := commands.NewRebuildReadModelsHandler(unitOfWork, readModelProjector)
+	if err != nil {
+		log.Fatalf("failed to create rebuild read models command handler: %v", err)
+	}
+
 	// Queries
-	getAllCouriersQueryHandler, err := queries.NewGetAllCouriersHandler(unitOfWork)
+	getAllCouriersQueryHandler, err := queries.NewGetAllCouriersHandler(readModelRepository)
 	if err != nil {
 		log.Fatalf("failed to create get all couriers query handler: %v", err)
 	}
 
-	getNotCompletedOrdersQueryHandler, err := queries.NewGetAllUncompletedOrdersHandler(unitOfWork)
+	getNotCompletedOrdersQueryHandler, err := queries.NewGetAllUncompletedOrdersHandler(readModelRepository)
 	if err != nil {
 		log.Fatalf("failed to create get not completed orders query handler: %v", err)
 	}
 
+	getCourierAssignmentQueryHandler, err := queries.NewGetCourierAssignmentHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create get courier assignment query handler: %v", err)
+	}
+
+	getAllZonesQueryHandler, err := queries.NewGetAllZonesHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create get all zones query handler: %v", err)
+	}
+
+	analyticsRules, err := config.Analytics.Rules()
+	if err != nil {
+		log.Fatalf("failed to create analytics rules: %v", err)
+	}
+	getSupplyDemandQueryHandler, err := queries.NewGetSupplyDemandHandler(unitOfWork, analyticsRules,
+		config.Analytics.CellSize)
+	if err != nil {
+		log.Fatalf("failed to create get supply demand query handler: %v", err)
+	}
+
+	getOrderTimelineQueryHandler, err := queries.NewGetOrderTimelineHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create get order timeline query handler: %v", err)
+	}
+
+	getCourierPathQueryHandler, err := queries.NewGetCourierPathHandler(unitOfWork)
+	if err != nil {
+		log.Fatalf("failed to create get courier path query handler: %v", err)
+	}
+
 	// Jobs
-	assignOrderJob, err := jobs.NewAssignOrderJob(assignOrderCommandHandler)
+	jobLocker, err := postgres.NewAdvisoryJobLocker(gormDb)
+	if err != nil {
+		log.Fatalf("failed to create job locker: %v", err)
+	}
+
+	assignOrder, err := jobs.NewAssignOrderJob(expireOffersCommandHandler, assignOrderCommandHandler, systemClock)
+	if err != nil {
+		log.Fatalf("failed to create assign order job: %v", err)
+	}
+
+	// every exclusive job keeps a connection while this replica leads it, ExclusiveJobs counts them
+	assignOrderJob, err := jobs.NewExclusiveJob("assign_order", jobLocker, assignOrder)
 	if err != nil {
 		log.Fatalf("failed to create assign order job: %v", err)
 	}
 
-	moveCourierJob, err := jobs.NewMoveCourierJob(moveCourierCommandHandler)
+	moveCourier, err := jobs.NewMoveCourierJob(moveCourierCommandHandler, systemClock)
 	if err != nil {
 		log.Fatalf("failed to create move courier job: %v", err)
 	}
 
+	moveCourierJob, err := jobs.NewExclusiveJob("move_courier", jobLocker, moveCourier)
+	if err != nil {
+		log.Fatalf("failed to create move courier job: %v", err)
+	}
+
+	reassignStalledOrders, err := jobs.NewReassignStalledOrdersJob(reassignStalledOrdersCommandHandler, systemClock)
+	if err != nil {
+		log.Fatalf("failed to create reassign stalled orders job: %v", err)
+	}
+
+	reassignStalledOrdersJob, err := jobs.NewExclusiveJob("reassign_stalled_orders", jobLocker, reassignStalledOrders)
+	if err != nil {
+		log.Fatalf("failed to create reassign stalled orders job: %v", err)
+	}
+
 	// Kafka Consumer
 	kafkaConsumer, err := consumer.NewConsumer(
-		[]string{config.KafkaHost},
-		config.KafkaBasketConfirmedTopic,
-		config.KafkaConsumerGroup,
+		config.Kafka.Brokers,
+		config.Kafka.BasketConfirmedTopic,
+		config.Kafka.ConsumerGroup,
+		config.Kafka.ConsumerSessionTimeout,
 		createOrderCommandHandler,
 	)
 	if err != nil {
@@ -142,63 +323,364 @@ func NewCompositionRoot(config *Config, gormDb *gorm.DB) CompositionRoot {
 
 	// Kafka Producer
 	kafkaProducer, err := producer.NewOrderStatusChangedProducer(
-		[]string{config.KafkaHost},
-		config.KafkaOrderChangedTopic,
+		config.Kafka.Brokers,
+		config.Kafka.OrderChangedTopic,
+		config.Kafka.ProducerMaxRetries,
+		config.Kafka.ProducerTimeout,
 	)
 	if err != nil {
 		log.Fatalf("failed to create kafka producer: %v", err)
 	}
 
 	//Handler
-	handler, err := eventhandlers.NewOrderStatusChangedEventHandler(kafkaProducer)
+	handler, err := eventhandlers.NewOrderStatusChangedEventHandler(kafkaProducer, config.Events.EnqueueTimeout)
 	if err != nil {
 		log.Fatalf("failed to create order status changed event handler: %v", err)
 	}
 
-	// Mediatr
-	event := order.NewStatusChangedDomainEventWithoutData()
-	mediatr.Subscribe(handler, event)
+	orderAssignedProducer, err := producer.NewOrderAssignedProducer(
+		config.Kafka.Brokers,
+		config.Kafka.OrderAssignedTopic,
+		config.Kafka.ProducerMaxRetries,
+		config.Kafka.ProducerTimeout,
+	)
+	if err != nil {
+		log.Fatalf("failed to create order assigned producer: %v", err)
+	}
+
+	orderAssignedHandler, err := eventhandlers.NewOrderAssignedEventHandler(orderAssignedProducer, config.Events.EnqueueTimeout)
+	if err != nil {
+		log.Fatalf("failed to create order assigned event handler: %v", err)
+	}
+
+	courierLocationProducer, err := producer.NewCourierLocationChangedProducer(
+		config.Kafka.Brokers,
+		config.Kafka.CourierLocationChangedTopic,
+		config.Kafka.ProducerMaxRetries,
+		config.Kafka.ProducerTimeout,
+	)
+	if err != nil {
+		log.Fatalf("failed to create courier location changed producer: %v", err)
+	}
+
+	courierLocationHandler, err := eventhandlers.NewCourierLocationChangedEventHandler(courierLocationProducer, config.Events.EnqueueTimeout)
+	if err != nil {
+		log.Fatalf("failed to create courier location changed event handler: %v", err)
+	}
+
+	courierViewProjector, err := eventhandlers.NewCourierViewProjector(readModelProjector)
+	if err != nil {
+		log.Fatalf("failed to create courier view projector: %v", err)
+	}
+
+	orderViewProjector, err := eventhandlers.NewOrderViewProjector(readModelProjector)
+	if err != nil {
+		log.Fatalf("failed to create order view projector: %v", err)
+	}
+
+	courierIndexUpdater, err := eventhandlers.NewCourierIndexUpdater(courierIndex)
+	if err != nil {
+		log.Fatalf("failed to create courier index updater: %v", err)
+	}
+
+	supplyDemandProducer, err := producer.NewSupplyDemandProducer(
+		config.Kafka.Brokers,
+		config.Kafka.SupplyDemandTopic,
+		config.Kafka.ProducerMaxRetries,
+		config.Kafka.ProducerTimeout,
+	)
+	if err != nil {
+		log.Fatalf("failed to create supply demand producer: %v", err)
+	}
+
+	supplyDemand, err := jobs.NewSupplyDemandJob(getSupplyDemandQueryHandler, supplyDemandProducer, systemClock)
+	if err != nil {
+		log.Fatalf("failed to create supply demand job: %v", err)
+	}
+
+	supplyDemandJob, err := jobs.NewExclusiveJob("supply_demand", jobLocker, supplyDemand)
+	if err != nil {
+		log.Fatalf("failed to create supply demand job: %v", err)
+	}
+
+	// Mediatr, the order status topic carries the transitions the basket service follows
+	mediatr.Subscribe(handler, order.NewAssignedDomainEventWithoutData())
+	mediatr.Subscribe(handler, order.NewAcceptedDomainEventWithoutData())
+	mediatr.Subscribe(handler, order.NewPickedUpDomainEventWithoutData())
+	mediatr.Subscribe(handler, order.NewUnassignedDomainEventWithoutData())
+	mediatr.Subscribe(handler, order.NewCompletedDomainEventWithoutData())
+	mediatr.Subscribe(orderAssignedHandler, order.NewAssignedDomainEventWithoutData())
+	mediatr.Subscribe(courierLocationHandler, courier.NewMovedDomainEventWithoutData())
+	if config.Dispatch.Nearest == NearestByIndex {
+		mediatr.Subscribe(courierIndexUpdater, courier.NewCreatedDomainEventWithoutData(),
+			courier.NewMovedDomainEventWithoutData())
+	}
+
+	// Read models, the projectors run in the transaction of the change
+	mediatr.Subscribe(courierViewProjector,
+		courier.NewCreatedDomainEventWithoutData(),
+		courier.NewMovedDomainEventWithoutData(),
+		courier.NewHomeZoneAssignedDomainEventWithoutData(),
+		courier.NewStoragePlaceAddedDomainEventWithoutData(),
+		offer.NewDeclinedDomainEventWithoutData(),
+		order.NewAssignedDomainEventWithoutData(),
+		order.NewAcceptedDomainEventWithoutData(),
+		order.NewPickedUpDomainEventWithoutData(),
+		order.NewUnassignedDomainEventWithoutData(),
+		order.NewCompletedDomainEventWithoutData(),
+	)
+	mediatr.Subscribe(orderViewProjector,
+		order.NewCreatedDomainEventWithoutData(),
+		order.NewZoneTaggedDomainEventWithoutData(),
+		order.NewDeliveryScheduledDomainEventWithoutData(),
+		order.NewAssignedDomainEventWithoutData(),
+		order.NewAcceptedDomainEventWithoutData(),
+		order.NewPickedUpDomainEventWithoutData(),
+		order.NewUnassignedDomainEventWithoutData(),
+		order.NewCompletedDomainEventWithoutData(),
+		order.NewEtaUpdatedDomainEventWithoutData(),
+	)
+
+	// Health
+	brokerChecker, err := producer.NewBrokerChecker(config.Kafka.Brokers)
+	if err != nil {
+		log.Fatalf("failed to create kafka broker checker: %v", err)
+	}
+
+	healthService, err := newHealthService(config, gormDb, geoClient, brokerChecker, kafkaConsumer, assignOrderJob,
+		moveCourierJob, reassignStalledOrdersJob, supplyDemandJob)
+	if err != nil {
+		log.Fatalf("failed to create health service: %v", err)
+	}
 
 	// Servers
 	httpServer, err := http.NewServer(
 		assignOrderCommandHandler,
 		createOrderCommandHandler,
 		createCourierCommandHandler,
+		unassignOrderCommandHandler,
+		reassignOrderCommandHandler,
+		acceptOfferCommandHandler,
+		declineOfferCommandHandler,
+		pickUpOrderCommandHandler,
+		deliverOrderCommandHandler,
+		createZoneCommandHandler,
+		assignHomeZoneCommandHandler,
+		rebuildReadModelsCommandHandler,
 		getAllCouriersQueryHandler,
 		getNotCompletedOrdersQueryHandler,
+		getCourierAssignmentQueryHandler,
+		getAllZonesQueryHandler,
+		getSupplyDemandQueryHandler,
+		getOrderTimelineQueryHandler,
+		getCourierPathQueryHandler,
+		systemClock,
 	)
+	if err != nil {
+		log.Fatalf("failed to create http server: %v", err)
+	}
+
+	healthHandler, err := http.NewHealthHandler(healthService)
+	if err != nil {
+		log.Fatalf("failed to create health handler: %v", err)
+	}
+
+	spec, err := servers.GetSwagger()
+	if err != nil {
+		log.Fatalf("failed to load openapi spec: %v", err)
+	}
+	requestValidator, err := http.NewRequestValidator(spec)
+	if err != nil {
+		log.Fatalf("failed to create request validator: %v", err)
+	}
+	authorizer, err := newAuthorizer(config.Auth, spec)
+	if err != nil {
+		log.Fatalf("failed to create authorizer: %v", err)
+	}
+	auditContext, err := http.NewAuditContext(spec)
+	if err != nil {
+		log.Fatalf("failed to create audit context: %v", err)
+	}
 
 	return CompositionRoot{
 		config: config,
 		gormDb: gormDb,
 		DomainServices: DomainServices{
 			DispatchService: dispatchService,
+			EtaService:      etaService,
 		},
 		Repositories: Repositories{
-			UnitOfWork:        unitOfWork,
-			OrderRepository:   orderRepository,
-			CourierRepository: courierRepository,
+			UnitOfWork:          unitOfWork,
+			OrderRepository:     orderRepository,
+			CourierRepository:   courierRepository,
+			ReadModelRepository: readModelRepository,
+			ReadModelProjector:  readModelProjector,
 		},
 		CommandHandlers: CommandHandlers{
-			AssignOrderCommandHandler:   assignOrderCommandHandler,
-			CreateOrderCommandHandler:   createOrderCommandHandler,
-			CreateCourierCommandHandler: createCourierCommandHandler,
-			MoveCourierCommandHandler:   moveCourierCommandHandler,
+			AssignOrderCommandHandler:           assignOrderCommandHandler,
+			ExpireOffersCommandHandler:          expireOffersCommandHandler,
+			CreateOrderCommandHandler:           createOrderCommandHandler,
+			CreateCourierCommandHandler:         createCourierCommandHandler,
+			MoveCourierCommandHandler:           moveCourierCommandHandler,
+			UnassignOrderCommandHandler:         unassignOrderCommandHandler,
+			ReassignOrderCommandHandler:         reassignOrderCommandHandler,
+			ReassignStalledOrdersCommandHandler: reassignStalledOrdersCommandHandler,
+			RebuildReadModelsCommandHandler:     rebuildReadModelsCommandHandler,
 		},
 		QueryHandlers: QueryHandlers{
 			GetAllCouriersQueryHandler:        getAllCouriersQueryHandler,
 			GetNotCompletedOrdersQueryHandler: getNotCompletedOrdersQueryHandler,
+			GetSupplyDemandQueryHandler:       getSupplyDemandQueryHandler,
+			GetOrderTimelineQueryHandler:      getOrderTimelineQueryHandler,
+			GetCourierPathQueryHandler:        getCourierPathQueryHandler,
 		},
 		Jobs: Jobs{
-			AssignOrderJob: *assignOrderJob,
-			MoveCourierJob: *moveCourierJob,
+			AssignOrderJob:           assignOrderJob,
+			MoveCourierJob:           moveCourierJob,
+			ReassignStalledOrdersJob: reassignStalledOrdersJob,
+			SupplyDemandJob:          supplyDemandJob,
+			Locker:                   jobLocker,
 		},
 		Servers: Servers{
-			HttpServer: httpServer,
+			HttpServer:       httpServer,
+			HealthHandler:    healthHandler,
+			Authorizer:       authorizer,
+			AuditContext:     auditContext,
+			RequestValidator: requestValidator,
+			ErrorHandler:     http.ErrorHandler,
+		},
+		Clients: Clients{
+			GeoClient:          geoClient,
+			KafkaBrokerChecker: brokerChecker,
 		},
-		KafkaConsumer: kafkaConsumer,
-		KafkaProducer: kafkaProducer,
-		EventHandler:  handler,
-		Mediatr:       mediatr,
+		KafkaConsumer:           kafkaConsumer,
+		KafkaProducer:           kafkaProducer,
+		OrderAssignedProducer:   orderAssignedProducer,
+		CourierLocationProducer: courierLocationProducer,
+		SupplyDemandProducer:    supplyDemandProducer,
+		Clock:                   systemClock,
+		EventHandler:            handler,
+		Mediatr:                 mediatr,
+		Health:                  healthService,
+	}
+}
+
+func newHealthService(
+	config *Config,
+	gormDb *gorm.DB,
+	geoClient *geo.Client,
+	brokerChecker *producer.BrokerChecker,
+	kafkaConsumer consumer.BasketConfirmedConsumer,
+	assignOrderJob jobs.LastRunReporter,
+	moveCourierJob jobs.LastRunReporter,
+	reassignStalledOrdersJob jobs.LastRunReporter,
+	supplyDemandJob jobs.LastRunReporter,
+) (health.Service, error) {
+	healthService := health.NewService(config.Health.CheckTimeout)
+
+	dbChecker, err := postgres.NewDbChecker(gormDb)
+	if err != nil {
+		return nil, err
+	}
+
+	assignOrderJobChecker, err := jobs.NewLastRunChecker(assignOrderJob, config.Jobs.MaxIdle)
+	if err != nil {
+		return nil, err
+	}
+
+	moveCourierJobChecker, err := jobs.NewLastRunChecker(moveCourierJob, config.Jobs.MaxIdle)
+	if err != nil {
+		return nil, err
+	}
+
+	reassignStalledOrdersJobChecker, err := jobs.NewLastRunChecker(reassignStalledOrdersJob, config.Jobs.MaxIdle)
+	if err != nil {
+		return nil, err
+	}
+
+	supplyDemandJobChecker, err := jobs.NewLastRunChecker(supplyDemandJob, config.Jobs.MaxIdle)
+	if err != nil {
+		return nil, err
 	}
+
+	// liveness only looks inside the process: the jobs fail along with the database, and a restart of every
+	// replica would not bring it back
+	healthService.AddLivenessCheck("kafka_consumer", health.CheckerFunc(kafkaConsumer.Alive))
+
+	healthService.AddReadinessCheck("postgres", dbChecker)
+	healthService.AddReadinessCheck("kafka_brokers", brokerChecker)
+	healthService.AddReadinessCheck("kafka_consumer_group", kafkaConsumer)
+	healthService.AddReadinessCheck("geo_service", geoClient)
+	healthService.AddReadinessCheck("assign_order_job", assignOrderJobChecker)
+	healthService.AddReadinessCheck("move_courier_job", moveCourierJobChecker)
+	healthService.AddReadinessCheck("reassign_stalled_orders_job", reassignStalledOrdersJobChecker)
+	healthService.AddReadinessCheck("supply_demand_job", supplyDemandJobChecker)
+
+	return healthService, nil
+}
+
+// newAssignOrderHandler looks up the couriers near a standard order the way the config asks for. The index
+// is kept in this process only, the moves made by the other replicas reach it with the next reseed.
+func newAssignOrderHandler(config DispatchConfig, uow ports.UnitOfWork, dispatcher service.DispatchService,
+	eta service.EtaService, index *service.CourierIndex, clock ports.Clock) (commands.AssignOrderHandler, error) {
+	switch config.Nearest {
+	case NearestByIndex:
+		locator, err := courierindex.NewLocator(index, uow.CourierRepository(), clock, config.IndexReseedEvery)
+		if err != nil {
+			return nil, err
+		}
+		return commands.NewNearestFirstAssignOrderHandler(uow, dispatcher, eta, locator, config.Candidates)
+	case NearestByQuery:
+		return commands.NewNearestFirstAssignOrderHandler(uow, dispatcher, eta, uow.CourierRepository(),
+			config.Candidates)
+	}
+	return commands.NewAssignOrderHandler(uow, dispatcher, eta)
+}
+
+// newUnitOfWork stores the orders event sourced when the config asks for it
+func newUnitOfWork(config OrdersConfig, gormDb *gorm.DB, mediatr ddd.Mediatr) (*postgres.UnitOfWork, error) {
+	if config.EventSourced {
+		return postgres.NewEventSourcedUnitOfWork(gormDb, mediatr, config.SnapshotEvery)
+	}
+	return postgres.NewUnitOfWork(gormDb, mediatr)
+}
+
+// publicPaths are the health checks served outside the spec, the authorizer lets them through without credentials
+var publicPaths = []string{"/health", "/health/live", "/health/ready"}
+
+// newAuthorizer verifies bearer tokens with the keys of the JWKS file or the static key files
+// and service calls with the api keys; with auth disabled every request passes
+func newAuthorizer(config AuthConfig, spec *openapi3.T) (echo.MiddlewareFunc, error) {
+	if !config.Enabled {
+		log.Printf("auth is disabled, the http api is open to anyone who can reach it")
+		return func(next echo.HandlerFunc) echo.HandlerFunc {
+			return next
+		}, nil
+	}
+
+	var keys auth.KeySet
+	var err error
+	switch {
+	case config.JwksFile != "":
+		keys, err = auth.LoadJWKS(config.JwksFile)
+	case len(config.StaticKeys) > 0:
+		keys, err = auth.LoadPublicKeys(config.StaticKeys)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var tokens *auth.TokenVerifier
+	if len(keys) > 0 {
+		tokens, err = auth.NewTokenVerifier(keys, config.Issuer, config.Audience)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	apiKeys, err := auth.ParseAPIKeys(config.ApiKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return http.NewAuthorizer(spec, tokens, apiKeys, publicPaths)
 }
@@ -1,16 +1,365 @@
 package cmd
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/analytics"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/robfig/cron/v3"
+)
+
 type Config struct {
-	HttpPort                  string
-	DbHost                    string
-	DbPort                    string
-	DbUser                    string
-	DbPassword                string
-	DbName                    string
-	DbSslMode                 string
-	GeoServiceGrpcHost        string
-	KafkaHost                 string
-	KafkaConsumerGroup        string
-	KafkaBasketConfirmedTopic string
-	KafkaOrderChangedTopic    string
+	Http      HttpConfig
+	Auth      AuthConfig
+	Db        DbConfig
+	Geo       GeoConfig
+	Kafka     KafkaConfig
+	Jobs      JobsConfig
+	Dispatch  DispatchConfig
+	Analytics AnalyticsConfig
+	Orders    OrdersConfig
+	Events    EventsConfig
+	Health    HealthConfig
+	Shutdown  ShutdownConfig
+}
+
+type HttpConfig struct {
+	Port int
+}
+
+// AuthConfig names where the keys verifying bearer tokens come from and the api keys of the calling services
+type AuthConfig struct {
+	Enabled    bool
+	JwksFile   string
+	StaticKeys []string
+	Issuer     string
+	Audience   string
+	ApiKeys    []string
+}
+
+// ExclusiveJobs is how many jobs run on the one replica holding their advisory lock. The lock lives on
+// a pooled connection, so the leader of every job keeps one of the db.max_open_conns for as long as it leads.
+const ExclusiveJobs = 4
+
+// MinFreeConns is how many connections db.max_open_conns must leave to the requests and the queries of the jobs
+// once every job lock is held
+const MinFreeConns = 4
+
+type DbConfig struct {
+	Host            string
+	Port            int
+	User            string
+	Password        string
+	Name            string
+	SslMode         string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+type GeoConfig struct {
+	GrpcHost string
+	Timeout  time.Duration
+}
+
+type KafkaConfig struct {
+	Brokers                     []string
+	ConsumerGroup               string
+	BasketConfirmedTopic        string
+	OrderChangedTopic           string
+	OrderAssignedTopic          string
+	CourierLocationChangedTopic string
+	SupplyDemandTopic           string
+	ConsumerSessionTimeout      time.Duration
+	ProducerMaxRetries          int
+	ProducerTimeout             time.Duration
 }
+
+type JobsConfig struct {
+	AssignOrderSchedule     string
+	MoveCourierSchedule     string
+	ReassignStalledSchedule string
+	SupplyDemandSchedule    string
+	StallTicks              int
+	MaxIdle                 time.Duration
+}
+
+// DispatchConfig holds the rules for giving an order to a courier based in another zone and how a standard
+// order looks up the couriers near it: by the in-memory index of their locations, by a postgres query or,
+// left empty, by loading every free courier. Candidates is how many nearest couriers are looked up,
+// IndexReseedEvery how often the index is loaded again, OfferTimeout how long a courier has to answer an offer.
+type DispatchConfig struct {
+	CrossZone            string
+	MaxCrossZoneDistance int
+	Nearest              string
+	Candidates           int
+	IndexReseedEvery     time.Duration
+	OfferTimeout         time.Duration
+}
+
+const (
+	NearestByIndex = "index"
+	NearestByQuery = "query"
+)
+
+// ZoneRules converts the config into the rules of the dispatch service
+func (c DispatchConfig) ZoneRules() service.ZoneRules {
+	return service.ZoneRules{
+		CrossZone:            service.CrossZoneRule(c.CrossZone),
+		MaxCrossZoneDistance: c.MaxCrossZoneDistance,
+	}
+}
+
+// AnalyticsConfig sets up the supply and demand snapshots: the sliding windows of the average waits,
+// the waiting orders per idle courier that are a surge and the grid cells used while there are no zones
+type AnalyticsConfig struct {
+	Windows    []time.Duration
+	SurgeRatio float64
+	CellSize   int
+}
+
+// Rules converts the config into the rules of the snapshots
+func (c AnalyticsConfig) Rules() (analytics.Rules, error) {
+	return analytics.NewRules(c.Windows, c.SurgeRatio)
+}
+
+// OrdersConfig chooses how the orders are stored: as rows of the orders table or, event sourced,
+// as streams of their events with a snapshot every SnapshotEvery events (0 takes none)
+type OrdersConfig struct {
+	EventSourced  bool
+	SnapshotEvery int
+}
+
+// EventsConfig sizes the workers the domain event handlers run on after commit
+type EventsConfig struct {
+	Workers      int
+	QueueSize    int
+	MaxAttempts  int
+	RetryBackoff time.Duration
+	// EnqueueTimeout is how long the handlers publishing to Kafka wait for room in their full queue
+	EnqueueTimeout time.Duration
+}
+
+type HealthConfig struct {
+	CheckTimeout time.Duration
+}
+
+type ShutdownConfig struct {
+	Timeout time.Duration
+	// CloseGrace bounds every close left for after the timeout, a close that hangs is abandoned
+	CloseGrace time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Http: HttpConfig{
+			Port: 8082,
+		},
+		Auth: AuthConfig{
+			Enabled: true,
+		},
+		Db: DbConfig{
+			Port:            5432,
+			SslMode:         "disable",
+			MaxOpenConns:    10,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 30 * time.Minute,
+		},
+		Geo: GeoConfig{
+			Timeout: 5 * time.Second,
+		},
+		Kafka: KafkaConfig{
+			OrderAssignedTopic:          "order.assigned",
+			CourierLocationChangedTopic: "courier.location.changed",
+			SupplyDemandTopic:           "analytics.supply_demand",
+			ConsumerSessionTimeout:      10 * time.Second,
+			ProducerMaxRetries:          3,
+			ProducerTimeout:             10 * time.Second,
+		},
+		Jobs: JobsConfig{
+			AssignOrderSchedule:     "* * * * * *",
+			MoveCourierSchedule:     "* * * * * *",
+			ReassignStalledSchedule: "*/5 * * * * *",
+			SupplyDemandSchedule:    "*/10 * * * * *",
+			StallTicks:              30,
+			MaxIdle:                 30 * time.Second,
+		},
+		Dispatch: DispatchConfig{
+			CrossZone:        string(service.CrossZoneFallback),
+			Candidates:       20,
+			IndexReseedEvery: time.Minute,
+			OfferTimeout:     offer.DefaultTimeout,
+		},
+		Analytics: AnalyticsConfig{
+			Windows:    []time.Duration{5 * time.Minute, 15 * time.Minute, time.Hour},
+			SurgeRatio: 2,
+			CellSize:   5,
+		},
+		Orders: OrdersConfig{
+			SnapshotEvery: 50,
+		},
+		Events: EventsConfig{
+			Workers:        4,
+			QueueSize:      1000,
+			MaxAttempts:    5,
+			RetryBackoff:   100 * time.Millisecond,
+			EnqueueTimeout: 2 * time.Second,
+		},
+		Health: HealthConfig{
+			CheckTimeout: 2 * time.Second,
+		},
+		Shutdown: ShutdownConfig{
+			Timeout:    30 * time.Second,
+			CloseGrace: 5 * time.Second,
+		},
+	}
+}
+
+// Validate returns all invalid fields at once, joined into a single error
+func (c Config) Validate() error {
+	var problems []error
+
+	required := func(field, value string) {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, errs.NewValueIsRequiredError(field))
+		}
+	}
+	positive := func(field string, value int64) {
+		if value <= 0 {
+			problems = append(problems, errs.NewValidationErrorWithValue(field, value, "must be greater than zero"))
+		}
+	}
+	port := func(field string, value int) {
+		if value <= 0 || value > 65535 {
+			problems = append(problems, errs.NewValidationErrorWithValue(field, value, "must be a valid port"))
+		}
+	}
+	schedule := func(field, value string) {
+		if _, err := cronParser.Parse(value); err != nil {
+			problems = append(problems, errs.NewValidationErrorWithCause(field, "must be a valid cron schedule", err))
+		}
+	}
+
+	port("http.port", c.Http.Port)
+
+	if c.Auth.Enabled && c.Auth.JwksFile == "" && len(c.Auth.StaticKeys) == 0 && len(c.Auth.ApiKeys) == 0 {
+		problems = append(problems, errs.NewValidationError("auth",
+			"auth.jwks_file, auth.static_keys or auth.api_keys is required when auth is enabled"))
+	}
+	if c.Auth.JwksFile != "" && len(c.Auth.StaticKeys) > 0 {
+		problems = append(problems, errs.NewValidationError("auth.static_keys", "must not be set together with auth.jwks_file"))
+	}
+
+	required("db.host", c.Db.Host)
+	port("db.port", c.Db.Port)
+	required("db.user", c.Db.User)
+	required("db.password", c.Db.Password)
+	required("db.name", c.Db.Name)
+	required("db.ssl_mode", c.Db.SslMode)
+	positive("db.max_open_conns", int64(c.Db.MaxOpenConns))
+	if c.Db.MaxOpenConns > 0 && c.Db.MaxOpenConns < ExclusiveJobs+MinFreeConns {
+		problems = append(problems, errs.NewValidationErrorWithValue("db.max_open_conns", c.Db.MaxOpenConns,
+			fmt.Sprintf("must be at least %d: %d connections hold the job locks and %d are left to the rest",
+				ExclusiveJobs+MinFreeConns, ExclusiveJobs, MinFreeConns)))
+	}
+	positive("db.max_idle_conns", int64(c.Db.MaxIdleConns))
+	if c.Db.MaxIdleConns > c.Db.MaxOpenConns {
+		problems = append(problems, errs.NewValidationErrorWithValue("db.max_idle_conns", c.Db.MaxIdleConns,
+			"must not exceed db.max_open_conns"))
+	}
+	positive("db.conn_max_lifetime", int64(c.Db.ConnMaxLifetime))
+
+	required("geo.grpc_host", c.Geo.GrpcHost)
+	positive("geo.timeout", int64(c.Geo.Timeout))
+
+	if len(c.Kafka.Brokers) == 0 {
+		problems = append(problems, errs.NewValueIsRequiredError("kafka.brokers"))
+	}
+	required("kafka.consumer_group", c.Kafka.ConsumerGroup)
+	required("kafka.basket_confirmed_topic", c.Kafka.BasketConfirmedTopic)
+	required("kafka.order_changed_topic", c.Kafka.OrderChangedTopic)
+	required("kafka.order_assigned_topic", c.Kafka.OrderAssignedTopic)
+	required("kafka.courier_location_changed_topic", c.Kafka.CourierLocationChangedTopic)
+	required("kafka.supply_demand_topic", c.Kafka.SupplyDemandTopic)
+	positive("kafka.consumer_session_timeout", int64(c.Kafka.ConsumerSessionTimeout))
+	if c.Kafka.ProducerMaxRetries < 0 {
+		problems = append(problems, errs.NewValidationErrorWithValue("kafka.producer_max_retries",
+			c.Kafka.ProducerMaxRetries, "must not be negative"))
+	}
+	positive("kafka.producer_timeout", int64(c.Kafka.ProducerTimeout))
+
+	schedule("jobs.assign_order_schedule", c.Jobs.AssignOrderSchedule)
+	schedule("jobs.move_courier_schedule", c.Jobs.MoveCourierSchedule)
+	schedule("jobs.reassign_stalled_schedule", c.Jobs.ReassignStalledSchedule)
+	schedule("jobs.supply_demand_schedule", c.Jobs.SupplyDemandSchedule)
+	positive("jobs.stall_ticks", int64(c.Jobs.StallTicks))
+	positive("jobs.max_idle", int64(c.Jobs.MaxIdle))
+
+	if c.Dispatch.CrossZone != string(service.CrossZoneFallback) && c.Dispatch.CrossZone != string(service.CrossZoneNever) &&
+		c.Dispatch.CrossZone != string(service.CrossZoneAnywhere) {
+		problems = append(problems, errs.NewValidationErrorWithValue("dispatch.cross_zone", c.Dispatch.CrossZone,
+			"must be fallback, never or anywhere"))
+	}
+	if c.Dispatch.MaxCrossZoneDistance < 0 {
+		problems = append(problems, errs.NewValidationErrorWithValue("dispatch.max_cross_zone_distance",
+			c.Dispatch.MaxCrossZoneDistance, "must not be negative"))
+	}
+	if c.Dispatch.Nearest != "" && c.Dispatch.Nearest != NearestByIndex && c.Dispatch.Nearest != NearestByQuery {
+		problems = append(problems, errs.NewValidationErrorWithValue("dispatch.nearest", c.Dispatch.Nearest,
+			"must be empty, index or query"))
+	}
+	positive("dispatch.candidates", int64(c.Dispatch.Candidates))
+	if c.Dispatch.Nearest == NearestByIndex {
+		positive("dispatch.index_reseed_every", int64(c.Dispatch.IndexReseedEvery))
+	}
+	positive("dispatch.offer_timeout", int64(c.Dispatch.OfferTimeout))
+
+	if _, err := c.Analytics.Rules(); err != nil {
+		problems = append(problems, errs.NewValidationErrorWithCause("analytics", "invalid windows or surge ratio", err))
+	}
+	positive("analytics.cell_size", int64(c.Analytics.CellSize))
+
+	if c.Orders.SnapshotEvery < 0 {
+		problems = append(problems, errs.NewValidationErrorWithValue("orders.snapshot_every",
+			c.Orders.SnapshotEvery, "must not be negative"))
+	}
+
+	positive("events.workers", int64(c.Events.Workers))
+	positive("events.queue_size", int64(c.Events.QueueSize))
+	positive("events.max_attempts", int64(c.Events.MaxAttempts))
+	positive("events.retry_backoff", int64(c.Events.RetryBackoff))
+	positive("events.enqueue_timeout", int64(c.Events.EnqueueTimeout))
+
+	positive("health.check_timeout", int64(c.Health.CheckTimeout))
+	positive("shutdown.timeout", int64(c.Shutdown.Timeout))
+	positive("shutdown.close_grace", int64(c.Shutdown.CloseGrace))
+
+	return errors.Join(problems...)
+}
+
+// MoveCourierInterval is the time between two runs of the move courier job,
+// irregular schedules are approximated by the interval of the next two runs
+func (c JobsConfig) MoveCourierInterval() time.Duration {
+	schedule, err := cronParser.Parse(c.MoveCourierSchedule)
+	if err != nil {
+		return time.Second
+	}
+	next := schedule.Next(time.Now())
+	return schedule.Next(next).Sub(next)
+}
+
+// StallTimeout is how long a courier carrying orders may go without getting closer to them:
+// StallTicks runs of the move courier job
+func (c JobsConfig) StallTimeout() time.Duration {
+	return time.Duration(c.StallTicks) * c.MoveCourierInterval()
+}
+
+// cronParser matches the scheduler created with cron.WithSeconds()
+var cronParser = cron.NewParser(
+	cron.Second | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
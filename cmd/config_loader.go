@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultConfigFile = "configs/delivery.yaml"
+	configFileEnv     = "CONFIG_FILE"
+	dotEnvFile        = ".env"
+	redactedValue     = "******"
+)
+
+// option describes a single config field and every source it can be read from
+type option struct {
+	key    string
+	env    string
+	secret bool
+	value  flag.Getter
+}
+
+func (o option) flagName() string {
+	return strings.NewReplacer(".", "-", "_", "-").Replace(o.key)
+}
+
+func bindOptions(c *Config) []option {
+	return []option{
+		{key: "http.port", env: "HTTP_PORT", value: (*intValue)(&c.Http.Port)},
+
+		{key: "auth.enabled", env: "AUTH_ENABLED", value: (*boolValue)(&c.Auth.Enabled)},
+		{key: "auth.jwks_file", env: "AUTH_JWKS_FILE", value: (*stringValue)(&c.Auth.JwksFile)},
+		{key: "auth.static_keys", env: "AUTH_STATIC_KEYS", value: (*stringListValue)(&c.Auth.StaticKeys)},
+		{key: "auth.issuer", env: "AUTH_ISSUER", value: (*stringValue)(&c.Auth.Issuer)},
+		{key: "auth.audience", env: "AUTH_AUDIENCE", value: (*stringValue)(&c.Auth.Audience)},
+		{key: "auth.api_keys", env: "AUTH_API_KEYS", value: (*stringListValue)(&c.Auth.ApiKeys), secret: true},
+
+		{key: "db.host", env: "DB_HOST", value: (*stringValue)(&c.Db.Host)},
+		{key: "db.port", env: "DB_PORT", value: (*intValue)(&c.Db.Port)},
+		{key: "db.user", env: "DB_USER", value: (*stringValue)(&c.Db.User)},
+		{key: "db.password", env: "DB_PASSWORD", value: (*stringValue)(&c.Db.Password), secret: true},
+		{key: "db.name", env: "DB_NAME", value: (*stringValue)(&c.Db.Name)},
+		{key: "db.ssl_mode", env: "DB_SSLMODE", value: (*stringValue)(&c.Db.SslMode)},
+		{key: "db.max_open_conns", env: "DB_MAX_OPEN_CONNS", value: (*intValue)(&c.Db.MaxOpenConns)},
+		{key: "db.max_idle_conns", env: "DB_MAX_IDLE_CONNS", value: (*intValue)(&c.Db.MaxIdleConns)},
+		{key: "db.conn_max_lifetime", env: "DB_CONN_MAX_LIFETIME", value: (*durationValue)(&c.Db.ConnMaxLifetime)},
+
+		{key: "geo.grpc_host", env: "GEO_SERVICE_GRPC_HOST", value: (*stringValue)(&c.Geo.GrpcHost)},
+		{key: "geo.timeout", env: "GEO_SERVICE_TIMEOUT", value: (*durationValue)(&c.Geo.Timeout)},
+
+		{key: "kafka.brokers", env: "KAFKA_HOST", value: (*stringListValue)(&c.Kafka.Brokers)},
+		{key: "kafka.consumer_group", env: "KAFKA_CONSUMER_GROUP", value: (*stringValue)(&c.Kafka.ConsumerGroup)},
+		{key: "kafka.basket_confirmed_topic", env: "KAFKA_BASKET_CONFIRMED_TOPIC", value: (*stringValue)(&c.Kafka.BasketConfirmedTopic)},
+		{key: "kafka.order_changed_topic", env: "KAFKA_ORDER_CHANGED_TOPIC", value: (*stringValue)(&c.Kafka.OrderChangedTopic)},
+		{key: "kafka.order_assigned_topic", env: "KAFKA_ORDER_ASSIGNED_TOPIC", value: (*stringValue)(&c.Kafka.OrderAssignedTopic)},
+		{key: "kafka.courier_location_changed_topic", env: "KAFKA_COURIER_LOCATION_CHANGED_TOPIC", value: (*stringValue)(&c.Kafka.CourierLocationChangedTopic)},
+		{key: "kafka.supply_demand_topic", env: "KAFKA_SUPPLY_DEMAND_TOPIC", value: (*stringValue)(&c.Kafka.SupplyDemandTopic)},
+		{key: "kafka.consumer_session_timeout", env: "KAFKA_CONSUMER_SESSION_TIMEOUT", value: (*durationValue)(&c.Kafka.ConsumerSessionTimeout)},
+		{key: "kafka.producer_max_retries", env: "KAFKA_PRODUCER_MAX_RETRIES", value: (*intValue)(&c.Kafka.ProducerMaxRetries)},
+		{key: "kafka.producer_timeout", env: "KAFKA_PRODUCER_TIMEOUT", value: (*durationValue)(&c.Kafka.ProducerTimeout)},
+
+		{key: "jobs.assign_order_schedule", env: "JOBS_ASSIGN_ORDER_SCHEDULE", value: (*stringValue)(&c.Jobs.AssignOrderSchedule)},
+		{key: "jobs.move_courier_schedule", env: "JOBS_MOVE_COURIER_SCHEDULE", value: (*stringValue)(&c.Jobs.MoveCourierSchedule)},
+		{key: "jobs.reassign_stalled_schedule", env: "JOBS_REASSIGN_STALLED_SCHEDULE", value: (*stringValue)(&c.Jobs.ReassignStalledSchedule)},
+		{key: "jobs.supply_demand_schedule", env: "JOBS_SUPPLY_DEMAND_SCHEDULE", value: (*stringValue)(&c.Jobs.SupplyDemandSchedule)},
+		{key: "jobs.stall_ticks", env: "JOBS_STALL_TICKS", value: (*intValue)(&c.Jobs.StallTicks)},
+		{key: "jobs.max_idle", env: "JOBS_MAX_IDLE", value: (*durationValue)(&c.Jobs.MaxIdle)},
+
+		{key: "dispatch.cross_zone", env: "DISPATCH_CROSS_ZONE", value: (*stringValue)(&c.Dispatch.CrossZone)},
+		{key: "dispatch.max_cross_zone_distance", env: "DISPATCH_MAX_CROSS_ZONE_DISTANCE", value: (*intValue)(&c.Dispatch.MaxCrossZoneDistance)},
+		{key: "dispatch.nearest", env: "DISPATCH_NEAREST", value: (*stringValue)(&c.Dispatch.Nearest)},
+		{key: "dispatch.candidates", env: "DISPATCH_CANDIDATES", value: (*intValue)(&c.Dispatch.Candidates)},
+		{key: "dispatch.index_reseed_every", env: "DISPATCH_INDEX_RESEED_EVERY", value: (*durationValue)(&c.Dispatch.IndexReseedEvery)},
+		{key: "dispatch.offer_timeout", env: "DISPATCH_OFFER_TIMEOUT", value: (*durationValue)(&c.Dispatch.OfferTimeout)},
+
+		{key: "analytics.windows", env: "ANALYTICS_WINDOWS", value: (*durationListValue)(&c.Analytics.Windows)},
+		{key: "analytics.surge_ratio", env: "ANALYTICS_SURGE_RATIO", value: (*floatValue)(&c.Analytics.SurgeRatio)},
+		{key: "analytics.cell_size", env: "ANALYTICS_CELL_SIZE", value: (*intValue)(&c.Analytics.CellSize)},
+
+		{key: "orders.event_sourced", env: "ORDERS_EVENT_SOURCED", value: (*boolValue)(&c.Orders.EventSourced)},
+		{key: "orders.snapshot_every", env: "ORDERS_SNAPSHOT_EVERY", value: (*intValue)(&c.Orders.SnapshotEvery)},
+
+		{key: "events.workers", env: "EVENTS_WORKERS", value: (*intValue)(&c.Events.Workers)},
+		{key: "events.queue_size", env: "EVENTS_QUEUE_SIZE", value: (*intValue)(&c.Events.QueueSize)},
+		{key: "events.max_attempts", env: "EVENTS_MAX_ATTEMPTS", value: (*intValue)(&c.Events.MaxAttempts)},
+		{key: "events.retry_backoff", env: "EVENTS_RETRY_BACKOFF", value: (*durationValue)(&c.Events.RetryBackoff)},
+		{key: "events.enqueue_timeout", env: "EVENTS_ENQUEUE_TIMEOUT", value: (*durationValue)(&c.Events.EnqueueTimeout)},
+
+		{key: "health.check_timeout", env: "HEALTH_CHECK_TIMEOUT", value: (*durationValue)(&c.Health.CheckTimeout)},
+		{key: "shutdown.timeout", env: "SHUTDOWN_TIMEOUT", value: (*durationValue)(&c.Shutdown.Timeout)},
+		{key: "shutdown.close_grace", env: "SHUTDOWN_CLOSE_GRACE", value: (*durationValue)(&c.Shutdown.CloseGrace)},
+	}
+}
+
+// LoadConfig builds the config from defaults, then the YAML file, then the environment
+// (including an optional .env file) and finally the command line flags
+func LoadConfig(args []string) (*Config, error) {
+	config := DefaultConfig()
+	options := bindOptions(&config)
+
+	flagSet := flag.NewFlagSet("delivery", flag.ContinueOnError)
+	configFile := flagSet.String("config", "", "path to the YAML config file")
+	for _, opt := range options {
+		flagSet.Var(opt.value, opt.flagName(), fmt.Sprintf("%s (env %s)", opt.key, opt.env))
+	}
+	if err := flagSet.Parse(args); err != nil {
+		return nil, err
+	}
+
+	// flags have the highest priority, so they are remembered now and re-applied last
+	cliValues := make(map[string]string)
+	flagSet.Visit(func(f *flag.Flag) {
+		if f.Name != "config" {
+			cliValues[f.Name] = f.Value.String()
+		}
+	})
+
+	var problems []error
+
+	if err := godotenv.Load(dotEnvFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		problems = append(problems, fmt.Errorf("failed to load %s: %w", dotEnvFile, err))
+	}
+
+	path, required := *configFile, true
+	if path == "" {
+		path, required = os.Getenv(configFileEnv), true
+	}
+	if path == "" {
+		path, required = defaultConfigFile, false
+	}
+	fileValues, err := readConfigFile(path, required)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(options))
+	for _, opt := range options {
+		known[opt.key] = true
+
+		if value, ok := fileValues[opt.key]; ok {
+			problems = appendSetError(problems, opt, value, path)
+		}
+		if value, ok := os.LookupEnv(opt.env); ok {
+			problems = appendSetError(problems, opt, value, "env "+opt.env)
+		}
+		if value, ok := cliValues[opt.flagName()]; ok {
+			problems = appendSetError(problems, opt, value, "flag -"+opt.flagName())
+		}
+	}
+	for _, key := range sortedKeys(fileValues) {
+		if !known[key] {
+			problems = append(problems, errs.NewValidationError(key, fmt.Sprintf("unknown key in %s", path)))
+		}
+	}
+
+	if err := errors.Join(problems...); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &config, nil
+}
+
+// WriteConfig prints the effective config as YAML with the secrets redacted
+func WriteConfig(w io.Writer, config Config) error {
+	tree := make(map[string]interface{})
+	for _, opt := range bindOptions(&config) {
+		value := opt.value.Get()
+		switch v := value.(type) {
+		case time.Duration:
+			value = v.String()
+		case []time.Duration:
+			items := make([]string, len(v))
+			for i, d := range v {
+				items[i] = d.String()
+			}
+			value = items
+		}
+		if opt.secret && opt.value.String() != "" {
+			value = redactedValue
+		}
+
+		parts := strings.Split(opt.key, ".")
+		node := tree
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = value
+	}
+
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+	return encoder.Encode(tree)
+}
+
+func readConfigFile(path string, required bool) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !required {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+
+	var tree map[string]interface{}
+	if err := yaml.Unmarshal(content, &tree); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten("", tree, values)
+	return values, nil
+}
+
+func flatten(prefix string, node map[string]interface{}, values map[string]string) {
+	for key, value := range node {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(key, v, values)
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[key] = strings.Join(items, ",")
+		case nil:
+			values[key] = ""
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+func appendSetError(problems []error, opt option, value string, source string) []error {
+	if err := opt.value.Set(value); err != nil {
+		return append(problems, errs.NewValidationErrorWithCause(opt.key, "invalid value from "+source, err))
+	}
+	return problems
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
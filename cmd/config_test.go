@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	chdir(t, dir)
+
+	file := filepath.Join(dir, "delivery.yaml")
+	err := os.WriteFile(file, []byte(`
+http:
+  port: 9000
+db:
+  host: file-host
+  user: file-user
+  name: delivery
+geo:
+  grpc_host: geo:5004
+  timeout: 3s
+kafka:
+  brokers: [kafka-1:9092, kafka-2:9092]
+  consumer_group: group
+  basket_confirmed_topic: basket.confirmed
+  order_changed_topic: order.status.changed
+analytics:
+  windows: [10m, 30m]
+`), 0o600)
+	assert.NoError(t, err)
+
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("DB_PASSWORD", "secret")
+	t.Setenv("GEO_SERVICE_TIMEOUT", "4s")
+	t.Setenv("AUTH_API_KEYS", "dispatcher:secret-key, reader:other-key")
+	t.Setenv("ANALYTICS_SURGE_RATIO", "1.5")
+	t.Setenv("ORDERS_EVENT_SOURCED", "true")
+
+	config, err := LoadConfig([]string{"-config", file, "-geo-timeout", "7s"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9000, config.Http.Port)
+	assert.Equal(t, "env-host", config.Db.Host)
+	assert.Equal(t, "file-user", config.Db.User)
+	assert.Equal(t, "secret", config.Db.Password)
+	assert.Equal(t, 7*time.Second, config.Geo.Timeout)
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, config.Kafka.Brokers)
+	assert.Equal(t, "* * * * * *", config.Jobs.AssignOrderSchedule)
+	assert.Equal(t, 10, config.Db.MaxOpenConns)
+	assert.True(t, config.Auth.Enabled)
+	assert.Equal(t, []string{"dispatcher:secret-key", "reader:other-key"}, config.Auth.ApiKeys)
+	assert.Equal(t, []time.Duration{10 * time.Minute, 30 * time.Minute}, config.Analytics.Windows)
+	assert.Equal(t, 1.5, config.Analytics.SurgeRatio)
+	assert.True(t, config.Orders.EventSourced)
+	assert.Equal(t, 50, config.Orders.SnapshotEvery)
+}
+
+func TestLoadConfig_ReportsEveryInvalidField(t *testing.T) {
+	chdir(t, t.TempDir())
+
+	t.Setenv("HTTP_PORT", "not-a-port")
+	t.Setenv("JOBS_MAX_IDLE", "forever")
+
+	_, err := LoadConfig(nil)
+
+	assert.ErrorIs(t, err, errs.ErrValidation)
+	assert.ErrorContains(t, err, "http.port")
+	assert.ErrorContains(t, err, "jobs.max_idle")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	config := validConfig()
+	config.Db.Host = ""
+	config.Db.MaxIdleConns = 20
+	config.Kafka.Brokers = nil
+	config.Jobs.MoveCourierSchedule = "every second"
+	config.Shutdown.Timeout = 0
+	config.Shutdown.CloseGrace = 0
+	config.Auth.ApiKeys = nil
+	config.Dispatch.CrossZone = "sometimes"
+	config.Dispatch.Nearest = "closest"
+	config.Dispatch.OfferTimeout = 0
+	config.Analytics.Windows = nil
+	config.Orders.SnapshotEvery = -1
+	config.Events.EnqueueTimeout = 0
+
+	err := config.Validate()
+
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+	assert.ErrorIs(t, err, errs.ErrValidation)
+	for _, field := range []string{"db.host", "db.max_idle_conns", "kafka.brokers", "jobs.move_courier_schedule", "shutdown.timeout", "shutdown.close_grace", "auth", "dispatch.cross_zone", "dispatch.nearest", "dispatch.offer_timeout", "analytics", "orders.snapshot_every", "events.enqueue_timeout"} {
+		assert.ErrorContains(t, err, field)
+	}
+	assert.NoError(t, validConfig().Validate())
+}
+
+func TestConfig_Validate_LeavesConnectionsBesidesTheJobLocks(t *testing.T) {
+	tests := map[string]struct {
+		maxOpenConns int
+		wantErr      bool
+	}{
+		"every job lock and the headroom": {maxOpenConns: ExclusiveJobs + MinFreeConns},
+		"job locks take the headroom":     {maxOpenConns: ExclusiveJobs + MinFreeConns - 1, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := validConfig()
+			config.Db.MaxOpenConns = tc.maxOpenConns
+			config.Db.MaxIdleConns = 1
+
+			err := config.Validate()
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, errs.ErrValidation)
+				assert.ErrorContains(t, err, "db.max_open_conns")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_IndexReseed(t *testing.T) {
+	tests := map[string]struct {
+		nearest string
+		wantErr bool
+	}{
+		"index needs the reseed interval":      {nearest: NearestByIndex, wantErr: true},
+		"query does without the index":         {nearest: NearestByQuery},
+		"loading every courier needs no index": {nearest: ""},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := validConfig()
+			config.Dispatch.Nearest = tc.nearest
+			config.Dispatch.IndexReseedEvery = 0
+
+			err := config.Validate()
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, errs.ErrValidation)
+				assert.ErrorContains(t, err, "dispatch.index_reseed_every")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWriteConfig_RedactsSecrets(t *testing.T) {
+	var out bytes.Buffer
+
+	err := WriteConfig(&out, validConfig())
+
+	assert.NoError(t, err)
+	assert.NotContains(t, out.String(), "secret")
+	assert.Contains(t, out.String(), redactedValue)
+	assert.Contains(t, out.String(), "timeout: 5s")
+}
+
+func validConfig() Config {
+	config := DefaultConfig()
+	config.Db.Host = "localhost"
+	config.Db.User = "username"
+	config.Db.Password = "secret"
+	config.Db.Name = "delivery"
+	config.Geo.GrpcHost = "localhost:5004"
+	config.Kafka.Brokers = []string{"localhost:9092"}
+	config.Kafka.ConsumerGroup = "delivery-service-group"
+	config.Kafka.BasketConfirmedTopic = "basket.confirmed"
+	config.Kafka.OrderChangedTopic = "order.status.changed"
+	config.Auth.ApiKeys = []string{"dispatcher:secret-key"}
+	return config
+}
+
+// chdir keeps LoadConfig away from the .env and configs/ of the repository
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+}
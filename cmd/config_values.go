@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	_ flag.Getter = (*stringValue)(nil)
+	_ flag.Getter = (*intValue)(nil)
+	_ flag.Getter = (*durationValue)(nil)
+	_ flag.Getter = (*stringListValue)(nil)
+	_ flag.Getter = (*boolValue)(nil)
+	_ flag.Getter = (*floatValue)(nil)
+	_ flag.Getter = (*durationListValue)(nil)
+)
+
+type stringValue string
+
+func (v *stringValue) Set(s string) error {
+	*v = stringValue(s)
+	return nil
+}
+
+func (v *stringValue) Get() interface{} { return string(*v) }
+
+func (v *stringValue) String() string { return string(*v) }
+
+type intValue int
+
+func (v *intValue) Set(s string) error {
+	i, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	*v = intValue(i)
+	return nil
+}
+
+func (v *intValue) Get() interface{} { return int(*v) }
+
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+
+type boolValue bool
+
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	*v = boolValue(b)
+	return nil
+}
+
+func (v *boolValue) Get() interface{} { return bool(*v) }
+
+func (v *boolValue) String() string { return strconv.FormatBool(bool(*v)) }
+
+// IsBoolFlag lets -flag stand for -flag=true
+func (v *boolValue) IsBoolFlag() bool { return true }
+
+type floatValue float64
+
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return err
+	}
+	*v = floatValue(f)
+	return nil
+}
+
+func (v *floatValue) Get() interface{} { return float64(*v) }
+
+func (v *floatValue) String() string { return strconv.FormatFloat(float64(*v), 'g', -1, 64) }
+
+type durationValue time.Duration
+
+func (v *durationValue) Set(s string) error {
+	d, err := time.ParseDuration(strings.TrimSpace(s))
+	if err != nil {
+		return err
+	}
+	*v = durationValue(d)
+	return nil
+}
+
+func (v *durationValue) Get() interface{} { return time.Duration(*v) }
+
+func (v *durationValue) String() string { return time.Duration(*v).String() }
+
+// stringListValue is a comma separated list; every Set replaces the previous value
+type stringListValue []string
+
+func (v *stringListValue) Set(s string) error {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	*v = items
+	return nil
+}
+
+func (v *stringListValue) Get() interface{} { return []string(*v) }
+
+func (v *stringListValue) String() string { return strings.Join(*v, ",") }
+
+// durationListValue is a comma separated list of durations; every Set replaces the previous value
+type durationListValue []time.Duration
+
+func (v *durationListValue) Set(s string) error {
+	var items []time.Duration
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		d, err := time.ParseDuration(item)
+		if err != nil {
+			return err
+		}
+		items = append(items, d)
+	}
+	*v = items
+	return nil
+}
+
+func (v *durationListValue) Get() interface{} { return []time.Duration(*v) }
+
+func (v *durationListValue) String() string {
+	items := make([]string, len(*v))
+	for i, d := range *v {
+		items[i] = d.String()
+	}
+	return strings.Join(items, ",")
+}
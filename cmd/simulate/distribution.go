@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+// distribution draws random values, it is configured as "kind:param:param", e.g.
+// "const:3", "uniform:1:5", "normal:10:2" (mean, stddev) or "exp:6" (mean)
+type distribution struct {
+	spec   string
+	sample func(rnd *rand.Rand) float64
+}
+
+func parseDistribution(spec string) (distribution, error) {
+	parts := strings.Split(spec, ":")
+	params := make([]float64, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		value, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return distribution{}, errs.NewValidationErrorWithCause("distribution", fmt.Sprintf("invalid parameter in %q", spec), err)
+		}
+		params = append(params, value)
+	}
+
+	invalid := func(reason string) (distribution, error) {
+		return distribution{}, errs.NewValidationErrorWithValue("distribution", spec, reason)
+	}
+
+	d := distribution{spec: spec}
+	switch parts[0] {
+	case "const":
+		if len(params) != 1 {
+			return invalid("const needs a value")
+		}
+		value := params[0]
+		d.sample = func(*rand.Rand) float64 { return value }
+	case "uniform":
+		if len(params) != 2 || params[0] > params[1] {
+			return invalid("uniform needs min and max")
+		}
+		low, high := params[0], params[1]
+		d.sample = func(rnd *rand.Rand) float64 { return low + rnd.Float64()*(high-low) }
+	case "normal":
+		if len(params) != 2 || params[1] < 0 {
+			return invalid("normal needs mean and a non negative stddev")
+		}
+		mean, stddev := params[0], params[1]
+		d.sample = func(rnd *rand.Rand) float64 { return mean + rnd.NormFloat64()*stddev }
+	case "exp":
+		if len(params) != 1 || params[0] <= 0 {
+			return invalid("exp needs a positive mean")
+		}
+		mean := params[0]
+		d.sample = func(rnd *rand.Rand) float64 { return rnd.ExpFloat64() * mean }
+	default:
+		return invalid("unknown kind, expected const, uniform, normal or exp")
+	}
+
+	return d, nil
+}
+
+func (d distribution) Float(rnd *rand.Rand) float64 {
+	return d.sample(rnd)
+}
+
+// Int rounds the sample and keeps it within [low, high]
+func (d distribution) Int(rnd *rand.Rand, low, high int) int {
+	value := int(math.Round(d.sample(rnd)))
+	if value < low {
+		return low
+	}
+	if value > high {
+		return high
+	}
+	return value
+}
+
+func (d distribution) String() string {
+	return d.spec
+}
+
+func (d *distribution) Set(spec string) error {
+	parsed, err := parseDistribution(spec)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func mustParseDistribution(spec string) distribution {
+	d, err := parseDistribution(spec)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"context"
+	"hash/fnv"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+var _ ports.GeoServiceClient = &fakeGeoClient{}
+
+// fakeGeoClient always resolves the same street to the same location
+type fakeGeoClient struct {
+}
+
+func (c *fakeGeoClient) GetLocation(_ context.Context, street string) (kernel.Location, error) {
+	if street == "" {
+		return kernel.Location{}, errs.NewValueIsRequiredError("street")
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(street))
+	sum := hash.Sum32()
+
+	return kernel.NewLocation(int(sum%10)+1, int(sum/10%10)+1)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+func main() {
+	config := defaultSimulationConfig()
+	format := "json"
+	output := ""
+
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	flags.StringVar(&config.Strategy, "strategy", config.Strategy, "dispatch strategy: "+strings.Join(strategyNames(), ", "))
+	flags.Int64Var(&config.Seed, "seed", config.Seed, "random seed, runs with the same seed are identical")
+	flags.DurationVar(&config.Duration, "duration", config.Duration, "simulated time")
+	flags.DurationVar(&config.Tick, "tick", config.Tick, "simulated time between job runs")
+	flags.DurationVar(&config.SampleEvery, "sample", config.SampleEvery, "simulated time between metric samples")
+	flags.IntVar(&config.AssignPerTick, "assign-per-tick", config.AssignPerTick, "orders assigned per tick at most")
+	flags.IntVar(&config.Couriers, "couriers", config.Couriers, "number of couriers")
+	flags.Var(&config.CourierSpeed, "courier-speed", "courier speed in cells per second")
+	flags.Var(&config.CourierCapacity, "courier-capacity", "courier storage volume")
+	flags.Var(&config.OrderInterarrival, "order-interarrival", "seconds between order arrivals")
+	flags.Var(&config.OrderVolume, "order-volume", "order volume")
+	flags.Float64Var(&config.ExpressShare, "express-share", config.ExpressShare, "share of express orders, from 0 to 1")
+	flags.IntVar(&config.Streets, "streets", config.Streets, "number of distinct delivery streets")
+	flags.StringVar(&format, "format", format, "report format: json or csv")
+	flags.StringVar(&output, "out", output, "report file, stdout by default")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: simulate [flags]")
+		fmt.Fprintln(flags.Output(), "Distributions are const:v, uniform:min:max, normal:mean:stddev or exp:mean.")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(os.Args[1:])
+
+	write, ok := map[string]func(io.Writer, report) error{"json": writeJSON, "csv": writeCSV}[format]
+	if !ok {
+		log.Fatalf("unknown format %q, expected json or csv", format)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	sim, err := newSimulation(config)
+	if err != nil {
+		log.Fatalf("invalid simulation: %v", err)
+	}
+	result, err := sim.Run(ctx)
+	if err != nil {
+		log.Fatalf("simulation failed: %v", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output)
+		if err != nil {
+			log.Fatalf("failed to create report file: %v", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	if err := write(w, result); err != nil {
+		log.Fatalf("failed to write report: %v", err)
+	}
+
+	log.Printf("orders created %d, completed %d, delivery time mean %.1fs p95 %.1fs, utilisation %.2f, backlog max %d",
+		result.OrdersCreated, result.OrdersCompleted, result.DeliveryTimeMeanSeconds, result.DeliveryTimeP95Seconds,
+		result.UtilisationMean, result.BacklogMax)
+}
+
+func strategyNames() []string {
+	names := make([]string, 0, len(strategies))
+	for name := range strategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type report struct {
+	Strategy                string   `json:"strategy"`
+	Seed                    int64    `json:"seed"`
+	DurationSeconds         float64  `json:"duration_seconds"`
+	Couriers                int      `json:"couriers"`
+	OrdersCreated           int      `json:"orders_created"`
+	OrdersCompleted         int      `json:"orders_completed"`
+	DeliveryTimeMeanSeconds float64  `json:"delivery_time_mean_seconds"`
+	DeliveryTimeP95Seconds  float64  `json:"delivery_time_p95_seconds"`
+	UtilisationMean         float64  `json:"utilisation_mean"`
+	BacklogMax              int      `json:"backlog_max"`
+	BacklogFinal            int      `json:"backlog_final"`
+	Samples                 []sample `json:"samples"`
+}
+
+type sample struct {
+	TimeSeconds  float64 `json:"time_seconds"`
+	Backlog      int     `json:"backlog"`
+	BusyCouriers int     `json:"busy_couriers"`
+	Utilisation  float64 `json:"utilisation"`
+}
+
+type metricsCollector struct {
+	config         simulationConfig
+	couriers       int
+	ticks          int
+	utilisationSum float64
+	backlogMax     int
+	samples        []sample
+}
+
+func newMetricsCollector(config simulationConfig, couriers int) *metricsCollector {
+	return &metricsCollector{
+		config:   config,
+		couriers: couriers,
+	}
+}
+
+func (m *metricsCollector) observeTick(backlog int, busyCouriers int) {
+	m.ticks++
+	m.utilisationSum += m.utilisation(busyCouriers)
+	if backlog > m.backlogMax {
+		m.backlogMax = backlog
+	}
+}
+
+func (m *metricsCollector) sample(elapsed time.Duration, backlog int, busyCouriers int) {
+	m.samples = append(m.samples, sample{
+		TimeSeconds:  elapsed.Seconds(),
+		Backlog:      backlog,
+		BusyCouriers: busyCouriers,
+		Utilisation:  m.utilisation(busyCouriers),
+	})
+}
+
+func (m *metricsCollector) report(orders map[uuid.UUID]*trackedOrder, backlog int) report {
+	deliveryTimes := sortedDeliveryTimes(orders)
+
+	r := report{
+		Strategy:               m.config.Strategy,
+		Seed:                   m.config.Seed,
+		DurationSeconds:        m.config.Duration.Seconds(),
+		Couriers:               m.couriers,
+		OrdersCreated:          len(orders),
+		OrdersCompleted:        len(deliveryTimes),
+		DeliveryTimeP95Seconds: percentile(deliveryTimes, 95),
+		BacklogMax:             m.backlogMax,
+		BacklogFinal:           backlog,
+		Samples:                m.samples,
+	}
+	if len(deliveryTimes) > 0 {
+		sum := 0.0
+		for _, t := range deliveryTimes {
+			sum += t
+		}
+		r.DeliveryTimeMeanSeconds = sum / float64(len(deliveryTimes))
+	}
+	if m.ticks > 0 {
+		r.UtilisationMean = m.utilisationSum / float64(m.ticks)
+	}
+	return r
+}
+
+func (m *metricsCollector) utilisation(busyCouriers int) float64 {
+	if m.couriers == 0 {
+		return 0
+	}
+	return float64(busyCouriers) / float64(m.couriers)
+}
+
+func sortedDeliveryTimes(orders map[uuid.UUID]*trackedOrder) []float64 {
+	times := make([]float64, 0, len(orders))
+	for _, tracked := range orders {
+		if !tracked.completedAt.IsZero() {
+			times = append(times, tracked.completedAt.Sub(tracked.createdAt).Seconds())
+		}
+	}
+	sort.Float64s(times)
+	return times
+}
+
+// percentile uses the nearest rank method on sorted values
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = max(1, min(rank, len(sorted)))
+	return sorted[rank-1]
+}
+
+func writeJSON(w io.Writer, r report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// writeCSV writes the samples over time, the summary is only part of the JSON report
+func writeCSV(w io.Writer, r report) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"time_seconds", "backlog", "busy_couriers", "utilisation"}); err != nil {
+		return err
+	}
+	for _, s := range r.Samples {
+		record := []string{
+			strconv.FormatFloat(s.TimeSeconds, 'f', -1, 64),
+			strconv.Itoa(s.Backlog),
+			strconv.Itoa(s.BusyCouriers),
+			strconv.FormatFloat(s.Utilisation, 'f', 4, 64),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
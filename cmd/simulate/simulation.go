@@ -0,0 +1,406 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/delivery/internal/adapters/out/clock"
+	"github.com/delivery/internal/adapters/out/memory"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// nearestFirstCandidates is how many of the nearest free couriers the nearest-first strategy offers an order to first
+const nearestFirstCandidates = 3
+
+// strategies are the ways of assigning orders that can be compared with the simulation
+var strategies = map[string]func(uow ports.UnitOfWork, eta service.EtaService) (commands.AssignOrderHandler, error){
+	// nearest weighs every free courier for every order
+	"nearest": func(uow ports.UnitOfWork, eta service.EtaService) (commands.AssignOrderHandler, error) {
+		return commands.NewAssignOrderHandler(uow, service.NewDispatchService(), eta)
+	},
+	// nearest-first offers a standard order to the couriers reaching it first, the rest only when none of them can take it
+	"nearest-first": func(uow ports.UnitOfWork, eta service.EtaService) (commands.AssignOrderHandler, error) {
+		return commands.NewNearestFirstAssignOrderHandler(uow, service.NewDispatchService(), eta,
+			uow.CourierRepository(), nearestFirstCandidates)
+	},
+}
+
+// simulationStart is fixed, so runs with the same seed produce the same report
+var simulationStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type simulationConfig struct {
+	Strategy          string
+	Seed              int64
+	Duration          time.Duration
+	Tick              time.Duration
+	SampleEvery       time.Duration
+	AssignPerTick     int
+	Couriers          int
+	CourierSpeed      distribution
+	CourierCapacity   distribution
+	OrderInterarrival distribution
+	OrderVolume       distribution
+	ExpressShare      float64
+	Streets           int
+}
+
+func defaultSimulationConfig() simulationConfig {
+	return simulationConfig{
+		Strategy:          "nearest",
+		Seed:              1,
+		Duration:          time.Hour,
+		Tick:              time.Second,
+		SampleEvery:       time.Minute,
+		AssignPerTick:     1,
+		Couriers:          10,
+		CourierSpeed:      mustParseDistribution("uniform:1:3"),
+		CourierCapacity:   mustParseDistribution("const:10"),
+		OrderInterarrival: mustParseDistribution("exp:10"),
+		OrderVolume:       mustParseDistribution("uniform:1:10"),
+		Streets:           100,
+	}
+}
+
+func (c simulationConfig) Validate() error {
+	if _, ok := strategies[c.Strategy]; !ok {
+		return errs.NewValidationErrorWithValue("strategy", c.Strategy, "unknown dispatch strategy")
+	}
+	if c.Duration <= 0 || c.Tick <= 0 || c.SampleEvery <= 0 {
+		return errs.NewValidationError("duration", "duration, tick and sample interval must be greater than zero")
+	}
+	if c.AssignPerTick <= 0 {
+		return errs.NewValidationErrorWithValue("assign per tick", c.AssignPerTick, "must be greater than zero")
+	}
+	if c.Couriers <= 0 {
+		return errs.NewValidationErrorWithValue("couriers", c.Couriers, "must be greater than zero")
+	}
+	if c.ExpressShare < 0 || c.ExpressShare > 1 {
+		return errs.NewValidationErrorWithValue("express share", c.ExpressShare, "must be between 0 and 1")
+	}
+	if c.Streets <= 0 {
+		return errs.NewValidationErrorWithValue("streets", c.Streets, "must be greater than zero")
+	}
+	return nil
+}
+
+type trackedOrder struct {
+	order       *order.Order
+	createdAt   time.Time
+	completedAt time.Time
+}
+
+// simulation drives the real command handlers against in-memory storage on a virtual clock
+type simulation struct {
+	config simulationConfig
+	rnd    *rand.Rand
+	clock  *clock.FakeClock
+	uow    *memory.UnitOfWork
+
+	createOrder  commands.CreateOrderHandler
+	expireOffers commands.ExpireOffersHandler
+	assignOrder  commands.AssignOrderHandler
+	moveCourier  commands.MoveCourierHandler
+	acceptOffer  commands.AcceptOfferHandler
+	pickUpOrder  commands.PickUpOrderHandler
+	deliver      commands.DeliverOrderHandler
+
+	couriers    []*courier.Courier
+	orders      map[uuid.UUID]*trackedOrder
+	waiting     []*trackedOrder
+	nextArrival time.Time
+}
+
+func newSimulation(config simulationConfig) (*simulation, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	mediatr := ddd.NewMediatr()
+	uow, err := memory.NewUnitOfWork(mediatr)
+	if err != nil {
+		return nil, err
+	}
+
+	virtualClock := clock.NewFakeClock(simulationStart)
+	createOrder, err := commands.NewAddCreateOrderHandler(uow, &fakeGeoClient{}, virtualClock)
+	if err != nil {
+		return nil, err
+	}
+	eta, err := service.NewEtaService(config.Tick)
+	if err != nil {
+		return nil, err
+	}
+	expireOffers, err := commands.NewExpireOffersHandler(uow)
+	if err != nil {
+		return nil, err
+	}
+	assignOrder, err := strategies[config.Strategy](uow, eta)
+	if err != nil {
+		return nil, err
+	}
+	moveCourier, err := commands.NewMoveCourierHandler(uow, eta, config.Tick)
+	if err != nil {
+		return nil, err
+	}
+	acceptOffer, err := commands.NewAcceptOfferHandler(uow)
+	if err != nil {
+		return nil, err
+	}
+	pickUpOrder, err := commands.NewPickUpOrderHandler(uow)
+	if err != nil {
+		return nil, err
+	}
+	deliver, err := commands.NewDeliverOrderHandler(uow)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &simulation{
+		config:       config,
+		rnd:          rand.New(rand.NewSource(config.Seed)),
+		clock:        virtualClock,
+		uow:          uow,
+		createOrder:  createOrder,
+		expireOffers: expireOffers,
+		assignOrder:  assignOrder,
+		moveCourier:  moveCourier,
+		acceptOffer:  acceptOffer,
+		pickUpOrder:  pickUpOrder,
+		deliver:      deliver,
+		orders:       make(map[uuid.UUID]*trackedOrder),
+		nextArrival:  simulationStart,
+	}
+	mediatr.Subscribe(&completionRecorder{simulation: s}, order.NewCompletedDomainEventWithoutData())
+
+	return s, nil
+}
+
+func (s *simulation) Run(ctx context.Context) (report, error) {
+	if err := s.addCouriers(ctx); err != nil {
+		return report{}, err
+	}
+
+	metrics := newMetricsCollector(s.config, len(s.couriers))
+	end := simulationStart.Add(s.config.Duration)
+	nextSample := simulationStart
+
+	for now := s.clock.Now(); now.Before(end); now = s.clock.Advance(s.config.Tick) {
+		if err := ctx.Err(); err != nil {
+			return report{}, err
+		}
+
+		if err := s.receiveOrders(ctx, now); err != nil {
+			return report{}, err
+		}
+		if err := s.assignOrders(ctx, now); err != nil {
+			return report{}, err
+		}
+		if err := s.useCourierApps(ctx, now); err != nil {
+			return report{}, err
+		}
+		if err := s.moveCouriers(ctx, now); err != nil {
+			return report{}, err
+		}
+
+		backlog := s.backlog()
+		metrics.observeTick(backlog, s.busyCouriers())
+		if !now.Before(nextSample) {
+			metrics.sample(now.Sub(simulationStart), backlog, s.busyCouriers())
+			nextSample = nextSample.Add(s.config.SampleEvery)
+		}
+	}
+
+	return metrics.report(s.orders, s.backlog()), nil
+}
+
+func (s *simulation) addCouriers(ctx context.Context) error {
+	for i := 1; i <= s.config.Couriers; i++ {
+		speed := s.config.CourierSpeed.Int(s.rnd, 1, 20)
+		location := kernel.CreateRandomLocationFrom(s.rnd)
+
+		// the id comes from the seed too, the couriers reaching an order at once are ranked by it
+		courierID, err := uuid.NewRandomFromReader(s.rnd)
+		if err != nil {
+			return err
+		}
+		c := courier.RestoreCourier(courierID, fmt.Sprintf("Courier %d", i), speed, location, nil,
+			time.Time{}, 0, time.Time{}, 0, nil, time.Time{}, 0)
+		if err := c.AddStoragePlace("Bag", s.config.CourierCapacity.Int(s.rnd, 1, 1000)); err != nil {
+			return err
+		}
+		if err := s.uow.CourierRepository().Add(ctx, c); err != nil {
+			return err
+		}
+		s.couriers = append(s.couriers, c)
+	}
+	return nil
+}
+
+func (s *simulation) receiveOrders(ctx context.Context, now time.Time) error {
+	for !s.nextArrival.After(now) {
+		orderID, err := uuid.NewRandomFromReader(s.rnd)
+		if err != nil {
+			return err
+		}
+		street := fmt.Sprintf("Street %d", s.rnd.Intn(s.config.Streets)+1)
+		volume := s.config.OrderVolume.Int(s.rnd, 1, 1000)
+		priority := order.Standard
+		if s.config.ExpressShare > 0 && s.rnd.Float64() < s.config.ExpressShare {
+			priority = order.Express
+		}
+
+		command, err := commands.NewCreateOrderCommand(orderID, orderID.String(), street, volume, priority, nil)
+		if err != nil {
+			return err
+		}
+		if err := s.createOrder.Handle(ctx, command); err != nil {
+			return err
+		}
+
+		created, err := s.uow.OrderRepository().Get(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		tracked := &trackedOrder{order: created, createdAt: s.nextArrival}
+		s.orders[orderID] = tracked
+		s.waiting = append(s.waiting, tracked)
+
+		interarrival := time.Duration(s.config.OrderInterarrival.Float(s.rnd) * float64(time.Second))
+		if interarrival < 0 {
+			interarrival = 0
+		}
+		s.nextArrival = s.nextArrival.Add(interarrival)
+	}
+	return nil
+}
+
+// assignOrders runs the assignment as often per tick as AssignOrderJob would
+func (s *simulation) assignOrders(ctx context.Context, now time.Time) error {
+	expire, err := commands.NewExpireOffersCommand(now)
+	if err != nil {
+		return err
+	}
+	if err := s.expireOffers.Handle(ctx, expire); err != nil {
+		return err
+	}
+
+	for i := 0; i < s.config.AssignPerTick; i++ {
+		command, err := commands.NewAssignOrderCommand(now)
+		if err != nil {
+			return err
+		}
+		if err := s.assignOrder.Handle(ctx, command); err != nil {
+			if errs.IsNotFound(err) || errs.IsBusiness(err) {
+				// nothing to assign or nobody to assign it to
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// useCourierApps does what the couriers do in their app: they accept every offer and pick the order
+// up on the spot, and hand an order over as soon as they reach the customer
+func (s *simulation) useCourierApps(ctx context.Context, now time.Time) error {
+	withCourier, err := s.uow.OrderRepository().GetAllWithCourier(ctx)
+	if err != nil {
+		return err
+	}
+	couriers := make(map[uuid.UUID]*courier.Courier, len(s.couriers))
+	for _, c := range s.couriers {
+		couriers[c.ID()] = c
+	}
+
+	for _, o := range withCourier {
+		courierID := *o.CourierID()
+		switch o.Status() {
+		case order.Assigned:
+			accept, err := commands.NewAcceptOfferCommand(courierID, o.ID(), now)
+			if err != nil {
+				return err
+			}
+			if err := s.acceptOffer.Handle(ctx, accept); err != nil {
+				return err
+			}
+			pickUp, err := commands.NewPickUpOrderCommand(courierID, o.ID())
+			if err != nil {
+				return err
+			}
+			if err := s.pickUpOrder.Handle(ctx, pickUp); err != nil {
+				return err
+			}
+		case order.PickedUp:
+			if c, ok := couriers[courierID]; !ok || !c.Location().Equals(o.Location()) {
+				continue
+			}
+			deliver, err := commands.NewDeliverOrderCommand(courierID, o.ID(), nil)
+			if err != nil {
+				return err
+			}
+			if err := s.deliver.Handle(ctx, deliver); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *simulation) moveCouriers(ctx context.Context, now time.Time) error {
+	command, err := commands.NewMoveCourierCommand(now)
+	if err != nil {
+		return err
+	}
+	return s.moveCourier.Handle(ctx, command)
+}
+
+func (s *simulation) backlog() int {
+	waiting := s.waiting[:0]
+	for _, tracked := range s.waiting {
+		if tracked.order.Status() == order.Created {
+			waiting = append(waiting, tracked)
+		}
+	}
+	s.waiting = waiting
+	return len(waiting)
+}
+
+func (s *simulation) busyCouriers() int {
+	busy := 0
+	for _, c := range s.couriers {
+		for _, storagePlace := range c.StoragePlaces() {
+			if storagePlace.OrderID() != nil {
+				busy++
+				break
+			}
+		}
+	}
+	return busy
+}
+
+var _ ddd.EventHandler = &completionRecorder{}
+
+// completionRecorder notes the virtual time an order was delivered at
+type completionRecorder struct {
+	simulation *simulation
+}
+
+func (r *completionRecorder) Handle(_ context.Context, event ddd.DomainEvent) error {
+	if _, ok := event.(*order.CompletedDomainEvent); !ok {
+		return nil
+	}
+	if tracked, ok := r.simulation.orders[event.GetAggregateID()]; ok && tracked.completedAt.IsZero() {
+		tracked.completedAt = r.simulation.clock.Now()
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulation_Run(t *testing.T) {
+	tests := map[string]struct {
+		strategy            string
+		wantCompleted       int
+		wantDeliveryTime    float64
+		wantUtilisationMean float64
+	}{
+		"nearest": {
+			strategy:            "nearest",
+			wantCompleted:       143,
+			wantDeliveryTime:    3.5141207549650364,
+			wantUtilisationMean: 0.139,
+		},
+		"nearest first": {
+			strategy:            "nearest-first",
+			wantCompleted:       143,
+			wantDeliveryTime:    3.5840508248951073,
+			wantUtilisationMean: 0.14233333333333387,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := defaultSimulationConfig()
+			config.Strategy = tc.strategy
+			config.Duration = 10 * time.Minute
+			config.Couriers = 5
+			config.OrderInterarrival = mustParseDistribution("exp:4")
+
+			run := func() report {
+				sim, err := newSimulation(config)
+				require.NoError(t, err)
+				result, err := sim.Run(context.Background())
+				require.NoError(t, err)
+				return result
+			}
+
+			first := run()
+			second := run()
+
+			assert.Equal(t, first, second, "same seed must give the same report")
+			assert.Equal(t, 145, first.OrdersCreated)
+			assert.Equal(t, tc.wantCompleted, first.OrdersCompleted)
+			assert.InDelta(t, tc.wantDeliveryTime, first.DeliveryTimeMeanSeconds, 1e-9)
+			assert.InDelta(t, tc.wantUtilisationMean, first.UtilisationMean, 1e-9)
+			assert.LessOrEqual(t, first.DeliveryTimeMeanSeconds, first.DeliveryTimeP95Seconds)
+			assert.Len(t, first.Samples, 10)
+		})
+	}
+}
+
+func TestSimulationConfig_Validate(t *testing.T) {
+	config := defaultSimulationConfig()
+	config.Strategy = "random"
+
+	_, err := newSimulation(config)
+
+	assert.ErrorIs(t, err, errs.ErrValidation)
+}
+
+func TestParseDistribution(t *testing.T) {
+	tests := map[string]struct {
+		spec    string
+		low     float64
+		high    float64
+		wantErr bool
+	}{
+		"const":             {spec: "const:3", low: 3, high: 3},
+		"uniform":           {spec: "uniform:1:5", low: 1, high: 5},
+		"exp":               {spec: "exp:2", low: 0, high: 1000},
+		"normal":            {spec: "normal:10:0", low: 10, high: 10},
+		"unknown kind":      {spec: "poisson:3", wantErr: true},
+		"missing parameter": {spec: "uniform:1", wantErr: true},
+		"not a number":      {spec: "const:x", wantErr: true},
+		"inverted uniform":  {spec: "uniform:5:1", wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, err := parseDistribution(tc.spec)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, errs.ErrValidation)
+				return
+			}
+			assert.NoError(t, err)
+			rnd := rand.New(rand.NewSource(1))
+			for i := 0; i < 100; i++ {
+				value := d.Float(rnd)
+				assert.GreaterOrEqual(t, value, tc.low)
+				assert.LessOrEqual(t, value, tc.high)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+
+	assert.Equal(t, 10.0, percentile(values, 95))
+	assert.Equal(t, 5.0, percentile(values, 50))
+	assert.Equal(t, 1.0, percentile(values, 1))
+	assert.Zero(t, percentile(nil, 95))
+}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) AcceptOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error {
+	command, err := commands.NewAcceptOfferCommand(courierId, orderId, s.clock.Now())
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	if err = s.acceptOrder.Handle(ctx.Request().Context(), command); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/delivery/internal/adapters/in/http/auth"
+	"github.com/delivery/internal/pkg/audit"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+)
+
+// NewAuditContext tells the audit log who changed the aggregates: the caller the authorizer
+// authenticated, the operation id as the command and the request id as the correlation id.
+// It runs after the request id and the authorizer middlewares.
+func NewAuditContext(spec *openapi3.T) (echo.MiddlewareFunc, error) {
+	router, err := newSpecRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			request := ctx.Request()
+			auditCtx := audit.WithActor(request.Context(), actor(request.Context()))
+			auditCtx = audit.WithCorrelationID(auditCtx, ctx.Response().Header().Get(echo.HeaderXRequestID))
+			if route, _, err := router.FindRoute(request); err == nil {
+				auditCtx = audit.WithCommand(auditCtx, route.Operation.OperationID)
+			}
+			ctx.SetRequest(request.WithContext(auditCtx))
+			return next(ctx)
+		}
+	}, nil
+}
+
+func actor(ctx context.Context) string {
+	principal := auth.FromContext(ctx)
+	switch {
+	case principal == nil:
+		return "anonymous"
+	case principal.CourierID != nil:
+		return fmt.Sprintf("courier:%s", principal.CourierID)
+	default:
+		return principal.Subject
+	}
+}
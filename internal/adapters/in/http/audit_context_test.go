@@ -0,0 +1,60 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/delivery/internal/pkg/audit"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AuditContext(t *testing.T) {
+	signer := newTestSigner(t)
+	courierID := uuid.New()
+
+	tests := map[string]struct {
+		token     string
+		wantActor string
+	}{
+		"courier app": {
+			token:     signer.token(t, "courier", &courierID),
+			wantActor: "courier:" + courierID.String(),
+		},
+		"dispatcher": {
+			token:     signer.token(t, "dispatcher", nil),
+			wantActor: "user-1",
+		},
+	}
+
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(courierAppSpec))
+	require.NoError(t, err)
+	auditContext, err := NewAuditContext(spec)
+	require.NoError(t, err)
+	e := newAuthorizedEcho(t, spec, signer.verifier(t), nil)
+	e.Pre(middleware.RequestIDWithConfig(middleware.RequestIDConfig{Generator: func() string { return "request-1" }}))
+	e.Use(auditContext)
+	var got audit.Metadata
+	e.GET("/api/v1/couriers/:courierId/assignment", func(ctx echo.Context) error {
+		got = audit.FromContext(ctx.Request().Context())
+		return ctx.NoContent(http.StatusOK)
+	})
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/couriers/"+courierID.String()+"/assignment", nil)
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+tc.token)
+			recorder := httptest.NewRecorder()
+
+			e.ServeHTTP(recorder, request)
+
+			require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+			assert.Equal(t, audit.Metadata{Actor: tc.wantActor, Command: "GetAssignment", CorrelationID: "request-1"}, got)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+var ErrUnknownAPIKey = errors.New("unknown api key")
+
+type apiKey struct {
+	hash [sha256.Size]byte
+	role Role
+}
+
+// APIKeys authenticate the services calling the api, each key grants a single role
+type APIKeys struct {
+	keys []apiKey
+}
+
+// ParseAPIKeys reads "role:key" entries
+func ParseAPIKeys(entries []string) (*APIKeys, error) {
+	keys := &APIKeys{}
+	for i, entry := range entries {
+		role, key, found := strings.Cut(entry, ":")
+		if !found || key == "" {
+			return nil, errs.NewValidationError(fmt.Sprintf("api key %d", i), "must look like role:key")
+		}
+		switch Role(role) {
+		case Dispatcher, Reader:
+		default:
+			return nil, errs.NewValidationErrorWithValue(fmt.Sprintf("api key %d role", i), role,
+				"must be dispatcher or reader")
+		}
+		keys.keys = append(keys.keys, apiKey{hash: sha256.Sum256([]byte(key)), role: Role(role)})
+	}
+	return keys, nil
+}
+
+// Verify compares the hashes of the keys in constant time, so a response time tells nothing about a key
+func (k *APIKeys) Verify(key string) (*Principal, error) {
+	hash := sha256.Sum256([]byte(key))
+	var principal *Principal
+	for i, candidate := range k.keys {
+		if subtle.ConstantTimeCompare(hash[:], candidate.hash[:]) == 1 && principal == nil {
+			principal = &Principal{
+				Subject: fmt.Sprintf("api-key-%d", i),
+				Roles:   []Role{candidate.role},
+			}
+		}
+	}
+	if principal == nil {
+		return nil, ErrUnknownAPIKey
+	}
+	return principal, nil
+}
+
+func (k *APIKeys) Len() int {
+	return len(k.keys)
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	tests := map[string]struct {
+		entries []string
+		err     error
+	}{
+		"dispatcher and reader keys": {
+			entries: []string{"dispatcher:key-1", "reader:key:with:colons"},
+		},
+		"no role": {
+			entries: []string{"key-1"},
+			err:     errs.ErrValidation,
+		},
+		"courier role": {
+			entries: []string{"courier:key-1"},
+			err:     errs.ErrValidation,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			keys, err := ParseAPIKeys(tc.entries)
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, len(tc.entries), keys.Len())
+			}
+		})
+	}
+}
+
+func TestAPIKeys_Verify(t *testing.T) {
+	keys, err := ParseAPIKeys([]string{"dispatcher:key-1", "reader:key:with:colons"})
+	require.NoError(t, err)
+
+	principal, err := keys.Verify("key:with:colons")
+	assert.NoError(t, err)
+	assert.Equal(t, []Role{Reader}, principal.Roles)
+
+	_, err = keys.Verify("key-2")
+	assert.ErrorIs(t, err, ErrUnknownAPIKey)
+}
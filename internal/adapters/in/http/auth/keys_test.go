@@ -0,0 +1,152 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, ecKey, edKey := generateKeys(t)
+
+	tests := map[string]struct {
+		keys    []map[string]string
+		wantKid []string
+		err     error
+	}{
+		"every supported key type": {
+			keys:    []map[string]string{rsaJWK("rsa", rsaKey), ecJWK("ec", ecKey), edJWK("ed", edKey)},
+			wantKid: []string{"rsa", "ec", "ed"},
+		},
+		"encryption key is skipped": {
+			keys: []map[string]string{
+				rsaJWK("rsa", rsaKey),
+				withUse(ecJWK("enc", ecKey), "enc"),
+			},
+			wantKid: []string{"rsa"},
+		},
+		"no signing key": {
+			keys: []map[string]string{withUse(ecJWK("enc", ecKey), "enc")},
+			err:  errs.ErrValueIsRequired,
+		},
+		"point off the curve": {
+			keys: []map[string]string{func() map[string]string {
+				key := ecJWK("ec", ecKey)
+				key["y"] = encodeBigInt(big.NewInt(1))
+				return key
+			}()},
+			err: errs.ErrValidation,
+		},
+		"symmetric key": {
+			keys: []map[string]string{{"kid": "hmac", "kty": "oct", "k": "c2VjcmV0"}},
+			err:  errs.ErrValidation,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			content, err := json.Marshal(map[string]interface{}{"keys": tc.keys})
+			require.NoError(t, err)
+
+			keys, err := ParseJWKS(content)
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, keys, len(tc.wantKid))
+			for _, kid := range tc.wantKid {
+				assert.Contains(t, keys, kid)
+			}
+		})
+	}
+}
+
+func TestLoadPublicKeys(t *testing.T) {
+	rsaKey, _, edKey := generateKeys(t)
+	dir := t.TempDir()
+	rsaPath := writePublicKey(t, dir, "issuer-1.pem", rsaKey.Public())
+	edPath := writePublicKey(t, dir, "issuer-2.pem", edKey.Public())
+
+	keys, err := LoadPublicKeys([]string{rsaPath, edPath})
+
+	assert.NoError(t, err)
+	assert.True(t, rsaKey.PublicKey.Equal(keys["issuer-1"]))
+	assert.True(t, edKey.Public().(ed25519.PublicKey).Equal(keys["issuer-2"]))
+
+	notPem := filepath.Join(dir, "broken.pem")
+	require.NoError(t, os.WriteFile(notPem, []byte("not a key"), 0o600))
+	_, err = LoadPublicKeys([]string{notPem})
+	assert.ErrorIs(t, err, errs.ErrValidation)
+}
+
+func generateKeys(t *testing.T) (*rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return rsaKey, ecKey, edKey
+}
+
+func rsaJWK(kid string, key *rsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "RSA",
+		"n":   encodeBigInt(key.N),
+		"e":   encodeBigInt(big.NewInt(int64(key.E))),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   encodeBigInt(key.X),
+		"y":   encodeBigInt(key.Y),
+	}
+}
+
+func edJWK(kid string, key ed25519.PrivateKey) map[string]string {
+	return map[string]string{
+		"kid": kid,
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"x":   base64.RawURLEncoding.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}
+}
+
+func withUse(key map[string]string, use string) map[string]string {
+	key["use"] = use
+	return key
+}
+
+func encodeBigInt(value *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(value.Bytes())
+}
+
+func writePublicKey(t *testing.T, dir string, name string, key crypto.PublicKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	require.NoError(t, err)
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600))
+	return path
+}
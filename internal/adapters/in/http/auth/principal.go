@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	Dispatcher Role = "dispatcher"
+	Courier    Role = "courier"
+	Reader     Role = "reader"
+)
+
+// Principal is the caller a request was authenticated as
+type Principal struct {
+	Subject string
+	Roles   []Role
+	// CourierID is set for the courier app, it acts only on this courier
+	CourierID *uuid.UUID
+}
+
+func (p *Principal) HasRole(role Role) bool {
+	return slices.Contains(p.Roles, role)
+}
+
+// OnlyCourier tells that of the given roles the principal holds the courier one alone,
+// so it is limited to its own courier
+func (p *Principal) OnlyCourier(roles []Role) bool {
+	granted := false
+	for _, role := range roles {
+		if !p.HasRole(role) {
+			continue
+		}
+		if role != Courier {
+			return false
+		}
+		granted = true
+	}
+	return granted
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the principal of an authenticated request, nil for a public route
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
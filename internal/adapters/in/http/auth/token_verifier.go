@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+var ErrInvalidToken = errors.New("invalid token")
+
+// claims are the registered ones plus the roles of the caller and, for the courier app, its courier
+type claims struct {
+	jwt.RegisteredClaims
+	Roles     []Role `json:"roles"`
+	CourierID string `json:"courier_id"`
+}
+
+type TokenVerifier struct {
+	keys   KeySet
+	parser *jwt.Parser
+}
+
+// NewTokenVerifier accepts tokens signed by any of the keys with an asymmetric algorithm,
+// issuer and audience are checked when they are set
+func NewTokenVerifier(keys KeySet, issuer string, audience string) (*TokenVerifier, error) {
+	if len(keys) == 0 {
+		return nil, errs.NewValueIsRequiredError("keys")
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512",
+			"ES256", "ES384", "ES512", "EdDSA"}),
+		jwt.WithExpirationRequired(),
+	}
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+
+	return &TokenVerifier{
+		keys:   keys,
+		parser: jwt.NewParser(options...),
+	}, nil
+}
+
+func (v *TokenVerifier) Verify(token string) (*Principal, error) {
+	var c claims
+	if _, err := v.parser.ParseWithClaims(token, &c, v.key); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	principal := &Principal{
+		Subject: c.Subject,
+		Roles:   c.Roles,
+	}
+	if principal.HasRole(Courier) {
+		courierID, err := uuid.Parse(c.CourierID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: courier token must name its courier: %w", ErrInvalidToken, err)
+		}
+		principal.CourierID = &courierID
+	}
+	return principal, nil
+}
+
+// key picks the key by the kid header, a token without one is accepted only when there is a single key
+func (v *TokenVerifier) key(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" && len(v.keys) == 1 {
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	return key, nil
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenVerifier_Verify(t *testing.T) {
+	rsaKey, ecKey, edKey := generateKeys(t)
+	keys := KeySet{"rsa": &rsaKey.PublicKey, "ec": &ecKey.PublicKey, "ed": edKey.Public()}
+	verifier, err := NewTokenVerifier(keys, "https://issuer", "delivery")
+	require.NoError(t, err)
+	courierID := uuid.New()
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":   "user-1",
+			"iss":   "https://issuer",
+			"aud":   "delivery",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"roles": []string{"dispatcher"},
+		}
+	}
+	with := func(key string, value interface{}) jwt.MapClaims {
+		c := valid()
+		c[key] = value
+		return c
+	}
+	without := func(key string) jwt.MapClaims {
+		c := valid()
+		delete(c, key)
+		return c
+	}
+	courierClaims := with("roles", []string{"courier"})
+	courierClaims["courier_id"] = courierID.String()
+
+	tests := map[string]struct {
+		method    jwt.SigningMethod
+		kid       string
+		key       interface{}
+		claims    jwt.MapClaims
+		wantErr   bool
+		wantRoles []Role
+	}{
+		"rsa signed dispatcher": {
+			method: jwt.SigningMethodRS256, kid: "rsa", key: rsaKey, claims: valid(),
+			wantRoles: []Role{Dispatcher},
+		},
+		"ecdsa signed reader": {
+			method: jwt.SigningMethodES256, kid: "ec", key: ecKey, claims: with("roles", []string{"reader"}),
+			wantRoles: []Role{Reader},
+		},
+		"ed25519 signed courier": {
+			method: jwt.SigningMethodEdDSA, kid: "ed", key: edKey,
+			claims:    courierClaims,
+			wantRoles: []Role{Courier},
+		},
+		"expired": {
+			method: jwt.SigningMethodRS256, kid: "rsa", key: rsaKey,
+			claims: with("exp", time.Now().Add(-time.Minute).Unix()), wantErr: true,
+		},
+		"no expiry": {
+			method: jwt.SigningMethodRS256, kid: "rsa", key: rsaKey, claims: without("exp"), wantErr: true,
+		},
+		"other issuer": {
+			method: jwt.SigningMethodRS256, kid: "rsa", key: rsaKey, claims: with("iss", "https://evil"), wantErr: true,
+		},
+		"other audience": {
+			method: jwt.SigningMethodRS256, kid: "rsa", key: rsaKey, claims: with("aud", "billing"), wantErr: true,
+		},
+		"unknown key id": {
+			method: jwt.SigningMethodRS256, kid: "other", key: rsaKey, claims: valid(), wantErr: true,
+		},
+		"signed by a key of another kid": {
+			method: jwt.SigningMethodES256, kid: "rsa", key: ecKey, claims: valid(), wantErr: true,
+		},
+		"symmetric algorithm": {
+			method: jwt.SigningMethodHS256, kid: "rsa", key: []byte("secret"), claims: valid(), wantErr: true,
+		},
+		"courier without its courier id": {
+			method: jwt.SigningMethodEdDSA, kid: "ed", key: edKey,
+			claims:  with("roles", []string{"courier"}),
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			token := jwt.NewWithClaims(tc.method, tc.claims)
+			token.Header["kid"] = tc.kid
+			signed, err := token.SignedString(tc.key)
+			require.NoError(t, err)
+
+			principal, err := verifier.Verify(signed)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "user-1", principal.Subject)
+			assert.Equal(t, tc.wantRoles, principal.Roles)
+			if principal.HasRole(Courier) {
+				assert.Equal(t, &courierID, principal.CourierID)
+			}
+		})
+	}
+}
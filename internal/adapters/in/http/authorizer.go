@@ -0,0 +1,173 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/delivery/internal/adapters/in/http/auth"
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// courierIDParam is the path parameter a courier app may only fill with its own courier
+const courierIDParam = "courierId"
+
+type authorizer struct {
+	spec        *openapi3.T
+	tokens      *auth.TokenVerifier
+	apiKeys     *auth.APIKeys
+	publicPaths map[string]bool
+}
+
+// NewAuthorizer lets a request through when it carries the credentials and one of the roles
+// the security requirements of its operation in the spec name, an empty requirement list makes
+// an operation public. A request the spec has no operation for is not found, unless its path is
+// one of the public paths, e.g. the health checks. Either the token verifier or the api keys may
+// be nil when not configured.
+func NewAuthorizer(spec *openapi3.T, tokens *auth.TokenVerifier, apiKeys *auth.APIKeys,
+	publicPaths []string) (echo.MiddlewareFunc, error) {
+	router, err := newSpecRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+	a := &authorizer{
+		spec:        spec,
+		tokens:      tokens,
+		apiKeys:     apiKeys,
+		publicPaths: make(map[string]bool, len(publicPaths)),
+	}
+	for _, path := range publicPaths {
+		a.publicPaths[path] = true
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			request := ctx.Request()
+			route, pathParams, err := router.FindRoute(request)
+			if err != nil {
+				if a.publicPaths[request.URL.Path] {
+					return next(ctx)
+				}
+				// a route the spec does not describe has no security requirements to check
+				return problems.NewNotFound("no operation " + request.Method + " " + request.URL.Path)
+			}
+			requirements := spec.Security
+			if route.Operation.Security != nil {
+				requirements = *route.Operation.Security
+			}
+			if len(requirements) == 0 {
+				return next(ctx)
+			}
+
+			principal, err := a.authorize(request, requirements, pathParams)
+			if err != nil {
+				return err
+			}
+			ctx.SetRequest(request.WithContext(auth.WithPrincipal(request.Context(), principal)))
+			return next(ctx)
+		}
+	}, nil
+}
+
+// authorize tries the requirements in turn: credentials that do not verify fail the request,
+// missing ones only skip the requirement
+func (a *authorizer) authorize(request *http.Request, requirements openapi3.SecurityRequirements,
+	pathParams map[string]string) (*auth.Principal, error) {
+	var authenticated *auth.Principal
+	for _, requirement := range requirements {
+		principal, roles, err := a.satisfy(request, requirement)
+		if err != nil {
+			return nil, err
+		}
+		if principal == nil {
+			continue
+		}
+		authenticated = principal
+		if !holdsAnyRole(principal, roles) {
+			continue
+		}
+		if principal.OnlyCourier(roles) && !ownsCourier(principal, pathParams) {
+			return nil, problems.NewForbidden("a courier may act only on its own courier id")
+		}
+		return principal, nil
+	}
+
+	if authenticated == nil {
+		return nil, problems.NewUnauthorized("a bearer token or an api key is required")
+	}
+	return nil, problems.NewForbidden("the caller has none of the roles the operation requires")
+}
+
+func (a *authorizer) satisfy(request *http.Request, requirement openapi3.SecurityRequirement) (*auth.Principal, []auth.Role, error) {
+	var principal *auth.Principal
+	var roles []auth.Role
+	for schemeName, scopes := range requirement {
+		p, err := a.authenticate(request, schemeName)
+		if err != nil || p == nil {
+			return nil, nil, err
+		}
+		principal = p
+		for _, scope := range scopes {
+			roles = append(roles, auth.Role(scope))
+		}
+	}
+	return principal, roles, nil
+}
+
+// authenticate returns nil without an error when the request has no credentials for the scheme
+func (a *authorizer) authenticate(request *http.Request, schemeName string) (*auth.Principal, error) {
+	schemeRef := a.spec.Components.SecuritySchemes[schemeName]
+	if schemeRef == nil || schemeRef.Value == nil {
+		return nil, nil
+	}
+	scheme := schemeRef.Value
+
+	switch {
+	case scheme.Type == "http" && strings.EqualFold(scheme.Scheme, "bearer"):
+		header := request.Header.Get(echo.HeaderAuthorization)
+		if a.tokens == nil || len(header) < len("Bearer ") || !strings.EqualFold(header[:len("Bearer ")], "Bearer ") {
+			return nil, nil
+		}
+		principal, err := a.tokens.Verify(header[len("Bearer "):])
+		if err != nil {
+			return nil, problems.NewUnauthorized("the bearer token is invalid or expired")
+		}
+		return principal, nil
+	case scheme.Type == "apiKey" && scheme.In == "header":
+		key := request.Header.Get(scheme.Name)
+		if a.apiKeys == nil || key == "" {
+			return nil, nil
+		}
+		principal, err := a.apiKeys.Verify(key)
+		if err != nil {
+			return nil, problems.NewUnauthorized("the api key is unknown")
+		}
+		return principal, nil
+	default:
+		return nil, nil
+	}
+}
+
+// holdsAnyRole accepts any authenticated caller when the requirement names no roles
+func holdsAnyRole(principal *auth.Principal, roles []auth.Role) bool {
+	if len(roles) == 0 {
+		return true
+	}
+	for _, role := range roles {
+		if principal.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func ownsCourier(principal *auth.Principal, pathParams map[string]string) bool {
+	value, ok := pathParams[courierIDParam]
+	if !ok {
+		return true
+	}
+	courierID, err := uuid.Parse(value)
+	return err == nil && principal.CourierID != nil && *principal.CourierID == courierID
+}
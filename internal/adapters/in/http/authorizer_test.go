@@ -0,0 +1,257 @@
+package http
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/adapters/in/http/auth"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const courierAppSpec = `
+openapi: 3.0.0
+info:
+  title: courier app
+  version: 1.0.0
+security:
+- bearerAuth: [dispatcher]
+paths:
+  /api/v1/couriers/{courierId}/assignment:
+    get:
+      operationId: GetAssignment
+      security:
+      - bearerAuth: [courier, dispatcher]
+      parameters:
+      - in: path
+        name: courierId
+        required: true
+        schema:
+          type: string
+      responses:
+        '200':
+          description: ok
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+`
+
+func Test_Authorizer_Roles(t *testing.T) {
+	signer := newTestSigner(t)
+	courierID := uuid.New()
+	acceptPath := "/api/v1/couriers/" + courierID.String() + "/orders/" + uuid.NewString() + "/accept"
+
+	tests := map[string]struct {
+		method     string
+		path       string
+		token      string
+		apiKey     string
+		wantStatus int
+	}{
+		"dispatcher creates a courier": {
+			method: http.MethodPost, path: "/api/v1/couriers",
+			token:      signer.token(t, "dispatcher", nil),
+			wantStatus: http.StatusOK,
+		},
+		"reader lists couriers": {
+			method: http.MethodGet, path: "/api/v1/couriers",
+			token:      signer.token(t, "reader", nil),
+			wantStatus: http.StatusOK,
+		},
+		"reader may not create a courier": {
+			method: http.MethodPost, path: "/api/v1/couriers",
+			token:      signer.token(t, "reader", nil),
+			wantStatus: http.StatusForbidden,
+		},
+		"service creates an order with its api key": {
+			method: http.MethodPost, path: "/api/v1/orders",
+			apiKey:     "checkout-key",
+			wantStatus: http.StatusOK,
+		},
+		"read only service may not create an order": {
+			method: http.MethodPost, path: "/api/v1/orders",
+			apiKey:     "analytics-key",
+			wantStatus: http.StatusForbidden,
+		},
+		"unknown api key": {
+			method: http.MethodGet, path: "/api/v1/couriers",
+			apiKey:     "guessed-key",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"token signed by another key": {
+			method: http.MethodGet, path: "/api/v1/couriers",
+			token:      newTestSigner(t).token(t, "dispatcher", nil),
+			wantStatus: http.StatusUnauthorized,
+		},
+		"courier accepts its own order": {
+			method: http.MethodPost, path: acceptPath,
+			token:      signer.token(t, "courier", &courierID),
+			wantStatus: http.StatusOK,
+		},
+		"dispatcher may not accept an order for a courier": {
+			method: http.MethodPost, path: acceptPath,
+			token:      signer.token(t, "dispatcher", nil),
+			wantStatus: http.StatusForbidden,
+		},
+		"no credentials": {
+			method: http.MethodGet, path: "/api/v1/orders/active",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"public path outside the spec": {
+			method: http.MethodGet, path: "/health",
+			wantStatus: http.StatusOK,
+		},
+		"route outside the spec is not found": {
+			method: http.MethodGet, path: "/debug/vars",
+			wantStatus: http.StatusNotFound,
+		},
+		"trailing slash still needs credentials": {
+			method: http.MethodGet, path: "/api/v1/couriers/",
+			wantStatus: http.StatusUnauthorized,
+		},
+		"method the spec does not describe is not found": {
+			method: http.MethodDelete, path: "/api/v1/couriers",
+			token:      signer.token(t, "dispatcher", nil),
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	spec, err := servers.GetSwagger()
+	require.NoError(t, err)
+	apiKeys, err := auth.ParseAPIKeys([]string{"dispatcher:checkout-key", "reader:analytics-key"})
+	require.NoError(t, err)
+	e := newAuthorizedEcho(t, spec, signer.verifier(t), apiKeys,
+		"/api/v1/couriers", "/api/v1/orders", "/api/v1/orders/active", acceptPath, "/health", "/debug/vars",
+		"/api/v1/couriers/")
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.token != "" {
+				request.Header.Set(echo.HeaderAuthorization, "Bearer "+tc.token)
+			}
+			if tc.apiKey != "" {
+				request.Header.Set("X-API-Key", tc.apiKey)
+			}
+			recorder := httptest.NewRecorder()
+
+			e.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tc.wantStatus, recorder.Code, recorder.Body.String())
+			if tc.wantStatus == http.StatusUnauthorized {
+				assert.Equal(t, "Bearer", recorder.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func Test_Authorizer_CourierActsOnItself(t *testing.T) {
+	signer := newTestSigner(t)
+	courierID := uuid.New()
+	otherID := uuid.New()
+
+	tests := map[string]struct {
+		token      string
+		courierID  uuid.UUID
+		wantStatus int
+	}{
+		"courier reads its own assignment": {
+			token:      signer.token(t, "courier", &courierID),
+			courierID:  courierID,
+			wantStatus: http.StatusOK,
+		},
+		"courier may not read the assignment of another courier": {
+			token:      signer.token(t, "courier", &courierID),
+			courierID:  otherID,
+			wantStatus: http.StatusForbidden,
+		},
+		"dispatcher reads the assignment of any courier": {
+			token:      signer.token(t, "dispatcher", nil),
+			courierID:  otherID,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	spec, err := openapi3.NewLoader().LoadFromData([]byte(courierAppSpec))
+	require.NoError(t, err)
+	e := newAuthorizedEcho(t, spec, signer.verifier(t), nil)
+	e.GET("/api/v1/couriers/:courierId/assignment", func(ctx echo.Context) error {
+		return ctx.String(http.StatusOK, auth.FromContext(ctx.Request().Context()).Subject)
+	})
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/couriers/"+tc.courierID.String()+"/assignment", nil)
+			request.Header.Set(echo.HeaderAuthorization, "Bearer "+tc.token)
+			recorder := httptest.NewRecorder()
+
+			e.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tc.wantStatus, recorder.Code, recorder.Body.String())
+			if tc.wantStatus == http.StatusOK {
+				assert.Equal(t, "user-1", recorder.Body.String())
+			}
+		})
+	}
+}
+
+// newAuthorizedEcho answers 200 on the given paths to any method once the authorizer lets the request in,
+// /health is its only public path
+func newAuthorizedEcho(t *testing.T, spec *openapi3.T, tokens *auth.TokenVerifier, apiKeys *auth.APIKeys,
+	paths ...string) *echo.Echo {
+	authorizer, err := NewAuthorizer(spec, tokens, apiKeys, []string{"/health"})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.Use(authorizer)
+	for _, path := range paths {
+		e.Any(path, func(ctx echo.Context) error {
+			return ctx.NoContent(http.StatusOK)
+		})
+	}
+	return e
+}
+
+type testSigner struct {
+	key ed25519.PrivateKey
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &testSigner{key: key}
+}
+
+func (s *testSigner) verifier(t *testing.T) *auth.TokenVerifier {
+	verifier, err := auth.NewTokenVerifier(auth.KeySet{"test": s.key.Public()}, "", "")
+	require.NoError(t, err)
+	return verifier
+}
+
+func (s *testSigner) token(t *testing.T, role string, courierID *uuid.UUID) string {
+	claims := jwt.MapClaims{
+		"sub":   "user-1",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{role},
+	}
+	if courierID != nil {
+		claims["courier_id"] = courierID.String()
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = "test"
+	signed, err := token.SignedString(s.key)
+	require.NoError(t, err)
+	return signed
+}
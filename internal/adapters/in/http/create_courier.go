@@ -1,13 +1,11 @@
 package http
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/delivery/internal/adapters/in/http/problems"
 	"github.com/delivery/internal/core/application/usecases/commands"
 	"github.com/delivery/internal/generated/servers"
-	"github.com/delivery/internal/pkg/errs"
 	"github.com/labstack/echo/v4"
 )
 
@@ -23,11 +21,7 @@ func (s *Server) CreateCourier(ctx echo.Context) error {
 	}
 
 	if err = s.createCourier.Handle(ctx.Request().Context(), command); err != nil {
-		if errors.Is(err, errs.ErrNotFound) {
-			return problems.NewNotFound(err.Error())
-		}
-		return problems.NewConflict(err.Error(), "/")
-
+		return err
 	}
 
 	return ctx.JSON(http.StatusOK, nil)
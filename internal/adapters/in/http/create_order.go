@@ -5,20 +5,57 @@ import (
 
 	"github.com/delivery/internal/adapters/in/http/problems"
 	"github.com/delivery/internal/core/application/usecases/commands"
-	"github.com/google/uuid"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/generated/servers"
 	"github.com/labstack/echo/v4"
 )
 
-func (s *Server) CreateOrder(ctx echo.Context) error {
-	command, err := commands.NewCreateOrderCommand(uuid.New(), "Несуществующая", 1)
-	if err != nil {
+// CreateOrder expects a body already checked against the api schema, it is safe to repeat: the order id
+// and the Idempotency-Key both name the request, so a retry is acknowledged without a second order
+// and a key reused for another order is a conflict
+func (s *Server) CreateOrder(ctx echo.Context, params servers.CreateOrderParams) error {
+	var newOrder servers.NewOrder
+	if err := ctx.Bind(&newOrder); err != nil {
 		return problems.NewBadRequest(err.Error())
 	}
 
-	err = s.createOrder.Handle(ctx.Request().Context(), command)
+	var priority string
+	if newOrder.Priority != nil {
+		priority = string(*newOrder.Priority)
+	}
+	orderPriority, err := order.ParsePriority(priority)
+	if err != nil {
+		return problems.NewBadRequestWithFieldErrors(err.Error(), []problems.FieldError{
+			{Field: "priority", Detail: err.Error()},
+		})
+	}
+
+	var deliveryWindow *order.DeliveryWindow
+	if newOrder.DeliveryWindow != nil {
+		window, err := order.NewDeliveryWindow(newOrder.DeliveryWindow.From, newOrder.DeliveryWindow.To)
+		if err != nil {
+			return problems.NewBadRequestWithFieldErrors(err.Error(), []problems.FieldError{
+				{Field: "deliveryWindow", Detail: err.Error()},
+			})
+		}
+		deliveryWindow = &window
+	}
+
+	messageID := "http/" + newOrder.OrderId.String()
+	if params.IdempotencyKey != nil {
+		messageID = "http/" + *params.IdempotencyKey
+	}
+
+	command, err := commands.NewCreateOrderCommand(newOrder.OrderId, messageID, newOrder.Address.Street,
+		newOrder.Volume, orderPriority, deliveryWindow)
 	if err != nil {
-		return problems.NewConflict(err.Error(), "/")
+		return problems.NewBadRequest(err.Error())
+	}
+
+	if err = s.createOrder.Handle(ctx.Request().Context(), command); err != nil {
+		return err
 	}
 
-	return ctx.JSON(http.StatusOK, nil)
+	ctx.Response().Header().Set(echo.HeaderLocation, "/api/v1/orders/"+newOrder.OrderId.String())
+	return ctx.NoContent(http.StatusCreated)
 }
@@ -0,0 +1,174 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/adapters/out/clock"
+	"github.com/delivery/internal/adapters/out/memory"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type createOrderHandlerFunc func(ctx context.Context, command *commands.CreateOrderCommand) error
+
+func (f createOrderHandlerFunc) Handle(ctx context.Context, command *commands.CreateOrderCommand) error {
+	return f(ctx, command)
+}
+
+func Test_CreateOrder(t *testing.T) {
+	const orderID = "0f8fad5b-d9cb-469f-a165-70867728950e"
+
+	tests := map[string]struct {
+		body           string
+		idempotencyKey string
+		wantStatus     int
+		wantFields     []string
+		wantMessageID  string
+	}{
+		"order with an address": {
+			body:          `{"orderId":"` + orderID + `","address":{"city":"Москва","street":"Тверская","house":"1"},"volume":3}`,
+			wantStatus:    http.StatusCreated,
+			wantMessageID: "http/" + orderID,
+		},
+		"order with a delivery window and an idempotency key": {
+			body: `{"orderId":"` + orderID + `","address":{"street":"Тверская"},"volume":3,"priority":"express",` +
+				`"deliveryWindow":{"from":"2025-01-01T12:00:00Z","to":"2025-01-01T14:00:00Z"}}`,
+			idempotencyKey: "retry-me",
+			wantStatus:     http.StatusCreated,
+			wantMessageID:  "http/retry-me",
+		},
+		"every missing field is reported": {
+			body:       `{"address":{}}`,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"address.street", "orderId", "volume"},
+		},
+		"values out of the schema": {
+			body:       `{"orderId":"not-a-uuid","address":{"street":""},"volume":0,"priority":"overnight"}`,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"address.street", "orderId", "priority", "volume"},
+		},
+		"delivery window that ends before it starts": {
+			body: `{"orderId":"` + orderID + `","address":{"street":"Тверская"},"volume":3,` +
+				`"deliveryWindow":{"from":"2025-01-01T14:00:00Z","to":"2025-01-01T12:00:00Z"}}`,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{"deliveryWindow"},
+		},
+		"no body": {
+			wantStatus: http.StatusBadRequest,
+			wantFields: []string{""},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var handled *commands.CreateOrderCommand
+			server := &Server{createOrder: createOrderHandlerFunc(func(_ context.Context, command *commands.CreateOrderCommand) error {
+				handled = command
+				return nil
+			})}
+			e := newTestEcho(t, server)
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(tc.body))
+			request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if tc.idempotencyKey != "" {
+				request.Header.Set("Idempotency-Key", tc.idempotencyKey)
+			}
+			recorder := httptest.NewRecorder()
+
+			e.ServeHTTP(recorder, request)
+
+			require.Equal(t, tc.wantStatus, recorder.Code, recorder.Body.String())
+			if tc.wantStatus == http.StatusCreated {
+				assert.Equal(t, "/api/v1/orders/"+orderID, recorder.Header().Get(echo.HeaderLocation))
+				require.NotNil(t, handled)
+				assert.Equal(t, orderID, handled.OrderID().String())
+				assert.Equal(t, tc.wantMessageID, handled.MessageID())
+				assert.Equal(t, "Тверская", handled.Street())
+				assert.Equal(t, 3, handled.Volume())
+				return
+			}
+			assert.Nil(t, handled)
+			assert.Equal(t, "application/problem+json", recorder.Header().Get(echo.HeaderContentType))
+			var problem problems.BadRequest
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			var fields []string
+			for _, fieldError := range problem.Errors {
+				fields = append(fields, fieldError.Field)
+				assert.NotEmpty(t, fieldError.Detail)
+			}
+			assert.ElementsMatch(t, tc.wantFields, fields)
+		})
+	}
+}
+
+func Test_RequestValidator_SkipsRoutesOutsideSpec(t *testing.T) {
+	e := newTestEcho(t, &Server{})
+	e.GET("/health", func(ctx echo.Context) error {
+		return ctx.NoContent(http.StatusOK)
+	})
+	recorder := httptest.NewRecorder()
+
+	e.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func newTestEcho(t *testing.T, server *Server) *echo.Echo {
+	spec, err := servers.GetSwagger()
+	require.NoError(t, err)
+	validator, err := NewRequestValidator(spec)
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.Use(validator)
+	servers.RegisterHandlers(e, server)
+	return e
+}
+
+func Test_CreateOrder_ReusedIdempotencyKey(t *testing.T) {
+	const orderID = "0f8fad5b-d9cb-469f-a165-70867728950e"
+	uow, err := memory.NewUnitOfWork(ddd.NewMediatr())
+	require.NoError(t, err)
+	geoClient := mocks.NewGeoServiceClient(t)
+	location, err := kernel.NewLocation(1, 1)
+	require.NoError(t, err)
+	geoClient.EXPECT().GetLocation(mock.Anything, "Тверская").Return(location, nil)
+	createOrder, err := commands.NewAddCreateOrderHandler(uow, geoClient, clock.NewFakeClock(time.Now()))
+	require.NoError(t, err)
+	e := newTestEcho(t, &Server{createOrder: createOrder})
+
+	tests := []struct {
+		name       string
+		volume     string
+		wantStatus int
+	}{
+		{name: "first request", volume: "3", wantStatus: http.StatusCreated},
+		{name: "retry", volume: "3", wantStatus: http.StatusCreated},
+		{name: "same key, other body", volume: "4", wantStatus: http.StatusConflict},
+	}
+	for _, tc := range tests {
+		body := `{"orderId":"` + orderID + `","address":{"street":"Тверская"},"volume":` + tc.volume + `}`
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		request.Header.Set("Idempotency-Key", "retry-me")
+		recorder := httptest.NewRecorder()
+
+		e.ServeHTTP(recorder, request)
+
+		assert.Equal(t, tc.wantStatus, recorder.Code, "%s: %s", tc.name, recorder.Body.String())
+	}
+}
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// CreateZone adds a service zone under a new id and answers with the zone
+func (s *Server) CreateZone(ctx echo.Context) error {
+	var newZone servers.NewZone
+	if err := ctx.Bind(&newZone); err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	from, err := kernel.NewLocation(newZone.From.X, newZone.From.Y)
+	if err != nil {
+		return problems.NewBadRequestWithFieldErrors(err.Error(), []problems.FieldError{
+			{Field: "from", Detail: err.Error()},
+		})
+	}
+	to, err := kernel.NewLocation(newZone.To.X, newZone.To.Y)
+	if err != nil {
+		return problems.NewBadRequestWithFieldErrors(err.Error(), []problems.FieldError{
+			{Field: "to", Detail: err.Error()},
+		})
+	}
+	area, err := kernel.NewRectangle(from, to)
+	if err != nil {
+		return problems.NewBadRequestWithFieldErrors(err.Error(), []problems.FieldError{
+			{Field: "to", Detail: err.Error()},
+		})
+	}
+
+	command, err := commands.NewCreateZoneCommand(uuid.New(), newZone.Name, area)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	if err = s.createZone.Handle(ctx.Request().Context(), command); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusCreated, servers.Zone{
+		Id:   command.ZoneID(),
+		Name: command.Name(),
+		From: newZone.From,
+		To:   newZone.To,
+	})
+}
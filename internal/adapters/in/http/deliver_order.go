@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) DeliverOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error {
+	var request servers.ProofOfDelivery
+	if err := ctx.Bind(&request); err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	proof, err := proofOfDelivery(request)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	command, err := commands.NewDeliverOrderCommand(courierId, orderId, proof)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	if err = s.deliverOrder.Handle(ctx.Request().Context(), command); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
+
+// proofOfDelivery is nil when the courier sent neither a photo hash nor a pin
+func proofOfDelivery(request servers.ProofOfDelivery) (*order.ProofOfDelivery, error) {
+	var photoHash, pin string
+	if request.PhotoHash != nil {
+		photoHash = *request.PhotoHash
+	}
+	if request.Pin != nil {
+		pin = *request.Pin
+	}
+	if photoHash == "" && pin == "" {
+		return nil, nil
+	}
+
+	proof, err := order.NewProofOfDelivery(photoHash, pin)
+	if err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
@@ -0,0 +1,40 @@
+package http
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/labstack/echo/v4"
+)
+
+// ErrorHandler answers every error returned by a handler or a middleware with problem details
+// naming the request path and its trace id; a server error is logged with its original message.
+func ErrorHandler(err error, ctx echo.Context) {
+	if ctx.Response().Committed {
+		return
+	}
+
+	problem := problems.FromError(err)
+	details := problem.Details()
+	details.Instance = ctx.Request().URL.Path
+	details.TraceID = traceID(ctx)
+	if details.Status >= http.StatusInternalServerError {
+		log.Printf("request %s %s (trace %s) failed: %v",
+			ctx.Request().Method, details.Instance, details.TraceID, err)
+	}
+
+	if ctx.Request().Method == http.MethodHead {
+		ctx.Response().WriteHeader(details.Status)
+		return
+	}
+	problem.WriteResponse(ctx.Response())
+}
+
+// traceID is the request id the RequestID middleware answers with, or the one the caller sent
+func traceID(ctx echo.Context) string {
+	if id := ctx.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return ctx.Request().Header.Get(echo.HeaderXRequestID)
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ErrorHandler(t *testing.T) {
+	dbFailure := errors.New(`pq: duplicate key value violates unique constraint "orders_pkey"`)
+
+	tests := map[string]struct {
+		err        error
+		wantStatus int
+		wantType   string
+		wantDetail string
+	}{
+		"validation error": {
+			err:        errs.NewValidationError("name", "must not be empty"),
+			wantStatus: http.StatusBadRequest,
+			wantType:   problems.TypeValidation,
+			wantDetail: errs.NewValidationError("name", "must not be empty").Error(),
+		},
+		"missing value": {
+			err:        errs.NewValueIsRequiredError("speed"),
+			wantStatus: http.StatusBadRequest,
+			wantType:   problems.TypeValidation,
+			wantDetail: errs.NewValueIsRequiredError("speed").Error(),
+		},
+		"not found": {
+			err:        errs.NewNotFoundError("order", "42"),
+			wantStatus: http.StatusNotFound,
+			wantType:   problems.TypeNotFound,
+			wantDetail: errs.NewNotFoundError("order", "42").Error(),
+		},
+		"conflict": {
+			err:        errs.NewConflictError("courier", "7", "already exists"),
+			wantStatus: http.StatusConflict,
+			wantType:   problems.TypeConflict,
+			wantDetail: errs.NewConflictError("courier", "7", "already exists").Error(),
+		},
+		"business rule": {
+			err:        errs.NewBusinessError("unassign order", "only an assigned order can be unassigned"),
+			wantStatus: http.StatusConflict,
+			wantType:   problems.TypeBusinessRule,
+			wantDetail: errs.NewBusinessError("unassign order", "only an assigned order can be unassigned").Error(),
+		},
+		"database error": {
+			err:        errs.NewDatabaseError("add", "order", dbFailure),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   problems.TypeInternal,
+		},
+		"business error caused by the database": {
+			err:        errs.NewBusinessErrorWithCause("create order", "failed", errs.NewDatabaseError("add", "order", dbFailure)),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   problems.TypeInternal,
+		},
+		"business rule broken by a cause": {
+			err:        errs.NewBusinessErrorWithCause("assign order", "order is taken", errs.NewValidationError("status", "is picked up")),
+			wantStatus: http.StatusConflict,
+			wantType:   problems.TypeBusinessRule,
+			wantDetail: errs.NewBusinessError("assign order", "order is taken").Error(),
+		},
+		"service the api depends on is down": {
+			err:        errs.NewUnavailableError("geo service", "get location", errors.New("rpc error: code = Unavailable desc = connection refused")),
+			wantStatus: http.StatusServiceUnavailable,
+			wantType:   problems.TypeUnavailable,
+		},
+		"business error caused by a service that is down": {
+			err: errs.NewBusinessErrorWithCause("create order", "failed",
+				errs.NewUnavailableError("geo service", "get location", errors.New("dial tcp: connection refused"))),
+			wantStatus: http.StatusServiceUnavailable,
+			wantType:   problems.TypeUnavailable,
+		},
+		"unexpected error": {
+			err:        dbFailure,
+			wantStatus: http.StatusInternalServerError,
+			wantType:   problems.TypeInternal,
+		},
+		"problem returned by a handler": {
+			err:        problems.NewBadRequest("body is not json"),
+			wantStatus: http.StatusBadRequest,
+			wantType:   problems.TypeBadRequest,
+			wantDetail: "body is not json",
+		},
+		"echo error": {
+			err:        echo.ErrMethodNotAllowed,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantType:   problems.TypeBlank,
+			wantDetail: "Method Not Allowed",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := echo.New()
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/orders/42/unassign", nil)
+			request.Header.Set(echo.HeaderXRequestID, "trace-1")
+			recorder := httptest.NewRecorder()
+
+			ErrorHandler(tc.err, e.NewContext(request, recorder))
+
+			assert.Equal(t, tc.wantStatus, recorder.Code)
+			assert.Equal(t, "application/problem+json", recorder.Header().Get(echo.HeaderContentType))
+			var body servers.Error
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+			assert.Equal(t, tc.wantStatus, body.Status)
+			assert.Equal(t, tc.wantType, body.Type)
+			assert.NotEmpty(t, body.Title)
+			require.NotNil(t, body.Instance)
+			assert.Equal(t, "/api/v1/orders/42/unassign", *body.Instance)
+			require.NotNil(t, body.TraceId)
+			assert.Equal(t, "trace-1", *body.TraceId)
+			require.NotNil(t, body.Detail)
+			assert.NotContains(t, *body.Detail, "duplicate key")
+			assert.NotContains(t, *body.Detail, "cause")
+			if tc.wantDetail != "" {
+				assert.Equal(t, tc.wantDetail, *body.Detail)
+			}
+		})
+	}
+}
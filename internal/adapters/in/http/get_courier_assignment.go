@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) GetCourierAssignment(ctx echo.Context, courierId openapi_types.UUID) error {
+	query, err := queries.NewGetCourierAssignmentQuery(courierId)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	result, err := s.getCourierAssignment.Handle(*query)
+	if err != nil {
+		return err
+	}
+
+	orders := make([]servers.AssignedOrder, 0, len(result.Orders))
+	for _, order := range result.Orders {
+		orders = append(orders, servers.AssignedOrder{
+			Id:       order.ID,
+			Location: servers.Location{X: order.Location.X, Y: order.Location.Y},
+			Volume:   order.Volume,
+			Status:   servers.AssignedOrderStatus(order.Status),
+			Priority: servers.AssignedOrderPriority(order.Priority),
+			Eta:      order.Eta,
+			AcceptBy: order.AcceptBy,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, servers.CourierAssignment{Orders: orders})
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) GetCourierPath(ctx echo.Context, courierId openapi_types.UUID, params servers.GetCourierPathParams) error {
+	query, err := queries.NewGetCourierPathQuery(courierId, params.From, params.To)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	result, err := s.getCourierPath.Handle(*query)
+	if err != nil {
+		return err
+	}
+
+	points := make([]servers.PathPoint, 0, len(result.Points))
+	for _, point := range result.Points {
+		points = append(points, servers.PathPoint{
+			Location: servers.Location{X: point.Location.X, Y: point.Location.Y},
+			At:       point.At,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, servers.CourierPath{Points: points})
+}
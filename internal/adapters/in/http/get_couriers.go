@@ -1,28 +1,24 @@
 package http
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/delivery/internal/adapters/in/http/problems"
 	"github.com/delivery/internal/core/application/usecases/queries"
 	"github.com/delivery/internal/generated/servers"
-	"github.com/delivery/internal/pkg/errs"
 	"github.com/labstack/echo/v4"
 )
 
-func (s *Server) GetCouriers(ctx echo.Context) error {
-	query, err := queries.NewGetAllCouriersQuery()
+func (s *Server) GetCouriers(ctx echo.Context, params servers.GetCouriersParams) error {
+	query, err := queries.NewGetAllCouriersQuery(valueOf(params.Limit), valueOf(params.Cursor),
+		string(valueOf(params.Sort)), valueOf(params.Name), valueOf(params.Bbox))
 	if err != nil {
 		return problems.NewBadRequest(err.Error())
 	}
 
 	result, err := s.getAllCouriers.Handle(*query)
 	if err != nil {
-		if errors.Is(err, errs.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
-		}
-		return problems.NewBadRequest(err.Error())
+		return err
 	}
 
 	var couriers []servers.Courier
@@ -33,13 +29,19 @@ func (s *Server) GetCouriers(ctx echo.Context) error {
 		}
 
 		courier := servers.Courier{
-			Id:       courier.ID,
-			Name:     courier.Name,
-			Location: location,
+			Id:             courier.ID,
+			Name:           courier.Name,
+			Location:       location,
+			DeclinedOffers: courier.DeclinedOffers,
+			HomeZoneId:     courier.HomeZoneID,
+			OrdersCount:    courier.OrdersCount,
+			UsedVolume:     courier.UsedVolume,
+			Capacity:       courier.Capacity,
 		}
 
 		couriers = append(couriers, courier)
 	}
 
+	setPageHeaders(ctx, result.Total, result.NextCursor)
 	return ctx.JSON(http.StatusOK, couriers)
 }
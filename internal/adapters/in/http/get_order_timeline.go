@@ -0,0 +1,53 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) GetOrderTimeline(ctx echo.Context, orderId openapi_types.UUID) error {
+	query, err := queries.NewGetOrderTimelineQuery(orderId)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	result, err := s.getOrderTimeline.Handle(*query)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]servers.AuditEntry, 0, len(result.Entries))
+	for _, entry := range result.Entries {
+		var after map[string]interface{}
+		if err := json.Unmarshal([]byte(entry.After), &after); err != nil {
+			return err
+		}
+		var before *map[string]interface{}
+		if entry.Before != nil {
+			if err := json.Unmarshal([]byte(*entry.Before), &before); err != nil {
+				return err
+			}
+		}
+
+		entries = append(entries, servers.AuditEntry{
+			AggregateType: servers.AuditEntryAggregateType(entry.AggregateType),
+			AggregateId:   entry.AggregateID,
+			Version:       entry.Version,
+			Events:        entry.Events,
+			Command:       entry.Command,
+			Actor:         entry.Actor,
+			CorrelationId: entry.CorrelationID,
+			Before:        before,
+			After:         after,
+			RecordedAt:    entry.RecordedAt,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, entries)
+}
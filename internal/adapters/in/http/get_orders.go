@@ -1,28 +1,30 @@
 package http
 
 import (
-	"errors"
 	"net/http"
 
 	"github.com/delivery/internal/adapters/in/http/problems"
 	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/core/domain/model/order"
 	"github.com/delivery/internal/generated/servers"
-	"github.com/delivery/internal/pkg/errs"
 	"github.com/labstack/echo/v4"
 )
 
-func (s *Server) GetOrders(ctx echo.Context) error {
-	query, err := queries.NewGetAllUncompletedOrdersQuery()
+func (s *Server) GetOrders(ctx echo.Context, params servers.GetOrdersParams) error {
+	var statuses []order.Status
+	for _, status := range valueOf(params.Status) {
+		statuses = append(statuses, order.Status(status))
+	}
+
+	query, err := queries.NewGetAllUncompletedOrdersQuery(valueOf(params.Limit), valueOf(params.Cursor),
+		string(valueOf(params.Sort)), statuses, valueOf(params.CourierName), valueOf(params.Bbox))
 	if err != nil {
 		return problems.NewBadRequest(err.Error())
 	}
 
 	result, err := s.getAllUncompletedOrders.Handle(*query)
 	if err != nil {
-		if errors.Is(err, errs.ErrNotFound) {
-			return ctx.JSON(http.StatusNotFound, problems.NewNotFound(err.Error()))
-		}
-		return problems.NewBadRequest(err.Error())
+		return err
 	}
 
 	var orders []servers.Order
@@ -33,12 +35,20 @@ func (s *Server) GetOrders(ctx echo.Context) error {
 		}
 
 		order := servers.Order{
-			Id:       order.ID,
-			Location: location,
+			Id:          order.ID,
+			Location:    location,
+			Volume:      order.Volume,
+			Status:      servers.OrderStatus(order.Status),
+			Priority:    servers.OrderPriority(order.Priority),
+			Eta:         order.Eta,
+			ZoneId:      order.ZoneID,
+			CourierId:   order.CourierID,
+			CourierName: order.CourierName,
 		}
 
 		orders = append(orders, order)
 	}
 
+	setPageHeaders(ctx, result.Total, result.NextCursor)
 	return ctx.JSON(http.StatusOK, orders)
 }
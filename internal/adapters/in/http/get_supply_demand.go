@@ -0,0 +1,58 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) GetSupplyDemand(ctx echo.Context, params servers.GetSupplyDemandParams) error {
+	var cellSize int
+	if params.CellSize != nil {
+		cellSize = *params.CellSize
+	}
+
+	query, err := queries.NewGetSupplyDemandQuery(s.clock.Now(), cellSize)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	result, err := s.getSupplyDemand.Handle(*query)
+	if err != nil {
+		return err
+	}
+
+	snapshot := result.Snapshot
+	areas := make([]servers.AreaSupplyDemand, 0, len(snapshot.Areas()))
+	for _, area := range snapshot.Areas() {
+		windows := make([]servers.WindowSupplyDemand, 0, len(area.Windows()))
+		for _, window := range area.Windows() {
+			windows = append(windows, servers.WindowSupplyDemand{
+				Window:             window.Window().String(),
+				Orders:             window.Orders(),
+				AverageWaitSeconds: window.AverageWait().Seconds(),
+			})
+		}
+
+		bounds := area.Area().Bounds()
+		areas = append(areas, servers.AreaSupplyDemand{
+			ZoneId:     area.Area().ZoneID(),
+			Name:       area.Area().Name(),
+			From:       servers.Location{X: bounds.From().X(), Y: bounds.From().Y()},
+			To:         servers.Location{X: bounds.To().X(), Y: bounds.To().Y()},
+			Supply:     area.Supply(),
+			Demand:     area.Demand(),
+			SurgeRatio: area.SurgeRatio(),
+			Surge:      area.Surge(),
+			Windows:    windows,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, servers.SupplyDemand{
+		TakenAt: snapshot.TakenAt(),
+		Areas:   areas,
+	})
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) GetZones(ctx echo.Context) error {
+	query, err := queries.NewGetAllZonesQuery()
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	result, err := s.getAllZones.Handle(*query)
+	if err != nil {
+		return err
+	}
+
+	zones := make([]servers.Zone, 0, len(result.Zones))
+	for _, zone := range result.Zones {
+		zones = append(zones, servers.Zone{
+			Id:   zone.ID,
+			Name: zone.Name,
+			From: servers.Location{X: zone.From.X, Y: zone.From.Y},
+			To:   servers.Location{X: zone.To.X, Y: zone.To.Y},
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, zones)
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/health"
+	"github.com/labstack/echo/v4"
+)
+
+type HealthHandler struct {
+	health health.Service
+}
+
+func NewHealthHandler(healthService health.Service) (*HealthHandler, error) {
+	if healthService == nil {
+		return nil, errs.NewValueIsRequiredError("health service")
+	}
+	return &HealthHandler{
+		health: healthService,
+	}, nil
+}
+
+func (h *HealthHandler) Live(ctx echo.Context) error {
+	return writeHealthReport(ctx, h.health.Live(ctx.Request().Context()))
+}
+
+func (h *HealthHandler) Ready(ctx echo.Context) error {
+	return writeHealthReport(ctx, h.health.Ready(ctx.Request().Context()))
+}
+
+func writeHealthReport(ctx echo.Context, report health.Report) error {
+	if !report.IsUp() {
+		return ctx.JSON(http.StatusServiceUnavailable, report)
+	}
+	return ctx.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,29 @@
+package http
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	totalCountHeader = "X-Total-Count"
+	nextCursorHeader = "X-Next-Cursor"
+)
+
+// setPageHeaders tells the client how many rows match on all pages and where the next page starts
+func setPageHeaders(ctx echo.Context, total int64, nextCursor string) {
+	ctx.Response().Header().Set(totalCountHeader, strconv.FormatInt(total, 10))
+	if nextCursor != "" {
+		ctx.Response().Header().Set(nextCursorHeader, nextCursor)
+	}
+}
+
+// valueOf is the zero value for a parameter the request left out
+func valueOf[T any](param *T) T {
+	var value T
+	if param != nil {
+		value = *param
+	}
+	return value
+}
@@ -7,14 +7,21 @@ import (
 
 var ProblemBadRequest = errors.New("bad request")
 
+// FieldError names a request value that failed validation
+type FieldError struct {
+	Field  string `json:"field"`
+	Detail string `json:"detail"`
+}
+
 type BadRequest struct {
 	ProblemDetails
+	Errors []FieldError `json:"errors,omitempty"`
 }
 
 func NewBadRequest(detail string) *BadRequest {
 	return &BadRequest{
 		ProblemDetails: ProblemDetails{
-			Type:   "bad-request",
+			Type:   TypeBadRequest,
 			Title:  "Bad Request",
 			Status: http.StatusBadRequest,
 			Detail: detail,
@@ -22,6 +29,17 @@ func NewBadRequest(detail string) *BadRequest {
 	}
 }
 
+func NewBadRequestWithFieldErrors(detail string, fieldErrors []FieldError) *BadRequest {
+	problem := NewBadRequest(detail)
+	problem.Type = TypeValidation
+	problem.Errors = fieldErrors
+	return problem
+}
+
+func (e *BadRequest) WriteResponse(w http.ResponseWriter) {
+	writeJSON(w, e.Status, e)
+}
+
 func (e *BadRequest) Error() string {
 	return e.ProblemDetails.Error()
 }
@@ -11,10 +11,10 @@ type ConflictError struct {
 	ProblemDetails
 }
 
-func NewConflict(problemType string, detail string) *ConflictError {
+func NewConflict(detail string) *ConflictError {
 	return &ConflictError{
 		ProblemDetails: ProblemDetails{
-			Type:   problemType,
+			Type:   TypeConflict,
 			Title:  "Conflict",
 			Status: http.StatusConflict,
 			Detail: detail,
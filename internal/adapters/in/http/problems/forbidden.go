@@ -0,0 +1,31 @@
+package problems
+
+import (
+	"errors"
+	"net/http"
+)
+
+var ProblemForbidden = errors.New("forbidden")
+
+type ForbiddenError struct {
+	ProblemDetails
+}
+
+func NewForbidden(detail string) *ForbiddenError {
+	return &ForbiddenError{
+		ProblemDetails: ProblemDetails{
+			Type:   TypeForbidden,
+			Title:  "Forbidden",
+			Status: http.StatusForbidden,
+			Detail: detail,
+		},
+	}
+}
+
+func (e *ForbiddenError) Error() string {
+	return e.ProblemDetails.Error()
+}
+
+func (e *ForbiddenError) Unwrap() error {
+	return ProblemForbidden
+}
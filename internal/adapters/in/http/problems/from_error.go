@@ -0,0 +1,54 @@
+package problems
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/labstack/echo/v4"
+)
+
+// internalDetail replaces the message of an error that may carry database or driver internals
+const internalDetail = "the request could not be completed, retry later or report the trace id"
+
+// unavailableDetail replaces the message of a failed call to a service the api depends on
+const unavailableDetail = "a service the request depends on is unavailable, retry later"
+
+// FromError maps any error returned by a handler to a problem. The detail is the message of the error
+// without the causes it was made from. A failure of the database or of another service answers 5xx
+// whatever the error wrapping it says, with a fixed detail, so internals never reach the client.
+func FromError(err error) Problem {
+	var problem Problem
+	if errors.As(err, &problem) {
+		return problem
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		detail, ok := httpErr.Message.(string)
+		if !ok || httpErr.Code >= http.StatusInternalServerError {
+			detail = http.StatusText(httpErr.Code)
+		}
+		return New(httpErr.Code, TypeBlank, detail)
+	}
+
+	detail := errs.Message(err)
+	switch {
+	case errs.HasCause(err, errs.ErrUnavailable):
+		return New(http.StatusServiceUnavailable, TypeUnavailable, unavailableDetail)
+	case errs.HasCause(err, errs.ErrDatabase):
+		return New(http.StatusInternalServerError, TypeInternal, internalDetail)
+	case errs.IsNotFound(err):
+		return NewNotFound(detail)
+	case errs.IsConflict(err):
+		return NewConflict(detail)
+	case errs.IsValidation(err), errs.IsValueRequired(err):
+		problem := NewBadRequest(detail)
+		problem.Type = TypeValidation
+		return problem
+	case errs.IsBusiness(err):
+		return New(http.StatusConflict, TypeBusinessRule, detail)
+	default:
+		return New(http.StatusInternalServerError, TypeInternal, internalDetail)
+	}
+}
@@ -14,7 +14,7 @@ type NotFoundError struct {
 func NewNotFound(detail string) *NotFoundError {
 	return &NotFoundError{
 		ProblemDetails: ProblemDetails{
-			Type:   "not-found",
+			Type:   TypeNotFound,
 			Title:  "Resource Not Found",
 			Status: http.StatusNotFound,
 			Detail: detail,
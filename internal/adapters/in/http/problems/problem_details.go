@@ -6,20 +6,60 @@ import (
 	"net/http"
 )
 
+// Problem types are URI references relative to the service, "about:blank" means the status says it all
+const (
+	TypeBlank        = "about:blank"
+	TypeBadRequest   = "/problems/bad-request"
+	TypeValidation   = "/problems/validation"
+	TypeUnauthorized = "/problems/unauthorized"
+	TypeForbidden    = "/problems/forbidden"
+	TypeNotFound     = "/problems/not-found"
+	TypeConflict     = "/problems/conflict"
+	TypeBusinessRule = "/problems/business-rule"
+	TypeInternal     = "/problems/internal"
+	TypeUnavailable  = "/problems/unavailable"
+)
+
+// Problem is an error that is answered as RFC 7807 problem details
+type Problem interface {
+	error
+	Details() *ProblemDetails
+	WriteResponse(w http.ResponseWriter)
+}
+
 // ProblemDetails RFC 7807
 type ProblemDetails struct {
-	Type   string `json:"type"`
-	Title  string `json:"title"`
-	Status int    `json:"status"`
-	Detail string `json:"detail"`
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance,omitempty"`
+	TraceID  string `json:"traceId,omitempty"`
+}
+
+func New(status int, problemType string, detail string) *ProblemDetails {
+	return &ProblemDetails{
+		Type:   problemType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
 }
 
 func (p *ProblemDetails) Error() string {
 	return fmt.Sprintf("%d: %s - %s", p.Status, p.Title, p.Detail)
 }
 
+func (p *ProblemDetails) Details() *ProblemDetails {
+	return p
+}
+
 func (p *ProblemDetails) WriteResponse(w http.ResponseWriter) {
+	writeJSON(w, p.Status, p)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
 	w.Header().Set("Content-Type", "application/problem+json")
-	w.WriteHeader(p.Status)
-	json.NewEncoder(w).Encode(p)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
 }
@@ -0,0 +1,37 @@
+package problems
+
+import (
+	"errors"
+	"net/http"
+)
+
+var ProblemUnauthorized = errors.New("unauthorized")
+
+type UnauthorizedError struct {
+	ProblemDetails
+}
+
+func NewUnauthorized(detail string) *UnauthorizedError {
+	return &UnauthorizedError{
+		ProblemDetails: ProblemDetails{
+			Type:   TypeUnauthorized,
+			Title:  "Unauthorized",
+			Status: http.StatusUnauthorized,
+			Detail: detail,
+		},
+	}
+}
+
+// WriteResponse tells the client to come back with a bearer token
+func (e *UnauthorizedError) WriteResponse(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", "Bearer")
+	e.ProblemDetails.WriteResponse(w)
+}
+
+func (e *UnauthorizedError) Error() string {
+	return e.ProblemDetails.Error()
+}
+
+func (e *UnauthorizedError) Unwrap() error {
+	return ProblemUnauthorized
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/labstack/echo/v4"
+)
+
+func (s *Server) RebuildReadModels(ctx echo.Context) error {
+	command, err := commands.NewRebuildReadModelsCommand()
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	if err = s.rebuildReadModels.Handle(ctx.Request().Context(), command); err != nil {
+		return err
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
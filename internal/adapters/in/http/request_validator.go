@@ -0,0 +1,101 @@
+package http
+
+import (
+	"strings"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func init() {
+	openapi3.DefineStringFormatCallback("uuid", func(value string) error {
+		_, err := uuid.Parse(value)
+		return err
+	})
+}
+
+// NewRequestValidator checks requests against the OpenAPI spec before they reach the handlers
+// and answers with a bad request listing every invalid field. Routes the spec does not describe pass through.
+func NewRequestValidator(spec *openapi3.T) (echo.MiddlewareFunc, error) {
+	router, err := newSpecRouter(spec)
+	if err != nil {
+		return nil, err
+	}
+	// the caller is authenticated by the authorizer, the validator checks the values only
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(ctx echo.Context) error {
+			request := ctx.Request()
+			route, pathParams, err := router.FindRoute(request)
+			if err != nil {
+				return next(ctx)
+			}
+
+			err = openapi3filter.ValidateRequest(request.Context(), &openapi3filter.RequestValidationInput{
+				Request:    request,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			})
+			if err != nil {
+				return problems.NewBadRequestWithFieldErrors("request does not match the api schema", fieldErrors(err))
+			}
+
+			return next(ctx)
+		}
+	}, nil
+}
+
+// newSpecRouter finds the operation of a request by its path alone,
+// the spec describes the paths, not where the service is deployed
+func newSpecRouter(spec *openapi3.T) (routers.Router, error) {
+	if spec == nil {
+		return nil, errs.NewValueIsRequiredError("openapi spec")
+	}
+	spec.Servers = nil
+	return legacy.NewRouter(spec)
+}
+
+// fieldErrors flattens the validation errors into one entry per invalid value, a body field
+// is named by its dotted path and a parameter by its name
+func fieldErrors(err error) []problems.FieldError {
+	var result []problems.FieldError
+	var collect func(err error, field string)
+	collect = func(err error, field string) {
+		switch e := err.(type) {
+		case openapi3.MultiError:
+			for _, inner := range e {
+				collect(inner, field)
+			}
+		case *openapi3filter.RequestError:
+			if e.Parameter != nil {
+				field = e.Parameter.Name
+			}
+			switch e.Err.(type) {
+			case openapi3.MultiError, *openapi3.SchemaError:
+				collect(e.Err, field)
+			default:
+				result = append(result, problems.FieldError{Field: field, Detail: e.Error()})
+			}
+		case *openapi3.SchemaError:
+			if pointer := e.JSONPointer(); len(pointer) > 0 {
+				field = strings.Join(pointer, ".")
+			}
+			result = append(result, problems.FieldError{Field: field, Detail: e.Reason})
+		default:
+			result = append(result, problems.FieldError{Field: field, Detail: err.Error()})
+		}
+	}
+	collect(err, "")
+	return result
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"github.com/delivery/internal/core/application/usecases/commands"
 	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/generated/servers"
 	"github.com/delivery/internal/pkg/errs"
 )
@@ -13,16 +14,46 @@ type Server struct {
 	assignOrder             commands.AssignOrderHandler
 	createOrder             commands.CreateOrderHandler
 	createCourier           commands.CreateCourierHandler
+	unassignOrder           commands.UnassignOrderHandler
+	reassignOrder           commands.ReassignOrderHandler
+	acceptOrder             commands.AcceptOfferHandler
+	declineOrder            commands.DeclineOfferHandler
+	pickUpOrder             commands.PickUpOrderHandler
+	deliverOrder            commands.DeliverOrderHandler
+	createZone              commands.CreateZoneHandler
+	assignHomeZone          commands.AssignHomeZoneHandler
+	rebuildReadModels       commands.RebuildReadModelsHandler
 	getAllCouriers          queries.GetAllCouriersHandler
 	getAllUncompletedOrders queries.GetAllUncompletedOrdersHandler
+	getCourierAssignment    queries.GetCourierAssignmentHandler
+	getAllZones             queries.GetAllZonesHandler
+	getSupplyDemand         queries.GetSupplyDemandHandler
+	getOrderTimeline        queries.GetOrderTimelineHandler
+	getCourierPath          queries.GetCourierPathHandler
+	clock                   ports.Clock
 }
 
 func NewServer(
 	assignOrder commands.AssignOrderHandler,
 	createOrder commands.CreateOrderHandler,
 	createCourier commands.CreateCourierHandler,
+	unassignOrder commands.UnassignOrderHandler,
+	reassignOrder commands.ReassignOrderHandler,
+	acceptOrder commands.AcceptOfferHandler,
+	declineOrder commands.DeclineOfferHandler,
+	pickUpOrder commands.PickUpOrderHandler,
+	deliverOrder commands.DeliverOrderHandler,
+	createZone commands.CreateZoneHandler,
+	assignHomeZone commands.AssignHomeZoneHandler,
+	rebuildReadModels commands.RebuildReadModelsHandler,
 	getAllCouriers queries.GetAllCouriersHandler,
 	getAllUncompletedOrders queries.GetAllUncompletedOrdersHandler,
+	getCourierAssignment queries.GetCourierAssignmentHandler,
+	getAllZones queries.GetAllZonesHandler,
+	getSupplyDemand queries.GetSupplyDemandHandler,
+	getOrderTimeline queries.GetOrderTimelineHandler,
+	getCourierPath queries.GetCourierPathHandler,
+	clock ports.Clock,
 ) (*Server, error) {
 	if assignOrder == nil {
 		return nil, errs.NewValueIsRequiredError("assign order handler")
@@ -33,18 +64,78 @@ func NewServer(
 	if createCourier == nil {
 		return nil, errs.NewValueIsRequiredError("create courier handler")
 	}
+	if unassignOrder == nil {
+		return nil, errs.NewValueIsRequiredError("unassign order handler")
+	}
+	if reassignOrder == nil {
+		return nil, errs.NewValueIsRequiredError("reassign order handler")
+	}
+	if acceptOrder == nil {
+		return nil, errs.NewValueIsRequiredError("accept order handler")
+	}
+	if declineOrder == nil {
+		return nil, errs.NewValueIsRequiredError("decline order handler")
+	}
+	if pickUpOrder == nil {
+		return nil, errs.NewValueIsRequiredError("pick up order handler")
+	}
+	if deliverOrder == nil {
+		return nil, errs.NewValueIsRequiredError("deliver order handler")
+	}
+	if createZone == nil {
+		return nil, errs.NewValueIsRequiredError("create zone handler")
+	}
+	if assignHomeZone == nil {
+		return nil, errs.NewValueIsRequiredError("assign home zone handler")
+	}
+	if rebuildReadModels == nil {
+		return nil, errs.NewValueIsRequiredError("rebuild read models handler")
+	}
 	if getAllCouriers == nil {
 		return nil, errs.NewValueIsRequiredError("get all couriers handler")
 	}
 	if getAllUncompletedOrders == nil {
 		return nil, errs.NewValueIsRequiredError("get all uncompleted orders handler")
 	}
+	if getCourierAssignment == nil {
+		return nil, errs.NewValueIsRequiredError("get courier assignment handler")
+	}
+	if getAllZones == nil {
+		return nil, errs.NewValueIsRequiredError("get all zones handler")
+	}
+	if getSupplyDemand == nil {
+		return nil, errs.NewValueIsRequiredError("get supply demand handler")
+	}
+	if getOrderTimeline == nil {
+		return nil, errs.NewValueIsRequiredError("get order timeline handler")
+	}
+	if getCourierPath == nil {
+		return nil, errs.NewValueIsRequiredError("get courier path handler")
+	}
+	if clock == nil {
+		return nil, errs.NewValueIsRequiredError("clock")
+	}
 
 	return &Server{
 		assignOrder:             assignOrder,
 		createOrder:             createOrder,
 		createCourier:           createCourier,
+		unassignOrder:           unassignOrder,
+		reassignOrder:           reassignOrder,
+		acceptOrder:             acceptOrder,
+		declineOrder:            declineOrder,
+		pickUpOrder:             pickUpOrder,
+		deliverOrder:            deliverOrder,
+		createZone:              createZone,
+		assignHomeZone:          assignHomeZone,
+		rebuildReadModels:       rebuildReadModels,
 		getAllCouriers:          getAllCouriers,
 		getAllUncompletedOrders: getAllUncompletedOrders,
+		getCourierAssignment:    getCourierAssignment,
+		getAllZones:             getAllZones,
+		getSupplyDemand:         getSupplyDemand,
+		getOrderTimeline:        getOrderTimeline,
+		getCourierPath:          getCourierPath,
+		clock:                   clock,
 	}, nil
 }
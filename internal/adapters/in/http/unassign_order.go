@@ -0,0 +1,29 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/delivery/internal/adapters/in/http/problems"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/generated/servers"
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) UnassignOrder(ctx echo.Context, orderId openapi_types.UUID) error {
+	var request servers.UnassignOrder
+	if err := ctx.Bind(&request); err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	command, err := commands.NewUnassignOrderCommand(orderId, request.Reason)
+	if err != nil {
+		return problems.NewBadRequest(err.Error())
+	}
+
+	if err = s.unassignOrder.Handle(ctx.Request().Context(), command); err != nil {
+		return err
+	}
+
+	return ctx.JSON(http.StatusOK, nil)
+}
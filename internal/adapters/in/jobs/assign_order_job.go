@@ -1,9 +1,12 @@
 package jobs
 
 import (
-	"context"
+	"sync/atomic"
+	"time"
 
 	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/audit"
 	"github.com/delivery/internal/pkg/errs"
 	"github.com/labstack/gommon/log"
 	"github.com/robfig/cron/v3"
@@ -11,26 +14,59 @@ import (
 
 var _ cron.Job = &AssignOrderJob{}
 
+// AssignOrderJob first closes the offers couriers let lapse, then offers the next order
 type AssignOrderJob struct {
-	command commands.AssignOrderHandler
+	expire      commands.ExpireOffersHandler
+	command     commands.AssignOrderHandler
+	clock       ports.Clock
+	lastSuccess atomic.Int64
 }
 
-func NewAssignOrderJob(command commands.AssignOrderHandler) (*AssignOrderJob, error) {
+func NewAssignOrderJob(expire commands.ExpireOffersHandler, command commands.AssignOrderHandler,
+	clock ports.Clock) (*AssignOrderJob, error) {
+	if expire == nil {
+		return nil, errs.NewValueIsRequiredError("ExpireOffersHandler")
+	}
 	if command == nil {
 		return nil, errs.NewValueIsRequiredError("AssignOrderHandler")
 	}
+	if clock == nil {
+		return nil, errs.NewValueIsRequiredError("clock")
+	}
 	return &AssignOrderJob{
+		expire:  expire,
 		command: command,
+		clock:   clock,
 	}, nil
 }
 
 func (j *AssignOrderJob) Run() {
-	ctx := context.Background()
-	command, err := commands.NewAssignOrderCommand()
+	ctx := runContext("assign_order")
+	now := j.clock.Now()
+	expire, err := commands.NewExpireOffersCommand(now)
+	if err != nil {
+		log.Error("failed to create expire offers command: ", err)
+		return
+	}
+	if err := j.expire.Handle(audit.WithCommand(ctx, "ExpireOffers"), expire); err != nil {
+		log.Error("failed to handle expire offers command: ", err)
+		return
+	}
+
+	command, err := commands.NewAssignOrderCommand(now)
 	if err != nil {
 		log.Error("failed to create assign order command: ", err)
+		return
 	}
-	if err := j.command.Handle(ctx, command); err != nil {
+	if err := j.command.Handle(audit.WithCommand(ctx, "AssignOrder"), command); err != nil {
 		log.Error("failed to handle assign order command: ", err)
+		if !isIdleRun(err) {
+			return
+		}
 	}
+	j.lastSuccess.Store(time.Now().UnixNano())
+}
+
+func (j *AssignOrderJob) LastSuccess() time.Time {
+	return unixNanoToTime(j.lastSuccess.Load())
 }
@@ -0,0 +1,76 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/labstack/gommon/log"
+	"github.com/robfig/cron/v3"
+)
+
+type ReportingJob interface {
+	cron.Job
+	LastRunReporter
+}
+
+var (
+	_ ReportingJob    = &ExclusiveJob{}
+	_ StandbyReporter = &ExclusiveJob{}
+)
+
+// ExclusiveJob runs the wrapped job only on the replica holding the job lock.
+// The other replicas stay on standby and take over once the leader is gone.
+type ExclusiveJob struct {
+	name        string
+	locker      ports.JobLocker
+	job         ReportingJob
+	lastStandby atomic.Int64
+	// standby tells whether another replica held the lock on the last run
+	standby atomic.Bool
+}
+
+func NewExclusiveJob(name string, locker ports.JobLocker, job ReportingJob) (*ExclusiveJob, error) {
+	if name == "" {
+		return nil, errs.NewValueIsRequiredError("name")
+	}
+	if locker == nil {
+		return nil, errs.NewValueIsRequiredError("JobLocker")
+	}
+	if job == nil {
+		return nil, errs.NewValueIsRequiredError("job")
+	}
+	return &ExclusiveJob{
+		name:   name,
+		locker: locker,
+		job:    job,
+	}, nil
+}
+
+func (j *ExclusiveJob) Run() {
+	locked, err := j.locker.TryLock(context.Background(), j.name)
+	if err != nil {
+		log.Error("failed to acquire lock for job ", j.name, ": ", err)
+		return
+	}
+	if !locked {
+		// another replica is the leader, waiting is the expected outcome
+		j.lastStandby.Store(time.Now().UnixNano())
+		j.standby.Store(true)
+		return
+	}
+	j.standby.Store(false)
+	j.job.Run()
+}
+
+// LastSuccess is the last successful run of the job on this replica, the runs of the leader elsewhere don't count
+func (j *ExclusiveJob) LastSuccess() time.Time {
+	return j.job.LastSuccess()
+}
+
+// Standby returns when the replica last found another one holding the lock and whether it still did on the last run
+func (j *ExclusiveJob) Standby() (time.Time, bool) {
+	return unixNanoToTime(j.lastStandby.Load()), j.standby.Load()
+}
@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/delivery/internal/pkg/audit"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/health"
+	"github.com/google/uuid"
+)
+
+type LastRunReporter interface {
+	LastSuccess() time.Time
+}
+
+// StandbyReporter is a job that runs on one replica only, the others wait on standby
+type StandbyReporter interface {
+	Standby() (lastStandby time.Time, onStandby bool)
+}
+
+var _ health.Checker = &LastRunChecker{}
+
+type LastRunChecker struct {
+	job       LastRunReporter
+	maxAge    time.Duration
+	startedAt time.Time
+}
+
+func NewLastRunChecker(job LastRunReporter, maxAge time.Duration) (*LastRunChecker, error) {
+	if job == nil {
+		return nil, errs.NewValueIsRequiredError("job")
+	}
+	if maxAge <= 0 {
+		return nil, errs.NewValueIsRequiredError("max age")
+	}
+	return &LastRunChecker{
+		job:       job,
+		maxAge:    maxAge,
+		startedAt: time.Now(),
+	}, nil
+}
+
+// Check fails when the job has not succeeded for the max age. A replica on standby is checked by how recently
+// it saw the lock held elsewhere instead, the successes of the job are reported by the replica running it.
+func (c *LastRunChecker) Check(_ context.Context) error {
+	if standby, ok := c.job.(StandbyReporter); ok {
+		if lastStandby, onStandby := standby.Standby(); onStandby {
+			if age := time.Since(lastStandby); age > c.maxAge {
+				return fmt.Errorf("on standby, last checked the job lock %s ago", age.Round(time.Second))
+			}
+			return nil
+		}
+	}
+
+	lastSuccess := c.job.LastSuccess()
+	if lastSuccess.IsZero() {
+		// give the scheduler a chance to run the job for the first time
+		if time.Since(c.startedAt) <= c.maxAge {
+			return nil
+		}
+		return fmt.Errorf("job has not succeeded since start %s ago", time.Since(c.startedAt).Round(time.Second))
+	}
+
+	if age := time.Since(lastSuccess); age > c.maxAge {
+		return fmt.Errorf("last successful run was %s ago", age.Round(time.Second))
+	}
+	return nil
+}
+
+// isIdleRun reports whether the command failed only because there was nothing to do
+func isIdleRun(err error) bool {
+	return errs.IsNotFound(err) || errs.IsBusiness(err)
+}
+
+func unixNanoToTime(nanos int64) time.Time {
+	if nanos == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, nanos)
+}
+
+// runContext tells the audit log that the changes of one run were made by the job
+func runContext(job string) context.Context {
+	ctx := audit.WithActor(context.Background(), "job:"+job)
+	return audit.WithCorrelationID(ctx, uuid.NewString())
+}
@@ -0,0 +1,74 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type stubJob struct {
+	lastSuccess time.Time
+}
+
+func (j *stubJob) Run() {}
+
+func (j *stubJob) LastSuccess() time.Time {
+	return j.lastSuccess
+}
+
+func TestLastRunChecker_ExclusiveJob(t *testing.T) {
+	maxAge := time.Minute
+
+	tests := map[string]struct {
+		locked      []bool
+		lastSuccess time.Duration
+		wantErr     bool
+	}{
+		"leader that succeeded recently": {
+			locked:      []bool{true},
+			lastSuccess: time.Second,
+		},
+		"leader whose runs stopped succeeding": {
+			locked:      []bool{true},
+			lastSuccess: 2 * maxAge,
+			wantErr:     true,
+		},
+		"leader that was on standby before is judged by its runs only": {
+			locked:      []bool{false, true},
+			lastSuccess: 2 * maxAge,
+			wantErr:     true,
+		},
+		"standby while another replica leads": {
+			locked: []bool{true, false},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			locker := mocks.NewJobLocker(t)
+			for _, locked := range tc.locked {
+				locker.EXPECT().TryLock(mock.Anything, "job").Return(locked, nil).Once()
+			}
+			job := &stubJob{lastSuccess: time.Now().Add(-tc.lastSuccess)}
+			exclusive, err := NewExclusiveJob("job", locker, job)
+			assert.NoError(t, err)
+			for range tc.locked {
+				exclusive.Run()
+			}
+			checker, err := NewLastRunChecker(exclusive, maxAge)
+			assert.NoError(t, err)
+
+			err = checker.Check(context.Background())
+
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
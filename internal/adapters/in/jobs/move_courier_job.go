@@ -1,9 +1,12 @@
 package jobs
 
 import (
-	"context"
+	"sync/atomic"
+	"time"
 
 	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/audit"
 	"github.com/delivery/internal/pkg/errs"
 	"github.com/labstack/gommon/log"
 	"github.com/robfig/cron/v3"
@@ -12,25 +15,40 @@ import (
 var _ cron.Job = &MoveCourierJob{}
 
 type MoveCourierJob struct {
-	command commands.MoveCourierHandler
+	command     commands.MoveCourierHandler
+	clock       ports.Clock
+	lastSuccess atomic.Int64
 }
 
-func NewMoveCourierJob(command commands.MoveCourierHandler) (*MoveCourierJob, error) {
+func NewMoveCourierJob(command commands.MoveCourierHandler, clock ports.Clock) (*MoveCourierJob, error) {
 	if command == nil {
 		return nil, errs.NewValueIsRequiredError("MoveCourierHandler")
 	}
+	if clock == nil {
+		return nil, errs.NewValueIsRequiredError("clock")
+	}
 	return &MoveCourierJob{
 		command: command,
+		clock:   clock,
 	}, nil
 }
 
 func (j *MoveCourierJob) Run() {
-	ctx := context.Background()
-	command, err := commands.NewMoveCourierCommand()
+	ctx := audit.WithCommand(runContext("move_courier"), "MoveCourier")
+	command, err := commands.NewMoveCourierCommand(j.clock.Now())
 	if err != nil {
 		log.Error("failed to create move courier command: ", err)
+		return
 	}
 	if err := j.command.Handle(ctx, command); err != nil {
 		log.Error("failed to handle move courier command: ", err)
+		if !isIdleRun(err) {
+			return
+		}
 	}
+	j.lastSuccess.Store(time.Now().UnixNano())
+}
+
+func (j *MoveCourierJob) LastSuccess() time.Time {
+	return unixNanoToTime(j.lastSuccess.Load())
 }
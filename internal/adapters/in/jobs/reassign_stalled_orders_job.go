@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/audit"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/labstack/gommon/log"
+	"github.com/robfig/cron/v3"
+)
+
+var _ cron.Job = &ReassignStalledOrdersJob{}
+
+type ReassignStalledOrdersJob struct {
+	command     commands.ReassignStalledOrdersHandler
+	clock       ports.Clock
+	lastSuccess atomic.Int64
+}
+
+func NewReassignStalledOrdersJob(command commands.ReassignStalledOrdersHandler, clock ports.Clock) (*ReassignStalledOrdersJob, error) {
+	if command == nil {
+		return nil, errs.NewValueIsRequiredError("ReassignStalledOrdersHandler")
+	}
+	if clock == nil {
+		return nil, errs.NewValueIsRequiredError("clock")
+	}
+	return &ReassignStalledOrdersJob{
+		command: command,
+		clock:   clock,
+	}, nil
+}
+
+func (j *ReassignStalledOrdersJob) Run() {
+	ctx := audit.WithCommand(runContext("reassign_stalled_orders"), "ReassignStalledOrders")
+	command, err := commands.NewReassignStalledOrdersCommand(j.clock.Now())
+	if err != nil {
+		log.Error("failed to create reassign stalled orders command: ", err)
+		return
+	}
+	if err := j.command.Handle(ctx, command); err != nil {
+		log.Error("failed to handle reassign stalled orders command: ", err)
+		if !isIdleRun(err) {
+			return
+		}
+	}
+	j.lastSuccess.Store(time.Now().UnixNano())
+}
+
+func (j *ReassignStalledOrdersJob) LastSuccess() time.Time {
+	return unixNanoToTime(j.lastSuccess.Load())
+}
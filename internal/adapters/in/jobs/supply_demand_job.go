@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/delivery/internal/core/application/usecases/queries"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/labstack/gommon/log"
+	"github.com/robfig/cron/v3"
+)
+
+var _ cron.Job = &SupplyDemandJob{}
+
+// SupplyDemandJob publishes a supply and demand snapshot of the service zones or grid cells
+type SupplyDemandJob struct {
+	query       queries.GetSupplyDemandHandler
+	producer    ports.SupplyDemandProducer
+	clock       ports.Clock
+	lastSuccess atomic.Int64
+}
+
+func NewSupplyDemandJob(query queries.GetSupplyDemandHandler, producer ports.SupplyDemandProducer,
+	clock ports.Clock) (*SupplyDemandJob, error) {
+	if query == nil {
+		return nil, errs.NewValueIsRequiredError("GetSupplyDemandHandler")
+	}
+	if producer == nil {
+		return nil, errs.NewValueIsRequiredError("SupplyDemandProducer")
+	}
+	if clock == nil {
+		return nil, errs.NewValueIsRequiredError("clock")
+	}
+	return &SupplyDemandJob{
+		query:    query,
+		producer: producer,
+		clock:    clock,
+	}, nil
+}
+
+func (j *SupplyDemandJob) Run() {
+	ctx := context.Background()
+	query, err := queries.NewGetSupplyDemandQuery(j.clock.Now(), 0)
+	if err != nil {
+		log.Error("failed to create get supply demand query: ", err)
+		return
+	}
+	result, err := j.query.Handle(*query)
+	if err != nil {
+		log.Error("failed to handle get supply demand query: ", err)
+		return
+	}
+	if err := j.producer.Publish(ctx, result.Snapshot); err != nil {
+		log.Error("failed to publish supply demand snapshot: ", err)
+		return
+	}
+	j.lastSuccess.Store(time.Now().UnixNano())
+}
+
+func (j *SupplyDemandJob) LastSuccess() time.Time {
+	return unixNanoToTime(j.lastSuccess.Load())
+}
@@ -3,22 +3,39 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/model/zone"
 	"github.com/delivery/internal/generated/events/queues/basketconfirmedpb"
+	"github.com/delivery/internal/pkg/audit"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/health"
 	"github.com/google/uuid"
 )
 
+// messageIDHeader lets the producer give a message an id that survives its redelivery
+const messageIDHeader = "message-id"
+
 type BasketConfirmedConsumer interface {
 	Consume() error
 	Close() error
+
+	// Check reports whether the consumer holds an active consumer group session
+	Check(ctx context.Context) error
+	// Alive reports whether the consume loop is still running
+	Alive(ctx context.Context) error
 }
 
 var _ BasketConfirmedConsumer = &basketConfirmedConsumer{}
 var _ sarama.ConsumerGroupHandler = &basketConfirmedConsumer{}
+var _ health.Checker = &basketConfirmedConsumer{}
 
 type basketConfirmedConsumer struct {
 	topic                     string
@@ -26,12 +43,21 @@ type basketConfirmedConsumer struct {
 	createOrderCommandHandler commands.CreateOrderHandler
 	ctx                       context.Context
 	cancel                    context.CancelFunc
+
+	done chan struct{}
+
+	mu            sync.RWMutex
+	started       bool
+	running       bool
+	sessionActive bool
+	consumeErr    error
 }
 
 func NewConsumer(
 	brokers []string,
 	topic string,
 	group string,
+	sessionTimeout time.Duration,
 	createOrderCommandHandler commands.CreateOrderHandler,
 ) (BasketConfirmedConsumer, error) {
 
@@ -39,6 +65,9 @@ func NewConsumer(
 	saramaCfg.Version = sarama.V3_4_0_0
 	saramaCfg.Consumer.Return.Errors = true
 	saramaCfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	if sessionTimeout > 0 {
+		saramaCfg.Consumer.Group.Session.Timeout = sessionTimeout
+	}
 
 	consumerGroup, err := sarama.NewConsumerGroup(brokers, group, saramaCfg)
 	if err != nil {
@@ -52,30 +81,81 @@ func NewConsumer(
 		createOrderCommandHandler: createOrderCommandHandler,
 		ctx:                       ctx,
 		cancel:                    cancel,
+		done:                      make(chan struct{}),
 	}, nil
 }
 
 func (b *basketConfirmedConsumer) Consume() error {
+	b.mu.Lock()
+	b.started = true
+	b.mu.Unlock()
+	defer close(b.done)
+
+	b.setRunning(true, nil)
 	for {
 		if err := b.consumerGroup.Consume(b.ctx, []string{b.topic}, b); err != nil {
+			b.setRunning(false, err)
 			return err
 		}
 		if b.ctx.Err() != nil {
+			b.setRunning(false, nil)
 			return nil
 		}
 	}
 }
 
+// Close stops consuming, waits for the in-flight message and the offsets commit, then leaves the group
 func (b *basketConfirmedConsumer) Close() error {
 	b.cancel()
-	return b.consumerGroup.Close()
+
+	b.mu.RLock()
+	started := b.started
+	b.mu.RUnlock()
+	if started {
+		<-b.done
+	}
+
+	if err := b.consumerGroup.Close(); err != nil {
+		return fmt.Errorf("failed to close consumer group: %w", err)
+	}
+	return nil
+}
+
+func (b *basketConfirmedConsumer) Check(ctx context.Context) error {
+	if err := b.Alive(ctx); err != nil {
+		return err
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if !b.sessionActive {
+		return errors.New("kafka consumer has no active group session")
+	}
+	return nil
+}
+
+func (b *basketConfirmedConsumer) Alive(_ context.Context) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.consumeErr != nil {
+		return fmt.Errorf("kafka consumer stopped: %w", b.consumeErr)
+	}
+	if !b.running {
+		return errors.New("kafka consumer is not running")
+	}
+	return nil
 }
 
 func (b *basketConfirmedConsumer) Setup(_ sarama.ConsumerGroupSession) error {
+	b.setSessionActive(true)
 	return nil
 }
 
-func (b *basketConfirmedConsumer) Cleanup(_ sarama.ConsumerGroupSession) error {
+func (b *basketConfirmedConsumer) Cleanup(session sarama.ConsumerGroupSession) error {
+	b.setSessionActive(false)
+	session.Commit()
 	return nil
 }
 
@@ -104,10 +184,21 @@ func (b *basketConfirmedConsumer) ConsumeClaim(session sarama.ConsumerGroupSessi
 				continue
 			}
 
+			priority, err := order.ParsePriority(event.GetPriority())
+			if err != nil {
+				log.Printf("Failed to parse priority '%s' for topic %s, partition %d, offset %d: %v. Skipping message.",
+					event.GetPriority(), message.Topic, message.Partition, message.Offset, err)
+				session.MarkMessage(message, "")
+				continue
+			}
+
 			command, err := commands.NewCreateOrderCommand(
 				parsedBasketID,
+				messageID(message),
 				event.GetAddress().GetStreet(),
 				int(event.GetVolume()),
+				priority,
+				nil,
 			)
 
 			if err != nil {
@@ -117,8 +208,18 @@ func (b *basketConfirmedConsumer) ConsumeClaim(session sarama.ConsumerGroupSessi
 				continue
 			}
 
-			handlerCtx := session.Context()
+			// the message must be processed to the end even if the session is being shut down
+			handlerCtx := context.WithoutCancel(session.Context())
+			handlerCtx = audit.WithActor(handlerCtx, "kafka:"+message.Topic)
+			handlerCtx = audit.WithCommand(handlerCtx, "CreateOrder")
+			handlerCtx = audit.WithCorrelationID(handlerCtx, command.MessageID())
 			if err := b.createOrderCommandHandler.Handle(handlerCtx, command); err != nil {
+				if errs.HasCause(err, zone.ErrOutsideServiceZones) {
+					log.Printf("Basket %s of topic %s, partition %d, offset %d is outside every service zone: %v. Skipping message.",
+						event.BasketId, message.Topic, message.Partition, message.Offset, err)
+					session.MarkMessage(message, "")
+					continue
+				}
 				log.Printf("Failed to handle CreateOrderCommand for topic %s, partition %d, offset %d: %v. Message will be reprocessed.",
 					message.Topic, message.Partition, message.Offset, err)
 				return fmt.Errorf("failed to process message offset %d: %w", message.Offset, err)
@@ -132,3 +233,31 @@ func (b *basketConfirmedConsumer) ConsumeClaim(session sarama.ConsumerGroupSessi
 		}
 	}
 }
+
+func (b *basketConfirmedConsumer) setRunning(running bool, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.running = running
+	b.consumeErr = err
+	if !running {
+		b.sessionActive = false
+	}
+}
+
+func (b *basketConfirmedConsumer) setSessionActive(active bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.sessionActive = active
+}
+
+// messageID is the id the producer gave the message, or else its position in the topic
+func messageID(message *sarama.ConsumerMessage) string {
+	for _, header := range message.Headers {
+		if header != nil && string(header.Key) == messageIDHeader && len(header.Value) > 0 {
+			return string(header.Value)
+		}
+	}
+	return fmt.Sprintf("%s/%d/%d", message.Topic, message.Partition, message.Offset)
+}
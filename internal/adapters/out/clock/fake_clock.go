@@ -0,0 +1,42 @@
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/delivery/internal/core/ports"
+)
+
+var _ ports.Clock = &FakeClock{}
+
+// FakeClock only moves when told to, which makes tests deterministic
+// and lets simulations run faster than real time
+type FakeClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+func NewFakeClock(start time.Time) *FakeClock {
+	return &FakeClock{
+		now: start,
+	}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+func (c *FakeClock) Advance(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	return c.now
+}
+
+func (c *FakeClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
@@ -0,0 +1,20 @@
+package clock
+
+import (
+	"time"
+
+	"github.com/delivery/internal/core/ports"
+)
+
+var _ ports.Clock = &SystemClock{}
+
+type SystemClock struct {
+}
+
+func NewSystemClock() *SystemClock {
+	return &SystemClock{}
+}
+
+func (c *SystemClock) Now() time.Time {
+	return time.Now()
+}
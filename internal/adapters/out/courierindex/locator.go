@@ -0,0 +1,113 @@
+package courierindex
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+var _ ports.CourierLocator = &Locator{}
+
+// Locator finds the nearest couriers in the in-memory index and loads only those of them that are free.
+// The index is seeded with the whole fleet on the first lookup and again once reseedEvery has passed:
+// the courier events only reach the replica that raised them, so the moves made elsewhere come in with the reseed.
+type Locator struct {
+	index       *service.CourierIndex
+	couriers    ports.CourierRepository
+	clock       ports.Clock
+	reseedEvery time.Duration
+
+	mu       sync.Mutex
+	seededAt time.Time
+}
+
+func NewLocator(index *service.CourierIndex, couriers ports.CourierRepository, clock ports.Clock,
+	reseedEvery time.Duration) (*Locator, error) {
+	if index == nil {
+		return nil, errs.NewValueIsRequiredError("courier index")
+	}
+	if couriers == nil {
+		return nil, errs.NewValueIsRequiredError("courier repository")
+	}
+	if clock == nil {
+		return nil, errs.NewValueIsRequiredError("clock")
+	}
+	if reseedEvery <= 0 {
+		return nil, errs.NewValueIsRequiredError("reseed every")
+	}
+	return &Locator{
+		index:       index,
+		couriers:    couriers,
+		clock:       clock,
+		reseedEvery: reseedEvery,
+	}, nil
+}
+
+// GetNearestAvailable asks the index for twice as many couriers every time the busy ones leave
+// fewer than limit free, until the index has no more
+func (l *Locator) GetNearestAvailable(ctx context.Context, location kernel.Location,
+	limit int) ([]*courier.Courier, error) {
+	if err := l.seed(ctx); err != nil {
+		return nil, err
+	}
+
+	asked := make(map[uuid.UUID]bool)
+	var found []*courier.Courier
+	for k := limit; len(found) < limit; k *= 2 {
+		ids := l.index.Nearest(location, k)
+		var fresh []uuid.UUID
+		for _, id := range ids {
+			if !asked[id] {
+				asked[id] = true
+				fresh = append(fresh, id)
+			}
+		}
+		available, err := l.couriers.GetAvailableByIDs(ctx, fresh)
+		if err != nil {
+			return nil, err
+		}
+		found = append(found, available...)
+		if len(ids) < k {
+			break
+		}
+	}
+
+	// the loaded couriers are ranked again, the index may lag behind their last move
+	sort.SliceStable(found, func(i, j int) bool {
+		ti, tj := found[i].CalculateTimeToLocation(location), found[j].CalculateTimeToLocation(location)
+		if ti != tj {
+			return ti < tj
+		}
+		idI, idJ := found[i].ID(), found[j].ID()
+		return bytes.Compare(idI[:], idJ[:]) < 0
+	})
+	return found[:min(limit, len(found))], nil
+}
+
+func (l *Locator) seed(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	if !l.seededAt.IsZero() && now.Sub(l.seededAt) < l.reseedEvery {
+		return nil
+	}
+
+	couriers, err := l.couriers.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range couriers {
+		l.index.Put(c.ID(), c.Location(), c.Speed())
+	}
+	l.seededAt = now
+	return nil
+}
@@ -0,0 +1,126 @@
+package courierindex
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/adapters/out/memory"
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocator_GetNearestAvailable(t *testing.T) {
+	ctx := context.Background()
+	uow, err := memory.NewUnitOfWork(ddd.NewMediatr())
+	require.NoError(t, err)
+	addCourier := func(x, y int) *courier.Courier {
+		location, err := kernel.NewLocation(x, y)
+		require.NoError(t, err)
+		c, err := courier.NewCourier("courier", 1, location)
+		require.NoError(t, err)
+		require.NoError(t, c.AddStoragePlace("bag", 10))
+		require.NoError(t, uow.CourierRepository().Add(ctx, c))
+		return c
+	}
+	busy := addCourier(1, 1)
+	next := addCourier(2, 3)
+	far := addCourier(10, 10)
+	held, err := order.NewOrder(uuid.New(), busy.Location(), 1, order.Standard, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, busy.TakeOrder(held))
+	target, err := kernel.NewLocation(1, 2)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		limit int
+		want  []*courier.Courier
+	}{
+		"asks the index for more while the nearest couriers are busy": {
+			limit: 1,
+			want:  []*courier.Courier{next},
+		},
+		"returns every free courier when the fleet is smaller than the limit": {
+			limit: 5,
+			want:  []*courier.Courier{next, far},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			locator, err := NewLocator(service.NewCourierIndex(), uow.CourierRepository(), &testClock{}, time.Minute)
+			require.NoError(t, err)
+
+			got, err := locator.GetNearestAvailable(ctx, target, tc.limit)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLocator_GetNearestAvailable_Reseeds(t *testing.T) {
+	ctx := context.Background()
+	target, err := kernel.NewLocation(1, 2)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		elapsed time.Duration
+		wantNew bool
+	}{
+		"keeps the index within the reseed interval": {
+			elapsed: 59 * time.Second,
+		},
+		"reseeds the index once the interval passed": {
+			elapsed: time.Minute,
+			wantNew: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			uow, err := memory.NewUnitOfWork(ddd.NewMediatr())
+			require.NoError(t, err)
+			addCourier := func(x, y int) *courier.Courier {
+				location, err := kernel.NewLocation(x, y)
+				require.NoError(t, err)
+				c, err := courier.NewCourier("courier", 1, location)
+				require.NoError(t, err)
+				require.NoError(t, uow.CourierRepository().Add(ctx, c))
+				return c
+			}
+			known := addCourier(3, 3)
+			clock := &testClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+			locator, err := NewLocator(service.NewCourierIndex(), uow.CourierRepository(), clock, time.Minute)
+			require.NoError(t, err)
+			_, err = locator.GetNearestAvailable(ctx, target, 1)
+			require.NoError(t, err)
+
+			// a courier the events of this process never told the index about, e.g. one added by another replica
+			unknown := addCourier(1, 1)
+			clock.now = clock.now.Add(tc.elapsed)
+			got, err := locator.GetNearestAvailable(ctx, target, 1)
+
+			require.NoError(t, err)
+			want := known
+			if tc.wantNew {
+				want = unknown
+			}
+			assert.Equal(t, []*courier.Courier{want}, got)
+		})
+	}
+}
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
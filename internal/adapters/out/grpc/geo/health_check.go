@@ -0,0 +1,23 @@
+package geo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/delivery/internal/pkg/health"
+	"google.golang.org/grpc/connectivity"
+)
+
+var _ health.Checker = (*Client)(nil)
+
+func (c *Client) Check(_ context.Context) error {
+	state := c.conn.GetState()
+	switch state {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return fmt.Errorf("geo service connection is in %s state", state)
+	case connectivity.Idle:
+		// connection is lazy, wake it up so that the next check sees the real state
+		c.conn.Connect()
+	}
+	return nil
+}
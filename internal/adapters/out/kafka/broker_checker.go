@@ -0,0 +1,64 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/health"
+)
+
+var _ health.Checker = &BrokerChecker{}
+
+type BrokerChecker struct {
+	client sarama.Client
+}
+
+func NewBrokerChecker(brokers []string) (*BrokerChecker, error) {
+	if brokers == nil || len(brokers) == 0 {
+		return nil, errs.NewValueIsRequiredError("brokers")
+	}
+
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Version = sarama.V3_4_0_0
+
+	client, err := sarama.NewClient(brokers, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sarama client: %w", err)
+	}
+
+	return &BrokerChecker{
+		client: client,
+	}, nil
+}
+
+func (c *BrokerChecker) Check(ctx context.Context) error {
+	resultCh := make(chan error, 1)
+
+	go func() {
+		resultCh <- c.client.RefreshMetadata()
+		close(resultCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-resultCh:
+		if err != nil {
+			return fmt.Errorf("failed to refresh kafka metadata: %w", err)
+		}
+		if len(c.client.Brokers()) == 0 {
+			return errors.New("no kafka brokers available")
+		}
+		return nil
+	}
+}
+
+func (c *BrokerChecker) Close() error {
+	if err := c.client.Close(); err != nil {
+		return fmt.Errorf("error closing sarama client: %w", err)
+	}
+	return nil
+}
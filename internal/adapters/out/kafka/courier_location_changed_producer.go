@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/generated/events/queues/courierlocationchangedpb"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ ports.CourierProducer = &courierLocationChangedProducer{}
+
+type courierLocationChangedProducer struct {
+	topic    string
+	producer sarama.SyncProducer
+}
+
+func NewCourierLocationChangedProducer(
+	brokers []string,
+	topic string,
+	maxRetries int,
+	timeout time.Duration,
+) (ports.CourierProducer, error) {
+	producer, err := newSyncProducer(brokers, topic, maxRetries, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &courierLocationChangedProducer{
+		topic:    topic,
+		producer: producer,
+	}, nil
+}
+
+func (c *courierLocationChangedProducer) Publish(ctx context.Context, domainEvent ddd.DomainEvent) error {
+	if domainEvent == nil {
+		return errs.NewValueIsRequiredError("event")
+	}
+
+	moved, ok := domainEvent.(*courier.MovedDomainEvent)
+	if !ok {
+		return fmt.Errorf("invalid event type: %T, expected: %T", domainEvent, &courier.MovedDomainEvent{})
+	}
+
+	integrationEvent := mapMovedToIntegrationEvent(moved)
+	return send(ctx, c.producer, c.topic, integrationEvent.CourierId, integrationEvent)
+}
+
+func (c *courierLocationChangedProducer) Close() error {
+	return closeProducer(c.producer)
+}
+
+func mapMovedToIntegrationEvent(event *courier.MovedDomainEvent) *courierlocationchangedpb.CourierLocationChangedIntegrationEvent {
+	return &courierlocationchangedpb.CourierLocationChangedIntegrationEvent{
+		CourierId:  event.GetAggregateID().String(),
+		X:          int32(event.To.X()),
+		Y:          int32(event.To.Y()),
+		OccurredAt: timestamppb.New(event.GetOccurredAt()),
+	}
+}
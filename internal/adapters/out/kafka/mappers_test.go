@@ -0,0 +1,115 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/analytics"
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/generated/events/queues/orderstatuschangedpb"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAssignedToIntegrationEvent(t *testing.T) {
+	o, courierID := mustCreateAssignedOrder(t)
+	eta := time.Date(2025, 1, 1, 12, 30, 0, 0, time.UTC)
+	require.NoError(t, o.UpdateEta(eta))
+	assigned := lastEvent(t, o.GetDomainEvents()).(*order.AssignedDomainEvent)
+
+	integrationEvent := mapAssignedToIntegrationEvent(assigned)
+
+	assert.Equal(t, o.ID().String(), integrationEvent.OrderId)
+	assert.Equal(t, courierID.String(), integrationEvent.CourierId)
+	assert.Equal(t, eta, integrationEvent.Eta.AsTime())
+}
+
+func TestMapMovedToIntegrationEvent(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	location, err := kernel.NewLocation(1, 1)
+	require.NoError(t, err)
+	target, err := kernel.NewLocation(1, 5)
+	require.NoError(t, err)
+	c, err := courier.NewCourier("courier", 2, location)
+	require.NoError(t, err)
+	require.NoError(t, c.Move(target, start, time.Minute))
+	require.NoError(t, c.Move(target, start.Add(time.Second), time.Minute))
+	moved := lastEvent(t, c.GetDomainEvents()).(*courier.MovedDomainEvent)
+
+	integrationEvent := mapMovedToIntegrationEvent(moved)
+
+	assert.Equal(t, c.ID().String(), integrationEvent.CourierId)
+	assert.Equal(t, int32(1), integrationEvent.X)
+	assert.Equal(t, int32(3), integrationEvent.Y)
+	assert.Equal(t, moved.GetOccurredAt(), integrationEvent.OccurredAt.AsTime())
+}
+
+func TestMapDomainEventToIntegrationEvent(t *testing.T) {
+	o, courierID := mustCreateAssignedOrder(t)
+	transitions := []struct {
+		apply func() error
+		want  orderstatuschangedpb.OrderStatus
+	}{
+		{apply: func() error { return o.Accept(courierID) }, want: orderstatuschangedpb.OrderStatus_Accepted},
+		{apply: func() error { return o.PickUp(courierID) }, want: orderstatuschangedpb.OrderStatus_PickedUp},
+		{apply: func() error { return o.Complete(nil) }, want: orderstatuschangedpb.OrderStatus_Completed},
+	}
+
+	for _, transition := range transitions {
+		require.NoError(t, transition.apply())
+
+		integrationEvent, err := mapDomainEventToIntegrationEvent(lastEvent(t, o.GetDomainEvents()))
+
+		require.NoError(t, err)
+		assert.Equal(t, o.ID().String(), integrationEvent.OrderId)
+		assert.Equal(t, transition.want, integrationEvent.OrderStatus)
+	}
+
+	_, err := mapDomainEventToIntegrationEvent(o.GetDomainEvents()[0])
+	assert.Error(t, err, "order creation is not a status change for the basket service")
+}
+
+func TestMapSnapshotToIntegrationEvent(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	location, err := kernel.NewLocation(2, 2)
+	require.NoError(t, err)
+	rules, err := analytics.NewRules([]time.Duration{15 * time.Minute}, 2)
+	require.NoError(t, err)
+	areas, err := analytics.CellAreas(5)
+	require.NoError(t, err)
+	snapshot := analytics.NewSnapshot(now, rules, areas, nil, []analytics.OrderSample{
+		{Location: location, CreatedAt: now.Add(-3 * time.Minute), Waiting: true},
+	})
+
+	integrationEvent := mapSnapshotToIntegrationEvent(snapshot)
+
+	assert.Equal(t, now, integrationEvent.TakenAt.AsTime())
+	require.Len(t, integrationEvent.Areas, 4)
+	area := integrationEvent.Areas[0]
+	assert.Empty(t, area.ZoneId)
+	assert.Equal(t, "1:1-5:5", area.Name)
+	assert.Equal(t, int32(5), area.To.X)
+	assert.Equal(t, int32(1), area.Demand)
+	assert.True(t, area.Surge)
+	require.Len(t, area.Windows, 1)
+	assert.Equal(t, 15*time.Minute, area.Windows[0].Window.AsDuration())
+	assert.Equal(t, 3*time.Minute, area.Windows[0].AverageWait.AsDuration())
+}
+
+func mustCreateAssignedOrder(t *testing.T) (*order.Order, uuid.UUID) {
+	location, err := kernel.NewLocation(5, 5)
+	require.NoError(t, err)
+	o, err := order.NewOrder(uuid.New(), location, 1, order.Standard, time.Now())
+	require.NoError(t, err)
+	courierID := uuid.New()
+	require.NoError(t, o.Assign(&courierID, time.Now()))
+	return o, courierID
+}
+
+func lastEvent[T any](t *testing.T, events []T) T {
+	require.NotEmpty(t, events)
+	return events[len(events)-1]
+}
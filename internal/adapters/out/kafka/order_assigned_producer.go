@@ -0,0 +1,68 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/generated/events/queues/orderassignedpb"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var _ ports.OrderProducer = &orderAssignedProducer{}
+
+type orderAssignedProducer struct {
+	topic    string
+	producer sarama.SyncProducer
+}
+
+func NewOrderAssignedProducer(
+	brokers []string,
+	topic string,
+	maxRetries int,
+	timeout time.Duration,
+) (ports.OrderProducer, error) {
+	producer, err := newSyncProducer(brokers, topic, maxRetries, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orderAssignedProducer{
+		topic:    topic,
+		producer: producer,
+	}, nil
+}
+
+func (o *orderAssignedProducer) Publish(ctx context.Context, domainEvent ddd.DomainEvent) error {
+	if domainEvent == nil {
+		return errs.NewValueIsRequiredError("event")
+	}
+
+	assigned, ok := domainEvent.(*order.AssignedDomainEvent)
+	if !ok {
+		return fmt.Errorf("invalid event type: %T, expected: %T", domainEvent, &order.AssignedDomainEvent{})
+	}
+
+	integrationEvent := mapAssignedToIntegrationEvent(assigned)
+	return send(ctx, o.producer, o.topic, integrationEvent.OrderId, integrationEvent)
+}
+
+func (o *orderAssignedProducer) Close() error {
+	return closeProducer(o.producer)
+}
+
+func mapAssignedToIntegrationEvent(event *order.AssignedDomainEvent) *orderassignedpb.OrderAssignedIntegrationEvent {
+	integrationEvent := &orderassignedpb.OrderAssignedIntegrationEvent{
+		OrderId:   event.GetAggregateID().String(),
+		CourierId: event.CourierID.String(),
+	}
+	if event.Eta != nil {
+		integrationEvent.Eta = timestamppb.New(*event.Eta)
+	}
+	return integrationEvent
+}
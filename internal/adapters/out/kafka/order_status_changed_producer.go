@@ -3,7 +3,7 @@ package kafka
 import (
 	"context"
 	"fmt"
-	"log"
+	"time"
 
 	"github.com/IBM/sarama"
 	"github.com/delivery/internal/core/domain/model/order"
@@ -11,29 +11,25 @@ import (
 	"github.com/delivery/internal/generated/events/queues/orderstatuschangedpb"
 	"github.com/delivery/internal/pkg/ddd"
 	"github.com/delivery/internal/pkg/errs"
-	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+var _ ports.OrderProducer = &orderStatusChangedProducer{}
+
 type orderStatusChangedProducer struct {
 	topic    string
 	producer sarama.SyncProducer
 }
 
-func NewOrderStatusChangedProducer(brokers []string, topic string) (ports.OrderProducer, error) {
-	if brokers == nil || len(brokers) == 0 {
-		return nil, errs.NewValueIsRequiredError("brokers")
-	}
-	if topic == "" {
-		return nil, errs.NewValueIsRequiredError("topic")
-	}
-
-	saramaCfg := sarama.NewConfig()
-	saramaCfg.Version = sarama.V3_4_0_0
-	saramaCfg.Producer.Return.Successes = true
-
-	producer, err := sarama.NewSyncProducer(brokers, saramaCfg)
+func NewOrderStatusChangedProducer(
+	brokers []string,
+	topic string,
+	maxRetries int,
+	timeout time.Duration,
+) (ports.OrderProducer, error) {
+	producer, err := newSyncProducer(brokers, topic, maxRetries, timeout)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create sarama sync producer: %w", err)
+		return nil, err
 	}
 
 	return &orderStatusChangedProducer{
@@ -47,71 +43,49 @@ func (o *orderStatusChangedProducer) Publish(ctx context.Context, domainEvent dd
 		return errs.NewValueIsRequiredError("event")
 	}
 
-	completedDomainEvent, ok := domainEvent.(*order.StatusChangedDomainEvent)
-	if !ok {
-		return fmt.Errorf("invalid event type: %T, expected: %T", domainEvent, &order.StatusChangedDomainEvent{})
-	}
-
-	integrationEvent, err := mapDomainEventToIntegrationEvent(completedDomainEvent)
+	integrationEvent, err := mapDomainEventToIntegrationEvent(domainEvent)
 	if err != nil {
 		return fmt.Errorf("failed to map domain event to integration event: %w", err)
 	}
 
-	eventBytes, err := proto.Marshal(integrationEvent)
-	if err != nil {
-		return fmt.Errorf("failed to marshal integration event: %w", err)
-	}
-
-	message := &sarama.ProducerMessage{
-		Topic: o.topic,
-		Key:   sarama.StringEncoder(completedDomainEvent.OrderID.String()),
-		Value: sarama.ByteEncoder(eventBytes),
-	}
-
-	resultCh := make(chan error, 1)
-
-	go func() {
-		partition, offset, errSend := o.producer.SendMessage(message)
-		if errSend == nil {
-			log.Printf("Message for order %s sent successfully to topic %s, partition %d, offset %d",
-				completedDomainEvent.OrderID.String(), o.topic, partition, offset)
-		}
-		resultCh <- errSend
-		close(resultCh)
-	}()
-
-	select {
-	case <-ctx.Done():
-		return ctx.Err()
-	case errSend := <-resultCh:
-		if errSend != nil {
-			return fmt.Errorf("failed to send message to kafka: %w", errSend)
-		}
-		return nil
-	}
+	return send(ctx, o.producer, o.topic, integrationEvent.OrderId, integrationEvent)
 }
 
 func (o *orderStatusChangedProducer) Close() error {
-	if err := o.producer.Close(); err != nil {
-		return fmt.Errorf("error closing sarama producer: %w", err)
-	}
-	return nil
+	return closeProducer(o.producer)
 }
 
-func mapDomainEventToIntegrationEvent(event *order.StatusChangedDomainEvent) (*orderstatuschangedpb.OrderStatusChangedIntegrationEvent, error) {
-	if event == nil {
-		return nil, errs.NewValueIsRequiredError("event (StatusChangedDomainEvent)")
+// mapDomainEventToIntegrationEvent turns an order transition into the status it moved the order to
+func mapDomainEventToIntegrationEvent(event ddd.DomainEvent) (*orderstatuschangedpb.OrderStatusChangedIntegrationEvent, error) {
+	var orderStatus order.Status
+	var eta *time.Time
+	switch e := event.(type) {
+	case *order.AssignedDomainEvent:
+		orderStatus, eta = order.Assigned, e.Eta
+	case *order.AcceptedDomainEvent:
+		orderStatus = order.Accepted
+	case *order.PickedUpDomainEvent:
+		orderStatus = order.PickedUp
+	case *order.UnassignedDomainEvent:
+		orderStatus = order.Created
+	case *order.CompletedDomainEvent:
+		orderStatus = order.Completed
+	default:
+		return nil, fmt.Errorf("invalid event type: %T, expected an order status transition", event)
 	}
 
-	status, ok := orderstatuschangedpb.OrderStatus_value[event.OrderStatus.String()]
+	status, ok := orderstatuschangedpb.OrderStatus_value[orderStatus.String()]
 	if !ok {
-		return nil, fmt.Errorf("invalid order status: %s", event.OrderStatus.String())
+		return nil, fmt.Errorf("invalid order status: %s", orderStatus.String())
 	}
 
 	integrationEvent := orderstatuschangedpb.OrderStatusChangedIntegrationEvent{
-		OrderId:     event.OrderID.String(),
+		OrderId:     event.GetAggregateID().String(),
 		OrderStatus: orderstatuschangedpb.OrderStatus(status),
 	}
+	if eta != nil {
+		integrationEvent.Eta = timestamppb.New(*eta)
+	}
 
 	return &integrationEvent, nil
 }
@@ -0,0 +1,86 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/delivery/internal/core/domain/model/analytics"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/generated/events/queues/supplydemandsnapshotpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// supplyDemandKey keeps the snapshots in one partition, so consumers read them in the order they were taken
+const supplyDemandKey = "supply-demand"
+
+var _ ports.SupplyDemandProducer = &supplyDemandProducer{}
+
+type supplyDemandProducer struct {
+	topic    string
+	producer sarama.SyncProducer
+}
+
+func NewSupplyDemandProducer(
+	brokers []string,
+	topic string,
+	maxRetries int,
+	timeout time.Duration,
+) (ports.SupplyDemandProducer, error) {
+	producer, err := newSyncProducer(brokers, topic, maxRetries, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &supplyDemandProducer{
+		topic:    topic,
+		producer: producer,
+	}, nil
+}
+
+func (p *supplyDemandProducer) Publish(ctx context.Context, snapshot analytics.Snapshot) error {
+	return send(ctx, p.producer, p.topic, supplyDemandKey, mapSnapshotToIntegrationEvent(snapshot))
+}
+
+func (p *supplyDemandProducer) Close() error {
+	return closeProducer(p.producer)
+}
+
+func mapSnapshotToIntegrationEvent(snapshot analytics.Snapshot) *supplydemandsnapshotpb.SupplyDemandSnapshotIntegrationEvent {
+	areas := make([]*supplydemandsnapshotpb.AreaSupplyDemand, 0, len(snapshot.Areas()))
+	for _, s := range snapshot.Areas() {
+		windows := make([]*supplydemandsnapshotpb.WindowSupplyDemand, 0, len(s.Windows()))
+		for _, w := range s.Windows() {
+			windows = append(windows, &supplydemandsnapshotpb.WindowSupplyDemand{
+				Window:      durationpb.New(w.Window()),
+				Orders:      int32(w.Orders()),
+				AverageWait: durationpb.New(w.AverageWait()),
+			})
+		}
+
+		area := &supplydemandsnapshotpb.AreaSupplyDemand{
+			Name:       s.Area().Name(),
+			From:       mapLocation(s.Area().Bounds().From().X(), s.Area().Bounds().From().Y()),
+			To:         mapLocation(s.Area().Bounds().To().X(), s.Area().Bounds().To().Y()),
+			Supply:     int32(s.Supply()),
+			Demand:     int32(s.Demand()),
+			SurgeRatio: s.SurgeRatio(),
+			Surge:      s.Surge(),
+			Windows:    windows,
+		}
+		if zoneID := s.Area().ZoneID(); zoneID != nil {
+			area.ZoneId = zoneID.String()
+		}
+		areas = append(areas, area)
+	}
+
+	return &supplydemandsnapshotpb.SupplyDemandSnapshotIntegrationEvent{
+		TakenAt: timestamppb.New(snapshot.TakenAt()),
+		Areas:   areas,
+	}
+}
+
+func mapLocation(x, y int) *supplydemandsnapshotpb.Location {
+	return &supplydemandsnapshotpb.Location{X: int32(x), Y: int32(y)}
+}
@@ -0,0 +1,79 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/delivery/internal/pkg/errs"
+	"google.golang.org/protobuf/proto"
+)
+
+// newSyncProducer creates the sarama producer shared by the integration event producers
+func newSyncProducer(brokers []string, topic string, maxRetries int, timeout time.Duration) (sarama.SyncProducer, error) {
+	if len(brokers) == 0 {
+		return nil, errs.NewValueIsRequiredError("brokers")
+	}
+	if topic == "" {
+		return nil, errs.NewValueIsRequiredError("topic")
+	}
+
+	saramaCfg := sarama.NewConfig()
+	saramaCfg.Version = sarama.V3_4_0_0
+	saramaCfg.Producer.Return.Successes = true
+	saramaCfg.Producer.Retry.Max = maxRetries
+	if timeout > 0 {
+		saramaCfg.Producer.Timeout = timeout
+	}
+
+	producer, err := sarama.NewSyncProducer(brokers, saramaCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sarama sync producer: %w", err)
+	}
+	return producer, nil
+}
+
+// send publishes the integration event keyed by its aggregate, giving up when ctx is done
+func send(ctx context.Context, producer sarama.SyncProducer, topic string, key string, integrationEvent proto.Message) error {
+	eventBytes, err := proto.Marshal(integrationEvent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal integration event: %w", err)
+	}
+
+	message := &sarama.ProducerMessage{
+		Topic: topic,
+		Key:   sarama.StringEncoder(key),
+		Value: sarama.ByteEncoder(eventBytes),
+	}
+
+	resultCh := make(chan error, 1)
+
+	go func() {
+		partition, offset, errSend := producer.SendMessage(message)
+		if errSend == nil {
+			log.Printf("Message for %s sent successfully to topic %s, partition %d, offset %d",
+				key, topic, partition, offset)
+		}
+		resultCh <- errSend
+		close(resultCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case errSend := <-resultCh:
+		if errSend != nil {
+			return fmt.Errorf("failed to send message to kafka: %w", errSend)
+		}
+		return nil
+	}
+}
+
+func closeProducer(producer sarama.SyncProducer) error {
+	if err := producer.Close(); err != nil {
+		return fmt.Errorf("error closing sarama producer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"sort"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+var _ ports.CourierRepository = &CourierRepository{}
+
+type CourierRepository struct {
+	uow      *UnitOfWork
+	couriers []*courier.Courier
+	byID     map[uuid.UUID]*courier.Courier
+}
+
+func newCourierRepository(uow *UnitOfWork) *CourierRepository {
+	return &CourierRepository{
+		uow:  uow,
+		byID: make(map[uuid.UUID]*courier.Courier),
+	}
+}
+
+func (r *CourierRepository) Add(ctx context.Context, courier *courier.Courier) error {
+	if courier == nil {
+		return errs.NewValueIsRequiredError("courier")
+	}
+	if _, ok := r.byID[courier.ID()]; ok {
+		return errs.NewConflictError("courier", courier.ID().String(), "courier already exists")
+	}
+
+	r.couriers = append(r.couriers, courier)
+	r.byID[courier.ID()] = courier
+
+	return r.save(ctx, courier)
+}
+
+func (r *CourierRepository) Update(ctx context.Context, courier *courier.Courier) error {
+	if courier == nil {
+		return errs.NewValueIsRequiredError("courier")
+	}
+	if _, ok := r.byID[courier.ID()]; !ok {
+		return errs.NewNotFoundError("courier", courier.ID().String())
+	}
+
+	return r.save(ctx, courier)
+}
+
+func (r *CourierRepository) Get(_ context.Context, courierID uuid.UUID) (*courier.Courier, error) {
+	c, ok := r.byID[courierID]
+	if !ok {
+		return nil, errs.NewNotFoundError("courier", courierID.String())
+	}
+	return c, nil
+}
+
+// GetAll returns the couriers in the order they were added
+func (r *CourierRepository) GetAll(_ context.Context) ([]*courier.Courier, error) {
+	return append([]*courier.Courier(nil), r.couriers...), nil
+}
+
+func (r *CourierRepository) GetAllAvailable(_ context.Context) ([]*courier.Courier, error) {
+	var available []*courier.Courier
+	for _, c := range r.couriers {
+		if isAvailable(c) {
+			available = append(available, c)
+		}
+	}
+	return available, nil
+}
+
+func (r *CourierRepository) GetAvailableByIDs(_ context.Context, courierIDs []uuid.UUID) ([]*courier.Courier, error) {
+	var available []*courier.Courier
+	for _, id := range courierIDs {
+		if c, ok := r.byID[id]; ok && isAvailable(c) {
+			available = append(available, c)
+		}
+	}
+	return available, nil
+}
+
+// GetNearestAvailable ranks the free couriers like the postgres repository: by the time to the location, then by id
+func (r *CourierRepository) GetNearestAvailable(ctx context.Context, location kernel.Location,
+	limit int) ([]*courier.Courier, error) {
+	available, err := r.GetAllAvailable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(available, func(i, j int) bool {
+		ti, tj := available[i].CalculateTimeToLocation(location), available[j].CalculateTimeToLocation(location)
+		if ti != tj {
+			return ti < tj
+		}
+		idI, idJ := available[i].ID(), available[j].ID()
+		return bytes.Compare(idI[:], idJ[:]) < 0
+	})
+	return available[:min(limit, len(available))], nil
+}
+
+func (r *CourierRepository) save(ctx context.Context, courier *courier.Courier) error {
+	r.uow.Track(courier)
+	if r.uow.InTx() {
+		return nil
+	}
+
+	r.uow.Begin(ctx)
+	return r.uow.Commit(ctx)
+}
+
+// isAvailable matches the postgres repository: a courier with any order on board is busy
+func isAvailable(c *courier.Courier) bool {
+	for _, storagePlace := range c.StoragePlaces() {
+		if storagePlace.OrderID() != nil {
+			return false
+		}
+	}
+	return true
+}
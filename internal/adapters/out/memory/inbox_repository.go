@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+var _ ports.InboxRepository = &InboxRepository{}
+
+type InboxRepository struct {
+	messages map[string]ports.ProcessedMessage
+	baskets  map[uuid.UUID]string
+}
+
+func newInboxRepository() *InboxRepository {
+	return &InboxRepository{
+		messages: make(map[string]ports.ProcessedMessage),
+		baskets:  make(map[uuid.UUID]string),
+	}
+}
+
+func (r *InboxRepository) Add(_ context.Context, message ports.ProcessedMessage) error {
+	if _, ok := r.messages[message.MessageID]; ok {
+		return errs.NewConflictError("processed message", message.MessageID, "message was already processed")
+	}
+	if _, ok := r.baskets[message.BasketID]; ok {
+		return errs.NewConflictError("processed message", message.MessageID, "basket was already processed")
+	}
+
+	r.messages[message.MessageID] = message
+	r.baskets[message.BasketID] = message.MessageID
+	return nil
+}
+
+func (r *InboxRepository) Processed(_ context.Context, messageID string, basketID uuid.UUID) (bool, error) {
+	_, messageProcessed := r.messages[messageID]
+	_, basketProcessed := r.baskets[basketID]
+	return messageProcessed || basketProcessed, nil
+}
+
+func (r *InboxRepository) Get(_ context.Context, messageID string) (ports.ProcessedMessage, error) {
+	message, ok := r.messages[messageID]
+	if !ok {
+		return ports.ProcessedMessage{}, errs.NewNotFoundError("processed message", messageID)
+	}
+	return message, nil
+}
@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+var _ ports.OfferRepository = &OfferRepository{}
+
+type OfferRepository struct {
+	uow    *UnitOfWork
+	offers []*offer.Offer
+	byID   map[uuid.UUID]*offer.Offer
+}
+
+func newOfferRepository(uow *UnitOfWork) *OfferRepository {
+	return &OfferRepository{
+		uow:  uow,
+		byID: make(map[uuid.UUID]*offer.Offer),
+	}
+}
+
+func (r *OfferRepository) Add(ctx context.Context, offer *offer.Offer) error {
+	if offer == nil {
+		return errs.NewValueIsRequiredError("offer")
+	}
+	if _, ok := r.byID[offer.ID()]; ok {
+		return errs.NewConflictError("offer", offer.ID().String(), "offer already exists")
+	}
+
+	r.offers = append(r.offers, offer)
+	r.byID[offer.ID()] = offer
+
+	return r.save(ctx, offer)
+}
+
+func (r *OfferRepository) Update(ctx context.Context, offer *offer.Offer) error {
+	if offer == nil {
+		return errs.NewValueIsRequiredError("offer")
+	}
+	if _, ok := r.byID[offer.ID()]; !ok {
+		return errs.NewNotFoundError("offer", offer.ID().String())
+	}
+
+	return r.save(ctx, offer)
+}
+
+func (r *OfferRepository) GetPending(_ context.Context, orderID uuid.UUID) (*offer.Offer, error) {
+	for i := len(r.offers) - 1; i >= 0; i-- {
+		o := r.offers[i]
+		if o.OrderID() == orderID && o.Status() == offer.Pending {
+			return o, nil
+		}
+	}
+	return nil, errs.NewNotFoundError("pending offer", orderID.String())
+}
+
+func (r *OfferRepository) GetAllPending(_ context.Context) ([]*offer.Offer, error) {
+	var pending []*offer.Offer
+	for _, o := range r.offers {
+		if o.Status() == offer.Pending {
+			pending = append(pending, o)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].ExpiresAt().Before(pending[j].ExpiresAt())
+	})
+	return pending, nil
+}
+
+// GetAllByOrder returns the offers in the sequence they were added
+func (r *OfferRepository) GetAllByOrder(_ context.Context, orderID uuid.UUID) ([]*offer.Offer, error) {
+	var offers []*offer.Offer
+	for _, o := range r.offers {
+		if o.OrderID() == orderID {
+			offers = append(offers, o)
+		}
+	}
+	return offers, nil
+}
+
+func (r *OfferRepository) save(ctx context.Context, offer *offer.Offer) error {
+	r.uow.Track(offer)
+	if r.uow.InTx() {
+		return nil
+	}
+
+	r.uow.Begin(ctx)
+	return r.uow.Commit(ctx)
+}
@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+var _ ports.OrderRepository = &OrderRepository{}
+
+type OrderRepository struct {
+	uow    *UnitOfWork
+	orders []*order.Order
+	byID   map[uuid.UUID]*order.Order
+}
+
+func newOrderRepository(uow *UnitOfWork) *OrderRepository {
+	return &OrderRepository{
+		uow:  uow,
+		byID: make(map[uuid.UUID]*order.Order),
+	}
+}
+
+func (r *OrderRepository) Add(ctx context.Context, order *order.Order) error {
+	if order == nil {
+		return errs.NewValueIsRequiredError("order")
+	}
+	if _, ok := r.byID[order.ID()]; ok {
+		return errs.NewConflictError("order", order.ID().String(), "order already exists")
+	}
+
+	r.orders = append(r.orders, order)
+	r.byID[order.ID()] = order
+
+	return r.save(ctx, order)
+}
+
+func (r *OrderRepository) Update(ctx context.Context, order *order.Order) error {
+	if order == nil {
+		return errs.NewValueIsRequiredError("order")
+	}
+	if _, ok := r.byID[order.ID()]; !ok {
+		return errs.NewNotFoundError("order", order.ID().String())
+	}
+
+	return r.save(ctx, order)
+}
+
+func (r *OrderRepository) Get(_ context.Context, orderID uuid.UUID) (*order.Order, error) {
+	o, ok := r.byID[orderID]
+	if !ok {
+		return nil, errs.NewNotFoundError("order", orderID.String())
+	}
+	return o, nil
+}
+
+// GetFirstInStatusCreate returns the order to dispatch next
+func (r *OrderRepository) GetFirstInStatusCreate(_ context.Context) (*order.Order, error) {
+	var first *order.Order
+	for _, o := range r.getAllInStatus(order.Created) {
+		if first == nil || o.DispatchBefore(first) {
+			first = o
+		}
+	}
+	if first == nil {
+		return nil, errs.NewNotFoundError("order", "in created status")
+	}
+	return first, nil
+}
+
+func (r *OrderRepository) GetAllWithCourier(_ context.Context) ([]*order.Order, error) {
+	orders := r.getAllInStatus(order.WithCourierStatuses...)
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].DeliverBefore(orders[j])
+	})
+	return orders, nil
+}
+
+func (r *OrderRepository) GetAllInStatusCreate(_ context.Context) ([]*order.Order, error) {
+	return r.getAllInStatus(order.Created), nil
+}
+
+func (r *OrderRepository) getAllInStatus(statuses ...order.Status) []*order.Order {
+	var orders []*order.Order
+	for _, o := range r.orders {
+		if slices.Contains(statuses, o.Status()) {
+			orders = append(orders, o)
+		}
+	}
+	return orders
+}
+
+func (r *OrderRepository) save(ctx context.Context, order *order.Order) error {
+	r.uow.Track(order)
+	if r.uow.InTx() {
+		return nil
+	}
+
+	r.uow.Begin(ctx)
+	return r.uow.Commit(ctx)
+}
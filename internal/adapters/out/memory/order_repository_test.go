@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var start = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestOrderRepository_GetFirstInStatusCreate_ExpressOvertakesStandardBacklog(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestOrderRepository(t)
+	for i := 0; i < 50; i++ {
+		addOrder(t, repo, order.Standard, start.Add(time.Duration(i)*10*time.Second))
+	}
+	express := addOrder(t, repo, order.Express, start.Add(order.AgingThreshold-time.Second))
+
+	first, err := repo.GetFirstInStatusCreate(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, express.ID(), first.ID())
+}
+
+func TestOrderRepository_GetFirstInStatusCreate_ExpressNeverStarves(t *testing.T) {
+	repo := newTestOrderRepository(t)
+	courierID := uuid.New()
+	var express []*order.Order
+
+	// three standard orders arrive for every one dispatched, an express order every five minutes
+	for minute := 0; minute < 120; minute++ {
+		now := start.Add(time.Duration(minute) * time.Minute)
+		for i := 0; i < 3; i++ {
+			addOrder(t, repo, order.Standard, now.Add(time.Duration(i)*time.Second))
+		}
+		if minute%5 == 0 {
+			express = append(express, addOrder(t, repo, order.Express, now))
+		}
+
+		dispatched := dispatchNext(t, repo, courierID)
+
+		// nothing created after a waiting express order gets ahead of it, so however many
+		// orders keep arriving, the express one is dispatched after a fixed number of them
+		for _, e := range express {
+			if e.Status() == order.Created {
+				assert.True(t, dispatched.CreatedAt().Before(e.CreatedAt()),
+					"order created at %s overtook express order created at %s", dispatched.CreatedAt(), e.CreatedAt())
+			}
+		}
+	}
+}
+
+func TestOrderRepository_GetFirstInStatusCreate_StandardAgingBoost(t *testing.T) {
+	repo := newTestOrderRepository(t)
+	courierID := uuid.New()
+	standard := addOrder(t, repo, order.Standard, start)
+
+	// a new express order every minute would keep a standard order waiting forever without aging
+	var dispatchedAt time.Time
+	for minute := 1; minute <= 30 && dispatchedAt.IsZero(); minute++ {
+		now := start.Add(time.Duration(minute) * time.Minute)
+		addOrder(t, repo, order.Express, now)
+		if dispatchNext(t, repo, courierID).ID() == standard.ID() {
+			dispatchedAt = now
+		}
+	}
+
+	assert.False(t, dispatchedAt.IsZero(), "standard order was never dispatched")
+	assert.LessOrEqual(t, dispatchedAt.Sub(start), order.AgingThreshold+time.Minute)
+}
+
+func TestOrderRepository_GetAllWithCourier(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestOrderRepository(t)
+	courierID := uuid.New()
+	standard := addOrder(t, repo, order.Standard, start)
+	newerStandard := addOrder(t, repo, order.Standard, start.Add(time.Minute))
+	express := addOrder(t, repo, order.Express, start.Add(2*time.Minute))
+	for _, o := range []*order.Order{standard, newerStandard, express} {
+		require.NoError(t, o.Assign(&courierID, start))
+	}
+	require.NoError(t, newerStandard.Accept(courierID))
+	require.NoError(t, express.Accept(courierID))
+	require.NoError(t, express.PickUp(courierID))
+	addOrder(t, repo, order.Standard, start)
+
+	assigned, err := repo.GetAllWithCourier(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*order.Order{express, standard, newerStandard}, assigned)
+}
+
+func newTestOrderRepository(t *testing.T) *OrderRepository {
+	uow, err := NewUnitOfWork(ddd.NewMediatr())
+	require.NoError(t, err)
+	return uow.orderRepository
+}
+
+func addOrder(t *testing.T, repo *OrderRepository, priority order.Priority, createdAt time.Time) *order.Order {
+	location, err := kernel.NewLocation(1, 1)
+	require.NoError(t, err)
+	o, err := order.NewOrder(uuid.New(), location, 1, priority, createdAt)
+	require.NoError(t, err)
+	require.NoError(t, repo.Add(context.Background(), o))
+	return o
+}
+
+func dispatchNext(t *testing.T, repo *OrderRepository, courierID uuid.UUID) *order.Order {
+	ctx := context.Background()
+	o, err := repo.GetFirstInStatusCreate(ctx)
+	require.NoError(t, err)
+	require.NoError(t, o.Assign(&courierID, start))
+	require.NoError(t, repo.Update(ctx, o))
+	return o
+}
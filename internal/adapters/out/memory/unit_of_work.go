@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/labstack/gommon/log"
+	"gorm.io/gorm"
+)
+
+var _ ports.UnitOfWork = &UnitOfWork{}
+
+// UnitOfWork keeps the aggregates in memory. It is meant for simulations and tests:
+// changes are applied right away, so there is nothing to roll back on failure.
+type UnitOfWork struct {
+	inTx              bool
+	trackedAggregates []ddd.AggregateRoot
+	courierRepository *CourierRepository
+	orderRepository   *OrderRepository
+	offerRepository   *OfferRepository
+	zoneRepository    *ZoneRepository
+	inboxRepository   *InboxRepository
+	mediatr           ddd.Mediatr
+}
+
+func NewUnitOfWork(mediatr ddd.Mediatr) (*UnitOfWork, error) {
+	if mediatr == nil {
+		return nil, errs.NewValueIsRequiredError("mediatr")
+	}
+
+	uow := &UnitOfWork{
+		mediatr: mediatr,
+	}
+	uow.courierRepository = newCourierRepository(uow)
+	uow.orderRepository = newOrderRepository(uow)
+	uow.offerRepository = newOfferRepository(uow)
+	uow.zoneRepository = newZoneRepository(uow)
+	uow.inboxRepository = newInboxRepository()
+
+	return uow, nil
+}
+
+// Tx has no database behind it
+func (uow *UnitOfWork) Tx() *gorm.DB {
+	return nil
+}
+
+// Db has no database behind it
+func (uow *UnitOfWork) Db() *gorm.DB {
+	return nil
+}
+
+func (uow *UnitOfWork) InTx() bool {
+	return uow.inTx
+}
+
+func (uow *UnitOfWork) Track(agg ddd.AggregateRoot) {
+	uow.trackedAggregates = append(uow.trackedAggregates, agg)
+}
+
+func (uow *UnitOfWork) Begin(_ context.Context) {
+	uow.inTx = true
+}
+
+func (uow *UnitOfWork) Commit(ctx context.Context) error {
+	if !uow.inTx {
+		return errs.NewBusinessError("commit transaction", "cannot commit: transaction is nil")
+	}
+
+	defer uow.clearTx()
+
+	// a BeforeCommit handler can only report the failure, the changes are applied already
+	for _, aggregate := range uow.trackedAggregates {
+		for _, event := range aggregate.GetDomainEvents() {
+			if err := uow.mediatr.PublishBeforeCommit(ctx, event); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, aggregate := range uow.trackedAggregates {
+		for _, event := range aggregate.GetDomainEvents() {
+			if err := uow.mediatr.Publish(ctx, event); err != nil {
+				log.Error(err)
+			}
+		}
+		aggregate.ClearDomainEvents()
+	}
+
+	return nil
+}
+
+// Rollback can't undo the changes, it only drops the events raised since Begin
+func (uow *UnitOfWork) Rollback(_ context.Context) {
+	for _, aggregate := range uow.trackedAggregates {
+		aggregate.ClearDomainEvents()
+	}
+	uow.clearTx()
+}
+
+func (uow *UnitOfWork) CourierRepository() ports.CourierRepository {
+	return uow.courierRepository
+}
+
+func (uow *UnitOfWork) OrderRepository() ports.OrderRepository {
+	return uow.orderRepository
+}
+
+func (uow *UnitOfWork) OfferRepository() ports.OfferRepository {
+	return uow.offerRepository
+}
+
+func (uow *UnitOfWork) ZoneRepository() ports.ZoneRepository {
+	return uow.zoneRepository
+}
+
+func (uow *UnitOfWork) InboxRepository() ports.InboxRepository {
+	return uow.inboxRepository
+}
+
+func (uow *UnitOfWork) clearTx() {
+	uow.inTx = false
+	uow.trackedAggregates = nil
+}
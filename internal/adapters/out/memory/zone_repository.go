@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/zone"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+var _ ports.ZoneRepository = &ZoneRepository{}
+
+type ZoneRepository struct {
+	uow   *UnitOfWork
+	zones []*zone.Zone
+	byID  map[uuid.UUID]*zone.Zone
+}
+
+func newZoneRepository(uow *UnitOfWork) *ZoneRepository {
+	return &ZoneRepository{
+		uow:  uow,
+		byID: make(map[uuid.UUID]*zone.Zone),
+	}
+}
+
+func (r *ZoneRepository) Add(ctx context.Context, zone *zone.Zone) error {
+	if zone == nil {
+		return errs.NewValueIsRequiredError("zone")
+	}
+	if _, ok := r.byID[zone.ID()]; ok {
+		return errs.NewConflictError("zone", zone.ID().String(), "zone already exists")
+	}
+
+	r.zones = append(r.zones, zone)
+	r.byID[zone.ID()] = zone
+
+	return r.save(ctx, zone)
+}
+
+func (r *ZoneRepository) Get(_ context.Context, zoneID uuid.UUID) (*zone.Zone, error) {
+	z, ok := r.byID[zoneID]
+	if !ok {
+		return nil, errs.NewNotFoundError("zone", zoneID.String())
+	}
+	return z, nil
+}
+
+// GetAll returns the zones in the sequence they were added
+func (r *ZoneRepository) GetAll(_ context.Context) ([]*zone.Zone, error) {
+	zones := make([]*zone.Zone, len(r.zones))
+	copy(zones, r.zones)
+	return zones, nil
+}
+
+func (r *ZoneRepository) save(ctx context.Context, zone *zone.Zone) error {
+	r.uow.Track(zone)
+	if r.uow.InTx() {
+		return nil
+	}
+
+	r.uow.Begin(ctx)
+	return r.uow.Commit(ctx)
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hash/fnv"
+	"sync"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"gorm.io/gorm"
+)
+
+var _ ports.JobLocker = &AdvisoryJobLocker{}
+
+// AdvisoryJobLocker elects a leader per job with session level pg_try_advisory_lock.
+// The lock lives as long as the connection it was taken on, so that connection is kept
+// out of the pool while the replica is the leader and a crashed replica releases it automatically.
+type AdvisoryJobLocker struct {
+	db    *sql.DB
+	mu    sync.Mutex
+	conns map[string]*sql.Conn
+}
+
+func NewAdvisoryJobLocker(db *gorm.DB) (*AdvisoryJobLocker, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("database")
+	}
+	sqlDb, err := db.DB()
+	if err != nil {
+		return nil, errs.NewDatabaseError("get", "connection pool", err)
+	}
+	return &AdvisoryJobLocker{
+		db:    sqlDb,
+		conns: make(map[string]*sql.Conn),
+	}, nil
+}
+
+func (l *AdvisoryJobLocker) TryLock(ctx context.Context, job string) (bool, error) {
+	if job == "" {
+		return false, errs.NewValueIsRequiredError("job")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if conn, ok := l.conns[job]; ok {
+		// the lock is still ours while the session is alive
+		if err := conn.PingContext(ctx); err == nil {
+			return true, nil
+		}
+		_ = conn.Close()
+		delete(l.conns, job)
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, errs.NewDatabaseError("get", "connection", err)
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", lockKey(job)).Scan(&locked); err != nil {
+		_ = conn.Close()
+		return false, errs.NewDatabaseError("lock", job, err)
+	}
+	if !locked {
+		_ = conn.Close()
+		return false, nil
+	}
+
+	l.conns[job] = conn
+	return true, nil
+}
+
+func (l *AdvisoryJobLocker) Unlock(ctx context.Context, job string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.unlock(ctx, job)
+}
+
+// Close gives up leadership of every job, so another replica can take over right away
+func (l *AdvisoryJobLocker) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var problems []error
+	for job := range l.conns {
+		problems = append(problems, l.unlock(context.Background(), job))
+	}
+	return errors.Join(problems...)
+}
+
+func (l *AdvisoryJobLocker) unlock(ctx context.Context, job string) error {
+	conn, ok := l.conns[job]
+	if !ok {
+		return nil
+	}
+	delete(l.conns, job)
+
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey(job))
+	// closing the connection releases the lock even if the unlock failed
+	if closeErr := conn.Close(); err == nil && closeErr != nil && !errors.Is(closeErr, sql.ErrConnDone) {
+		err = closeErr
+	}
+	if err != nil {
+		return errs.NewDatabaseError("unlock", job, err)
+	}
+	return nil
+}
+
+func lockKey(job string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte("delivery.jobs." + job))
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,156 @@
+package postgres
+
+import (
+	"context"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/adapters/in/jobs"
+	embeddedpostgres "github.com/fergusstrange/embedded-postgres"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testDbPort = 54329
+
+type countingJob struct {
+	runs        atomic.Int64
+	lastSuccess atomic.Int64
+}
+
+func (j *countingJob) Run() {
+	j.runs.Add(1)
+	j.lastSuccess.Store(time.Now().UnixNano())
+}
+
+func (j *countingJob) LastSuccess() time.Time {
+	if nanos := j.lastSuccess.Load(); nanos != 0 {
+		return time.Unix(0, nanos)
+	}
+	return time.Time{}
+}
+
+// replica is what a single service instance needs to run the jobs: own pool, own locker, own scheduler
+type replica struct {
+	locker *AdvisoryJobLocker
+	job    *countingJob
+	leader *jobs.ExclusiveJob
+}
+
+func newReplica(t *testing.T, dsn string) replica {
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = closeGormDb(db)
+	})
+
+	locker, err := NewAdvisoryJobLocker(db)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = locker.Close()
+	})
+
+	job := &countingJob{}
+	exclusiveJob, err := jobs.NewExclusiveJob("move_courier", locker, job)
+	assert.NoError(t, err)
+
+	return replica{locker: locker, job: job, leader: exclusiveJob}
+}
+
+func startTestDb(t *testing.T) string {
+	if testing.Short() {
+		t.Skip("starts an embedded postgres")
+	}
+
+	config := embeddedpostgres.DefaultConfig().
+		Port(testDbPort).
+		RuntimePath(t.TempDir()).
+		Logger(io.Discard)
+	db := embeddedpostgres.NewDatabase(config)
+	if err := db.Start(); err != nil {
+		t.Skipf("embedded postgres is not available: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = db.Stop()
+	})
+
+	return config.GetConnectionURL() + "?sslmode=disable"
+}
+
+func TestAdvisoryJobLocker_RunsJobOnSingleReplica(t *testing.T) {
+	dsn := startTestDb(t)
+	first := newReplica(t, dsn)
+	second := newReplica(t, dsn)
+
+	schedulers := []*cron.Cron{cron.New(cron.WithSeconds()), cron.New(cron.WithSeconds())}
+	for i, r := range []replica{first, second} {
+		_, err := schedulers[i].AddJob("* * * * * *", r.leader)
+		assert.NoError(t, err)
+		schedulers[i].Start()
+	}
+	time.Sleep(3500 * time.Millisecond)
+	for _, scheduler := range schedulers {
+		<-scheduler.Stop().Done()
+	}
+
+	leader, standby := first, second
+	if second.job.runs.Load() > 0 {
+		leader, standby = second, first
+	}
+	assert.GreaterOrEqual(t, leader.job.runs.Load(), int64(3))
+	assert.Zero(t, standby.job.runs.Load())
+	lastStandby, onStandby := standby.leader.Standby()
+	assert.True(t, onStandby)
+	assert.False(t, lastStandby.IsZero())
+	assert.True(t, standby.leader.LastSuccess().IsZero(), "the standby never runs the job")
+	checker, err := jobs.NewLastRunChecker(standby.leader, time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, checker.Check(context.Background()), "standby replica must stay healthy")
+
+	// once the leader is gone the standby takes over
+	assert.NoError(t, leader.locker.Close())
+	standby.leader.Run()
+	assert.Equal(t, int64(1), standby.job.runs.Load())
+}
+
+func TestAdvisoryJobLocker_TryLock(t *testing.T) {
+	dsn := startTestDb(t)
+	first := newReplica(t, dsn)
+	second := newReplica(t, dsn)
+	ctx := context.Background()
+
+	locked, err := first.locker.TryLock(ctx, "assign_order")
+	assert.NoError(t, err)
+	assert.True(t, locked)
+
+	locked, err = first.locker.TryLock(ctx, "assign_order")
+	assert.NoError(t, err)
+	assert.True(t, locked, "leader keeps the lock between runs")
+
+	locked, err = second.locker.TryLock(ctx, "assign_order")
+	assert.NoError(t, err)
+	assert.False(t, locked)
+
+	locked, err = second.locker.TryLock(ctx, "move_courier")
+	assert.NoError(t, err)
+	assert.True(t, locked, "jobs are locked independently")
+
+	assert.NoError(t, first.locker.Unlock(ctx, "assign_order"))
+
+	locked, err = second.locker.TryLock(ctx, "assign_order")
+	assert.NoError(t, err)
+	assert.True(t, locked)
+}
+
+func closeGormDb(db *gorm.DB) error {
+	sqlDb, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDb.Close()
+}
@@ -0,0 +1,27 @@
+package auditrepo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// EntryDTO is a row of the append-only audit log, the rows are never updated or deleted
+type EntryDTO struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	AggregateType string         `gorm:"type:varchar(20);index:idx_audit_log_aggregate,priority:1"`
+	AggregateID   uuid.UUID      `gorm:"type:uuid;index:idx_audit_log_aggregate,priority:2"`
+	Version       int            `gorm:"index:idx_audit_log_aggregate,priority:3"`
+	Events        pq.StringArray `gorm:"type:text[]"`
+	Command       string         `gorm:"type:varchar(100)"`
+	Actor         string
+	CorrelationID string    `gorm:"index"`
+	Before        *string   `gorm:"type:jsonb"`
+	After         string    `gorm:"type:jsonb;not null"`
+	RecordedAt    time.Time `gorm:"index"`
+}
+
+func (EntryDTO) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,125 @@
+package auditrepo
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/delivery/internal/pkg/audit"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	OrderAggregate   = "order"
+	CourierAggregate = "courier"
+	OfferAggregate   = "offer"
+	ZoneAggregate    = "zone"
+)
+
+// Change is an aggregate a repository is about to save, Before is nil for a new aggregate
+type Change struct {
+	AggregateType string
+	AggregateID   uuid.UUID
+	Version       int
+	Events        []ddd.DomainEvent
+	// StoredVersion is the version in the database, the events up to it are recorded already
+	StoredVersion int
+	Before        any
+	After         any
+	// Volatile fields change on every tick, they do not make an entry on their own
+	Volatile []string
+}
+
+// Record appends the change to the audit log in the transaction the aggregate is saved in,
+// a change without new events and without a difference in the snapshots is skipped
+func Record(ctx context.Context, tx *gorm.DB, change Change) error {
+	entry, ok, err := newEntry(audit.FromContext(ctx), change)
+	if err != nil {
+		return errs.NewDatabaseError("audit", change.AggregateType, err)
+	}
+	if !ok {
+		return nil
+	}
+
+	if err := tx.WithContext(ctx).Create(&entry).Error; err != nil {
+		return errs.NewDatabaseError("audit", change.AggregateType, err)
+	}
+	return nil
+}
+
+func newEntry(metadata audit.Metadata, change Change) (EntryDTO, bool, error) {
+	after, err := snapshot(change.After)
+	if err != nil {
+		return EntryDTO{}, false, err
+	}
+	var before *string
+	if change.Before != nil {
+		value, err := snapshot(change.Before)
+		if err != nil {
+			return EntryDTO{}, false, err
+		}
+		before = &value
+	}
+
+	events := make([]string, 0, len(change.Events))
+	recordedAt := time.Now().UTC()
+	for _, event := range change.Events {
+		if event.GetVersion() <= change.StoredVersion {
+			continue
+		}
+		events = append(events, event.GetName())
+		recordedAt = event.GetOccurredAt()
+	}
+
+	if len(events) == 0 {
+		changed, err := differ(before, after, change.Volatile)
+		if err != nil || !changed {
+			return EntryDTO{}, false, err
+		}
+	}
+
+	return EntryDTO{
+		ID:            uuid.New(),
+		AggregateType: change.AggregateType,
+		AggregateID:   change.AggregateID,
+		Version:       change.Version,
+		Events:        events,
+		Command:       metadata.Command,
+		Actor:         metadata.Actor,
+		CorrelationID: metadata.CorrelationID,
+		Before:        before,
+		After:         after,
+		RecordedAt:    recordedAt,
+	}, true, nil
+}
+
+func snapshot(dto any) (string, error) {
+	data, err := json.Marshal(dto)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func differ(before *string, after string, volatile []string) (bool, error) {
+	if before == nil {
+		return true, nil
+	}
+
+	var beforeFields, afterFields map[string]any
+	if err := json.Unmarshal([]byte(*before), &beforeFields); err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal([]byte(after), &afterFields); err != nil {
+		return false, err
+	}
+	for _, field := range volatile {
+		delete(beforeFields, field)
+		delete(afterFields, field)
+	}
+	return !reflect.DeepEqual(beforeFields, afterFields), nil
+}
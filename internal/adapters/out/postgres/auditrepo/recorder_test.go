@@ -0,0 +1,118 @@
+package auditrepo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/audit"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type snapshotDTO struct {
+	Status  string
+	MovedAt time.Time
+	Version int
+}
+
+func TestNewEntry(t *testing.T) {
+	aggregateID := uuid.New()
+	occurredAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	metadata := audit.Metadata{Actor: "job:move_courier", Command: "MoveCouriers", CorrelationID: "run-1"}
+	event := func(name string, version int) ddd.DomainEvent {
+		return &ddd.BaseEvent{ID: uuid.New(), Name: name, AggregateID: aggregateID, Version: version, OccurredAt: occurredAt}
+	}
+
+	tests := map[string]struct {
+		change     Change
+		wantOk     bool
+		wantEvents []string
+		wantBefore *string
+		wantAfter  string
+	}{
+		"new aggregate": {
+			change: Change{
+				Version: 1,
+				Events:  []ddd.DomainEvent{event("OrderCreated", 1)},
+				After:   snapshotDTO{Status: "created", Version: 1},
+			},
+			wantOk:     true,
+			wantEvents: []string{"OrderCreated"},
+			wantAfter:  `{"Status":"created","MovedAt":"0001-01-01T00:00:00Z","Version":1}`,
+		},
+		"only the events after the stored version": {
+			change: Change{
+				Version:       3,
+				Events:        []ddd.DomainEvent{event("OrderAssigned", 2), event("OrderAccepted", 3)},
+				StoredVersion: 2,
+				Before:        snapshotDTO{Status: "assigned", Version: 2},
+				After:         snapshotDTO{Status: "accepted", Version: 3},
+			},
+			wantOk:     true,
+			wantEvents: []string{"OrderAccepted"},
+			wantBefore: ptr(`{"Status":"assigned","MovedAt":"0001-01-01T00:00:00Z","Version":2}`),
+			wantAfter:  `{"Status":"accepted","MovedAt":"0001-01-01T00:00:00Z","Version":3}`,
+		},
+		"a change without events": {
+			change: Change{
+				Version: 2,
+				Before:  snapshotDTO{Status: "assigned", Version: 2},
+				After:   snapshotDTO{Status: "created", Version: 2},
+			},
+			wantOk:     true,
+			wantEvents: []string{},
+			wantBefore: ptr(`{"Status":"assigned","MovedAt":"0001-01-01T00:00:00Z","Version":2}`),
+			wantAfter:  `{"Status":"created","MovedAt":"0001-01-01T00:00:00Z","Version":2}`,
+		},
+		"nothing new": {
+			change: Change{
+				Version:       2,
+				Events:        []ddd.DomainEvent{event("OrderAssigned", 2)},
+				StoredVersion: 2,
+				Before:        snapshotDTO{Status: "assigned", Version: 2},
+				After:         snapshotDTO{Status: "assigned", Version: 2},
+			},
+		},
+		"only volatile fields changed": {
+			change: Change{
+				Version:  2,
+				Before:   snapshotDTO{Status: "assigned", Version: 2},
+				After:    snapshotDTO{Status: "assigned", MovedAt: occurredAt, Version: 2},
+				Volatile: []string{"MovedAt"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test.change.AggregateType = OrderAggregate
+			test.change.AggregateID = aggregateID
+
+			entry, ok, err := newEntry(metadata, test.change)
+
+			require.NoError(t, err)
+			assert.Equal(t, test.wantOk, ok)
+			if !test.wantOk {
+				return
+			}
+			assert.Equal(t, OrderAggregate, entry.AggregateType)
+			assert.Equal(t, aggregateID, entry.AggregateID)
+			assert.Equal(t, test.change.Version, entry.Version)
+			assert.Equal(t, test.wantEvents, []string(entry.Events))
+			assert.Equal(t, metadata.Command, entry.Command)
+			assert.Equal(t, metadata.Actor, entry.Actor)
+			assert.Equal(t, metadata.CorrelationID, entry.CorrelationID)
+			assert.Equal(t, test.wantBefore, entry.Before)
+			assert.JSONEq(t, test.wantAfter, entry.After)
+			if len(test.wantEvents) > 0 {
+				assert.Equal(t, occurredAt, entry.RecordedAt)
+			}
+		})
+	}
+}
+
+func ptr(value string) *string {
+	return &value
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
+	"github.com/delivery/internal/adapters/out/postgres/courierrepo"
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestCourierRepository_GetNearestAvailable(t *testing.T) {
+	dsn := startTestDb(t)
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = closeGormDb(db)
+	})
+	require.NoError(t, db.AutoMigrate(&courierrepo.CourierDto{}, &courierrepo.StoragePlaceDto{}, &auditrepo.EntryDTO{}))
+
+	ctx := context.Background()
+	uow, err := NewUnitOfWork(db, ddd.NewMediatr())
+	require.NoError(t, err)
+	addCourier := func(x, y, speed int) *courier.Courier {
+		location, err := kernel.NewLocation(x, y)
+		require.NoError(t, err)
+		c, err := courier.NewCourier("courier", speed, location)
+		require.NoError(t, err)
+		require.NoError(t, c.AddStoragePlace("bag", 10))
+		require.NoError(t, uow.CourierRepository().Add(ctx, c))
+		return c
+	}
+	busy := addCourier(1, 1, 1)
+	slow := addCourier(2, 1, 1)
+	fast := addCourier(4, 1, 4)
+	addCourier(10, 10, 1)
+	held, err := order.NewOrder(uuid.New(), busy.Location(), 1, order.Standard, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, busy.TakeOrder(held))
+	require.NoError(t, uow.CourierRepository().Update(ctx, busy))
+	target, err := kernel.NewLocation(1, 1)
+	require.NoError(t, err)
+
+	t.Run("ranks the free couriers by the time they take to the location", func(t *testing.T) {
+		nearest, err := uow.CourierRepository().GetNearestAvailable(ctx, target, 2)
+
+		require.NoError(t, err)
+		require.Len(t, nearest, 2)
+		assert.Equal(t, fast.ID(), nearest[0].ID())
+		assert.Equal(t, slow.ID(), nearest[1].ID())
+		assert.Len(t, nearest[0].StoragePlaces(), 1)
+	})
+
+	t.Run("loads only the free couriers among the ids", func(t *testing.T) {
+		available, err := uow.CourierRepository().GetAvailableByIDs(ctx, []uuid.UUID{busy.ID(), slow.ID()})
+
+		require.NoError(t, err)
+		require.Len(t, available, 1)
+		assert.Equal(t, slow.ID(), available[0].ID())
+	})
+}
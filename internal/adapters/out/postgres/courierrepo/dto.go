@@ -1,15 +1,24 @@
 package courierrepo
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
 type CourierDto struct {
-	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Name          string
-	Speed         int
-	Location      LocationDTO        `gorm:"embedded;embeddedPrefix:location_"`
-	StoragePlaces []*StoragePlaceDto `gorm:"foreignKey:CourierID;constraint:OnDelete:CASCADE;"`
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name            string
+	Speed           int
+	Location        LocationDTO        `gorm:"embedded;embeddedPrefix:location_"`
+	StoragePlaces   []*StoragePlaceDto `gorm:"foreignKey:CourierID;constraint:OnDelete:CASCADE;"`
+	MovedAt         *time.Time
+	MoveProgress    float64
+	ProgressedAt    *time.Time
+	DeclinedOffers  int        `gorm:"not null;default:0"`
+	HomeZoneID      *uuid.UUID `gorm:"type:uuid;index"`
+	StallReportedAt *time.Time
+	Version         int `gorm:"not null;default:0"`
 }
 
 type StoragePlaceDto struct {
@@ -1,34 +1,65 @@
 package courierrepo
 
 import (
+	"time"
+
 	"github.com/delivery/internal/core/domain/model/courier"
 	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/google/uuid"
 )
 
 func DomainToDto(courier *courier.Courier) CourierDto {
 	return CourierDto{
-		ID:            courier.ID(),
-		Name:          courier.Name(),
-		Speed:         courier.Speed(),
-		Location:      LocationDTO{X: courier.Location().X(), Y: courier.Location().Y()},
-		StoragePlaces: mapStoragePlaces(courier),
+		ID:              courier.ID(),
+		Name:            courier.Name(),
+		Speed:           courier.Speed(),
+		Location:        LocationDTO{X: courier.Location().X(), Y: courier.Location().Y()},
+		StoragePlaces:   mapStoragePlaces(courier),
+		MovedAt:         optionalTime(courier.MovedAt()),
+		MoveProgress:    courier.MoveProgress(),
+		ProgressedAt:    optionalTime(courier.ProgressedAt()),
+		DeclinedOffers:  courier.DeclinedOffers(),
+		HomeZoneID:      courier.HomeZoneID(),
+		StallReportedAt: optionalTime(courier.StallReportedAt()),
+		Version:         courier.Version(),
 	}
 }
 
 func DtoToDomain(dto CourierDto) *courier.Courier {
 	var storagePlaces []*courier.StoragePlace
 	for _, dtoStoragePlace := range dto.StoragePlaces {
+		orderID := dtoStoragePlace.OrderID
+		if orderID != nil && *orderID == uuid.Nil {
+			// rows cleared before storage places were reset to NULL
+			orderID = nil
+		}
 		storagePlace := courier.RestoreStoragePlace(
 			dtoStoragePlace.ID,
 			dtoStoragePlace.Name,
 			dtoStoragePlace.TotalVolume,
-			dtoStoragePlace.OrderID,
+			orderID,
 		)
 
 		storagePlaces = append(storagePlaces, storagePlace)
 	}
 	location, _ := kernel.NewLocation(dto.Location.X, dto.Location.Y)
-	return courier.RestoreCourier(dto.ID, dto.Name, dto.Speed, location, storagePlaces)
+	return courier.RestoreCourier(dto.ID, dto.Name, dto.Speed, location, storagePlaces,
+		timeOrZero(dto.MovedAt), dto.MoveProgress, timeOrZero(dto.ProgressedAt), dto.DeclinedOffers, dto.HomeZoneID,
+		timeOrZero(dto.StallReportedAt), dto.Version)
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func timeOrZero(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
 }
 
 func mapStoragePlaces(courier *courier.Courier) []*StoragePlaceDto {
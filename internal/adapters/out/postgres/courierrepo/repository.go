@@ -3,7 +3,9 @@ package courierrepo
 import (
 	"context"
 
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
 	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/errs"
 	"github.com/google/uuid"
@@ -43,6 +45,16 @@ func (r *Repository) Add(ctx context.Context, courier *courier.Courier) error {
 		return errs.NewDatabaseError("create", "courier", err)
 	}
 
+	if err := auditrepo.Record(ctx, tx, auditrepo.Change{
+		AggregateType: auditrepo.CourierAggregate,
+		AggregateID:   courier.ID(),
+		Version:       courier.Version(),
+		Events:        courier.GetDomainEvents(),
+		After:         dto,
+	}); err != nil {
+		return err
+	}
+
 	// if inside other tx we not fix this one
 	if !isInTx {
 		if err := r.uow.Commit(ctx); err != nil {
@@ -66,10 +78,32 @@ func (r *Repository) Update(ctx context.Context, courier *courier.Courier) error
 	}
 	tx := r.uow.Tx()
 
+	before := CourierDto{}
+	result := tx.WithContext(ctx).Preload(clause.Associations).Find(&before, courier.ID())
+	if result.Error != nil {
+		return errs.NewDatabaseError("get", "courier", result.Error)
+	}
+
 	if err := tx.WithContext(ctx).Session(&gorm.Session{FullSaveAssociations: true}).Save(&dto).Error; err != nil {
 		return errs.NewDatabaseError("update", "courier", err)
 	}
 
+	change := auditrepo.Change{
+		AggregateType: auditrepo.CourierAggregate,
+		AggregateID:   courier.ID(),
+		Version:       courier.Version(),
+		Events:        courier.GetDomainEvents(),
+		After:         dto,
+		Volatile:      []string{"MovedAt", "MoveProgress", "ProgressedAt"},
+	}
+	if result.RowsAffected > 0 {
+		change.StoredVersion = before.Version
+		change.Before = before
+	}
+	if err := auditrepo.Record(ctx, tx, change); err != nil {
+		return err
+	}
+
 	// if inside other tx we not fix this one
 	if !isInTx {
 		if err := r.uow.Commit(ctx); err != nil {
@@ -125,12 +159,7 @@ func (r *Repository) GetAllAvailable(ctx context.Context) ([]*courier.Courier, e
 
 	tx := r.getTxOrDb()
 	result := tx.WithContext(ctx).
-		Joins(`
-        LEFT JOIN storage_places sp ON 
-            couriers.id = sp.courier_id AND 
-            sp.order_id IS NOT NULL
-    `).
-		Where("sp.id IS NULL").
+		Scopes(available).
 		Preload(clause.Associations).
 		Find(&dtos)
 
@@ -138,12 +167,70 @@ func (r *Repository) GetAllAvailable(ctx context.Context) ([]*courier.Courier, e
 		return nil, errs.NewDatabaseError("get", "available couriers", result.Error)
 	}
 
+	return dtosToDomain(dtos), nil
+}
+
+func (r *Repository) GetAvailableByIDs(ctx context.Context, courierIDs []uuid.UUID) ([]*courier.Courier, error) {
+	if len(courierIDs) == 0 {
+		return nil, nil
+	}
+	var dtos []CourierDto
+
+	tx := r.getTxOrDb()
+	result := tx.WithContext(ctx).
+		Scopes(available).
+		Where("couriers.id IN ?", courierIDs).
+		Preload(clause.Associations).
+		Find(&dtos)
+
+	if result.Error != nil {
+		return nil, errs.NewDatabaseError("get", "available couriers", result.Error)
+	}
+
+	return dtosToDomain(dtos), nil
+}
+
+// GetNearestAvailable ranks the free couriers by the time they take to the location in the query itself,
+// so only the storage places of the couriers returned are loaded
+func (r *Repository) GetNearestAvailable(ctx context.Context, location kernel.Location,
+	limit int) ([]*courier.Courier, error) {
+	var dtos []CourierDto
+
+	tx := r.getTxOrDb()
+	result := tx.WithContext(ctx).
+		Scopes(available).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "(abs(couriers.location_x - ?) + abs(couriers.location_y - ?))::float / couriers.speed, couriers.id",
+			Vars:               []any{location.X(), location.Y()},
+			WithoutParentheses: true,
+		}}).
+		Limit(limit).
+		Preload(clause.Associations).
+		Find(&dtos)
+
+	if result.Error != nil {
+		return nil, errs.NewDatabaseError("get", "nearest couriers", result.Error)
+	}
+
+	return dtosToDomain(dtos), nil
+}
+
+// available keeps the couriers with no order in any storage place
+func available(db *gorm.DB) *gorm.DB {
+	return db.Joins(`
+        LEFT JOIN storage_places sp ON 
+            couriers.id = sp.courier_id AND 
+            sp.order_id IS NOT NULL
+    `).
+		Where("sp.id IS NULL")
+}
+
+func dtosToDomain(dtos []CourierDto) []*courier.Courier {
 	couriers := make([]*courier.Courier, len(dtos))
 	for i, dto := range dtos {
 		couriers[i] = DtoToDomain(dto)
 	}
-
-	return couriers, nil
+	return couriers
 }
 
 func (r *Repository) getTxOrDb() *gorm.DB {
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/health"
+	"gorm.io/gorm"
+)
+
+var _ health.Checker = &DbChecker{}
+
+type DbChecker struct {
+	db *gorm.DB
+}
+
+func NewDbChecker(db *gorm.DB) (*DbChecker, error) {
+	if db == nil {
+		return nil, errs.NewValueIsRequiredError("database")
+	}
+	return &DbChecker{
+		db: db,
+	}, nil
+}
+
+func (c *DbChecker) Check(ctx context.Context) error {
+	sqlDb, err := c.db.DB()
+	if err != nil {
+		return errs.NewDatabaseError("get", "connection pool", err)
+	}
+
+	if err := sqlDb.PingContext(ctx); err != nil {
+		return errs.NewDatabaseError("ping", "database", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
+	"github.com/delivery/internal/adapters/out/postgres/ordereventrepo"
+	"github.com/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestEventSourcedOrders(t *testing.T) {
+	dsn := startTestDb(t)
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = closeGormDb(db)
+	})
+	require.NoError(t, db.AutoMigrate(&orderrepo.OrderDTO{}, &auditrepo.EntryDTO{},
+		&ordereventrepo.EventDTO{}, &ordereventrepo.SnapshotDTO{}))
+
+	ctx := context.Background()
+	uow, err := NewEventSourcedUnitOfWork(db, ddd.NewMediatr(), 3)
+	require.NoError(t, err)
+	repo := uow.OrderRepository()
+
+	location, err := kernel.NewLocation(2, 3)
+	require.NoError(t, err)
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	created, err := order.NewOrder(uuid.New(), location, 2, order.Standard, createdAt)
+	require.NoError(t, err)
+	require.NoError(t, repo.Add(ctx, created))
+
+	courierID := uuid.New()
+	loaded, err := repo.Get(ctx, created.ID())
+	require.NoError(t, err)
+	require.NoError(t, loaded.Assign(&courierID, createdAt.Add(time.Minute)))
+	require.NoError(t, loaded.Accept(courierID))
+	require.NoError(t, repo.Update(ctx, loaded))
+
+	t.Run("rebuilds the order from its snapshot and events", func(t *testing.T) {
+		rebuilt, err := repo.Get(ctx, created.ID())
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, rebuilt.Version())
+		assert.Equal(t, order.Accepted, rebuilt.Status())
+		assert.Equal(t, &courierID, rebuilt.CourierID())
+		assert.Equal(t, createdAt, rebuilt.CreatedAt())
+
+		var events []ordereventrepo.EventDTO
+		require.NoError(t, db.Order("version").Find(&events, "stream_id = ?", created.ID()).Error)
+		require.Len(t, events, 3)
+		assert.Equal(t, order.AcceptedEventName, events[2].Type)
+		var snapshot ordereventrepo.SnapshotDTO
+		require.NoError(t, db.Take(&snapshot, "stream_id = ?", created.ID()).Error)
+		assert.Equal(t, 3, snapshot.Version)
+	})
+
+	t.Run("keeps the projection for the queries", func(t *testing.T) {
+		var projected orderrepo.OrderDTO
+		require.NoError(t, db.Take(&projected, "id = ?", created.ID()).Error)
+
+		assert.Equal(t, order.Accepted, projected.Status)
+		assert.Equal(t, &courierID, projected.CourierID)
+		assert.Equal(t, 3, projected.Version)
+	})
+
+	t.Run("an order stored before the event store goes on from its row", func(t *testing.T) {
+		legacy := order.RestoreOrder(uuid.New(), nil, location, 1, order.Created, order.Standard, createdAt,
+			nil, time.Time{}, nil, "", nil, nil, 4)
+		require.NoError(t, db.Create(orderrepo.DomainToDto(legacy)).Error)
+
+		loaded, err := repo.Get(ctx, legacy.ID())
+		require.NoError(t, err)
+		require.NoError(t, loaded.Assign(&courierID, createdAt))
+		require.NoError(t, repo.Update(ctx, loaded))
+
+		rebuilt, err := repo.Get(ctx, legacy.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 5, rebuilt.Version())
+		assert.Equal(t, order.Assigned, rebuilt.Status())
+	})
+
+	t.Run("a stale copy is a conflict", func(t *testing.T) {
+		first, err := repo.Get(ctx, created.ID())
+		require.NoError(t, err)
+		second, err := repo.Get(ctx, created.ID())
+		require.NoError(t, err)
+		require.NoError(t, first.PickUp(courierID))
+		require.NoError(t, repo.Update(ctx, first))
+		require.NoError(t, second.PickUp(courierID))
+
+		err = repo.Update(ctx, second)
+
+		assert.ErrorIs(t, err, errs.ErrConflict)
+		assert.False(t, uow.InTx(), "the failed update must roll its transaction back")
+	})
+}
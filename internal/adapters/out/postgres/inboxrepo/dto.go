@@ -0,0 +1,18 @@
+package inboxrepo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProcessedMessageDTO struct {
+	MessageID   string    `gorm:"type:varchar(255);primaryKey"`
+	BasketID    uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	RequestHash string    `gorm:"type:varchar(64);not null;default:''"`
+	ProcessedAt time.Time
+}
+
+func (ProcessedMessageDTO) TableName() string {
+	return "processed_messages"
+}
@@ -0,0 +1,107 @@
+package inboxrepo
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ ports.InboxRepository = &Repository{}
+
+type Repository struct {
+	uow ports.UnitOfWork
+}
+
+func NewRepository(uow ports.UnitOfWork) (*Repository, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &Repository{
+		uow: uow,
+	}, nil
+}
+
+// Add inserts nothing when another transaction has recorded the message or the basket: it waits
+// for that transaction and reports a conflict once it commits, so only one of them creates the order
+func (r *Repository) Add(ctx context.Context, message ports.ProcessedMessage) error {
+	dto := ProcessedMessageDTO{
+		MessageID:   message.MessageID,
+		BasketID:    message.BasketID,
+		RequestHash: message.RequestHash,
+		ProcessedAt: message.ProcessedAt,
+	}
+
+	// check if we inside other tx
+	isInTx := r.uow.InTx()
+	if !isInTx {
+		// if not, create own tx
+		r.uow.Begin(ctx)
+	}
+	tx := r.uow.Tx()
+
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&dto)
+	if result.Error != nil {
+		if !isInTx {
+			r.uow.Rollback(ctx)
+		}
+		return errs.NewDatabaseError("create", "processed message", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		if !isInTx {
+			r.uow.Rollback(ctx)
+		}
+		return errs.NewConflictError("processed message", message.MessageID, "message or basket was already processed")
+	}
+
+	// if inside other tx we not fix this one
+	if !isInTx {
+		if err := r.uow.Commit(ctx); err != nil {
+			return errs.NewDatabaseError("commit", "transaction", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) Processed(ctx context.Context, messageID string, basketID uuid.UUID) (bool, error) {
+	var count int64
+	result := r.getTxOrDb().WithContext(ctx).
+		Model(&ProcessedMessageDTO{}).
+		Where("message_id = ? OR basket_id = ?", messageID, basketID).
+		Count(&count)
+	if result.Error != nil {
+		return false, errs.NewDatabaseError("get", "processed message", result.Error)
+	}
+	return count > 0, nil
+}
+
+func (r *Repository) Get(ctx context.Context, messageID string) (ports.ProcessedMessage, error) {
+	dto := ProcessedMessageDTO{}
+
+	result := r.getTxOrDb().WithContext(ctx).
+		Where("message_id = ?", messageID).
+		Take(&dto)
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return ports.ProcessedMessage{}, errs.NewNotFoundError("processed message", messageID)
+		}
+		return ports.ProcessedMessage{}, errs.NewDatabaseError("get", "processed message", result.Error)
+	}
+	return ports.ProcessedMessage{
+		MessageID:   dto.MessageID,
+		BasketID:    dto.BasketID,
+		RequestHash: dto.RequestHash,
+		ProcessedAt: dto.ProcessedAt,
+	}, nil
+}
+
+func (r *Repository) getTxOrDb() *gorm.DB {
+	if tx := r.uow.Tx(); tx != nil {
+		return tx
+	}
+	return r.uow.Db()
+}
@@ -0,0 +1,23 @@
+package offerrepo
+
+import (
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/google/uuid"
+)
+
+type OfferDTO struct {
+	ID        uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	OrderID   uuid.UUID    `gorm:"type:uuid;index"`
+	CourierID uuid.UUID    `gorm:"type:uuid;index"`
+	Status    offer.Status `gorm:"type:varchar(20);index"`
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	Reason    string
+	Version   int `gorm:"not null;default:0"`
+}
+
+func (OfferDTO) TableName() string {
+	return "offers"
+}
@@ -0,0 +1,23 @@
+package offerrepo
+
+import (
+	"github.com/delivery/internal/core/domain/model/offer"
+)
+
+func DomainToDto(offer *offer.Offer) OfferDTO {
+	return OfferDTO{
+		ID:        offer.ID(),
+		OrderID:   offer.OrderID(),
+		CourierID: offer.CourierID(),
+		Status:    offer.Status(),
+		CreatedAt: offer.CreatedAt(),
+		ExpiresAt: offer.ExpiresAt(),
+		Reason:    offer.Reason(),
+		Version:   offer.Version(),
+	}
+}
+
+func DtoToDomain(dto OfferDTO) *offer.Offer {
+	return offer.RestoreOffer(dto.ID, dto.OrderID, dto.CourierID, dto.Status, dto.CreatedAt, dto.ExpiresAt,
+		dto.Reason, dto.Version)
+}
@@ -0,0 +1,173 @@
+package offerrepo
+
+import (
+	"context"
+
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ ports.OfferRepository = &Repository{}
+
+type Repository struct {
+	uow ports.UnitOfWork
+}
+
+func NewRepository(uow ports.UnitOfWork) (*Repository, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &Repository{
+		uow: uow,
+	}, nil
+}
+
+func (r *Repository) Add(ctx context.Context, offer *offer.Offer) error {
+	r.uow.Track(offer)
+
+	dto := DomainToDto(offer)
+
+	// check if we inside other tx
+	isInTx := r.uow.InTx()
+	if !isInTx {
+		// if not, create own tx
+		r.uow.Begin(ctx)
+	}
+	tx := r.uow.Tx()
+
+	if err := tx.WithContext(ctx).Create(&dto).Error; err != nil {
+		return errs.NewDatabaseError("create", "offer", err)
+	}
+
+	if err := auditrepo.Record(ctx, tx, auditrepo.Change{
+		AggregateType: auditrepo.OfferAggregate,
+		AggregateID:   offer.ID(),
+		Version:       offer.Version(),
+		Events:        offer.GetDomainEvents(),
+		After:         dto,
+	}); err != nil {
+		return err
+	}
+
+	// if inside other tx we not fix this one
+	if !isInTx {
+		if err := r.uow.Commit(ctx); err != nil {
+			return errs.NewDatabaseError("commit", "transaction", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) Update(ctx context.Context, offer *offer.Offer) error {
+	r.uow.Track(offer)
+
+	dto := DomainToDto(offer)
+
+	// check if we inside other tx
+	isInTx := r.uow.InTx()
+	if !isInTx {
+		// if not, create own tx
+		r.uow.Begin(ctx)
+	}
+	tx := r.uow.Tx()
+
+	before := OfferDTO{}
+	result := tx.WithContext(ctx).Find(&before, offer.ID())
+	if result.Error != nil {
+		return errs.NewDatabaseError("get", "offer", result.Error)
+	}
+
+	if err := tx.WithContext(ctx).Save(&dto).Error; err != nil {
+		return errs.NewDatabaseError("update", "offer", err)
+	}
+
+	change := auditrepo.Change{
+		AggregateType: auditrepo.OfferAggregate,
+		AggregateID:   offer.ID(),
+		Version:       offer.Version(),
+		Events:        offer.GetDomainEvents(),
+		After:         dto,
+	}
+	if result.RowsAffected > 0 {
+		change.StoredVersion = before.Version
+		change.Before = before
+	}
+	if err := auditrepo.Record(ctx, tx, change); err != nil {
+		return err
+	}
+
+	// if inside other tx we not fix this one
+	if !isInTx {
+		if err := r.uow.Commit(ctx); err != nil {
+			return errs.NewDatabaseError("commit", "transaction", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) GetPending(ctx context.Context, orderID uuid.UUID) (*offer.Offer, error) {
+	dto := OfferDTO{}
+
+	result := r.getTxOrDb().WithContext(ctx).
+		Where("order_id = ? AND status = ?", orderID, offer.Pending).
+		Order("created_at DESC").
+		Take(&dto)
+
+	if result.Error != nil {
+		if result.Error == gorm.ErrRecordNotFound {
+			return nil, errs.NewNotFoundError("pending offer", orderID.String())
+		}
+		return nil, errs.NewDatabaseError("get", "offer", result.Error)
+	}
+
+	return DtoToDomain(dto), nil
+}
+
+func (r *Repository) GetAllPending(ctx context.Context) ([]*offer.Offer, error) {
+	var dtos []OfferDTO
+
+	result := r.getTxOrDb().WithContext(ctx).
+		Order("expires_at, id").
+		Find(&dtos, "status = ?", offer.Pending)
+
+	if result.Error != nil {
+		return nil, errs.NewDatabaseError("get", "offers", result.Error)
+	}
+
+	return dtosToDomain(dtos), nil
+}
+
+func (r *Repository) GetAllByOrder(ctx context.Context, orderID uuid.UUID) ([]*offer.Offer, error) {
+	var dtos []OfferDTO
+
+	result := r.getTxOrDb().WithContext(ctx).
+		Order("created_at, id").
+		Find(&dtos, "order_id = ?", orderID)
+
+	if result.Error != nil {
+		return nil, errs.NewDatabaseError("get", "offers", result.Error)
+	}
+
+	return dtosToDomain(dtos), nil
+}
+
+func (r *Repository) getTxOrDb() *gorm.DB {
+	if tx := r.uow.Tx(); tx != nil {
+		return tx
+	}
+	return r.uow.Db()
+}
+
+func dtosToDomain(dtos []OfferDTO) []*offer.Offer {
+	aggregates := make([]*offer.Offer, len(dtos))
+	for i, dto := range dtos {
+		aggregates[i] = DtoToDomain(dto)
+	}
+	return aggregates
+}
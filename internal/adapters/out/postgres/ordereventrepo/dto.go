@@ -0,0 +1,83 @@
+package ordereventrepo
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventDTO is an event of a stream in the append-only event store, the stream id and the version
+// are its key, so two writers can't append the same version
+type EventDTO struct {
+	StreamID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Version    int       `gorm:"primaryKey;autoIncrement:false"`
+	StreamType string    `gorm:"type:varchar(20);index"`
+	Type       string    `gorm:"type:varchar(50)"`
+	Payload    string    `gorm:"type:jsonb;not null"`
+	EventID    uuid.UUID `gorm:"type:uuid;uniqueIndex"`
+	OccurredAt time.Time `gorm:"index"`
+}
+
+func (EventDTO) TableName() string {
+	return "event_store"
+}
+
+// SnapshotDTO is the latest state of a stream, only the events after its version are replayed
+type SnapshotDTO struct {
+	StreamID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	StreamType string    `gorm:"type:varchar(20)"`
+	Version    int
+	Payload    string `gorm:"type:jsonb;not null"`
+	TakenAt    time.Time
+}
+
+func (SnapshotDTO) TableName() string {
+	return "snapshots"
+}
+
+type LocationDTO struct {
+	X int
+	Y int
+}
+
+type CreatedPayload struct {
+	Location  LocationDTO
+	Volume    int
+	Priority  string
+	CreatedAt time.Time
+}
+
+type DeliveryScheduledPayload struct {
+	From time.Time
+	To   time.Time
+}
+
+type ZoneTaggedPayload struct {
+	ZoneID uuid.UUID
+}
+
+type AssignedPayload struct {
+	CourierID  uuid.UUID
+	Eta        *time.Time
+	AssignedAt time.Time
+}
+
+type EtaUpdatedPayload struct {
+	Eta time.Time
+}
+
+// CourierPayload is the payload of the accepted and the picked up events
+type CourierPayload struct {
+	CourierID uuid.UUID
+}
+
+type UnassignedPayload struct {
+	CourierID uuid.UUID
+	Reason    string
+}
+
+type CompletedPayload struct {
+	CourierID      uuid.UUID
+	ProofPhotoHash string
+	ProofPin       string
+}
@@ -0,0 +1,151 @@
+package ordereventrepo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/ddd"
+)
+
+// orderStream is the stream type of the order events
+const orderStream = "order"
+
+func EventToDto(event ddd.DomainEvent) (EventDTO, error) {
+	var payload any
+	switch e := event.(type) {
+	case *order.CreatedDomainEvent:
+		payload = CreatedPayload{
+			Location:  LocationDTO{X: e.Location.X(), Y: e.Location.Y()},
+			Volume:    e.Volume,
+			Priority:  string(e.Priority),
+			CreatedAt: e.CreatedAt,
+		}
+	case *order.DeliveryScheduledDomainEvent:
+		payload = DeliveryScheduledPayload{From: e.Window.From(), To: e.Window.To()}
+	case *order.ZoneTaggedDomainEvent:
+		payload = ZoneTaggedPayload{ZoneID: e.ZoneID}
+	case *order.AssignedDomainEvent:
+		payload = AssignedPayload{CourierID: e.CourierID, Eta: e.Eta, AssignedAt: e.AssignedAt}
+	case *order.EtaUpdatedDomainEvent:
+		payload = EtaUpdatedPayload{Eta: e.Eta}
+	case *order.AcceptedDomainEvent:
+		payload = CourierPayload{CourierID: e.CourierID}
+	case *order.PickedUpDomainEvent:
+		payload = CourierPayload{CourierID: e.CourierID}
+	case *order.UnassignedDomainEvent:
+		payload = UnassignedPayload{CourierID: e.CourierID, Reason: e.Reason}
+	case *order.CompletedDomainEvent:
+		completed := CompletedPayload{CourierID: e.CourierID}
+		if e.Proof != nil {
+			completed.ProofPhotoHash = e.Proof.PhotoHash()
+			completed.ProofPin = e.Proof.Pin()
+		}
+		payload = completed
+	default:
+		return EventDTO{}, fmt.Errorf("unsupported order event %s", event.GetName())
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return EventDTO{}, err
+	}
+	return EventDTO{
+		StreamID:   event.GetAggregateID(),
+		Version:    event.GetVersion(),
+		StreamType: orderStream,
+		Type:       event.GetName(),
+		Payload:    string(data),
+		EventID:    event.GetID(),
+		OccurredAt: event.GetOccurredAt(),
+	}, nil
+}
+
+func DtoToEvent(dto EventDTO) (ddd.DomainEvent, error) {
+	base := ddd.BaseEvent{
+		ID:          dto.EventID,
+		Name:        dto.Type,
+		AggregateID: dto.StreamID,
+		Version:     dto.Version,
+		OccurredAt:  dto.OccurredAt,
+	}
+
+	switch dto.Type {
+	case order.CreatedEventName:
+		var payload CreatedPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		location, err := kernel.NewLocation(payload.Location.X, payload.Location.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &order.CreatedDomainEvent{BaseEvent: base, Location: location, Volume: payload.Volume,
+			Priority: order.Priority(payload.Priority), CreatedAt: payload.CreatedAt}, nil
+	case order.DeliveryScheduledEventName:
+		var payload DeliveryScheduledPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		window, err := order.NewDeliveryWindow(payload.From, payload.To)
+		if err != nil {
+			return nil, err
+		}
+		return &order.DeliveryScheduledDomainEvent{BaseEvent: base, Window: window}, nil
+	case order.ZoneTaggedEventName:
+		var payload ZoneTaggedPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		return &order.ZoneTaggedDomainEvent{BaseEvent: base, ZoneID: payload.ZoneID}, nil
+	case order.AssignedEventName:
+		var payload AssignedPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		return &order.AssignedDomainEvent{BaseEvent: base, CourierID: payload.CourierID, Eta: payload.Eta,
+			AssignedAt: payload.AssignedAt}, nil
+	case order.EtaUpdatedEventName:
+		var payload EtaUpdatedPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		return &order.EtaUpdatedDomainEvent{BaseEvent: base, Eta: payload.Eta}, nil
+	case order.AcceptedEventName:
+		var payload CourierPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		return &order.AcceptedDomainEvent{BaseEvent: base, CourierID: payload.CourierID}, nil
+	case order.PickedUpEventName:
+		var payload CourierPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		return &order.PickedUpDomainEvent{BaseEvent: base, CourierID: payload.CourierID}, nil
+	case order.UnassignedEventName:
+		var payload UnassignedPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		return &order.UnassignedDomainEvent{BaseEvent: base, CourierID: payload.CourierID,
+			Reason: payload.Reason}, nil
+	case order.CompletedEventName:
+		var payload CompletedPayload
+		if err := unmarshal(dto, &payload); err != nil {
+			return nil, err
+		}
+		return &order.CompletedDomainEvent{BaseEvent: base, CourierID: payload.CourierID,
+			Proof: order.RestoreProofOfDelivery(payload.ProofPhotoHash, payload.ProofPin)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported order event %s", dto.Type)
+	}
+}
+
+func unmarshal(dto EventDTO, payload any) error {
+	if err := json.Unmarshal([]byte(dto.Payload), payload); err != nil {
+		return fmt.Errorf("invalid payload of %s %d of stream %s: %w", dto.Type, dto.Version, dto.StreamID, err)
+	}
+	return nil
+}
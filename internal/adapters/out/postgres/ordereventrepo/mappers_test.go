@@ -0,0 +1,62 @@
+package ordereventrepo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventToDto_RoundTrip(t *testing.T) {
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	location, err := kernel.NewLocation(3, 7)
+	require.NoError(t, err)
+	window, err := order.NewDeliveryWindow(createdAt.Add(time.Hour), createdAt.Add(2*time.Hour))
+	require.NoError(t, err)
+	proof, err := order.NewProofOfDelivery("", "1234")
+	require.NoError(t, err)
+	first, second := uuid.New(), uuid.New()
+
+	o, err := order.NewOrder(uuid.New(), location, 2, order.Express, createdAt)
+	require.NoError(t, err)
+	require.NoError(t, o.ScheduleDelivery(window))
+	require.NoError(t, o.TagZone(uuid.New()))
+	require.NoError(t, o.Assign(&first, createdAt.Add(time.Minute)))
+	require.NoError(t, o.UpdateEta(createdAt.Add(20*time.Minute)))
+	require.NoError(t, o.Decline(first, "too far away"))
+	require.NoError(t, o.Assign(&second, createdAt.Add(2*time.Minute)))
+	require.NoError(t, o.Accept(second))
+	require.NoError(t, o.PickUp(second))
+	events := o.GetDomainEvents()
+	o.ClearDomainEvents()
+	require.NoError(t, o.UpdateEta(createdAt.Add(30*time.Minute)))
+	require.NoError(t, o.Complete(&proof))
+	events = append(events, o.GetDomainEvents()...)
+
+	require.Len(t, events, 10)
+	for _, event := range events {
+		t.Run(event.GetName(), func(t *testing.T) {
+			dto, err := EventToDto(event)
+			require.NoError(t, err)
+			assert.Equal(t, o.ID(), dto.StreamID)
+			assert.Equal(t, event.GetVersion(), dto.Version)
+			assert.Equal(t, "order", dto.StreamType)
+			assert.Equal(t, event.GetName(), dto.Type)
+
+			restored, err := DtoToEvent(dto)
+
+			require.NoError(t, err)
+			assert.Equal(t, event, restored)
+		})
+	}
+}
+
+func TestDtoToEvent_UnknownType(t *testing.T) {
+	_, err := DtoToEvent(EventDTO{StreamID: uuid.New(), Version: 1, Type: "OrderLost", Payload: "{}"})
+
+	assert.Error(t, err)
+}
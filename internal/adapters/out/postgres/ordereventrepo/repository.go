@@ -0,0 +1,322 @@
+package ordereventrepo
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var _ ports.OrderRepository = &Repository{}
+
+// Repository stores the orders as streams of their events and rebuilds them on every read.
+// The orders table is their projection: it is written in the same transaction, the queries read it
+// and the orders stored before the event store are loaded from it until their next change.
+type Repository struct {
+	uow        ports.UnitOfWork
+	projection *orderrepo.Repository
+	// snapshotEvery events a snapshot of the order is taken, 0 replays the whole stream every time
+	snapshotEvery int
+}
+
+func NewRepository(uow ports.UnitOfWork, snapshotEvery int) (*Repository, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	if snapshotEvery < 0 {
+		return nil, errs.NewValidationErrorWithValue("snapshot every", snapshotEvery, "must not be negative")
+	}
+	projection, err := orderrepo.NewRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{
+		uow:           uow,
+		projection:    projection,
+		snapshotEvery: snapshotEvery,
+	}, nil
+}
+
+func (r *Repository) Add(ctx context.Context, order *order.Order) error {
+	// check if we inside other tx
+	isInTx := r.uow.InTx()
+	if !isInTx {
+		// if not, create own tx
+		r.uow.Begin(ctx)
+	}
+
+	if err := r.add(ctx, r.uow.Tx(), order); err != nil {
+		if !isInTx {
+			r.uow.Rollback(ctx)
+		}
+		return err
+	}
+
+	// if inside other tx we not fix this one
+	if !isInTx {
+		if err := r.uow.Commit(ctx); err != nil {
+			return errs.NewDatabaseError("commit", "transaction", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) Update(ctx context.Context, order *order.Order) error {
+	// check if we inside other tx
+	isInTx := r.uow.InTx()
+	if !isInTx {
+		// if not, create own tx
+		r.uow.Begin(ctx)
+	}
+
+	if err := r.update(ctx, r.uow.Tx(), order); err != nil {
+		if !isInTx {
+			r.uow.Rollback(ctx)
+		}
+		return err
+	}
+
+	// if inside other tx we not fix this one
+	if !isInTx {
+		if err := r.uow.Commit(ctx); err != nil {
+			return errs.NewDatabaseError("commit", "transaction", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) add(ctx context.Context, tx *gorm.DB, order *order.Order) error {
+	if err := r.append(ctx, tx, order, 0); err != nil {
+		return err
+	}
+	if err := r.projection.Add(ctx, order); err != nil {
+		return err
+	}
+	return r.snapshot(ctx, tx, order, 0)
+}
+
+func (r *Repository) update(ctx context.Context, tx *gorm.DB, order *order.Order) error {
+	stored, err := r.storedVersion(ctx, tx, order.ID())
+	if err != nil {
+		return err
+	}
+	if stored == 0 {
+		// the order was stored before the event store, its projection starts the stream
+		if stored, err = r.seed(ctx, tx, order.ID()); err != nil {
+			return err
+		}
+	}
+
+	if err := r.append(ctx, tx, order, stored); err != nil {
+		return err
+	}
+	if err := r.projection.Update(ctx, order); err != nil {
+		return err
+	}
+	return r.snapshot(ctx, tx, order, stored)
+}
+
+func (r *Repository) Get(ctx context.Context, orderID uuid.UUID) (*order.Order, error) {
+	tx := r.getTxOrDb().WithContext(ctx)
+
+	var snapshots []SnapshotDTO
+	if err := tx.Limit(1).Find(&snapshots, "stream_id = ?", orderID).Error; err != nil {
+		return nil, errs.NewDatabaseError("get", "order snapshot", err)
+	}
+	from := 0
+	if len(snapshots) > 0 {
+		from = snapshots[0].Version
+	}
+
+	var dtos []EventDTO
+	if err := tx.Order("version").Find(&dtos, "stream_id = ? AND version > ?", orderID, from).Error; err != nil {
+		return nil, errs.NewDatabaseError("get", "order events", err)
+	}
+	history := make([]ddd.DomainEvent, 0, len(dtos))
+	for _, dto := range dtos {
+		event, err := DtoToEvent(dto)
+		if err != nil {
+			return nil, errs.NewDatabaseError("get", "order events", err)
+		}
+		history = append(history, event)
+	}
+
+	if len(snapshots) == 0 {
+		if len(history) == 0 {
+			// no stream yet, the order may be stored before the event store
+			return r.projection.Get(ctx, orderID)
+		}
+		return order.RebuildOrder(orderID, history)
+	}
+
+	var state orderrepo.OrderDTO
+	if err := json.Unmarshal([]byte(snapshots[0].Payload), &state); err != nil {
+		return nil, errs.NewDatabaseError("get", "order snapshot", err)
+	}
+	aggregate := orderrepo.DtoToDomain(state)
+	if err := aggregate.Replay(history...); err != nil {
+		return nil, err
+	}
+	return aggregate, nil
+}
+
+// GetFirstInStatusCreate picks the order from the projection, the order itself is rebuilt from its events
+func (r *Repository) GetFirstInStatusCreate(ctx context.Context) (*order.Order, error) {
+	projected, err := r.projection.GetFirstInStatusCreate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.Get(ctx, projected.ID())
+}
+
+func (r *Repository) GetAllInStatusCreate(ctx context.Context) ([]*order.Order, error) {
+	projected, err := r.projection.GetAllInStatusCreate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.rebuild(ctx, projected)
+}
+
+func (r *Repository) GetAllWithCourier(ctx context.Context) ([]*order.Order, error) {
+	projected, err := r.projection.GetAllWithCourier(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.rebuild(ctx, projected)
+}
+
+// rebuild keeps the sequence of the projected orders
+func (r *Repository) rebuild(ctx context.Context, projected []*order.Order) ([]*order.Order, error) {
+	aggregates := make([]*order.Order, len(projected))
+	for i, o := range projected {
+		aggregate, err := r.Get(ctx, o.ID())
+		if err != nil {
+			return nil, err
+		}
+		aggregates[i] = aggregate
+	}
+	return aggregates, nil
+}
+
+// append stores the events the order raised since it was loaded. The events an earlier update of
+// the transaction stored are skipped; any other event at their versions comes from a concurrent writer.
+func (r *Repository) append(ctx context.Context, tx *gorm.DB, order *order.Order, stored int) error {
+	events := order.GetDomainEvents()
+	if len(events) == 0 {
+		return nil
+	}
+
+	var storedIDs []uuid.UUID
+	result := tx.WithContext(ctx).Model(&EventDTO{}).
+		Where("stream_id = ? AND version >= ?", order.ID(), events[0].GetVersion()).
+		Pluck("event_id", &storedIDs)
+	if result.Error != nil {
+		return errs.NewDatabaseError("get", "order events", result.Error)
+	}
+	own := make(map[uuid.UUID]bool, len(events))
+	for _, event := range events {
+		own[event.GetID()] = true
+	}
+	for _, id := range storedIDs {
+		if !own[id] {
+			return errs.NewConflictError("order", order.ID().String(), "the order was changed by another transaction")
+		}
+	}
+
+	var dtos []EventDTO
+	for _, event := range events[len(storedIDs):] {
+		dto, err := EventToDto(event)
+		if err != nil {
+			return errs.NewDatabaseError("append", "order events", err)
+		}
+		dtos = append(dtos, dto)
+	}
+	if len(dtos) == 0 {
+		return nil
+	}
+	if dtos[0].Version != stored+1 {
+		return errs.NewConflictError("order", order.ID().String(), "the order was changed by another transaction")
+	}
+
+	result = tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&dtos)
+	if result.Error != nil {
+		return errs.NewDatabaseError("append", "order events", result.Error)
+	}
+	if result.RowsAffected != int64(len(dtos)) {
+		return errs.NewConflictError("order", order.ID().String(), "the order was changed by another transaction")
+	}
+	return nil
+}
+
+// snapshot stores the state once the order passed another snapshotEvery events since the stored version
+func (r *Repository) snapshot(ctx context.Context, tx *gorm.DB, order *order.Order, stored int) error {
+	if r.snapshotEvery == 0 || order.Version()/r.snapshotEvery == stored/r.snapshotEvery {
+		return nil
+	}
+	return r.saveSnapshot(ctx, tx, orderrepo.DomainToDto(order))
+}
+
+// seed snapshots the projection of an order stored before the event store, it is the version
+// its stream goes on from
+func (r *Repository) seed(ctx context.Context, tx *gorm.DB, orderID uuid.UUID) (int, error) {
+	var projected []orderrepo.OrderDTO
+	if err := tx.WithContext(ctx).Limit(1).Find(&projected, "id = ?", orderID).Error; err != nil {
+		return 0, errs.NewDatabaseError("get", "order", err)
+	}
+	if len(projected) == 0 || projected[0].Version == 0 {
+		return 0, nil
+	}
+	if err := r.saveSnapshot(ctx, tx, projected[0]); err != nil {
+		return 0, err
+	}
+	return projected[0].Version, nil
+}
+
+func (r *Repository) saveSnapshot(ctx context.Context, tx *gorm.DB, state orderrepo.OrderDTO) error {
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return errs.NewDatabaseError("snapshot", "order", err)
+	}
+	dto := SnapshotDTO{
+		StreamID:   state.ID,
+		StreamType: orderStream,
+		Version:    state.Version,
+		Payload:    string(payload),
+		TakenAt:    time.Now().UTC(),
+	}
+	if err := tx.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&dto).Error; err != nil {
+		return errs.NewDatabaseError("snapshot", "order", err)
+	}
+	return nil
+}
+
+// storedVersion is the last version in the event store or the snapshot
+func (r *Repository) storedVersion(ctx context.Context, tx *gorm.DB, orderID uuid.UUID) (int, error) {
+	var version int
+	result := tx.WithContext(ctx).Raw(
+		"SELECT GREATEST("+
+			"(SELECT COALESCE(MAX(version), 0) FROM event_store WHERE stream_id = ?), "+
+			"(SELECT COALESCE(MAX(version), 0) FROM snapshots WHERE stream_id = ?))",
+		orderID, orderID).Scan(&version)
+	if result.Error != nil {
+		return 0, errs.NewDatabaseError("get", "order stream version", result.Error)
+	}
+	return version, nil
+}
+
+func (r *Repository) getTxOrDb() *gorm.DB {
+	if tx := r.uow.Tx(); tx != nil {
+		return tx
+	}
+	return r.uow.Db()
+}
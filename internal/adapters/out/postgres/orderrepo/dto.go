@@ -1,17 +1,29 @@
 package orderrepo
 
 import (
-	"github.com/delivery/internal/core/domain/model/kernel"
+	"time"
+
 	"github.com/delivery/internal/core/domain/model/order"
 	"github.com/google/uuid"
 )
 
 type OrderDTO struct {
-	ID        uuid.UUID       `gorm:"type:uuid;primaryKey"`
-	CourierID *uuid.UUID      `gorm:"type:uuid;index"`
-	Location  kernel.Location `gorm:"embedded;embeddedPrefix:location_"`
-	Volume    int
-	Status    order.Status `gorm:"type:varchar(20)"`
+	ID             uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	CourierID      *uuid.UUID  `gorm:"type:uuid;index"`
+	Location       LocationDTO `gorm:"embedded;embeddedPrefix:location_"`
+	Volume         int
+	Status         order.Status   `gorm:"type:varchar(20)"`
+	Priority       order.Priority `gorm:"type:varchar(20);not null;default:standard"`
+	CreatedAt      time.Time      `gorm:"index"`
+	Eta            *time.Time
+	AssignedAt     *time.Time
+	DeliveryFrom   *time.Time
+	DeliveryTo     *time.Time
+	UnassignReason string
+	ProofPhotoHash string     `gorm:"type:varchar(64)"`
+	ProofPin       string     `gorm:"type:varchar(8)"`
+	ZoneID         *uuid.UUID `gorm:"type:uuid;index"`
+	Version        int        `gorm:"not null;default:0"`
 }
 
 type LocationDTO struct {
@@ -1,23 +1,84 @@
 package orderrepo
 
 import (
+	"time"
+
 	"github.com/delivery/internal/core/domain/model/kernel"
 	"github.com/delivery/internal/core/domain/model/order"
 )
 
 func DomainToDto(order *order.Order) OrderDTO {
 	return OrderDTO{
-		ID:        order.ID(),
-		CourierID: order.CourierID(),
-		Location:  order.Location(),
-		Volume:    order.Volume(),
-		Status:    order.Status(),
+		ID:             order.ID(),
+		CourierID:      order.CourierID(),
+		Location:       LocationDTO{X: order.Location().X(), Y: order.Location().Y()},
+		Volume:         order.Volume(),
+		Status:         order.Status(),
+		Priority:       order.Priority(),
+		CreatedAt:      order.CreatedAt(),
+		Eta:            order.Eta(),
+		AssignedAt:     assignedAt(order.AssignedAt()),
+		DeliveryFrom:   deliveryFrom(order.DeliveryWindow()),
+		DeliveryTo:     deliveryTo(order.DeliveryWindow()),
+		UnassignReason: order.UnassignReason(),
+		ProofPhotoHash: proofPhotoHash(order.Proof()),
+		ProofPin:       proofPin(order.Proof()),
+		ZoneID:         order.ZoneID(),
+		Version:        order.Version(),
 	}
 }
 
 func DtoToDomain(dto OrderDTO) *order.Order {
 	var aggregate *order.Order
-	location, _ := kernel.NewLocation(dto.Location.X(), dto.Location.Y())
-	aggregate = order.RestoreOrder(dto.ID, dto.CourierID, location, dto.Volume, dto.Status)
+	location, _ := kernel.NewLocation(dto.Location.X, dto.Location.Y)
+	var deliveryWindow *order.DeliveryWindow
+	if dto.DeliveryFrom != nil && dto.DeliveryTo != nil {
+		window, _ := order.NewDeliveryWindow(*dto.DeliveryFrom, *dto.DeliveryTo)
+		deliveryWindow = &window
+	}
+	var assignedAt time.Time
+	if dto.AssignedAt != nil {
+		assignedAt = *dto.AssignedAt
+	}
+	aggregate = order.RestoreOrder(dto.ID, dto.CourierID, location, dto.Volume, dto.Status,
+		dto.Priority, dto.CreatedAt, dto.Eta, assignedAt, deliveryWindow, dto.UnassignReason,
+		order.RestoreProofOfDelivery(dto.ProofPhotoHash, dto.ProofPin), dto.ZoneID, dto.Version)
 	return aggregate
 }
+
+func deliveryFrom(window *order.DeliveryWindow) *time.Time {
+	if window == nil {
+		return nil
+	}
+	from := window.From()
+	return &from
+}
+
+func deliveryTo(window *order.DeliveryWindow) *time.Time {
+	if window == nil {
+		return nil
+	}
+	to := window.To()
+	return &to
+}
+
+func assignedAt(at time.Time) *time.Time {
+	if at.IsZero() {
+		return nil
+	}
+	return &at
+}
+
+func proofPhotoHash(proof *order.ProofOfDelivery) string {
+	if proof == nil {
+		return ""
+	}
+	return proof.PhotoHash()
+}
+
+func proofPin(proof *order.ProofOfDelivery) string {
+	if proof == nil {
+		return ""
+	}
+	return proof.Pin()
+}
@@ -3,6 +3,7 @@ package orderrepo
 import (
 	"context"
 
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
 	"github.com/delivery/internal/core/domain/model/order"
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/errs"
@@ -43,6 +44,16 @@ func (r *Repository) Add(ctx context.Context, order *order.Order) error {
 		return errs.NewDatabaseError("create", "order", err)
 	}
 
+	if err := auditrepo.Record(ctx, tx, auditrepo.Change{
+		AggregateType: auditrepo.OrderAggregate,
+		AggregateID:   order.ID(),
+		Version:       order.Version(),
+		Events:        order.GetDomainEvents(),
+		After:         dto,
+	}); err != nil {
+		return err
+	}
+
 	// if inside other tx we not fix this one
 	if !isInTx {
 		if err := r.uow.Commit(ctx); err != nil {
@@ -66,10 +77,32 @@ func (r *Repository) Update(ctx context.Context, order *order.Order) error {
 	}
 	tx := r.uow.Tx()
 
+	before := OrderDTO{}
+	result := tx.WithContext(ctx).Preload(clause.Associations).Find(&before, order.ID())
+	if result.Error != nil {
+		return errs.NewDatabaseError("get", "order", result.Error)
+	}
+
 	if err := tx.WithContext(ctx).Session(&gorm.Session{FullSaveAssociations: true}).Save(&dto).Error; err != nil {
 		return errs.NewDatabaseError("update", "order", err)
 	}
 
+	change := auditrepo.Change{
+		AggregateType: auditrepo.OrderAggregate,
+		AggregateID:   order.ID(),
+		Version:       order.Version(),
+		Events:        order.GetDomainEvents(),
+		After:         dto,
+		Volatile:      []string{"Eta"},
+	}
+	if result.RowsAffected > 0 {
+		change.StoredVersion = before.Version
+		change.Before = before
+	}
+	if err := auditrepo.Record(ctx, tx, change); err != nil {
+		return err
+	}
+
 	// if inside other tx we not fix this one
 	if !isInTx {
 		if err := r.uow.Commit(ctx); err != nil {
@@ -103,9 +136,18 @@ func (r *Repository) Get(ctx context.Context, orderID uuid.UUID) (*order.Order,
 func (r *Repository) GetFirstInStatusCreate(ctx context.Context) (*order.Order, error) {
 	dto := OrderDTO{}
 	tx := r.getTxOrDb()
+	// the earliest dispatch deadline first, it matches order.DispatchBefore
+	dispatchOrder := clause.OrderBy{Expression: clause.Expr{
+		SQL: "CASE WHEN priority = ? THEN created_at ELSE created_at + make_interval(secs => ?) END, " +
+			"created_at, id",
+		Vars:               []interface{}{order.Express, order.AgingThreshold.Seconds()},
+		WithoutParentheses: true,
+	}}
 	result := tx.WithContext(ctx).
 		Preload(clause.Associations).
-		First(&dto, "status = ?", order.Created)
+		Where("status = ?", order.Created).
+		Order(dispatchOrder).
+		Take(&dto)
 
 	if result.Error != nil {
 		if result.Error == gorm.ErrRecordNotFound {
@@ -138,13 +180,18 @@ func (r *Repository) GetAllInStatusCreate(ctx context.Context) ([]*order.Order,
 	return aggregates, nil
 }
 
-func (r *Repository) GetAllInStatusAssigned(ctx context.Context) ([]*order.Order, error) {
+func (r *Repository) GetAllWithCourier(ctx context.Context) ([]*order.Order, error) {
 	var dtos []OrderDTO
 
 	tx := r.getTxOrDb()
 	result := tx.WithContext(ctx).
 		Preload(clause.Associations).
-		Find(&dtos, "status = ?", order.Assigned)
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "CASE WHEN priority = ? THEN 0 ELSE 1 END, created_at, id",
+			Vars:               []interface{}{order.Express},
+			WithoutParentheses: true,
+		}}).
+		Find(&dtos, "status IN ?", order.WithCourierStatuses)
 
 	if result.Error != nil {
 		return nil, errs.NewDatabaseError("get", "orders", result.Error)
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
+	"github.com/delivery/internal/adapters/out/postgres/courierrepo"
+	"github.com/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/delivery/internal/adapters/out/postgres/viewrepo"
+	"github.com/delivery/internal/core/application/eventhandlers"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestReadModels(t *testing.T) {
+	dsn := startTestDb(t)
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = closeGormDb(db)
+	})
+	require.NoError(t, db.AutoMigrate(&courierrepo.CourierDto{}, &courierrepo.StoragePlaceDto{},
+		&orderrepo.OrderDTO{}, &auditrepo.EntryDTO{}, &viewrepo.CourierViewDTO{}, &viewrepo.OrderViewDTO{}))
+
+	ctx := context.Background()
+	mediatr := ddd.NewMediatr()
+	uow, err := NewUnitOfWork(db, mediatr)
+	require.NoError(t, err)
+	projector, err := viewrepo.NewProjector(uow)
+	require.NoError(t, err)
+	views, err := viewrepo.NewRepository(uow)
+	require.NoError(t, err)
+	courierViewProjector, err := eventhandlers.NewCourierViewProjector(projector)
+	require.NoError(t, err)
+	orderViewProjector, err := eventhandlers.NewOrderViewProjector(projector)
+	require.NoError(t, err)
+	mediatr.Subscribe(courierViewProjector, courier.NewCreatedDomainEventWithoutData(),
+		courier.NewStoragePlaceAddedDomainEventWithoutData(), order.NewAssignedDomainEventWithoutData())
+	mediatr.Subscribe(orderViewProjector, order.NewCreatedDomainEventWithoutData(),
+		order.NewAssignedDomainEventWithoutData())
+
+	location, err := kernel.NewLocation(2, 3)
+	require.NoError(t, err)
+	bob, err := courier.NewCourier("Bob", 2, location)
+	require.NoError(t, err)
+	require.NoError(t, bob.AddStoragePlace("bag", 10))
+	require.NoError(t, uow.CourierRepository().Add(ctx, bob))
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	created, err := order.NewOrder(uuid.New(), location, 4, order.Express, now)
+	require.NoError(t, err)
+	require.NoError(t, uow.OrderRepository().Add(ctx, created))
+
+	uow.Begin(ctx)
+	courierID := bob.ID()
+	require.NoError(t, bob.TakeOrder(created))
+	require.NoError(t, created.Assign(&courierID, now))
+	require.NoError(t, uow.OrderRepository().Update(ctx, created))
+	require.NoError(t, uow.CourierRepository().Update(ctx, bob))
+	require.NoError(t, uow.Commit(ctx))
+
+	assertViews := func(t *testing.T) {
+		couriers, total, err := views.ListCouriers(ctx, ports.CourierFilter{}, ports.CourierSortName, ports.Page{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, couriers, 1)
+		assert.Equal(t, "Bob", couriers[0].Name)
+		assert.Equal(t, 1, couriers[0].OrdersCount)
+		assert.Equal(t, 4, couriers[0].UsedVolume)
+		assert.Equal(t, 10, couriers[0].Capacity)
+
+		orders, total, err := views.ListUncompletedOrders(ctx, ports.OrderFilter{}, ports.OrderSortCreatedAt, ports.Page{Limit: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		require.Len(t, orders, 1)
+		assert.Equal(t, order.Assigned, orders[0].Status)
+		assert.Equal(t, order.Express, orders[0].Priority)
+		assert.Equal(t, &courierID, orders[0].CourierID)
+		require.NotNil(t, orders[0].CourierName)
+		assert.Equal(t, "Bob", *orders[0].CourierName)
+	}
+
+	t.Run("projectors keep the views in the transaction of the change", assertViews)
+
+	t.Run("rebuild regenerates the views from the write tables", func(t *testing.T) {
+		require.NoError(t, db.Exec("DELETE FROM order_view").Error)
+		require.NoError(t, db.Exec("UPDATE courier_view SET orders_count = 0, name = 'stale'").Error)
+		handler, err := commands.NewRebuildReadModelsHandler(uow, projector)
+		require.NoError(t, err)
+		command, err := commands.NewRebuildReadModelsCommand()
+		require.NoError(t, err)
+
+		require.NoError(t, handler.Handle(ctx, command))
+
+		assertViews(t)
+	})
+
+	t.Run("pages through the filtered list by the cursor of the last row", func(t *testing.T) {
+		for _, name := range []string{"Alice", "alex", "Bella", "Al_"} {
+			c, err := courier.NewCourier(name, 1, location)
+			require.NoError(t, err)
+			require.NoError(t, uow.CourierRepository().Add(ctx, c))
+		}
+		filter := ports.CourierFilter{NamePrefix: "AL"}
+
+		first, total, err := views.ListCouriers(ctx, filter, ports.CourierSortName, ports.Page{Limit: 2, Desc: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), total, "the prefix ignores the case")
+		require.Len(t, first, 2)
+		after := first[1].Cursor(ports.CourierSortName)
+		second, _, err := views.ListCouriers(ctx, filter, ports.CourierSortName, ports.Page{Limit: 2, Desc: true, After: &after})
+		require.NoError(t, err)
+		require.Len(t, second, 1)
+
+		var names []string
+		for _, view := range append(first, second...) {
+			names = append(names, view.Name)
+		}
+		assert.ElementsMatch(t, []string{"Alice", "alex", "Al_"}, names)
+
+		_, total, err = views.ListCouriers(ctx, ports.CourierFilter{NamePrefix: "al_"}, ports.CourierSortName, ports.Page{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total, "a wildcard in the prefix is taken literally")
+	})
+}
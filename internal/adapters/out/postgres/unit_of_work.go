@@ -4,7 +4,11 @@ import (
 	"context"
 
 	"github.com/delivery/internal/adapters/out/postgres/courierrepo"
+	"github.com/delivery/internal/adapters/out/postgres/inboxrepo"
+	"github.com/delivery/internal/adapters/out/postgres/offerrepo"
+	"github.com/delivery/internal/adapters/out/postgres/ordereventrepo"
 	"github.com/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/delivery/internal/adapters/out/postgres/zonerepo"
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/ddd"
 	"github.com/delivery/internal/pkg/errs"
@@ -20,6 +24,9 @@ type UnitOfWork struct {
 	trackedAggregates []ddd.AggregateRoot
 	courierRepository ports.CourierRepository
 	orderRepository   ports.OrderRepository
+	offerRepository   ports.OfferRepository
+	zoneRepository    ports.ZoneRepository
+	inboxRepository   ports.InboxRepository
 	mediatr           ddd.Mediatr
 }
 
@@ -48,6 +55,41 @@ func NewUnitOfWork(db *gorm.DB, mediatr ddd.Mediatr) (*UnitOfWork, error) {
 	}
 	uow.orderRepository = orderRepo
 
+	offerRepo, err := offerrepo.NewRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+	uow.offerRepository = offerRepo
+
+	zoneRepo, err := zonerepo.NewRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+	uow.zoneRepository = zoneRepo
+
+	inboxRepo, err := inboxrepo.NewRepository(uow)
+	if err != nil {
+		return nil, err
+	}
+	uow.inboxRepository = inboxRepo
+
+	return uow, nil
+}
+
+// NewEventSourcedUnitOfWork stores the orders in the event store, taking a snapshot every snapshotEvery
+// events (0 takes none); the orders table is kept as their projection
+func NewEventSourcedUnitOfWork(db *gorm.DB, mediatr ddd.Mediatr, snapshotEvery int) (*UnitOfWork, error) {
+	uow, err := NewUnitOfWork(db, mediatr)
+	if err != nil {
+		return nil, err
+	}
+
+	orderRepo, err := ordereventrepo.NewRepository(uow, snapshotEvery)
+	if err != nil {
+		return nil, err
+	}
+	uow.orderRepository = orderRepo
+
 	return uow, nil
 }
 
@@ -86,21 +128,35 @@ func (uow *UnitOfWork) Commit(ctx context.Context) error {
 		}
 	}()
 
+	if err := uow.publishBeforeCommit(ctx); err != nil {
+		return err
+	}
+
 	if err := uow.tx.WithContext(ctx).Commit().Error; err != nil && err != gorm.ErrInvalidTransaction {
 		log.Error(err)
 		return errs.NewDatabaseError("commit", "transaction", err)
 	}
-
-	if err := uow.publishDomainEvents(ctx); err != nil {
-		return err
-	}
-
 	commited = true
+
+	uow.publishDomainEvents(ctx)
 	uow.clearTx()
 
 	return nil
 }
 
+func (uow *UnitOfWork) Rollback(_ context.Context) {
+	if uow.tx == nil {
+		return
+	}
+	if err := uow.tx.Rollback().Error; err != nil {
+		log.Error(err)
+	}
+	for _, aggregate := range uow.trackedAggregates {
+		aggregate.ClearDomainEvents()
+	}
+	uow.clearTx()
+}
+
 func (uow *UnitOfWork) CourierRepository() ports.CourierRepository {
 	return uow.courierRepository
 }
@@ -109,21 +165,43 @@ func (uow *UnitOfWork) OrderRepository() ports.OrderRepository {
 	return uow.orderRepository
 }
 
+func (uow *UnitOfWork) OfferRepository() ports.OfferRepository {
+	return uow.offerRepository
+}
+
+func (uow *UnitOfWork) ZoneRepository() ports.ZoneRepository {
+	return uow.zoneRepository
+}
+
+func (uow *UnitOfWork) InboxRepository() ports.InboxRepository {
+	return uow.inboxRepository
+}
+
 func (uow *UnitOfWork) clearTx() {
 	uow.tx = nil
 	uow.trackedAggregates = nil
 }
 
-func (uow *UnitOfWork) publishDomainEvents(ctx context.Context) error {
+// publishBeforeCommit lets the BeforeCommit handlers veto the transaction
+func (uow *UnitOfWork) publishBeforeCommit(ctx context.Context) error {
 	for _, aggregate := range uow.trackedAggregates {
 		for _, event := range aggregate.GetDomainEvents() {
-			err := uow.mediatr.Publish(ctx, event)
-			if err != nil {
-				log.Error(err)
-				continue
+			if err := uow.mediatr.PublishBeforeCommit(ctx, event); err != nil {
+				return err
 			}
-			aggregate.ClearDomainEvents()
 		}
 	}
 	return nil
 }
+
+// publishDomainEvents only logs the failures, the changes are already stored
+func (uow *UnitOfWork) publishDomainEvents(ctx context.Context) {
+	for _, aggregate := range uow.trackedAggregates {
+		for _, event := range aggregate.GetDomainEvents() {
+			if err := uow.mediatr.Publish(ctx, event); err != nil {
+				log.Error(err)
+			}
+		}
+		aggregate.ClearDomainEvents()
+	}
+}
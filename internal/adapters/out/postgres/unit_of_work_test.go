@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
+	"github.com/delivery/internal/adapters/out/postgres/inboxrepo"
+	"github.com/delivery/internal/adapters/out/postgres/orderrepo"
+	"github.com/delivery/internal/adapters/out/postgres/zonerepo"
+	"github.com/delivery/internal/core/application/usecases/commands"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/audit"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestCreateOrder_ConcurrentCopiesOfMessageCreateOneOrder(t *testing.T) {
+	dsn := startTestDb(t)
+	db, err := gorm.Open(pg.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = closeGormDb(db)
+	})
+	require.NoError(t, db.AutoMigrate(&orderrepo.OrderDTO{}, &zonerepo.ZoneDTO{},
+		&inboxrepo.ProcessedMessageDTO{}, &auditrepo.EntryDTO{}))
+
+	location, err := kernel.NewLocation(1, 1)
+	require.NoError(t, err)
+	basketID := uuid.New()
+	ctx := audit.WithCommand(context.Background(), "CreateOrder")
+
+	// every consumer instance has its own unit of work and receives its own copy of the message
+	const consumers = 4
+	results := make([]error, consumers)
+	var wg sync.WaitGroup
+	for i := 0; i < consumers; i++ {
+		uow, err := NewUnitOfWork(db, ddd.NewMediatr())
+		require.NoError(t, err)
+		geoClient := mocks.NewGeoServiceClient(t)
+		geoClient.EXPECT().GetLocation(ctx, "street").Return(location, nil).Maybe()
+		clock := mocks.NewClock(t)
+		clock.EXPECT().Now().Return(time.Now()).Maybe()
+		handler, err := commands.NewAddCreateOrderHandler(uow, geoClient, clock)
+		require.NoError(t, err)
+		command, err := commands.NewCreateOrderCommand(basketID, "basket.confirmed/0/42", "street", 1, order.Standard, nil)
+		require.NoError(t, err)
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = handler.Handle(ctx, command)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range results {
+		assert.NoError(t, err, "a duplicate must be acknowledged")
+	}
+	var orders int64
+	require.NoError(t, db.Model(&orderrepo.OrderDTO{}).Where("id = ?", basketID).Count(&orders).Error)
+	assert.Equal(t, int64(1), orders)
+	var entries []auditrepo.EntryDTO
+	require.NoError(t, db.Find(&entries, "aggregate_id = ?", basketID).Error)
+	require.Len(t, entries, 1, "the rolled back copies must not leave audit entries")
+	assert.Equal(t, "CreateOrder", entries[0].Command)
+	assert.Equal(t, []string{order.CreatedEventName}, []string(entries[0].Events))
+}
@@ -0,0 +1,47 @@
+package viewrepo
+
+import (
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/google/uuid"
+)
+
+// CourierViewDTO is a row of the courier read model, it holds the load the couriers table does not.
+// Every sort has an index on the sorted column and the id the pages are cut by.
+type CourierViewDTO struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;index:idx_courier_view_name,priority:2;index:idx_courier_view_orders_count,priority:2;index:idx_courier_view_declined_offers,priority:2"`
+	Name           string     `gorm:"index:idx_courier_view_name,priority:1;index:idx_courier_view_name_prefix,expression:lower(name) text_pattern_ops"`
+	LocationX      int        `gorm:"index:idx_courier_view_location,priority:1"`
+	LocationY      int        `gorm:"index:idx_courier_view_location,priority:2"`
+	DeclinedOffers int        `gorm:"not null;default:0;index:idx_courier_view_declined_offers,priority:1"`
+	HomeZoneID     *uuid.UUID `gorm:"type:uuid"`
+	OrdersCount    int        `gorm:"not null;default:0;index:idx_courier_view_orders_count,priority:1"`
+	UsedVolume     int        `gorm:"not null;default:0"`
+	Capacity       int        `gorm:"not null;default:0"`
+	ProjectedAt    time.Time
+}
+
+// OrderViewDTO is a row of the order read model, it carries the name of the courier holding the order
+type OrderViewDTO struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey;index:idx_order_view_created_at,priority:2;index:idx_order_view_volume,priority:2"`
+	LocationX   int            `gorm:"index:idx_order_view_location,priority:1"`
+	LocationY   int            `gorm:"index:idx_order_view_location,priority:2"`
+	Volume      int            `gorm:"index:idx_order_view_volume,priority:1"`
+	Status      order.Status   `gorm:"type:varchar(20);index"`
+	Priority    order.Priority `gorm:"type:varchar(20)"`
+	CreatedAt   time.Time      `gorm:"index:idx_order_view_created_at,priority:1"`
+	Eta         *time.Time
+	ZoneID      *uuid.UUID `gorm:"type:uuid"`
+	CourierID   *uuid.UUID `gorm:"type:uuid;index"`
+	CourierName *string    `gorm:"index:idx_order_view_courier_name_prefix,expression:lower(courier_name) text_pattern_ops"`
+	ProjectedAt time.Time
+}
+
+func (CourierViewDTO) TableName() string {
+	return "courier_view"
+}
+
+func (OrderViewDTO) TableName() string {
+	return "order_view"
+}
@@ -0,0 +1,33 @@
+package viewrepo
+
+import "github.com/delivery/internal/core/ports"
+
+func DtoToCourierView(dto CourierViewDTO) ports.CourierView {
+	return ports.CourierView{
+		ID:             dto.ID,
+		Name:           dto.Name,
+		LocationX:      dto.LocationX,
+		LocationY:      dto.LocationY,
+		DeclinedOffers: dto.DeclinedOffers,
+		HomeZoneID:     dto.HomeZoneID,
+		OrdersCount:    dto.OrdersCount,
+		UsedVolume:     dto.UsedVolume,
+		Capacity:       dto.Capacity,
+	}
+}
+
+func DtoToOrderView(dto OrderViewDTO) ports.OrderView {
+	return ports.OrderView{
+		ID:          dto.ID,
+		LocationX:   dto.LocationX,
+		LocationY:   dto.LocationY,
+		Volume:      dto.Volume,
+		Status:      dto.Status,
+		Priority:    dto.Priority,
+		CreatedAt:   dto.CreatedAt,
+		Eta:         dto.Eta,
+		ZoneID:      dto.ZoneID,
+		CourierID:   dto.CourierID,
+		CourierName: dto.CourierName,
+	}
+}
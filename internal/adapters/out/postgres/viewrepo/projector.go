@@ -0,0 +1,123 @@
+package viewrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ ports.ReadModelProjector = &Projector{}
+
+// upsertCouriers derives the courier rows from the couriers, the orders they hold and their storage places
+const upsertCouriers = `
+INSERT INTO courier_view (id, name, location_x, location_y, declined_offers, home_zone_id,
+	orders_count, used_volume, capacity, projected_at)
+SELECT c.id, c.name, c.location_x, c.location_y, c.declined_offers, c.home_zone_id,
+	COALESCE(held.orders_count, 0), COALESCE(held.used_volume, 0), COALESCE(places.capacity, 0), now()
+FROM couriers c
+LEFT JOIN (
+	SELECT courier_id, count(*) AS orders_count, sum(volume) AS used_volume
+	FROM orders WHERE status IN ? GROUP BY courier_id
+) held ON held.courier_id = c.id
+LEFT JOIN (
+	SELECT courier_id, sum(total_volume) AS capacity FROM storage_places GROUP BY courier_id
+) places ON places.courier_id = c.id
+%s
+ON CONFLICT (id) DO UPDATE SET
+	name = EXCLUDED.name,
+	location_x = EXCLUDED.location_x,
+	location_y = EXCLUDED.location_y,
+	declined_offers = EXCLUDED.declined_offers,
+	home_zone_id = EXCLUDED.home_zone_id,
+	orders_count = EXCLUDED.orders_count,
+	used_volume = EXCLUDED.used_volume,
+	capacity = EXCLUDED.capacity,
+	projected_at = EXCLUDED.projected_at`
+
+// upsertOrders derives the order rows from the orders and the couriers holding them
+const upsertOrders = `
+INSERT INTO order_view (id, location_x, location_y, volume, status, priority, created_at, eta,
+	zone_id, courier_id, courier_name, projected_at)
+SELECT o.id, o.location_x, o.location_y, o.volume, o.status, o.priority, o.created_at, o.eta,
+	o.zone_id, o.courier_id, c.name, now()
+FROM orders o
+LEFT JOIN couriers c ON c.id = o.courier_id
+%s
+ON CONFLICT (id) DO UPDATE SET
+	location_x = EXCLUDED.location_x,
+	location_y = EXCLUDED.location_y,
+	volume = EXCLUDED.volume,
+	status = EXCLUDED.status,
+	priority = EXCLUDED.priority,
+	created_at = EXCLUDED.created_at,
+	eta = EXCLUDED.eta,
+	zone_id = EXCLUDED.zone_id,
+	courier_id = EXCLUDED.courier_id,
+	courier_name = EXCLUDED.courier_name,
+	projected_at = EXCLUDED.projected_at`
+
+// Projector rewrites the view rows of the changed aggregates from the write tables, in the
+// transaction that changed them, so the views never show a change that was rolled back
+type Projector struct {
+	uow ports.UnitOfWork
+}
+
+func NewProjector(uow ports.UnitOfWork) (*Projector, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &Projector{
+		uow: uow,
+	}, nil
+}
+
+func (p *Projector) ProjectCourier(ctx context.Context, courierID uuid.UUID) error {
+	tx := p.getTxOrDb().WithContext(ctx)
+	if err := tx.Exec(fmt.Sprintf(upsertCouriers, "WHERE c.id = ?"), order.WithCourierStatuses, courierID).Error; err != nil {
+		return errs.NewDatabaseError("project", "courier view", err)
+	}
+	// the orders show the name of the courier holding them and the eta it moves them to
+	if err := tx.Exec(fmt.Sprintf(upsertOrders, "WHERE o.courier_id = ?"), courierID).Error; err != nil {
+		return errs.NewDatabaseError("project", "order view", err)
+	}
+	return nil
+}
+
+func (p *Projector) ProjectOrder(ctx context.Context, orderID uuid.UUID) error {
+	tx := p.getTxOrDb().WithContext(ctx)
+	if err := tx.Exec(fmt.Sprintf(upsertOrders, "WHERE o.id = ?"), orderID).Error; err != nil {
+		return errs.NewDatabaseError("project", "order view", err)
+	}
+	return nil
+}
+
+// Rebuild empties the views and fills them again, a caller outside of a transaction
+// would let the queries see them empty
+func (p *Projector) Rebuild(ctx context.Context) error {
+	tx := p.getTxOrDb().WithContext(ctx)
+	if err := tx.Exec("DELETE FROM order_view").Error; err != nil {
+		return errs.NewDatabaseError("clear", "order view", err)
+	}
+	if err := tx.Exec("DELETE FROM courier_view").Error; err != nil {
+		return errs.NewDatabaseError("clear", "courier view", err)
+	}
+	if err := tx.Exec(fmt.Sprintf(upsertCouriers, "WHERE true"), order.WithCourierStatuses).Error; err != nil {
+		return errs.NewDatabaseError("rebuild", "courier view", err)
+	}
+	if err := tx.Exec(fmt.Sprintf(upsertOrders, "WHERE true")).Error; err != nil {
+		return errs.NewDatabaseError("rebuild", "order view", err)
+	}
+	return nil
+}
+
+func (p *Projector) getTxOrDb() *gorm.DB {
+	if tx := p.uow.Tx(); tx != nil {
+		return tx
+	}
+	return p.uow.Db()
+}
@@ -0,0 +1,187 @@
+package viewrepo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"gorm.io/gorm"
+)
+
+var _ ports.ReadModelRepository = &Repository{}
+
+var courierSortColumns = map[ports.CourierSort]string{
+	ports.CourierSortName:           "name",
+	ports.CourierSortOrdersCount:    "orders_count",
+	ports.CourierSortDeclinedOffers: "declined_offers",
+}
+
+var orderSortColumns = map[ports.OrderSort]string{
+	ports.OrderSortCreatedAt: "created_at",
+	ports.OrderSortVolume:    "volume",
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Repository reads the views outside of any transaction, they show what was committed
+type Repository struct {
+	uow ports.UnitOfWork
+}
+
+func NewRepository(uow ports.UnitOfWork) (*Repository, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &Repository{
+		uow: uow,
+	}, nil
+}
+
+func (r *Repository) ListCouriers(ctx context.Context, filter ports.CourierFilter, sort ports.CourierSort,
+	page ports.Page) ([]ports.CourierView, int64, error) {
+	column, ok := courierSortColumns[sort]
+	if !ok {
+		return nil, 0, errs.NewValidationErrorWithValue("sort", sort, "couriers can't be sorted by it")
+	}
+	var after any
+	if page.After != nil {
+		value, err := courierCursorValue(sort, page.After.Value)
+		if err != nil {
+			return nil, 0, errs.NewValidationErrorWithCause("cursor", "cursor does not match the sort", err)
+		}
+		after = value
+	}
+
+	var total int64
+	result := r.uow.Db().WithContext(ctx).Model(&CourierViewDTO{}).
+		Scopes(couriersMatching(filter)).
+		Count(&total)
+	if result.Error != nil {
+		return nil, 0, errs.NewDatabaseError("count", "courier view", result.Error)
+	}
+
+	var dtos []CourierViewDTO
+	result = r.uow.Db().WithContext(ctx).
+		Scopes(couriersMatching(filter), keyset(column, page, after)).
+		Find(&dtos)
+	if result.Error != nil {
+		return nil, 0, errs.NewDatabaseError("get", "courier view", result.Error)
+	}
+
+	views := make([]ports.CourierView, 0, len(dtos))
+	for _, dto := range dtos {
+		views = append(views, DtoToCourierView(dto))
+	}
+	return views, total, nil
+}
+
+func (r *Repository) ListUncompletedOrders(ctx context.Context, filter ports.OrderFilter, sort ports.OrderSort,
+	page ports.Page) ([]ports.OrderView, int64, error) {
+	column, ok := orderSortColumns[sort]
+	if !ok {
+		return nil, 0, errs.NewValidationErrorWithValue("sort", sort, "orders can't be sorted by it")
+	}
+	var after any
+	if page.After != nil {
+		value, err := orderCursorValue(sort, page.After.Value)
+		if err != nil {
+			return nil, 0, errs.NewValidationErrorWithCause("cursor", "cursor does not match the sort", err)
+		}
+		after = value
+	}
+
+	var total int64
+	result := r.uow.Db().WithContext(ctx).Model(&OrderViewDTO{}).
+		Scopes(ordersMatching(filter)).
+		Count(&total)
+	if result.Error != nil {
+		return nil, 0, errs.NewDatabaseError("count", "order view", result.Error)
+	}
+
+	var dtos []OrderViewDTO
+	result = r.uow.Db().WithContext(ctx).
+		Scopes(ordersMatching(filter), keyset(column, page, after)).
+		Find(&dtos)
+	if result.Error != nil {
+		return nil, 0, errs.NewDatabaseError("get", "order view", result.Error)
+	}
+
+	views := make([]ports.OrderView, 0, len(dtos))
+	for _, dto := range dtos {
+		views = append(views, DtoToOrderView(dto))
+	}
+	return views, total, nil
+}
+
+func couriersMatching(filter ports.CourierFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter.NamePrefix != "" {
+			db = db.Where("lower(name) LIKE ?", likePrefix(filter.NamePrefix))
+		}
+		return db.Scopes(within(filter.Area))
+	}
+}
+
+func ordersMatching(filter ports.OrderFilter) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		db = db.Where("status IN ?", append([]order.Status{order.Created}, order.WithCourierStatuses...))
+		if len(filter.Statuses) > 0 {
+			db = db.Where("status IN ?", filter.Statuses)
+		}
+		if filter.CourierNamePrefix != "" {
+			db = db.Where("lower(courier_name) LIKE ?", likePrefix(filter.CourierNamePrefix))
+		}
+		return db.Scopes(within(filter.Area))
+	}
+}
+
+// within keeps the rows located in the area, both corners included
+func within(area *kernel.Rectangle) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if area == nil {
+			return db
+		}
+		return db.Where("location_x BETWEEN ? AND ? AND location_y BETWEEN ? AND ?",
+			area.From().X(), area.To().X(), area.From().Y(), area.To().Y())
+	}
+}
+
+// keyset orders the rows by the column and the id and starts after the cursor, so a page costs the
+// same however deep in the list it is and rows changing meanwhile neither repeat nor go missing
+func keyset(column string, page ports.Page, after any) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		direction, compare := "ASC", ">"
+		if page.Desc {
+			direction, compare = "DESC", "<"
+		}
+		if page.After != nil {
+			db = db.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, compare), after, page.After.ID)
+		}
+		return db.Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).Limit(page.Limit)
+	}
+}
+
+// likePrefix matches the values starting with the prefix, the wildcards in it are taken literally
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(strings.ToLower(prefix)) + "%"
+}
+
+func courierCursorValue(sort ports.CourierSort, value string) (any, error) {
+	if sort == ports.CourierSortName {
+		return value, nil
+	}
+	return strconv.Atoi(value)
+}
+
+func orderCursorValue(sort ports.OrderSort, value string) (any, error) {
+	if sort == ports.OrderSortVolume {
+		return strconv.Atoi(value)
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
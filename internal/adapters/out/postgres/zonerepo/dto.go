@@ -0,0 +1,22 @@
+package zonerepo
+
+import (
+	"github.com/google/uuid"
+)
+
+type ZoneDTO struct {
+	ID      uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name    string
+	From    LocationDTO `gorm:"embedded;embeddedPrefix:from_"`
+	To      LocationDTO `gorm:"embedded;embeddedPrefix:to_"`
+	Version int         `gorm:"not null;default:0"`
+}
+
+type LocationDTO struct {
+	X int
+	Y int
+}
+
+func (ZoneDTO) TableName() string {
+	return "zones"
+}
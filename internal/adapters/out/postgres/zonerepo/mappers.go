@@ -0,0 +1,24 @@
+package zonerepo
+
+import (
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/zone"
+)
+
+func DomainToDto(zone *zone.Zone) ZoneDTO {
+	area := zone.Area()
+	return ZoneDTO{
+		ID:      zone.ID(),
+		Name:    zone.Name(),
+		From:    LocationDTO{X: area.From().X(), Y: area.From().Y()},
+		To:      LocationDTO{X: area.To().X(), Y: area.To().Y()},
+		Version: zone.Version(),
+	}
+}
+
+func DtoToDomain(dto ZoneDTO) *zone.Zone {
+	from, _ := kernel.NewLocation(dto.From.X, dto.From.Y)
+	to, _ := kernel.NewLocation(dto.To.X, dto.To.Y)
+	area, _ := kernel.NewRectangle(from, to)
+	return zone.RestoreZone(dto.ID, dto.Name, area, dto.Version)
+}
@@ -0,0 +1,100 @@
+package zonerepo
+
+import (
+	"context"
+
+	"github.com/delivery/internal/adapters/out/postgres/auditrepo"
+	"github.com/delivery/internal/core/domain/model/zone"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var _ ports.ZoneRepository = &Repository{}
+
+type Repository struct {
+	uow ports.UnitOfWork
+}
+
+func NewRepository(uow ports.UnitOfWork) (*Repository, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &Repository{
+		uow: uow,
+	}, nil
+}
+
+func (r *Repository) Add(ctx context.Context, zone *zone.Zone) error {
+	r.uow.Track(zone)
+
+	dto := DomainToDto(zone)
+
+	// check if we inside other tx
+	isInTx := r.uow.InTx()
+	if !isInTx {
+		// if not, create own tx
+		r.uow.Begin(ctx)
+	}
+	tx := r.uow.Tx()
+
+	if err := tx.WithContext(ctx).Create(&dto).Error; err != nil {
+		return errs.NewDatabaseError("create", "zone", err)
+	}
+
+	if err := auditrepo.Record(ctx, tx, auditrepo.Change{
+		AggregateType: auditrepo.ZoneAggregate,
+		AggregateID:   zone.ID(),
+		Version:       zone.Version(),
+		Events:        zone.GetDomainEvents(),
+		After:         dto,
+	}); err != nil {
+		return err
+	}
+
+	// if inside other tx we not fix this one
+	if !isInTx {
+		if err := r.uow.Commit(ctx); err != nil {
+			return errs.NewDatabaseError("commit", "transaction", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Repository) Get(ctx context.Context, zoneID uuid.UUID) (*zone.Zone, error) {
+	dto := ZoneDTO{}
+
+	result := r.getTxOrDb().WithContext(ctx).Find(&dto, zoneID)
+	if result.Error != nil {
+		return nil, errs.NewDatabaseError("get", "zone", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, errs.NewNotFoundError("zone", zoneID.String())
+	}
+
+	return DtoToDomain(dto), nil
+}
+
+func (r *Repository) GetAll(ctx context.Context) ([]*zone.Zone, error) {
+	var dtos []ZoneDTO
+
+	result := r.getTxOrDb().WithContext(ctx).Order("name, id").Find(&dtos)
+	if result.Error != nil {
+		return nil, errs.NewDatabaseError("get", "zones", result.Error)
+	}
+
+	aggregates := make([]*zone.Zone, len(dtos))
+	for i, dto := range dtos {
+		aggregates[i] = DtoToDomain(dto)
+	}
+	return aggregates, nil
+}
+
+func (r *Repository) getTxOrDb() *gorm.DB {
+	if tx := r.uow.Tx(); tx != nil {
+		return tx
+	}
+	return r.uow.Db()
+}
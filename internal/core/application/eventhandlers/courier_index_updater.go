@@ -0,0 +1,35 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type courierIndexUpdater struct {
+	index *service.CourierIndex
+}
+
+// NewCourierIndexUpdater puts the created couriers in the index and moves them in it as they move,
+// once the change is committed
+func NewCourierIndexUpdater(index *service.CourierIndex) (ddd.EventHandler, error) {
+	if index == nil {
+		return nil, errs.NewValueIsRequiredError("courier index")
+	}
+	return &courierIndexUpdater{
+		index: index,
+	}, nil
+}
+
+func (h *courierIndexUpdater) Handle(_ context.Context, event ddd.DomainEvent) error {
+	switch e := event.(type) {
+	case *courier.CreatedDomainEvent:
+		h.index.Put(e.GetAggregateID(), e.Location, e.Speed)
+	case *courier.MovedDomainEvent:
+		h.index.Move(e.GetAggregateID(), e.To)
+	}
+	return nil
+}
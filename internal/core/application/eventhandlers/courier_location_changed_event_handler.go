@@ -0,0 +1,37 @@
+package eventhandlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type courierLocationChangedEventHandler struct {
+	courierProducer ports.CourierProducer
+	enqueueTimeout  time.Duration
+}
+
+func NewCourierLocationChangedEventHandler(courierProducer ports.CourierProducer, enqueueTimeout time.Duration) (ddd.EventHandler, error) {
+	if courierProducer == nil {
+		return nil, errs.NewValueIsRequiredError("courier producer")
+	}
+	if enqueueTimeout <= 0 {
+		return nil, errs.NewValueIsRequiredError("enqueue timeout")
+	}
+	return &courierLocationChangedEventHandler{
+		courierProducer: courierProducer,
+		enqueueTimeout:  enqueueTimeout,
+	}, nil
+}
+
+func (h *courierLocationChangedEventHandler) Handle(ctx context.Context, event ddd.DomainEvent) error {
+	return h.courierProducer.Publish(ctx, event)
+}
+
+// Options let Publish wait for room, a dropped location update never reaches Kafka
+func (h *courierLocationChangedEventHandler) Options() ddd.HandlerOptions {
+	return ddd.HandlerOptions{EnqueueTimeout: h.enqueueTimeout}
+}
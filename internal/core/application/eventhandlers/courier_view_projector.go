@@ -0,0 +1,62 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type courierViewProjector struct {
+	projector ports.ReadModelProjector
+}
+
+// NewCourierViewProjector refreshes the courier view in the transaction that changed the courier,
+// its declined offers or the orders it holds
+func NewCourierViewProjector(projector ports.ReadModelProjector) (ddd.EventHandler, error) {
+	if projector == nil {
+		return nil, errs.NewValueIsRequiredError("read model projector")
+	}
+	return &courierViewProjector{
+		projector: projector,
+	}, nil
+}
+
+func (h *courierViewProjector) Handle(ctx context.Context, event ddd.DomainEvent) error {
+	courierID, ok := courierOf(event)
+	if !ok {
+		return nil
+	}
+	return h.projector.ProjectCourier(ctx, courierID)
+}
+
+func (h *courierViewProjector) Options() ddd.HandlerOptions {
+	return ddd.HandlerOptions{Phase: ddd.BeforeCommit}
+}
+
+// courierOf tells which courier the event changed the view of
+func courierOf(event ddd.DomainEvent) (uuid.UUID, bool) {
+	switch e := event.(type) {
+	case *courier.CreatedDomainEvent, *courier.MovedDomainEvent, *courier.HomeZoneAssignedDomainEvent,
+		*courier.StoragePlaceAddedDomainEvent:
+		return e.GetAggregateID(), true
+	case *offer.DeclinedDomainEvent:
+		return e.CourierID, true
+	case *order.AssignedDomainEvent:
+		return e.CourierID, true
+	case *order.AcceptedDomainEvent:
+		return e.CourierID, true
+	case *order.PickedUpDomainEvent:
+		return e.CourierID, true
+	case *order.UnassignedDomainEvent:
+		return e.CourierID, true
+	case *order.CompletedDomainEvent:
+		return e.CourierID, true
+	}
+	return uuid.Nil, false
+}
@@ -0,0 +1,37 @@
+package eventhandlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type orderAssignedEventHandler struct {
+	orderProducer  ports.OrderProducer
+	enqueueTimeout time.Duration
+}
+
+func NewOrderAssignedEventHandler(orderProducer ports.OrderProducer, enqueueTimeout time.Duration) (ddd.EventHandler, error) {
+	if orderProducer == nil {
+		return nil, errs.NewValueIsRequiredError("order producer")
+	}
+	if enqueueTimeout <= 0 {
+		return nil, errs.NewValueIsRequiredError("enqueue timeout")
+	}
+	return &orderAssignedEventHandler{
+		orderProducer:  orderProducer,
+		enqueueTimeout: enqueueTimeout,
+	}, nil
+}
+
+func (h *orderAssignedEventHandler) Handle(ctx context.Context, event ddd.DomainEvent) error {
+	return h.orderProducer.Publish(ctx, event)
+}
+
+// Options make Publish wait for room in a full queue, a dropped event never reaches Kafka
+func (h *orderAssignedEventHandler) Options() ddd.HandlerOptions {
+	return ddd.HandlerOptions{EnqueueTimeout: h.enqueueTimeout}
+}
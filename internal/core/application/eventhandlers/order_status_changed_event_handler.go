@@ -2,6 +2,7 @@ package eventhandlers
 
 import (
 	"context"
+	"time"
 
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/ddd"
@@ -9,15 +10,20 @@ import (
 )
 
 type orderStatusChangedEventHandler struct {
-	orderProducer ports.OrderProducer
+	orderProducer  ports.OrderProducer
+	enqueueTimeout time.Duration
 }
 
-func NewOrderStatusChangedEventHandler(orderProducer ports.OrderProducer) (ddd.EventHandler, error) {
+func NewOrderStatusChangedEventHandler(orderProducer ports.OrderProducer, enqueueTimeout time.Duration) (ddd.EventHandler, error) {
 	if orderProducer == nil {
 		return nil, errs.NewValueIsRequiredError("order producer")
 	}
+	if enqueueTimeout <= 0 {
+		return nil, errs.NewValueIsRequiredError("enqueue timeout")
+	}
 	return &orderStatusChangedEventHandler{
-		orderProducer: orderProducer,
+		orderProducer:  orderProducer,
+		enqueueTimeout: enqueueTimeout,
 	}, nil
 }
 
@@ -28,3 +34,8 @@ func (h *orderStatusChangedEventHandler) Handle(ctx context.Context, event ddd.D
 
 	return nil
 }
+
+// Options let Publish wait for room, the basket service follows the status topic
+func (h *orderStatusChangedEventHandler) Options() ddd.HandlerOptions {
+	return ddd.HandlerOptions{EnqueueTimeout: h.enqueueTimeout}
+}
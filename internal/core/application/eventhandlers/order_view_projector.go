@@ -0,0 +1,31 @@
+package eventhandlers
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type orderViewProjector struct {
+	projector ports.ReadModelProjector
+}
+
+// NewOrderViewProjector refreshes the order view in the transaction that changed the order
+func NewOrderViewProjector(projector ports.ReadModelProjector) (ddd.EventHandler, error) {
+	if projector == nil {
+		return nil, errs.NewValueIsRequiredError("read model projector")
+	}
+	return &orderViewProjector{
+		projector: projector,
+	}, nil
+}
+
+func (h *orderViewProjector) Handle(ctx context.Context, event ddd.DomainEvent) error {
+	return h.projector.ProjectOrder(ctx, event.GetAggregateID())
+}
+
+func (h *orderViewProjector) Options() ddd.HandlerOptions {
+	return ddd.HandlerOptions{Phase: ddd.BeforeCommit}
+}
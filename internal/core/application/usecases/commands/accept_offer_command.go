@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type AcceptOfferCommand struct {
+	courierID uuid.UUID
+	orderID   uuid.UUID
+	now       time.Time
+
+	isValid bool
+}
+
+func NewAcceptOfferCommand(courierID uuid.UUID, orderID uuid.UUID, now time.Time) (*AcceptOfferCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &AcceptOfferCommand{
+		courierID: courierID,
+		orderID:   orderID,
+		now:       now,
+		isValid:   true,
+	}, nil
+}
+
+func (c *AcceptOfferCommand) CourierID() uuid.UUID {
+	return c.courierID
+}
+
+func (c *AcceptOfferCommand) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+func (c *AcceptOfferCommand) Now() time.Time {
+	return c.now
+}
+
+func (c *AcceptOfferCommand) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type AcceptOfferHandler interface {
+	Handle(ctx context.Context, command *AcceptOfferCommand) error
+}
+
+type acceptOfferHandler struct {
+	uow ports.UnitOfWork
+}
+
+func NewAcceptOfferHandler(uow ports.UnitOfWork) (AcceptOfferHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+
+	return &acceptOfferHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *acceptOfferHandler) Handle(ctx context.Context, command *AcceptOfferCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "accept offer command is invalid")
+	}
+
+	o, _, err := getCourierOrder(ctx, h.uow, command.CourierID(), command.OrderID())
+	if err != nil {
+		return err
+	}
+	pending, err := getPendingOffer(ctx, h.uow, "accept offer", command.CourierID(), o.ID())
+	if err != nil {
+		return err
+	}
+	if err := pending.Accept(command.Now()); err != nil {
+		return err
+	}
+	if err := o.Accept(command.CourierID()); err != nil {
+		return err
+	}
+
+	h.uow.Begin(ctx)
+	if err := h.uow.OfferRepository().Update(ctx, pending); err != nil {
+		return errs.NewDatabaseError("update", "offer", err)
+	}
+	if err := h.uow.OrderRepository().Update(ctx, o); err != nil {
+		return errs.NewDatabaseError("update", "order", err)
+	}
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
+
+// getCourierOrder loads an order the courier holds together with the courier. The order of
+// another courier is reported as not found, so a courier app learns nothing about it.
+func getCourierOrder(ctx context.Context, uow ports.UnitOfWork, courierID uuid.UUID,
+	orderID uuid.UUID) (*order.Order, *courier.Courier, error) {
+	o, err := uow.OrderRepository().Get(ctx, orderID)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return nil, nil, errs.NewNotFoundError("order", orderID.String())
+		}
+		return nil, nil, errs.NewDatabaseError("get", "order", err)
+	}
+	if o.CourierID() == nil || *o.CourierID() != courierID {
+		return nil, nil, errs.NewNotFoundError("order", orderID.String())
+	}
+
+	c, err := uow.CourierRepository().Get(ctx, courierID)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return nil, nil, errs.NewNotFoundError("courier", courierID.String())
+		}
+		return nil, nil, errs.NewDatabaseError("get", "courier", err)
+	}
+	return o, c, nil
+}
+
+// getPendingOffer loads the offer of the order waiting for the courier's answer
+func getPendingOffer(ctx context.Context, uow ports.UnitOfWork, operation string, courierID uuid.UUID,
+	orderID uuid.UUID) (*offer.Offer, error) {
+	pending, err := uow.OfferRepository().GetPending(ctx, orderID)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return nil, errs.NewBusinessError(operation, "the order has no offer waiting for an answer")
+		}
+		return nil, errs.NewDatabaseError("get", "offer", err)
+	}
+	if pending.CourierID() != courierID {
+		return nil, errs.NewBusinessError(operation, "the offer was made to another courier")
+	}
+	return pending, nil
+}
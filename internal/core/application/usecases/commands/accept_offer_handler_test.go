@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AcceptOfferHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	offeredAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		otherCourier bool
+		now          time.Time
+		wantErr      bool
+		err          error
+	}{
+		"courier accepts the offer": {
+			now: offeredAt.Add(time.Minute),
+		},
+		"offer has expired": {
+			now:     offeredAt.Add(offer.DefaultTimeout + time.Second),
+			wantErr: true,
+			err:     errs.ErrBusiness,
+		},
+		"order of another courier is not found": {
+			otherCourier: true,
+			now:          offeredAt,
+			wantErr:      true,
+			err:          errs.ErrNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			location, err := kernel.NewLocation(1, 1)
+			require.NoError(t, err)
+			c, err := courier.NewCourier("courier", 1, location)
+			require.NoError(t, err)
+			o, err := order.NewOrder(uuid.New(), location, 1, order.Standard, offeredAt)
+			require.NoError(t, err)
+			courierID := c.ID()
+			require.NoError(t, o.Assign(&courierID, offeredAt))
+			pending, err := offer.NewOffer(uuid.New(), o.ID(), courierID, offeredAt, offer.DefaultTimeout)
+			require.NoError(t, err)
+			caller := courierID
+			if tc.otherCourier {
+				caller = uuid.New()
+			}
+
+			uow := mocks.NewUnitOfWork(t)
+			orderRepo := mocks.NewOrderRepository(t)
+			uow.EXPECT().OrderRepository().Return(orderRepo)
+			orderRepo.EXPECT().Get(ctx, o.ID()).Return(o, nil)
+			if !tc.otherCourier {
+				courierRepo := mocks.NewCourierRepository(t)
+				uow.EXPECT().CourierRepository().Return(courierRepo)
+				courierRepo.EXPECT().Get(ctx, courierID).Return(c, nil)
+				offerRepo := mocks.NewOfferRepository(t)
+				uow.EXPECT().OfferRepository().Return(offerRepo)
+				offerRepo.EXPECT().GetPending(ctx, o.ID()).Return(pending, nil)
+				if !tc.wantErr {
+					offerRepo.EXPECT().Update(ctx, pending).Return(nil)
+				}
+			}
+			if !tc.wantErr {
+				uow.EXPECT().Begin(ctx)
+				orderRepo.EXPECT().Update(ctx, o).Return(nil)
+				uow.EXPECT().Commit(ctx).Return(nil)
+			}
+
+			handler, err := NewAcceptOfferHandler(uow)
+			require.NoError(t, err)
+			command, err := NewAcceptOfferCommand(caller, o.ID(), tc.now)
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, order.Assigned, o.Status())
+				assert.Equal(t, offer.Pending, pending.Status())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, order.Accepted, o.Status())
+				assert.Equal(t, offer.Accepted, pending.Status())
+			}
+		})
+	}
+}
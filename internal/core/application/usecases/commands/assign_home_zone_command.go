@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type AssignHomeZoneCommand struct {
+	courierID uuid.UUID
+	zoneID    uuid.UUID
+
+	isValid bool
+}
+
+func NewAssignHomeZoneCommand(courierID uuid.UUID, zoneID uuid.UUID) (*AssignHomeZoneCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+	if zoneID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("zone id")
+	}
+
+	return &AssignHomeZoneCommand{
+		courierID: courierID,
+		zoneID:    zoneID,
+		isValid:   true,
+	}, nil
+}
+
+func (c *AssignHomeZoneCommand) CourierID() uuid.UUID {
+	return c.courierID
+}
+
+func (c *AssignHomeZoneCommand) ZoneID() uuid.UUID {
+	return c.zoneID
+}
+
+func (c *AssignHomeZoneCommand) IsValid() bool {
+	return c.isValid
+}
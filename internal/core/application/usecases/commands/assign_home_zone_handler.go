@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type AssignHomeZoneHandler interface {
+	Handle(ctx context.Context, command *AssignHomeZoneCommand) error
+}
+
+type assignHomeZoneHandler struct {
+	uow ports.UnitOfWork
+}
+
+func NewAssignHomeZoneHandler(uow ports.UnitOfWork) (AssignHomeZoneHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+
+	return &assignHomeZoneHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *assignHomeZoneHandler) Handle(ctx context.Context, command *AssignHomeZoneCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "assign home zone command is invalid")
+	}
+
+	z, err := h.uow.ZoneRepository().Get(ctx, command.ZoneID())
+	if err != nil {
+		return err
+	}
+	c, err := h.uow.CourierRepository().Get(ctx, command.CourierID())
+	if err != nil {
+		return err
+	}
+	if err = c.AssignHomeZone(z.ID()); err != nil {
+		return err
+	}
+
+	h.uow.Begin(ctx)
+	if err = h.uow.CourierRepository().Update(ctx, c); err != nil {
+		return errs.NewDatabaseError("update", "courier", err)
+	}
+	if err = h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
@@ -1,15 +1,32 @@
 package commands
 
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
 type AssignOrderCommand struct {
+	now time.Time
+
 	isValid bool
 }
 
-func NewAssignOrderCommand() (*AssignOrderCommand, error) {
+func NewAssignOrderCommand(now time.Time) (*AssignOrderCommand, error) {
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
 	return &AssignOrderCommand{
+		now:     now,
 		isValid: true,
 	}, nil
 }
 
+func (c *AssignOrderCommand) Now() time.Time {
+	return c.now
+}
+
 func (c *AssignOrderCommand) IsValid() bool {
 	return c.isValid
 }
@@ -2,10 +2,16 @@ package commands
 
 import (
 	"context"
+	"sort"
+	"time"
 
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
 	"github.com/delivery/internal/core/domain/service"
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
 )
 
 type AssignOrderHandler interface {
@@ -15,9 +21,19 @@ type AssignOrderHandler interface {
 type assignOrderHandler struct {
 	uow        ports.UnitOfWork
 	dispatcher service.DispatchService
+	eta        service.EtaService
+	// locator narrows a standard order down to the limit free couriers nearest to it, nil offers every courier
+	locator ports.CourierLocator
+	limit   int
 }
 
-func NewAssignOrderHandler(uow ports.UnitOfWork, dispatcher service.DispatchService) (AssignOrderHandler, error) {
+func NewAssignOrderHandler(uow ports.UnitOfWork, dispatcher service.DispatchService,
+	eta service.EtaService) (AssignOrderHandler, error) {
+	return newAssignOrderHandler(uow, dispatcher, eta)
+}
+
+func newAssignOrderHandler(uow ports.UnitOfWork, dispatcher service.DispatchService,
+	eta service.EtaService) (*assignOrderHandler, error) {
 	if uow == nil {
 		return nil, errs.NewValueIsRequiredError("unit of work")
 	}
@@ -26,12 +42,37 @@ func NewAssignOrderHandler(uow ports.UnitOfWork, dispatcher service.DispatchServ
 		return nil, errs.NewValueIsRequiredError("dispatcher service")
 	}
 
+	if eta == nil {
+		return nil, errs.NewValueIsRequiredError("eta service")
+	}
+
 	return &assignOrderHandler{
 		uow:        uow,
 		dispatcher: dispatcher,
+		eta:        eta,
 	}, nil
 }
 
+// NewNearestFirstAssignOrderHandler offers a standard order to the limit free couriers the locator finds
+// nearest to it first; only when none of them can take the order are all the free couriers loaded
+func NewNearestFirstAssignOrderHandler(uow ports.UnitOfWork, dispatcher service.DispatchService,
+	eta service.EtaService, locator ports.CourierLocator, limit int) (AssignOrderHandler, error) {
+	if locator == nil {
+		return nil, errs.NewValueIsRequiredError("courier locator")
+	}
+	if limit <= 0 {
+		return nil, errs.NewValidationErrorWithValue("limit", limit, "must be greater than zero")
+	}
+
+	handler, err := newAssignOrderHandler(uow, dispatcher, eta)
+	if err != nil {
+		return nil, err
+	}
+	handler.locator = locator
+	handler.limit = limit
+	return handler, nil
+}
+
 func (h *assignOrderHandler) Handle(ctx context.Context, command *AssignOrderCommand) error {
 	if !command.IsValid() {
 		return errs.NewValidationError("command", "assign order command is invalid")
@@ -45,23 +86,39 @@ func (h *assignOrderHandler) Handle(ctx context.Context, command *AssignOrderCom
 		return errs.NewDatabaseError("get", "order", err)
 	}
 
-	couriers, err := h.uow.CourierRepository().GetAllAvailable(ctx)
+	courier, newOffer, err := h.dispatchNearest(ctx, createdOrder, command.Now())
 	if err != nil {
-		return errs.NewDatabaseError("get", "available couriers", err)
+		return err
 	}
-	if len(couriers) == 0 {
-		return errs.NewBusinessError("assign order", "no available couriers found")
+	if courier == nil {
+		couriers, err := h.candidates(ctx, createdOrder)
+		if err != nil {
+			return err
+		}
+		if len(couriers) == 0 {
+			return errs.NewBusinessError("assign order", "no available couriers found")
+		}
+
+		courier, newOffer, err = h.dispatch(ctx, createdOrder, couriers, command.Now())
+		if err != nil {
+			return errs.NewBusinessErrorWithCause("dispatch order", "failed to assign order to courier", err)
+		}
 	}
 
-	courier, err := h.dispatcher.Dispatch(createdOrder, couriers)
+	route, err := planRoute(ctx, h.uow, h.eta, courier, createdOrder, command.Now())
 	if err != nil {
-		return errs.NewBusinessErrorWithCause("dispatch order", "failed to assign order to courier", err)
+		return err
 	}
 
 	h.uow.Begin(ctx)
 
-	if err := h.uow.OrderRepository().Update(ctx, createdOrder); err != nil {
-		return errs.NewDatabaseError("update", "order", err)
+	if err := h.uow.OfferRepository().Add(ctx, newOffer); err != nil {
+		return errs.NewDatabaseError("create", "offer", err)
+	}
+	for _, o := range route {
+		if err := h.uow.OrderRepository().Update(ctx, o); err != nil {
+			return errs.NewDatabaseError("update", "order", err)
+		}
 	}
 	if err := h.uow.CourierRepository().Update(ctx, courier); err != nil {
 		return errs.NewDatabaseError("update", "courier", err)
@@ -73,3 +130,115 @@ func (h *assignOrderHandler) Handle(ctx context.Context, command *AssignOrderCom
 
 	return nil
 }
+
+// dispatchNearest offers a standard order to the best of the nearest free couriers that were not offered
+// it yet. It returns no courier when there is no locator or none of them can take the order.
+func (h *assignOrderHandler) dispatchNearest(ctx context.Context, o *order.Order,
+	now time.Time) (*courier.Courier, *offer.Offer, error) {
+	if h.locator == nil || o.Priority() == order.Express {
+		return nil, nil, nil
+	}
+
+	nearest, err := h.locator.GetNearestAvailable(ctx, o.Location(), h.limit)
+	if err != nil {
+		return nil, nil, errs.NewDatabaseError("get", "nearest couriers", err)
+	}
+	offers, err := h.uow.OfferRepository().GetAllByOrder(ctx, o.ID())
+	if err != nil {
+		return nil, nil, errs.NewDatabaseError("get", "offers", err)
+	}
+	untried := withoutOffered(nearest, offers)
+	if len(untried) == 0 {
+		return nil, nil, nil
+	}
+
+	c, newOffer, err := h.dispatcher.Dispatch(o, untried, now)
+	if errs.HasCause(err, service.ErrCourierNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, errs.NewBusinessErrorWithCause("dispatch order", "failed to assign order to courier", err)
+	}
+	return c, newOffer, nil
+}
+
+// dispatch offers the order to the best courier that was not offered it yet. Once every courier
+// that could take the order declined it or let the offer expire, the offers start over.
+func (h *assignOrderHandler) dispatch(ctx context.Context, o *order.Order, couriers []*courier.Courier,
+	now time.Time) (*courier.Courier, *offer.Offer, error) {
+	offers, err := h.uow.OfferRepository().GetAllByOrder(ctx, o.ID())
+	if err != nil {
+		return nil, nil, errs.NewDatabaseError("get", "offers", err)
+	}
+	untried := withoutOffered(couriers, offers)
+	if len(untried) > 0 && len(untried) < len(couriers) {
+		c, newOffer, err := h.dispatcher.Dispatch(o, untried, now)
+		if !errs.HasCause(err, service.ErrCourierNotFound) {
+			return c, newOffer, err
+		}
+	}
+	return h.dispatcher.Dispatch(o, couriers, now)
+}
+
+// withoutOffered drops the couriers that were offered the order already
+func withoutOffered(couriers []*courier.Courier, offers []*offer.Offer) []*courier.Courier {
+	offered := make(map[uuid.UUID]bool, len(offers))
+	for _, o := range offers {
+		offered[o.CourierID()] = true
+	}
+	untried := make([]*courier.Courier, 0, len(couriers))
+	for _, c := range couriers {
+		if !offered[c.ID()] {
+			untried = append(untried, c)
+		}
+	}
+	return untried
+}
+
+// candidates returns the couriers the order can go to: free ones, or for an express order
+// also the busy ones with room left, whose planned route the order then preempts
+func (h *assignOrderHandler) candidates(ctx context.Context, o *order.Order) ([]*courier.Courier, error) {
+	if o.Priority() != order.Express {
+		couriers, err := h.uow.CourierRepository().GetAllAvailable(ctx)
+		if err != nil {
+			return nil, errs.NewDatabaseError("get", "available couriers", err)
+		}
+		return couriers, nil
+	}
+
+	couriers, err := h.uow.CourierRepository().GetAll(ctx)
+	if err != nil {
+		return nil, errs.NewDatabaseError("get", "couriers", err)
+	}
+	return couriers, nil
+}
+
+// planRoute puts the order on the courier's route and estimates every stop of it.
+// An express order preempts the standard stops of the route, so their etas move too.
+func planRoute(ctx context.Context, uow ports.UnitOfWork, eta service.EtaService, courier *courier.Courier,
+	o *order.Order, now time.Time) ([]*order.Order, error) {
+	planned, err := courierRoute(ctx, uow, courier.ID())
+	if err != nil {
+		return nil, err
+	}
+	route := []*order.Order{o}
+	for _, stop := range planned {
+		if stop.ID() != o.ID() {
+			route = append(route, stop)
+		}
+	}
+	sort.SliceStable(route, func(i, j int) bool {
+		return route[i].DeliverBefore(route[j])
+	})
+
+	etas, err := eta.Estimate(courier, route, now)
+	if err != nil {
+		return nil, errs.NewBusinessErrorWithCause("estimate arrival", "failed to estimate order eta", err)
+	}
+	for _, stop := range route {
+		if err := stop.UpdateEta(etas[stop.ID()]); err != nil {
+			return nil, err
+		}
+	}
+	return route, nil
+}
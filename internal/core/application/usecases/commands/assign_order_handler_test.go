@@ -0,0 +1,291 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AssignOrderHandler_Handle_Priority(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mustCreateLocation := func(x, y int) kernel.Location {
+		loc, err := kernel.NewLocation(x, y)
+		if err != nil {
+			panic(err)
+		}
+		return loc
+	}
+
+	tests := map[string]struct {
+		priority order.Priority
+		// the courier is already on the way to a standard order when the new one comes
+		busy        bool
+		wantBefore  bool
+		wantCourier bool
+	}{
+		"standard order goes to a free courier": {
+			priority:    order.Standard,
+			wantCourier: true,
+		},
+		"standard order waits for a busy courier": {
+			priority:    order.Standard,
+			busy:        true,
+			wantCourier: false,
+		},
+		"express order preempts the route of a busy courier": {
+			priority:    order.Express,
+			busy:        true,
+			wantBefore:  true,
+			wantCourier: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, err := courier.NewCourier("courier", 1, mustCreateLocation(1, 1))
+			require.NoError(t, err)
+			require.NoError(t, c.AddStoragePlace("bag", 10))
+			require.NoError(t, c.AddStoragePlace("trunk", 10))
+			courierID := c.ID()
+
+			planned, err := order.NewOrder(uuid.New(), mustCreateLocation(10, 10), 1, order.Standard, now.Add(-time.Minute))
+			require.NoError(t, err)
+			var route []*order.Order
+			if tc.busy {
+				require.NoError(t, planned.Assign(&courierID, now))
+				require.NoError(t, c.TakeOrder(planned))
+				route = append(route, planned)
+			}
+			created, err := order.NewOrder(uuid.New(), mustCreateLocation(1, 2), 1, tc.priority, now)
+			require.NoError(t, err)
+
+			uow := mocks.NewUnitOfWork(t)
+			orderRepo := mocks.NewOrderRepository(t)
+			courierRepo := mocks.NewCourierRepository(t)
+			uow.EXPECT().OrderRepository().Return(orderRepo)
+			uow.EXPECT().CourierRepository().Return(courierRepo)
+			orderRepo.EXPECT().GetFirstInStatusCreate(ctx).Return(created, nil)
+			switch {
+			case tc.priority == order.Express:
+				courierRepo.EXPECT().GetAll(ctx).Return([]*courier.Courier{c}, nil)
+			case tc.busy:
+				courierRepo.EXPECT().GetAllAvailable(ctx).Return(nil, nil)
+			default:
+				courierRepo.EXPECT().GetAllAvailable(ctx).Return([]*courier.Courier{c}, nil)
+			}
+			if tc.wantCourier {
+				offerRepo := mocks.NewOfferRepository(t)
+				uow.EXPECT().OfferRepository().Return(offerRepo)
+				offerRepo.EXPECT().GetAllByOrder(ctx, created.ID()).Return(nil, nil)
+				offerRepo.EXPECT().Add(ctx, mock.Anything).Return(nil)
+				orderRepo.EXPECT().GetAllWithCourier(ctx).Return(route, nil)
+				uow.EXPECT().Begin(ctx)
+				orderRepo.EXPECT().Update(ctx, mock.Anything).Return(nil)
+				courierRepo.EXPECT().Update(ctx, c).Return(nil)
+				uow.EXPECT().Commit(ctx).Return(nil)
+			}
+
+			eta, err := service.NewEtaService(time.Second)
+			require.NoError(t, err)
+			handler, err := NewAssignOrderHandler(uow, service.NewDispatchService(), eta)
+			require.NoError(t, err)
+			command, err := NewAssignOrderCommand(now)
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			if !tc.wantCourier {
+				assert.Error(t, err)
+				assert.Equal(t, order.Created, created.Status())
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, &courierID, created.CourierID())
+			require.NotNil(t, created.Eta())
+			if tc.busy {
+				require.NotNil(t, planned.Eta())
+				assert.Equal(t, tc.wantBefore, created.Eta().Before(*planned.Eta()))
+			}
+		})
+	}
+}
+
+func Test_AssignOrderHandler_Handle_NextCandidate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mustCreateCourier := func(x, y int) *courier.Courier {
+		location, err := kernel.NewLocation(x, y)
+		require.NoError(t, err)
+		c, err := courier.NewCourier("courier", 1, location)
+		require.NoError(t, err)
+		require.NoError(t, c.AddStoragePlace("bag", 10))
+		return c
+	}
+
+	tests := map[string]struct {
+		// offered lists the couriers that declined the order or let the offer expire, by index
+		offered     []int
+		wantCourier int
+	}{
+		"nearest courier gets the first offer": {
+			wantCourier: 0,
+		},
+		"next courier gets the order the nearest one declined": {
+			offered:     []int{0},
+			wantCourier: 1,
+		},
+		"offers start over once every courier was tried": {
+			offered:     []int{0, 1},
+			wantCourier: 0,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			couriers := []*courier.Courier{mustCreateCourier(1, 1), mustCreateCourier(9, 9)}
+			location, err := kernel.NewLocation(2, 2)
+			require.NoError(t, err)
+			created, err := order.NewOrder(uuid.New(), location, 1, order.Standard, now)
+			require.NoError(t, err)
+			var offers []*offer.Offer
+			for _, i := range tc.offered {
+				declined, err := offer.NewOffer(uuid.New(), created.ID(), couriers[i].ID(), now.Add(-time.Minute), offer.DefaultTimeout)
+				require.NoError(t, err)
+				require.NoError(t, declined.Decline("too far away"))
+				offers = append(offers, declined)
+			}
+
+			uow := mocks.NewUnitOfWork(t)
+			orderRepo := mocks.NewOrderRepository(t)
+			courierRepo := mocks.NewCourierRepository(t)
+			offerRepo := mocks.NewOfferRepository(t)
+			uow.EXPECT().OrderRepository().Return(orderRepo)
+			uow.EXPECT().CourierRepository().Return(courierRepo)
+			uow.EXPECT().OfferRepository().Return(offerRepo)
+			orderRepo.EXPECT().GetFirstInStatusCreate(ctx).Return(created, nil)
+			courierRepo.EXPECT().GetAllAvailable(ctx).Return(couriers, nil)
+			offerRepo.EXPECT().GetAllByOrder(ctx, created.ID()).Return(offers, nil)
+			var made *offer.Offer
+			offerRepo.EXPECT().Add(ctx, mock.Anything).RunAndReturn(func(_ context.Context, o *offer.Offer) error {
+				made = o
+				return nil
+			})
+			orderRepo.EXPECT().GetAllWithCourier(ctx).Return(nil, nil)
+			uow.EXPECT().Begin(ctx)
+			orderRepo.EXPECT().Update(ctx, created).Return(nil)
+			courierRepo.EXPECT().Update(ctx, couriers[tc.wantCourier]).Return(nil)
+			uow.EXPECT().Commit(ctx).Return(nil)
+
+			eta, err := service.NewEtaService(time.Second)
+			require.NoError(t, err)
+			handler, err := NewAssignOrderHandler(uow, service.NewDispatchService(), eta)
+			require.NoError(t, err)
+			command, err := NewAssignOrderCommand(now)
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			assert.NoError(t, err)
+			wantID := couriers[tc.wantCourier].ID()
+			assert.Equal(t, &wantID, created.CourierID())
+			require.NotNil(t, made)
+			assert.Equal(t, wantID, made.CourierID())
+			assert.Equal(t, now.Add(offer.DefaultTimeout), made.ExpiresAt())
+		})
+	}
+}
+
+func Test_AssignOrderHandler_Handle_NearestFirst(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mustCreateCourier := func(x, y int) *courier.Courier {
+		location, err := kernel.NewLocation(x, y)
+		require.NoError(t, err)
+		c, err := courier.NewCourier("courier", 1, location)
+		require.NoError(t, err)
+		require.NoError(t, c.AddStoragePlace("bag", 10))
+		return c
+	}
+
+	tests := map[string]struct {
+		// nearestOffered means the nearest courier was offered the order already
+		nearestOffered bool
+		// loadsAll means every free courier is loaded after the nearest ones
+		loadsAll    bool
+		wantCourier int
+	}{
+		"nearest courier takes the order without loading the fleet": {
+			wantCourier: 0,
+		},
+		"every free courier is loaded once the nearest ones were offered the order": {
+			nearestOffered: true,
+			loadsAll:       true,
+			wantCourier:    1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			couriers := []*courier.Courier{mustCreateCourier(1, 1), mustCreateCourier(9, 9)}
+			location, err := kernel.NewLocation(2, 2)
+			require.NoError(t, err)
+			created, err := order.NewOrder(uuid.New(), location, 1, order.Standard, now)
+			require.NoError(t, err)
+			var offers []*offer.Offer
+			if tc.nearestOffered {
+				expired, err := offer.NewOffer(uuid.New(), created.ID(), couriers[0].ID(), now.Add(-time.Minute), offer.DefaultTimeout)
+				require.NoError(t, err)
+				offers = append(offers, expired)
+			}
+
+			uow := mocks.NewUnitOfWork(t)
+			orderRepo := mocks.NewOrderRepository(t)
+			courierRepo := mocks.NewCourierRepository(t)
+			offerRepo := mocks.NewOfferRepository(t)
+			locator := mocks.NewCourierLocator(t)
+			uow.EXPECT().OrderRepository().Return(orderRepo)
+			uow.EXPECT().CourierRepository().Return(courierRepo)
+			uow.EXPECT().OfferRepository().Return(offerRepo)
+			orderRepo.EXPECT().GetFirstInStatusCreate(ctx).Return(created, nil)
+			locator.EXPECT().GetNearestAvailable(ctx, location, 1).Return(couriers[:1], nil)
+			if tc.loadsAll {
+				courierRepo.EXPECT().GetAllAvailable(ctx).Return(couriers, nil)
+			}
+			offerRepo.EXPECT().GetAllByOrder(ctx, created.ID()).Return(offers, nil)
+			offerRepo.EXPECT().Add(ctx, mock.Anything).Return(nil)
+			orderRepo.EXPECT().GetAllWithCourier(ctx).Return(nil, nil)
+			uow.EXPECT().Begin(ctx)
+			orderRepo.EXPECT().Update(ctx, created).Return(nil)
+			courierRepo.EXPECT().Update(ctx, couriers[tc.wantCourier]).Return(nil)
+			uow.EXPECT().Commit(ctx).Return(nil)
+
+			eta, err := service.NewEtaService(time.Second)
+			require.NoError(t, err)
+			handler, err := NewNearestFirstAssignOrderHandler(uow, service.NewDispatchService(), eta, locator, 1)
+			require.NoError(t, err)
+			command, err := NewAssignOrderCommand(now)
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			assert.NoError(t, err)
+			wantID := couriers[tc.wantCourier].ID()
+			assert.Equal(t, &wantID, created.CourierID())
+		})
+	}
+}
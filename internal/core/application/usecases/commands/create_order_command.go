@@ -1,41 +1,78 @@
 package commands
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/delivery/internal/core/domain/model/order"
 	"github.com/google/uuid"
 )
 
 var ErrInvalidOrderId = errors.New("order id must not be Empty")
+var ErrInvalidMessageId = errors.New("message id must not be empty")
 var ErrInvalidStreet = errors.New("street must not be empty")
 var ErrInvalidVolume = errors.New("volume must be greater than zero")
+var ErrInvalidPriority = errors.New("priority must be standard or express")
+var ErrMessageReused = errors.New("message id was used for another request")
 
 type CreateOrderCommand struct {
 	orderID uuid.UUID
-	street  string
-	volume  int
+	// messageID identifies the request or the message the order comes from, a repeated one is a no-op
+	messageID string
+	street    string
+	volume    int
+	priority  order.Priority
+	// deliveryWindow is nil when the customer did not ask for a time
+	deliveryWindow *order.DeliveryWindow
+	// requestHash fingerprints what the order is made of, a message id repeated with another one is a conflict
+	requestHash string
 
 	isValid bool
 }
 
-func NewCreateOrderCommand(orderID uuid.UUID, street string, volume int) (*CreateOrderCommand, error) {
+func NewCreateOrderCommand(orderID uuid.UUID, messageID string, street string, volume int,
+	priority order.Priority, deliveryWindow *order.DeliveryWindow) (*CreateOrderCommand, error) {
 	if orderID == uuid.Nil {
 		return nil, ErrInvalidOrderId
 	}
+	if messageID == "" {
+		return nil, ErrInvalidMessageId
+	}
 	if street == "" {
 		return nil, ErrInvalidStreet
 	}
 	if volume <= 0 {
 		return nil, ErrInvalidVolume
 	}
+	if priority != order.Standard && priority != order.Express {
+		return nil, ErrInvalidPriority
+	}
 	return &CreateOrderCommand{
-		orderID: orderID,
-		street:  street,
-		volume:  volume,
-		isValid: true,
+		orderID:        orderID,
+		messageID:      messageID,
+		street:         street,
+		volume:         volume,
+		priority:       priority,
+		deliveryWindow: deliveryWindow,
+		requestHash:    hashRequest(orderID, street, volume, priority, deliveryWindow),
+		isValid:        true,
 	}, nil
 }
 
+func hashRequest(orderID uuid.UUID, street string, volume int, priority order.Priority,
+	deliveryWindow *order.DeliveryWindow) string {
+	request := fmt.Sprintf("%s\n%q\n%d\n%s", orderID, street, volume, priority)
+	if deliveryWindow != nil {
+		request += fmt.Sprintf("\n%s\n%s", deliveryWindow.From().UTC().Format(time.RFC3339Nano),
+			deliveryWindow.To().UTC().Format(time.RFC3339Nano))
+	}
+	sum := sha256.Sum256([]byte(request))
+	return hex.EncodeToString(sum[:])
+}
+
 func (c *CreateOrderCommand) IsValid() bool {
 	return c.isValid
 }
@@ -44,6 +81,10 @@ func (c *CreateOrderCommand) OrderID() uuid.UUID {
 	return c.orderID
 }
 
+func (c *CreateOrderCommand) MessageID() string {
+	return c.messageID
+}
+
 func (c *CreateOrderCommand) Street() string {
 	return c.street
 }
@@ -51,3 +92,15 @@ func (c *CreateOrderCommand) Street() string {
 func (c *CreateOrderCommand) Volume() int {
 	return c.volume
 }
+
+func (c *CreateOrderCommand) Priority() order.Priority {
+	return c.priority
+}
+
+func (c *CreateOrderCommand) DeliveryWindow() *order.DeliveryWindow {
+	return c.deliveryWindow
+}
+
+func (c *CreateOrderCommand) RequestHash() string {
+	return c.requestHash
+}
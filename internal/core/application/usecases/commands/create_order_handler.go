@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/model/zone"
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/errs"
 )
@@ -15,48 +16,120 @@ type CreateOrderHandler interface {
 type addCreateOrderHandler struct {
 	uow       ports.UnitOfWork
 	geoClient ports.GeoServiceClient
+	clock     ports.Clock
 }
 
-func NewAddCreateOrderHandler(uow ports.UnitOfWork, geoClient ports.GeoServiceClient) (CreateOrderHandler, error) {
+func NewAddCreateOrderHandler(uow ports.UnitOfWork, geoClient ports.GeoServiceClient,
+	clock ports.Clock) (CreateOrderHandler, error) {
 	if uow == nil {
 		return nil, errs.NewValueIsRequiredError("unit of work")
 	}
 	if geoClient == nil {
 		return nil, errs.NewValueIsRequiredError("geo service client")
 	}
+	if clock == nil {
+		return nil, errs.NewValueIsRequiredError("clock")
+	}
 
 	return &addCreateOrderHandler{
 		uow:       uow,
 		geoClient: geoClient,
+		clock:     clock,
 	}, nil
 }
 
+// Handle creates the order once per message and basket: a message that was processed before,
+// or loses the race to a concurrent copy of itself, is acknowledged without changes. A message id
+// processed before with another request is a conflict.
 func (h *addCreateOrderHandler) Handle(ctx context.Context, command *CreateOrderCommand) error {
 	if !command.IsValid() {
 		return errs.NewValidationError("command", "create order command is invalid")
 	}
 
-	orderAgg, err := h.uow.OrderRepository().Get(ctx, command.OrderID())
-	if err != nil && !errs.IsNotFound(err) {
-		return errs.NewDatabaseError("get", "order", err)
+	processed, err := h.uow.InboxRepository().Processed(ctx, command.MessageID(), command.OrderID())
+	if err != nil {
+		return errs.NewDatabaseError("get", "processed message", err)
 	}
-	if orderAgg != nil {
-		return errs.NewConflictError("order", command.OrderID().String(), "order already exists")
+	if processed {
+		return h.checkSameRequest(ctx, command)
 	}
 
 	location, err := h.geoClient.GetLocation(ctx, command.Street())
 	if err != nil {
-		return errs.NewBusinessErrorWithCause("get location", "failed to get location from geo service", err)
+		return errs.NewUnavailableError("geo service", "get location", err)
 	}
-	newOrder, err := order.NewOrder(command.OrderID(), location, command.Volume())
+	now := h.clock.Now()
+	newOrder, err := order.NewOrder(command.OrderID(), location, command.Volume(), command.Priority(), now)
 	if err != nil {
 		return errs.NewBusinessErrorWithCause("create order", "failed to create order domain object", err)
 	}
+	if window := command.DeliveryWindow(); window != nil {
+		if err = newOrder.ScheduleDelivery(*window); err != nil {
+			return errs.NewBusinessErrorWithCause("create order", "failed to schedule delivery", err)
+		}
+	}
+	if err = h.tagZone(ctx, newOrder); err != nil {
+		return err
+	}
 
-	err = h.uow.OrderRepository().Add(ctx, newOrder)
+	h.uow.Begin(ctx)
+	err = h.uow.InboxRepository().Add(ctx, ports.ProcessedMessage{
+		MessageID:   command.MessageID(),
+		BasketID:    command.OrderID(),
+		RequestHash: command.RequestHash(),
+		ProcessedAt: now,
+	})
 	if err != nil {
+		h.uow.Rollback(ctx)
+		if errs.IsConflict(err) {
+			return h.checkSameRequest(ctx, command)
+		}
+		return err
+	}
+
+	if err = h.uow.OrderRepository().Add(ctx, newOrder); err != nil {
+		h.uow.Rollback(ctx)
 		return errs.NewDatabaseError("add", "order", err)
 	}
 
+	if err = h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+
+	return nil
+}
+
+// checkSameRequest acknowledges a repeated message unless its id was processed with another request,
+// e.g. a client reusing an idempotency key for another order. A basket processed under another message id
+// and a message processed before the requests were hashed are acknowledged.
+func (h *addCreateOrderHandler) checkSameRequest(ctx context.Context, command *CreateOrderCommand) error {
+	message, err := h.uow.InboxRepository().Get(ctx, command.MessageID())
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return nil
+		}
+		return errs.NewDatabaseError("get", "processed message", err)
+	}
+	if message.RequestHash != "" && message.RequestHash != command.RequestHash() {
+		return errs.NewConflictErrorWithCause("processed message", command.MessageID(),
+			"the message id was used for another request", ErrMessageReused)
+	}
 	return nil
 }
+
+// tagZone tags the order with the service zone of its location, without zones the whole grid is served
+func (h *addCreateOrderHandler) tagZone(ctx context.Context, newOrder *order.Order) error {
+	zones, err := h.uow.ZoneRepository().GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	if len(zones) == 0 {
+		return nil
+	}
+
+	z, err := zone.Locate(zones, newOrder.Location())
+	if err != nil {
+		return errs.NewBusinessErrorWithCause("create order", "the delivery address is outside every service zone", err)
+	}
+	return newOrder.TagZone(z.ID())
+}
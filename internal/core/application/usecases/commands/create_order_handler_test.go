@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/delivery/internal/core/domain/model/kernel"
 	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/model/zone"
 	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
 	"github.com/delivery/internal/pkg/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +19,7 @@ import (
 
 func Test_CreateOrderHandler_Handle(t *testing.T) {
 	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
 
 	mustCreateLocation := func(x, y int) kernel.Location {
 		loc, err := kernel.NewLocation(x, y)
@@ -25,112 +29,269 @@ func Test_CreateOrderHandler_Handle(t *testing.T) {
 		return loc
 	}
 
+	withZones := func(t *testing.T, uow *mocks.UnitOfWork, zones ...*zone.Zone) {
+		zoneRepo := mocks.NewZoneRepository(t)
+		uow.EXPECT().ZoneRepository().Return(zoneRepo)
+		zoneRepo.EXPECT().GetAll(ctx).Return(zones, nil)
+	}
+	noZones := func(t *testing.T, uow *mocks.UnitOfWork) {
+		withZones(t, uow)
+	}
+	area, err := kernel.NewRectangle(mustCreateLocation(1, 1), mustCreateLocation(5, 5))
+	if err != nil {
+		panic(err)
+	}
+	north, err := zone.NewZone(uuid.New(), "north", area)
+	if err != nil {
+		panic(err)
+	}
+
 	type args struct {
 		ctx     context.Context
 		command *CreateOrderCommand
 	}
 
+	newCommand := func() *CreateOrderCommand {
+		orderID := uuid.New()
+		cmd, _ := NewCreateOrderCommand(orderID, "message-1", "street", 1, order.Standard, nil)
+		return cmd
+	}
+	window, err := order.NewDeliveryWindow(now.Add(time.Hour), now.Add(2*time.Hour))
+	if err != nil {
+		panic(err)
+	}
+
 	tests := map[string]struct {
-		args    args
-		wantErr bool
-		deps    func(t *testing.T) (ports.UnitOfWork, ports.GeoServiceClient)
+		args      args
+		wantErr   bool
+		wantErrIs error
+		// wantKind is the errs predicate the error answers to
+		wantKind func(err error) bool
+		deps     func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient)
 	}{
 		"create success": {
-			args: args{
-				ctx: ctx,
-				command: func() *CreateOrderCommand {
-					orderID := uuid.New()
-					cmd, _ := NewCreateOrderCommand(orderID, "street", 1)
-					return cmd
-				}(),
-			},
+			args:    args{ctx: ctx, command: newCommand()},
 			wantErr: false,
-			deps: func(t *testing.T) (ports.UnitOfWork, ports.GeoServiceClient) {
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
 				uow := mocks.NewUnitOfWork(t)
 				orderRepo := mocks.NewOrderRepository(t)
+				inboxRepo := mocks.NewInboxRepository(t)
 				geoClient := mocks.NewGeoServiceClient(t)
 
 				uow.EXPECT().OrderRepository().Return(orderRepo)
-
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(false, nil)
+				geoClient.EXPECT().GetLocation(ctx, "street").Return(mustCreateLocation(1, 1), nil)
+				noZones(t, uow)
+				uow.EXPECT().Begin(ctx)
+				inboxRepo.EXPECT().Add(ctx, ports.ProcessedMessage{
+					MessageID:   "message-1",
+					BasketID:    command.OrderID(),
+					RequestHash: command.RequestHash(),
+					ProcessedAt: now,
+				}).Return(nil)
 				orderRepo.EXPECT().
-					Get(ctx, mock.MatchedBy(func(id uuid.UUID) bool {
-						return id != uuid.Nil
+					Add(ctx, mock.MatchedBy(func(o *order.Order) bool {
+						return o != nil && o.ID() == command.OrderID()
 					})).
-					Return(nil, nil)
+					Return(nil)
+				uow.EXPECT().Commit(ctx).Return(nil)
 
-				geoClient.EXPECT().GetLocation(ctx, "street").Return(
-					mustCreateLocation(1, 1),
-					nil,
-				)
+				return uow, geoClient
+			},
+		},
+		"order with a delivery window": {
+			args: args{ctx: ctx, command: func() *CreateOrderCommand {
+				cmd, _ := NewCreateOrderCommand(uuid.New(), "message-1", "street", 1, order.Express, &window)
+				return cmd
+			}()},
+			wantErr: false,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
+				uow := mocks.NewUnitOfWork(t)
+				orderRepo := mocks.NewOrderRepository(t)
+				inboxRepo := mocks.NewInboxRepository(t)
+				geoClient := mocks.NewGeoServiceClient(t)
 
+				uow.EXPECT().OrderRepository().Return(orderRepo)
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(false, nil)
+				geoClient.EXPECT().GetLocation(ctx, "street").Return(mustCreateLocation(1, 1), nil)
+				noZones(t, uow)
+				uow.EXPECT().Begin(ctx)
+				inboxRepo.EXPECT().Add(ctx, mock.Anything).Return(nil)
 				orderRepo.EXPECT().
 					Add(ctx, mock.MatchedBy(func(o *order.Order) bool {
-						return o != nil
+						return o.DeliveryWindow() != nil && *o.DeliveryWindow() == window
 					})).
 					Return(nil)
+				uow.EXPECT().Commit(ctx).Return(nil)
 
 				return uow, geoClient
 			},
 		},
-		"order already exists": {
-			args: args{
-				ctx: ctx,
-				command: func() *CreateOrderCommand {
-					orderID := uuid.New()
-					cmd, _ := NewCreateOrderCommand(orderID, "street", 1)
-					return cmd
-				}(),
-			},
-			wantErr: true,
-			deps: func(t *testing.T) (ports.UnitOfWork, ports.GeoServiceClient) {
+		"order tagged with its zone": {
+			args:    args{ctx: ctx, command: newCommand()},
+			wantErr: false,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
 				uow := mocks.NewUnitOfWork(t)
 				orderRepo := mocks.NewOrderRepository(t)
+				inboxRepo := mocks.NewInboxRepository(t)
 				geoClient := mocks.NewGeoServiceClient(t)
 
-				existingOrderID := uuid.New()
-				location := mustCreateLocation(1, 1)
-				existingOrder := order.RestoreOrder(existingOrderID, nil, location, 1, order.Created)
-
 				uow.EXPECT().OrderRepository().Return(orderRepo)
-
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(false, nil)
+				geoClient.EXPECT().GetLocation(ctx, "street").Return(mustCreateLocation(3, 4), nil)
+				withZones(t, uow, north)
+				uow.EXPECT().Begin(ctx)
+				inboxRepo.EXPECT().Add(ctx, mock.Anything).Return(nil)
 				orderRepo.EXPECT().
-					Get(ctx, mock.MatchedBy(func(id uuid.UUID) bool {
-						return id != uuid.Nil
+					Add(ctx, mock.MatchedBy(func(o *order.Order) bool {
+						return o.ZoneID() != nil && *o.ZoneID() == north.ID()
 					})).
-					Return(existingOrder, nil)
+					Return(nil)
+				uow.EXPECT().Commit(ctx).Return(nil)
 
 				return uow, geoClient
 			},
 		},
-		"error adding order": {
-			args: args{
-				ctx: ctx,
-				command: func() *CreateOrderCommand {
-					orderID := uuid.New()
-					cmd, _ := NewCreateOrderCommand(orderID, "street", 1)
-					return cmd
-				}(),
+		"address outside every service zone": {
+			args:      args{ctx: ctx, command: newCommand()},
+			wantErr:   true,
+			wantErrIs: zone.ErrOutsideServiceZones,
+			wantKind:  errs.IsBusiness,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
+				uow := mocks.NewUnitOfWork(t)
+				inboxRepo := mocks.NewInboxRepository(t)
+				geoClient := mocks.NewGeoServiceClient(t)
+
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(false, nil)
+				geoClient.EXPECT().GetLocation(ctx, "street").Return(mustCreateLocation(7, 7), nil)
+				withZones(t, uow, north)
+
+				return uow, geoClient
 			},
-			wantErr: true,
-			deps: func(t *testing.T) (ports.UnitOfWork, ports.GeoServiceClient) {
+		},
+		"message was processed before": {
+			args:    args{ctx: ctx, command: newCommand()},
+			wantErr: false,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
 				uow := mocks.NewUnitOfWork(t)
-				orderRepo := mocks.NewOrderRepository(t)
+				inboxRepo := mocks.NewInboxRepository(t)
 				geoClient := mocks.NewGeoServiceClient(t)
 
-				uow.EXPECT().OrderRepository().Return(orderRepo)
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(true, nil)
+				inboxRepo.EXPECT().Get(ctx, "message-1").Return(ports.ProcessedMessage{
+					MessageID:   "message-1",
+					BasketID:    command.OrderID(),
+					RequestHash: command.RequestHash(),
+				}, nil)
 
-				orderRepo.EXPECT().
-					Get(ctx, mock.Anything).
-					Return(nil, nil)
+				return uow, geoClient
+			},
+		},
+		"basket was processed under another message": {
+			args:    args{ctx: ctx, command: newCommand()},
+			wantErr: false,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
+				uow := mocks.NewUnitOfWork(t)
+				inboxRepo := mocks.NewInboxRepository(t)
+				geoClient := mocks.NewGeoServiceClient(t)
+
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(true, nil)
+				inboxRepo.EXPECT().Get(ctx, "message-1").
+					Return(ports.ProcessedMessage{}, errs.NewNotFoundError("processed message", "message-1"))
+
+				return uow, geoClient
+			},
+		},
+		"message id processed with another request": {
+			args:      args{ctx: ctx, command: newCommand()},
+			wantErr:   true,
+			wantErrIs: ErrMessageReused,
+			wantKind:  errs.IsConflict,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
+				uow := mocks.NewUnitOfWork(t)
+				inboxRepo := mocks.NewInboxRepository(t)
+				geoClient := mocks.NewGeoServiceClient(t)
 
-				geoClient.EXPECT().GetLocation(ctx, "street").Return(
-					mustCreateLocation(1, 1),
-					nil,
-				)
+				other, _ := NewCreateOrderCommand(command.OrderID(), "message-1", "street", 2, order.Standard, nil)
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(true, nil)
+				inboxRepo.EXPECT().Get(ctx, "message-1").Return(ports.ProcessedMessage{
+					MessageID:   "message-1",
+					BasketID:    command.OrderID(),
+					RequestHash: other.RequestHash(),
+				}, nil)
+
+				return uow, geoClient
+			},
+		},
+		"concurrent copy of the message wins": {
+			args:    args{ctx: ctx, command: newCommand()},
+			wantErr: false,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
+				uow := mocks.NewUnitOfWork(t)
+				inboxRepo := mocks.NewInboxRepository(t)
+				geoClient := mocks.NewGeoServiceClient(t)
 
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(false, nil)
+				geoClient.EXPECT().GetLocation(ctx, "street").Return(mustCreateLocation(1, 1), nil)
+				noZones(t, uow)
+				uow.EXPECT().Begin(ctx)
+				inboxRepo.EXPECT().Add(ctx, mock.Anything).
+					Return(errs.NewConflictError("processed message", "message-1", "already processed"))
+				uow.EXPECT().Rollback(ctx)
+				inboxRepo.EXPECT().Get(ctx, "message-1").Return(ports.ProcessedMessage{
+					MessageID:   "message-1",
+					BasketID:    command.OrderID(),
+					RequestHash: command.RequestHash(),
+				}, nil)
+
+				return uow, geoClient
+			},
+		},
+		"geo service is down": {
+			args:      args{ctx: ctx, command: newCommand()},
+			wantErr:   true,
+			wantErrIs: context.DeadlineExceeded,
+			wantKind:  errs.IsUnavailable,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
+				uow := mocks.NewUnitOfWork(t)
+				inboxRepo := mocks.NewInboxRepository(t)
+				geoClient := mocks.NewGeoServiceClient(t)
+
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(false, nil)
+				geoClient.EXPECT().GetLocation(ctx, "street").Return(kernel.Location{}, context.DeadlineExceeded)
+
+				return uow, geoClient
+			},
+		},
+		"error adding order": {
+			args:    args{ctx: ctx, command: newCommand()},
+			wantErr: true,
+			deps: func(t *testing.T, command *CreateOrderCommand) (ports.UnitOfWork, ports.GeoServiceClient) {
+				uow := mocks.NewUnitOfWork(t)
+				orderRepo := mocks.NewOrderRepository(t)
+				inboxRepo := mocks.NewInboxRepository(t)
+				geoClient := mocks.NewGeoServiceClient(t)
+
+				uow.EXPECT().OrderRepository().Return(orderRepo)
+				uow.EXPECT().InboxRepository().Return(inboxRepo)
+				inboxRepo.EXPECT().Processed(ctx, "message-1", command.OrderID()).Return(false, nil)
+				geoClient.EXPECT().GetLocation(ctx, "street").Return(mustCreateLocation(1, 1), nil)
+				noZones(t, uow)
+				uow.EXPECT().Begin(ctx)
+				inboxRepo.EXPECT().Add(ctx, mock.Anything).Return(nil)
 				orderRepo.EXPECT().
 					Add(ctx, mock.Anything).
 					Return(errors.New("database error"))
+				uow.EXPECT().Rollback(ctx)
 
 				return uow, geoClient
 			},
@@ -139,12 +300,18 @@ func Test_CreateOrderHandler_Handle(t *testing.T) {
 
 	for name, tt := range tests {
 		t.Run(name, func(t *testing.T) {
-			uow, geo := tt.deps(t)
-			handler, err := NewAddCreateOrderHandler(uow, geo)
+			uow, geo := tt.deps(t, tt.args.command)
+			clock := mocks.NewClock(t)
+			clock.EXPECT().Now().Return(now).Maybe()
+			handler, err := NewAddCreateOrderHandler(uow, geo, clock)
 			assert.NoError(t, err)
 
 			err = handler.Handle(tt.args.ctx, tt.args.command)
 
+			if tt.wantErrIs != nil {
+				assert.True(t, errs.HasCause(err, tt.wantErrIs), "got %v", err)
+				assert.True(t, tt.wantKind(err), "got %v", err)
+			}
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
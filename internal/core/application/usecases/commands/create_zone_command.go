@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type CreateZoneCommand struct {
+	zoneID uuid.UUID
+	name   string
+	area   kernel.Rectangle
+
+	isValid bool
+}
+
+func NewCreateZoneCommand(zoneID uuid.UUID, name string, area kernel.Rectangle) (*CreateZoneCommand, error) {
+	if zoneID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("zone id")
+	}
+	if name == "" {
+		return nil, errs.NewValueIsRequiredError("name")
+	}
+
+	return &CreateZoneCommand{
+		zoneID:  zoneID,
+		name:    name,
+		area:    area,
+		isValid: true,
+	}, nil
+}
+
+func (c *CreateZoneCommand) ZoneID() uuid.UUID {
+	return c.zoneID
+}
+
+func (c *CreateZoneCommand) Name() string {
+	return c.name
+}
+
+func (c *CreateZoneCommand) Area() kernel.Rectangle {
+	return c.area
+}
+
+func (c *CreateZoneCommand) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/zone"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type CreateZoneHandler interface {
+	Handle(ctx context.Context, command *CreateZoneCommand) error
+}
+
+type createZoneHandler struct {
+	uow ports.UnitOfWork
+}
+
+func NewCreateZoneHandler(uow ports.UnitOfWork) (CreateZoneHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+
+	return &createZoneHandler{
+		uow: uow,
+	}, nil
+}
+
+// Handle adds a service zone, it must not overlap another one so every location has at most one zone
+func (h *createZoneHandler) Handle(ctx context.Context, command *CreateZoneCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "create zone command is invalid")
+	}
+
+	newZone, err := zone.NewZone(command.ZoneID(), command.Name(), command.Area())
+	if err != nil {
+		return err
+	}
+
+	zones, err := h.uow.ZoneRepository().GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, z := range zones {
+		if z.Overlaps(newZone) {
+			return errs.NewBusinessError("create zone", "the zone overlaps zone "+z.Name())
+		}
+	}
+
+	if err = h.uow.ZoneRepository().Add(ctx, newZone); err != nil {
+		return errs.NewDatabaseError("add", "zone", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/zone"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateZoneHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+
+	mustCreateArea := func(fromX, fromY, toX, toY int) kernel.Rectangle {
+		from, err := kernel.NewLocation(fromX, fromY)
+		require.NoError(t, err)
+		to, err := kernel.NewLocation(toX, toY)
+		require.NoError(t, err)
+		area, err := kernel.NewRectangle(from, to)
+		require.NoError(t, err)
+		return area
+	}
+	north, err := zone.NewZone(uuid.New(), "north", mustCreateArea(1, 1, 10, 5))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		area    kernel.Rectangle
+		wantErr bool
+		err     error
+	}{
+		"zone next to another one": {
+			area: mustCreateArea(1, 6, 10, 10),
+		},
+		"zone sharing a row with another one": {
+			area:    mustCreateArea(1, 5, 10, 10),
+			wantErr: true,
+			err:     errs.ErrBusiness,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			uow := mocks.NewUnitOfWork(t)
+			zoneRepo := mocks.NewZoneRepository(t)
+			uow.EXPECT().ZoneRepository().Return(zoneRepo)
+			zoneRepo.EXPECT().GetAll(ctx).Return([]*zone.Zone{north}, nil)
+
+			command, err := NewCreateZoneCommand(uuid.New(), "south", tc.area)
+			require.NoError(t, err)
+			if !tc.wantErr {
+				zoneRepo.EXPECT().Add(ctx, mock.MatchedBy(func(z *zone.Zone) bool {
+					return z.ID() == command.ZoneID() && z.Area().Equals(tc.area)
+				})).Return(nil)
+			}
+
+			handler, err := NewCreateZoneHandler(uow)
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.ErrorContains(t, err, "north")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
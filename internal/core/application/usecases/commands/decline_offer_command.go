@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type DeclineOfferCommand struct {
+	courierID uuid.UUID
+	orderID   uuid.UUID
+	reason    string
+
+	isValid bool
+}
+
+func NewDeclineOfferCommand(courierID uuid.UUID, orderID uuid.UUID, reason string) (*DeclineOfferCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+	if reason == "" {
+		return nil, errs.NewValueIsRequiredError("reason")
+	}
+
+	return &DeclineOfferCommand{
+		courierID: courierID,
+		orderID:   orderID,
+		reason:    reason,
+		isValid:   true,
+	}, nil
+}
+
+func (c *DeclineOfferCommand) CourierID() uuid.UUID {
+	return c.courierID
+}
+
+func (c *DeclineOfferCommand) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+func (c *DeclineOfferCommand) Reason() string {
+	return c.reason
+}
+
+func (c *DeclineOfferCommand) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type DeclineOfferHandler interface {
+	Handle(ctx context.Context, command *DeclineOfferCommand) error
+}
+
+type declineOfferHandler struct {
+	uow ports.UnitOfWork
+}
+
+// NewDeclineOfferHandler returns a declined order to the dispatch queue, the assign order job
+// offers it to the next courier. The courier's declined offers are counted.
+func NewDeclineOfferHandler(uow ports.UnitOfWork) (DeclineOfferHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+
+	return &declineOfferHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *declineOfferHandler) Handle(ctx context.Context, command *DeclineOfferCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "decline offer command is invalid")
+	}
+
+	o, c, err := getCourierOrder(ctx, h.uow, command.CourierID(), command.OrderID())
+	if err != nil {
+		return err
+	}
+	pending, err := getPendingOffer(ctx, h.uow, "decline offer", command.CourierID(), o.ID())
+	if err != nil {
+		return err
+	}
+	if err := pending.Decline(command.Reason()); err != nil {
+		return err
+	}
+	if err := c.DeclineOrder(o, command.Reason()); err != nil {
+		return err
+	}
+
+	h.uow.Begin(ctx)
+	if err := h.uow.OfferRepository().Update(ctx, pending); err != nil {
+		return errs.NewDatabaseError("update", "offer", err)
+	}
+	if err := h.uow.OrderRepository().Update(ctx, o); err != nil {
+		return errs.NewDatabaseError("update", "order", err)
+	}
+	if err := h.uow.CourierRepository().Update(ctx, c); err != nil {
+		return errs.NewDatabaseError("update", "courier", err)
+	}
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type DeliverOrderCommand struct {
+	courierID uuid.UUID
+	orderID   uuid.UUID
+	proof     *order.ProofOfDelivery
+
+	isValid bool
+}
+
+// NewDeliverOrderCommand takes a nil proof when the courier hands the order over without one
+func NewDeliverOrderCommand(courierID uuid.UUID, orderID uuid.UUID, proof *order.ProofOfDelivery) (*DeliverOrderCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+
+	return &DeliverOrderCommand{
+		courierID: courierID,
+		orderID:   orderID,
+		proof:     proof,
+		isValid:   true,
+	}, nil
+}
+
+func (c *DeliverOrderCommand) CourierID() uuid.UUID {
+	return c.courierID
+}
+
+func (c *DeliverOrderCommand) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+func (c *DeliverOrderCommand) Proof() *order.ProofOfDelivery {
+	return c.proof
+}
+
+func (c *DeliverOrderCommand) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type DeliverOrderHandler interface {
+	Handle(ctx context.Context, command *DeliverOrderCommand) error
+}
+
+type deliverOrderHandler struct {
+	uow ports.UnitOfWork
+}
+
+// NewDeliverOrderHandler confirms the handover of a picked up order at the customer
+func NewDeliverOrderHandler(uow ports.UnitOfWork) (DeliverOrderHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+
+	return &deliverOrderHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *deliverOrderHandler) Handle(ctx context.Context, command *DeliverOrderCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "deliver order command is invalid")
+	}
+
+	o, c, err := getCourierOrder(ctx, h.uow, command.CourierID(), command.OrderID())
+	if err != nil {
+		return err
+	}
+	if err := c.CompleteOrder(o, command.Proof()); err != nil {
+		return err
+	}
+
+	h.uow.Begin(ctx)
+	if err := h.uow.OrderRepository().Update(ctx, o); err != nil {
+		return errs.NewDatabaseError("update", "order", err)
+	}
+	if err := h.uow.CourierRepository().Update(ctx, c); err != nil {
+		return errs.NewDatabaseError("update", "courier", err)
+	}
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeliverOrderHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	pin, err := order.NewProofOfDelivery("", "1234")
+	require.NoError(t, err)
+
+	mustCreateLocation := func(x, y int) kernel.Location {
+		location, err := kernel.NewLocation(x, y)
+		require.NoError(t, err)
+		return location
+	}
+
+	tests := map[string]struct {
+		courierAt kernel.Location
+		wantErr   bool
+		err       error
+	}{
+		"courier hands the order over at the customer": {
+			courierAt: mustCreateLocation(5, 5),
+		},
+		"courier is still on the way": {
+			courierAt: mustCreateLocation(4, 5),
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, err := courier.NewCourier("courier", 1, tc.courierAt)
+			require.NoError(t, err)
+			require.NoError(t, c.AddStoragePlace("bag", 10))
+			o, err := order.NewOrder(uuid.New(), mustCreateLocation(5, 5), 1, order.Standard, now)
+			require.NoError(t, err)
+			courierID := c.ID()
+			require.NoError(t, o.Assign(&courierID, now))
+			require.NoError(t, c.TakeOrder(o))
+			require.NoError(t, o.Accept(courierID))
+			require.NoError(t, o.PickUp(courierID))
+
+			uow := mocks.NewUnitOfWork(t)
+			orderRepo := mocks.NewOrderRepository(t)
+			courierRepo := mocks.NewCourierRepository(t)
+			uow.EXPECT().OrderRepository().Return(orderRepo)
+			uow.EXPECT().CourierRepository().Return(courierRepo)
+			orderRepo.EXPECT().Get(ctx, o.ID()).Return(o, nil)
+			courierRepo.EXPECT().Get(ctx, courierID).Return(c, nil)
+			if !tc.wantErr {
+				uow.EXPECT().Begin(ctx)
+				orderRepo.EXPECT().Update(ctx, o).Return(nil)
+				courierRepo.EXPECT().Update(ctx, c).Return(nil)
+				uow.EXPECT().Commit(ctx).Return(nil)
+			}
+
+			handler, err := NewDeliverOrderHandler(uow)
+			require.NoError(t, err)
+			command, err := NewDeliverOrderCommand(courierID, o.ID(), &pin)
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, order.PickedUp, o.Status())
+				assert.NotNil(t, c.StoragePlaces()[0].OrderID())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, order.Completed, o.Status())
+				assert.Equal(t, &pin, o.Proof())
+				assert.Nil(t, c.StoragePlaces()[0].OrderID())
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type ExpireOffersCommand struct {
+	now time.Time
+
+	isValid bool
+}
+
+func NewExpireOffersCommand(now time.Time) (*ExpireOffersCommand, error) {
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &ExpireOffersCommand{
+		now:     now,
+		isValid: true,
+	}, nil
+}
+
+func (c *ExpireOffersCommand) Now() time.Time {
+	return c.now
+}
+
+func (c *ExpireOffersCommand) IsValid() bool {
+	return c.isValid
+}
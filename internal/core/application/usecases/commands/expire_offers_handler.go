@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// OfferExpiredReason is recorded on the orders a courier did not accept in time
+const OfferExpiredReason = "courier did not accept the order in time"
+
+type ExpireOffersHandler interface {
+	Handle(ctx context.Context, command *ExpireOffersCommand) error
+}
+
+type expireOffersHandler struct {
+	uow ports.UnitOfWork
+}
+
+// NewExpireOffersHandler closes the offers couriers let lapse and returns their orders to the
+// dispatch queue, the assign order job offers them to the next courier
+func NewExpireOffersHandler(uow ports.UnitOfWork) (ExpireOffersHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+
+	return &expireOffersHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *expireOffersHandler) Handle(ctx context.Context, command *ExpireOffersCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "expire offers command is invalid")
+	}
+
+	pending, err := h.uow.OfferRepository().GetAllPending(ctx)
+	if err != nil {
+		return errs.NewDatabaseError("get", "pending offers", err)
+	}
+
+	h.uow.Begin(ctx)
+	for _, p := range pending {
+		if !p.ExpiredAt(command.Now()) {
+			continue
+		}
+		if err := p.Expire(command.Now()); err != nil {
+			return err
+		}
+		if err := h.uow.OfferRepository().Update(ctx, p); err != nil {
+			return errs.NewDatabaseError("update", "offer", err)
+		}
+		if err := h.release(ctx, p); err != nil {
+			return err
+		}
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+
+	return nil
+}
+
+// release takes the order of the expired offer away from the courier, unless it was reassigned meanwhile
+func (h *expireOffersHandler) release(ctx context.Context, expired *offer.Offer) error {
+	o, err := h.uow.OrderRepository().Get(ctx, expired.OrderID())
+	if err != nil {
+		return errs.NewDatabaseError("get", "order", err)
+	}
+	if o.Status() != order.Assigned || o.CourierID() == nil || *o.CourierID() != expired.CourierID() {
+		return nil
+	}
+
+	holder, err := h.uow.CourierRepository().Get(ctx, expired.CourierID())
+	if err != nil {
+		return errs.NewDatabaseError("get", "courier", err)
+	}
+	if err := holder.UnassignOrder(o, OfferExpiredReason); err != nil {
+		return errs.NewBusinessErrorWithCause("unassign order", "failed to take the order from the courier", err)
+	}
+
+	if err := h.uow.OrderRepository().Update(ctx, o); err != nil {
+		return errs.NewDatabaseError("update", "order", err)
+	}
+	if err := h.uow.CourierRepository().Update(ctx, holder); err != nil {
+		return errs.NewDatabaseError("update", "courier", err)
+	}
+	return nil
+}
+
+// withdrawOffer closes the offer of the order still waiting for an answer, if there is one
+func withdrawOffer(ctx context.Context, uow ports.UnitOfWork, orderID uuid.UUID, reason string) error {
+	pending, err := uow.OfferRepository().GetPending(ctx, orderID)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return nil
+		}
+		return errs.NewDatabaseError("get", "offer", err)
+	}
+	if err := pending.Withdraw(reason); err != nil {
+		return err
+	}
+	if err := uow.OfferRepository().Update(ctx, pending); err != nil {
+		return errs.NewDatabaseError("update", "offer", err)
+	}
+	return nil
+}
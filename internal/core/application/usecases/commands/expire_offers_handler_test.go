@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ExpireOffersHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	offeredAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		elapsed     time.Duration
+		wantOffer   offer.Status
+		wantOrder   order.Status
+		wantReason  string
+		wantRelease bool
+	}{
+		"courier still has time to answer": {
+			elapsed:   offer.DefaultTimeout,
+			wantOffer: offer.Pending,
+			wantOrder: order.Assigned,
+		},
+		"lapsed offer returns the order to the dispatch queue": {
+			elapsed:     offer.DefaultTimeout + time.Second,
+			wantOffer:   offer.Expired,
+			wantOrder:   order.Created,
+			wantReason:  OfferExpiredReason,
+			wantRelease: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			location, err := kernel.NewLocation(1, 1)
+			require.NoError(t, err)
+			c, err := courier.NewCourier("courier", 1, location)
+			require.NoError(t, err)
+			require.NoError(t, c.AddStoragePlace("bag", 10))
+			o, err := order.NewOrder(uuid.New(), location, 1, order.Standard, offeredAt)
+			require.NoError(t, err)
+			courierID := c.ID()
+			require.NoError(t, o.Assign(&courierID, offeredAt))
+			require.NoError(t, c.TakeOrder(o))
+			pending, err := offer.NewOffer(uuid.New(), o.ID(), courierID, offeredAt, offer.DefaultTimeout)
+			require.NoError(t, err)
+
+			uow := mocks.NewUnitOfWork(t)
+			offerRepo := mocks.NewOfferRepository(t)
+			uow.EXPECT().OfferRepository().Return(offerRepo)
+			offerRepo.EXPECT().GetAllPending(ctx).Return([]*offer.Offer{pending}, nil)
+			uow.EXPECT().Begin(ctx)
+			uow.EXPECT().Commit(ctx).Return(nil)
+			if tc.wantRelease {
+				orderRepo := mocks.NewOrderRepository(t)
+				courierRepo := mocks.NewCourierRepository(t)
+				uow.EXPECT().OrderRepository().Return(orderRepo)
+				uow.EXPECT().CourierRepository().Return(courierRepo)
+				offerRepo.EXPECT().Update(ctx, pending).Return(nil)
+				orderRepo.EXPECT().Get(ctx, o.ID()).Return(o, nil)
+				courierRepo.EXPECT().Get(ctx, courierID).Return(c, nil)
+				orderRepo.EXPECT().Update(ctx, o).Return(nil)
+				courierRepo.EXPECT().Update(ctx, c).Return(nil)
+			}
+
+			handler, err := NewExpireOffersHandler(uow)
+			require.NoError(t, err)
+			command, err := NewExpireOffersCommand(offeredAt.Add(tc.elapsed))
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantOffer, pending.Status())
+			assert.Equal(t, tc.wantOrder, o.Status())
+			assert.Equal(t, tc.wantReason, o.UnassignReason())
+			if tc.wantRelease {
+				assert.Nil(t, c.StoragePlaces()[0].OrderID())
+			}
+		})
+	}
+}
@@ -1,15 +1,32 @@
 package commands
 
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
 type MoveCourierCommand struct {
+	now time.Time
+
 	isValid bool
 }
 
-func NewMoveCourierCommand() (*MoveCourierCommand, error) {
+func NewMoveCourierCommand(now time.Time) (*MoveCourierCommand, error) {
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
 	return &MoveCourierCommand{
+		now:     now,
 		isValid: true,
 	}, nil
 }
 
+func (c *MoveCourierCommand) Now() time.Time {
+	return c.now
+}
+
 func (c *MoveCourierCommand) IsValid() bool {
 	return c.isValid
 }
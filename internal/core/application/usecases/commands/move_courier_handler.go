@@ -2,26 +2,47 @@ package commands
 
 import (
 	"context"
+	"time"
 
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/service"
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
 )
 
 type MoveCourierHandler interface {
 	Handle(ctx context.Context, command *MoveCourierCommand) error
 }
 
+// maxMoveTicks is how many runs of the move a single one may catch up on, a longer pause is not jumped over
+const maxMoveTicks = 5
+
 type moveCourierHandler struct {
 	uow ports.UnitOfWork
+	eta service.EtaService
+	// maxElapsed caps the time a single move accounts for
+	maxElapsed time.Duration
 }
 
-func NewMoveCourierHandler(uow ports.UnitOfWork) (MoveCourierHandler, error) {
+// NewMoveCourierHandler moves the couriers by the time elapsed since their previous move,
+// the tick is the time between two runs of the move
+func NewMoveCourierHandler(uow ports.UnitOfWork, eta service.EtaService, tick time.Duration) (MoveCourierHandler, error) {
 	if uow == nil {
 		return nil, errs.NewValueIsRequiredError("unit of work")
 	}
+	if eta == nil {
+		return nil, errs.NewValueIsRequiredError("eta service")
+	}
+	if tick <= 0 {
+		return nil, errs.NewValueIsRequiredError("tick")
+	}
 
 	return &moveCourierHandler{
-		uow: uow,
+		uow:        uow,
+		eta:        eta,
+		maxElapsed: maxMoveTicks * tick,
 	}, nil
 }
 
@@ -30,37 +51,33 @@ func (h *moveCourierHandler) Handle(ctx context.Context, command *MoveCourierCom
 		return errs.NewValidationError("command", "move courier command is invalid")
 	}
 
-	assignedOrders, err := h.uow.OrderRepository().GetAllInStatusAssigned(ctx)
+	assignedOrders, err := h.uow.OrderRepository().GetAllWithCourier(ctx)
 	if err != nil {
 		return errs.NewDatabaseError("get", "assigned orders", err)
 	}
 
 	h.uow.Begin(ctx)
-	for _, order := range assignedOrders {
-		courierID := order.CourierID()
-		courier, err := h.uow.CourierRepository().Get(ctx, *courierID)
+	for _, route := range groupByCourier(assignedOrders) {
+		courier, err := h.uow.CourierRepository().Get(ctx, route.courierID)
 		if err != nil {
 			return errs.NewDatabaseError("get", "courier", err)
 		}
 
-		if courier.Location().Equals(order.Location()) {
-			if err := order.Complete(); err != nil {
-				return errs.NewBusinessError("complete order", err.Error())
-			}
-			if err := courier.CompleteOrder(order); err != nil {
-				return errs.NewBusinessError("complete order", err.Error())
-			}
-		} else {
-			if err := courier.Move(order.Location()); err != nil {
-				return errs.NewBusinessError("move courier", err.Error())
-			}
+		if err := moveAlongRoute(courier, route.orders, command, h.maxElapsed); err != nil {
+			return err
+		}
+
+		if err := h.refreshEtas(courier, route.orders, command); err != nil {
+			return err
 		}
 
 		if err := h.uow.CourierRepository().Update(ctx, courier); err != nil {
 			return errs.NewDatabaseError("update", "courier", err)
 		}
-		if err := h.uow.OrderRepository().Update(ctx, order); err != nil {
-			return errs.NewDatabaseError("update", "order", err)
+		for _, order := range route.orders {
+			if err := h.uow.OrderRepository().Update(ctx, order); err != nil {
+				return errs.NewDatabaseError("update", "order", err)
+			}
 		}
 	}
 
@@ -70,3 +87,72 @@ func (h *moveCourierHandler) Handle(ctx context.Context, command *MoveCourierCom
 
 	return nil
 }
+
+// moveAlongRoute heads the courier to the first order it picked up, at the customer it waits
+// until it confirms the delivery. A courier that picked nothing up yet stays where it is.
+func moveAlongRoute(courier *courier.Courier, route []*order.Order, command *MoveCourierCommand,
+	maxElapsed time.Duration) error {
+	for _, o := range route {
+		if o.Status() != order.PickedUp {
+			continue
+		}
+		if err := courier.Move(o.Location(), command.Now(), maxElapsed); err != nil {
+			return errs.NewBusinessError("move courier", err.Error())
+		}
+		return nil
+	}
+	return nil
+}
+
+// refreshEtas estimates the orders on the route after the courier moved
+func (h *moveCourierHandler) refreshEtas(courier *courier.Courier, orders []*order.Order, command *MoveCourierCommand) error {
+	etas, err := h.eta.Estimate(courier, orders, command.Now())
+	if err != nil {
+		return errs.NewBusinessErrorWithCause("estimate arrival", "failed to estimate order eta", err)
+	}
+	for _, o := range orders {
+		if err := o.UpdateEta(etas[o.ID()]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type courierRouteOrders struct {
+	courierID uuid.UUID
+	orders    []*order.Order
+}
+
+// groupByCourier keeps the sequence of the orders, it is the route every courier follows
+func groupByCourier(orders []*order.Order) []courierRouteOrders {
+	var routes []courierRouteOrders
+	index := make(map[uuid.UUID]int)
+	for _, o := range orders {
+		courierID := o.CourierID()
+		if courierID == nil {
+			continue
+		}
+		i, ok := index[*courierID]
+		if !ok {
+			i = len(routes)
+			index[*courierID] = i
+			routes = append(routes, courierRouteOrders{courierID: *courierID})
+		}
+		routes[i].orders = append(routes[i].orders, o)
+	}
+	return routes
+}
+
+// courierRoute returns the orders the courier is already carrying, in the sequence they are delivered
+func courierRoute(ctx context.Context, uow ports.UnitOfWork, courierID uuid.UUID) ([]*order.Order, error) {
+	assignedOrders, err := uow.OrderRepository().GetAllWithCourier(ctx)
+	if err != nil {
+		return nil, errs.NewDatabaseError("get", "assigned orders", err)
+	}
+	for _, route := range groupByCourier(assignedOrders) {
+		if route.courierID == courierID {
+			return route.orders, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type PickUpOrderCommand struct {
+	courierID uuid.UUID
+	orderID   uuid.UUID
+
+	isValid bool
+}
+
+func NewPickUpOrderCommand(courierID uuid.UUID, orderID uuid.UUID) (*PickUpOrderCommand, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+
+	return &PickUpOrderCommand{
+		courierID: courierID,
+		orderID:   orderID,
+		isValid:   true,
+	}, nil
+}
+
+func (c *PickUpOrderCommand) CourierID() uuid.UUID {
+	return c.courierID
+}
+
+func (c *PickUpOrderCommand) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+func (c *PickUpOrderCommand) IsValid() bool {
+	return c.isValid
+}
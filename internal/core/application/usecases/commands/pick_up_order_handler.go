@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type PickUpOrderHandler interface {
+	Handle(ctx context.Context, command *PickUpOrderCommand) error
+}
+
+type pickUpOrderHandler struct {
+	uow ports.UnitOfWork
+}
+
+func NewPickUpOrderHandler(uow ports.UnitOfWork) (PickUpOrderHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+
+	return &pickUpOrderHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *pickUpOrderHandler) Handle(ctx context.Context, command *PickUpOrderCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "pick up order command is invalid")
+	}
+
+	o, _, err := getCourierOrder(ctx, h.uow, command.CourierID(), command.OrderID())
+	if err != nil {
+		return err
+	}
+	if err := o.PickUp(command.CourierID()); err != nil {
+		return err
+	}
+
+	h.uow.Begin(ctx)
+	if err := h.uow.OrderRepository().Update(ctx, o); err != nil {
+		return errs.NewDatabaseError("update", "order", err)
+	}
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type ReassignOrderCommand struct {
+	orderID   uuid.UUID
+	courierID uuid.UUID
+	reason    string
+	now       time.Time
+
+	isValid bool
+}
+
+func NewReassignOrderCommand(orderID uuid.UUID, courierID uuid.UUID, reason string,
+	now time.Time) (*ReassignOrderCommand, error) {
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+	if reason == "" {
+		return nil, errs.NewValueIsRequiredError("reason")
+	}
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &ReassignOrderCommand{
+		orderID:   orderID,
+		courierID: courierID,
+		reason:    reason,
+		now:       now,
+		isValid:   true,
+	}, nil
+}
+
+func (c *ReassignOrderCommand) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+// CourierID is the courier the order goes to
+func (c *ReassignOrderCommand) CourierID() uuid.UUID {
+	return c.courierID
+}
+
+func (c *ReassignOrderCommand) Reason() string {
+	return c.reason
+}
+
+func (c *ReassignOrderCommand) Now() time.Time {
+	return c.now
+}
+
+func (c *ReassignOrderCommand) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type ReassignOrderHandler interface {
+	Handle(ctx context.Context, command *ReassignOrderCommand) error
+}
+
+type reassignOrderHandler struct {
+	uow ports.UnitOfWork
+	eta service.EtaService
+	// offerTimeout is how long the next courier has to accept the order
+	offerTimeout time.Duration
+}
+
+func NewReassignOrderHandler(uow ports.UnitOfWork, eta service.EtaService,
+	offerTimeout time.Duration) (ReassignOrderHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	if eta == nil {
+		return nil, errs.NewValueIsRequiredError("eta service")
+	}
+	if offerTimeout <= 0 {
+		return nil, errs.NewValidationErrorWithValue("offer timeout", offerTimeout, "must be greater than zero")
+	}
+
+	return &reassignOrderHandler{
+		uow:          uow,
+		eta:          eta,
+		offerTimeout: offerTimeout,
+	}, nil
+}
+
+func (h *reassignOrderHandler) Handle(ctx context.Context, command *ReassignOrderCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "reassign order command is invalid")
+	}
+
+	assigned, previous, err := getAssignedOrder(ctx, h.uow, command.OrderID())
+	if err != nil {
+		return err
+	}
+	if previous.ID() == command.CourierID() {
+		return errs.NewBusinessError("reassign order", "order is already assigned to the courier")
+	}
+
+	next, err := h.uow.CourierRepository().Get(ctx, command.CourierID())
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return errs.NewNotFoundError("courier", command.CourierID().String())
+		}
+		return errs.NewDatabaseError("get", "courier", err)
+	}
+	canTake, err := next.CanTakeOrder(assigned)
+	if err != nil {
+		return err
+	}
+	if !canTake {
+		return errs.NewBusinessError("reassign order", "courier has no room for the order")
+	}
+
+	if err := previous.UnassignOrder(assigned, command.Reason()); err != nil {
+		return errs.NewBusinessErrorWithCause("reassign order", "failed to take the order from the courier", err)
+	}
+	nextID := next.ID()
+	if err := assigned.Assign(&nextID, command.Now()); err != nil {
+		return err
+	}
+	if err := next.TakeOrder(assigned); err != nil {
+		return errs.NewBusinessErrorWithCause("reassign order", "failed to give the order to the courier", err)
+	}
+	// the next courier has to accept the order like any other offer
+	nextOffer, err := offer.NewOffer(uuid.New(), assigned.ID(), nextID, command.Now(), h.offerTimeout)
+	if err != nil {
+		return err
+	}
+
+	route, err := planRoute(ctx, h.uow, h.eta, next, assigned, command.Now())
+	if err != nil {
+		return err
+	}
+
+	h.uow.Begin(ctx)
+
+	if err := withdrawOffer(ctx, h.uow, assigned.ID(), command.Reason()); err != nil {
+		return err
+	}
+	if err := h.uow.OfferRepository().Add(ctx, nextOffer); err != nil {
+		return errs.NewDatabaseError("create", "offer", err)
+	}
+	for _, o := range route {
+		if err := h.uow.OrderRepository().Update(ctx, o); err != nil {
+			return errs.NewDatabaseError("update", "order", err)
+		}
+	}
+	if err := h.uow.CourierRepository().Update(ctx, previous); err != nil {
+		return errs.NewDatabaseError("update", "courier", err)
+	}
+	if err := h.uow.CourierRepository().Update(ctx, next); err != nil {
+		return errs.NewDatabaseError("update", "courier", err)
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReassignOrderHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	mustCreateCourier := func(capacity int) *courier.Courier {
+		location, err := kernel.NewLocation(1, 1)
+		require.NoError(t, err)
+		c, err := courier.NewCourier("courier", 1, location)
+		require.NoError(t, err)
+		require.NoError(t, c.AddStoragePlace("bag", capacity))
+		return c
+	}
+
+	tests := map[string]struct {
+		sameCourier bool
+		pickedUp    bool
+		capacity    int
+		wantErr     bool
+		err         error
+	}{
+		"order goes to the other courier": {
+			capacity: 10,
+		},
+		"order is already with the courier": {
+			sameCourier: true,
+			capacity:    10,
+			wantErr:     true,
+			err:         errs.ErrBusiness,
+		},
+		"picked up order stays with the courier carrying it": {
+			pickedUp: true,
+			capacity: 10,
+			wantErr:  true,
+			err:      errs.ErrBusiness,
+		},
+		"courier has no room": {
+			capacity: 1,
+			wantErr:  true,
+			err:      errs.ErrBusiness,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			previous := mustCreateCourier(10)
+			next := mustCreateCourier(tc.capacity)
+			location, err := kernel.NewLocation(5, 5)
+			require.NoError(t, err)
+			o, err := order.NewOrder(uuid.New(), location, 5, order.Standard, now)
+			require.NoError(t, err)
+			previousID := previous.ID()
+			require.NoError(t, o.Assign(&previousID, now))
+			require.NoError(t, previous.TakeOrder(o))
+			if tc.pickedUp {
+				require.NoError(t, o.Accept(previousID))
+				require.NoError(t, o.PickUp(previousID))
+			}
+			previousOffer, err := offer.NewOffer(uuid.New(), o.ID(), previousID, now, offer.DefaultTimeout)
+			require.NoError(t, err)
+			target := next
+			if tc.sameCourier {
+				target = previous
+			}
+
+			uow := mocks.NewUnitOfWork(t)
+			orderRepo := mocks.NewOrderRepository(t)
+			courierRepo := mocks.NewCourierRepository(t)
+			uow.EXPECT().OrderRepository().Return(orderRepo)
+			orderRepo.EXPECT().Get(ctx, o.ID()).Return(o, nil)
+			if !tc.pickedUp {
+				uow.EXPECT().CourierRepository().Return(courierRepo)
+				courierRepo.EXPECT().Get(ctx, previousID).Return(previous, nil)
+			}
+			if !tc.sameCourier && !tc.pickedUp {
+				courierRepo.EXPECT().Get(ctx, next.ID()).Return(next, nil)
+			}
+			if !tc.wantErr {
+				orderRepo.EXPECT().GetAllWithCourier(ctx).Return(nil, nil)
+				offerRepo := mocks.NewOfferRepository(t)
+				uow.EXPECT().OfferRepository().Return(offerRepo)
+				offerRepo.EXPECT().GetPending(ctx, o.ID()).Return(previousOffer, nil)
+				offerRepo.EXPECT().Update(ctx, previousOffer).Return(nil)
+				offerRepo.EXPECT().Add(ctx, mock.MatchedBy(func(nextOffer *offer.Offer) bool {
+					return nextOffer.CourierID() == next.ID() && nextOffer.Status() == offer.Pending &&
+						nextOffer.ExpiresAt().Equal(now.Add(time.Minute))
+				})).Return(nil)
+				uow.EXPECT().Begin(ctx)
+				orderRepo.EXPECT().Update(ctx, o).Return(nil)
+				courierRepo.EXPECT().Update(ctx, previous).Return(nil)
+				courierRepo.EXPECT().Update(ctx, next).Return(nil)
+				uow.EXPECT().Commit(ctx).Return(nil)
+			}
+
+			eta, err := service.NewEtaService(time.Second)
+			require.NoError(t, err)
+			handler, err := NewReassignOrderHandler(uow, eta, time.Minute)
+			require.NoError(t, err)
+			command, err := NewReassignOrderCommand(o.ID(), target.ID(), "bike broke down", now)
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, &previousID, o.CourierID())
+			} else {
+				assert.NoError(t, err)
+				nextID := next.ID()
+				assert.Equal(t, &nextID, o.CourierID())
+				assert.Equal(t, "bike broke down", o.UnassignReason())
+				assert.NotNil(t, o.Eta())
+				assert.Nil(t, previous.StoragePlaces()[0].OrderID())
+				assert.Equal(t, offer.Withdrawn, previousOffer.Status())
+			}
+		})
+	}
+}
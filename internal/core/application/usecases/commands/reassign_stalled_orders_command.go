@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type ReassignStalledOrdersCommand struct {
+	now time.Time
+
+	isValid bool
+}
+
+func NewReassignStalledOrdersCommand(now time.Time) (*ReassignStalledOrdersCommand, error) {
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	return &ReassignStalledOrdersCommand{
+		now:     now,
+		isValid: true,
+	}, nil
+}
+
+func (c *ReassignStalledOrdersCommand) Now() time.Time {
+	return c.now
+}
+
+func (c *ReassignStalledOrdersCommand) IsValid() bool {
+	return c.isValid
+}
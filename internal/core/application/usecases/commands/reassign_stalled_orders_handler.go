@@ -0,0 +1,159 @@
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// StalledCourierReason is recorded on the orders the watchdog takes away from a courier
+const StalledCourierReason = "courier made no progress"
+
+type ReassignStalledOrdersHandler interface {
+	Handle(ctx context.Context, command *ReassignStalledOrdersCommand) error
+}
+
+type reassignStalledOrdersHandler struct {
+	uow          ports.UnitOfWork
+	dispatcher   service.DispatchService
+	eta          service.EtaService
+	stallTimeout time.Duration
+}
+
+// NewReassignStalledOrdersHandler takes the orders not picked up yet away from couriers that made no progress
+// with their orders for the stall timeout and gives them to other couriers, the picked up ones are reported
+// to the operators
+func NewReassignStalledOrdersHandler(uow ports.UnitOfWork, dispatcher service.DispatchService,
+	eta service.EtaService, stallTimeout time.Duration) (ReassignStalledOrdersHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	if dispatcher == nil {
+		return nil, errs.NewValueIsRequiredError("dispatcher service")
+	}
+	if eta == nil {
+		return nil, errs.NewValueIsRequiredError("eta service")
+	}
+	if stallTimeout <= 0 {
+		return nil, errs.NewValueIsRequiredError("stall timeout")
+	}
+
+	return &reassignStalledOrdersHandler{
+		uow:          uow,
+		dispatcher:   dispatcher,
+		eta:          eta,
+		stallTimeout: stallTimeout,
+	}, nil
+}
+
+func (h *reassignStalledOrdersHandler) Handle(ctx context.Context, command *ReassignStalledOrdersCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "reassign stalled orders command is invalid")
+	}
+
+	assignedOrders, err := h.uow.OrderRepository().GetAllWithCourier(ctx)
+	if err != nil {
+		return errs.NewDatabaseError("get", "assigned orders", err)
+	}
+
+	h.uow.Begin(ctx)
+	for _, route := range groupByCourier(assignedOrders) {
+		holder, err := h.uow.CourierRepository().Get(ctx, route.courierID)
+		if err != nil {
+			return errs.NewDatabaseError("get", "courier", err)
+		}
+		if !holder.Stalled(route.orders, command.Now(), h.stallTimeout) {
+			continue
+		}
+
+		// a picked up order is in the bag of the courier, it is flagged for the operators instead
+		var released []*order.Order
+		var pickedUpIDs []uuid.UUID
+		for _, o := range route.orders {
+			if o.Status() == order.PickedUp {
+				pickedUpIDs = append(pickedUpIDs, o.ID())
+				continue
+			}
+			if err := holder.UnassignOrder(o, StalledCourierReason); err != nil {
+				return errs.NewBusinessErrorWithCause("unassign order", "failed to take the order from the courier", err)
+			}
+			if err := withdrawOffer(ctx, h.uow, o.ID(), StalledCourierReason); err != nil {
+				return err
+			}
+			released = append(released, o)
+		}
+		reported := holder.ReportStall(pickedUpIDs, command.Now())
+		if len(released) == 0 && !reported {
+			continue
+		}
+		if err := h.uow.CourierRepository().Update(ctx, holder); err != nil {
+			return errs.NewDatabaseError("update", "courier", err)
+		}
+
+		for _, o := range released {
+			if err := h.reassign(ctx, o, holder.ID(), command.Now()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+
+	return nil
+}
+
+// reassign gives the order to the best free courier other than the previous one,
+// without one the order waits in the dispatch queue
+func (h *reassignStalledOrdersHandler) reassign(ctx context.Context, o *order.Order, previousID uuid.UUID,
+	now time.Time) error {
+	available, err := h.uow.CourierRepository().GetAllAvailable(ctx)
+	if err != nil {
+		return errs.NewDatabaseError("get", "available couriers", err)
+	}
+	candidates := make([]*courier.Courier, 0, len(available))
+	for _, c := range available {
+		if c.ID() != previousID {
+			candidates = append(candidates, c)
+		}
+	}
+
+	next, nextOffer, err := h.dispatcher.Dispatch(o, candidates, now)
+	if err != nil {
+		if errs.IsValidation(err) {
+			// nobody can take it now, the assign order job retries
+			return h.updateOrder(ctx, o)
+		}
+		return errs.NewBusinessErrorWithCause("dispatch order", "failed to reassign order", err)
+	}
+
+	etas, err := h.eta.Estimate(next, []*order.Order{o}, now)
+	if err != nil {
+		return errs.NewBusinessErrorWithCause("estimate arrival", "failed to estimate order eta", err)
+	}
+	if err := o.UpdateEta(etas[o.ID()]); err != nil {
+		return err
+	}
+
+	if err := h.uow.OfferRepository().Add(ctx, nextOffer); err != nil {
+		return errs.NewDatabaseError("create", "offer", err)
+	}
+	if err := h.uow.CourierRepository().Update(ctx, next); err != nil {
+		return errs.NewDatabaseError("update", "courier", err)
+	}
+	return h.updateOrder(ctx, o)
+}
+
+func (h *reassignStalledOrdersHandler) updateOrder(ctx context.Context, o *order.Order) error {
+	if err := h.uow.OrderRepository().Update(ctx, o); err != nil {
+		return errs.NewDatabaseError("update", "order", err)
+	}
+	return nil
+}
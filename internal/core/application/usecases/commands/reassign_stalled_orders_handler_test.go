@@ -0,0 +1,197 @@
+package commands
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/domain/service"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/delivery/internal/pkg/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReassignStalledOrdersHandler_Handle(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	timeout := 30 * time.Second
+
+	mustCreateCourier := func(x, y int) *courier.Courier {
+		location, err := kernel.NewLocation(x, y)
+		require.NoError(t, err)
+		c, err := courier.NewCourier("courier", 1, location)
+		require.NoError(t, err)
+		require.NoError(t, c.AddStoragePlace("bag", 10))
+		return c
+	}
+
+	tests := map[string]struct {
+		held           []order.Status
+		elapsed        time.Duration
+		reportedBefore bool
+		otherAvailable bool
+		wantStatuses   []order.Status
+		wantReleased   bool
+		wantReassigned bool
+		wantReported   bool
+	}{
+		"order not picked up yet goes to another courier, the picked up one is reported": {
+			held:           []order.Status{order.PickedUp, order.Accepted},
+			elapsed:        timeout,
+			otherAvailable: true,
+			wantStatuses:   []order.Status{order.PickedUp, order.Assigned},
+			wantReleased:   true,
+			wantReassigned: true,
+			wantReported:   true,
+		},
+		"order not picked up yet waits for a free courier": {
+			held:           []order.Status{order.PickedUp, order.Assigned},
+			elapsed:        timeout,
+			otherAvailable: false,
+			wantStatuses:   []order.Status{order.PickedUp, order.Created},
+			wantReleased:   true,
+			wantReported:   true,
+		},
+		"picked up order stays with the stalled courier": {
+			held:         []order.Status{order.PickedUp},
+			elapsed:      timeout,
+			wantStatuses: []order.Status{order.PickedUp},
+			wantReported: true,
+		},
+		"stall is reported once": {
+			held:           []order.Status{order.PickedUp},
+			elapsed:        timeout,
+			reportedBefore: true,
+			wantStatuses:   []order.Status{order.PickedUp},
+		},
+		"courier on the move keeps the orders": {
+			held:         []order.Status{order.PickedUp, order.Accepted},
+			elapsed:      timeout - time.Second,
+			wantStatuses: []order.Status{order.PickedUp, order.Accepted},
+		},
+		"courier that has not picked its accepted orders up for the timeout is stalled": {
+			held:           []order.Status{order.Accepted},
+			elapsed:        timeout,
+			otherAvailable: true,
+			wantStatuses:   []order.Status{order.Assigned},
+			wantReleased:   true,
+			wantReassigned: true,
+		},
+		"courier given the orders lately has time to pick them up": {
+			held:         []order.Status{order.Accepted},
+			elapsed:      timeout - time.Second,
+			wantStatuses: []order.Status{order.Accepted},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			stalled := mustCreateCourier(1, 1)
+			require.NoError(t, stalled.AddStoragePlace("trunk", 10))
+			other := mustCreateCourier(2, 2)
+			stalledID := stalled.ID()
+			location, err := kernel.NewLocation(5, 5)
+			require.NoError(t, err)
+			var held, released []*order.Order
+			for _, status := range tc.held {
+				o, err := order.NewOrder(uuid.New(), location, 1, order.Standard, start)
+				require.NoError(t, err)
+				require.NoError(t, o.Assign(&stalledID, start))
+				if status != order.Assigned {
+					require.NoError(t, o.Accept(stalledID))
+				}
+				if status == order.PickedUp {
+					require.NoError(t, o.PickUp(stalledID))
+				}
+				require.NoError(t, stalled.TakeOrder(o))
+				held = append(held, o)
+				if status != order.PickedUp {
+					released = append(released, o)
+				}
+			}
+			if slices.Contains(tc.held, order.PickedUp) {
+				// a courier only heads out with the orders picked up
+				require.NoError(t, stalled.Move(location, start, time.Minute))
+			}
+			if tc.reportedBefore {
+				require.True(t, stalled.ReportStall([]uuid.UUID{held[0].ID()}, start.Add(tc.elapsed-time.Second)))
+			}
+			stalled.ClearDomainEvents()
+
+			uow := mocks.NewUnitOfWork(t)
+			orderRepo := mocks.NewOrderRepository(t)
+			courierRepo := mocks.NewCourierRepository(t)
+			uow.EXPECT().OrderRepository().Return(orderRepo)
+			uow.EXPECT().CourierRepository().Return(courierRepo)
+			uow.EXPECT().Begin(ctx)
+			uow.EXPECT().Commit(ctx).Return(nil)
+			orderRepo.EXPECT().GetAllWithCourier(ctx).Return(held, nil)
+			courierRepo.EXPECT().Get(ctx, stalledID).Return(stalled, nil)
+			if tc.wantReported || tc.wantReleased {
+				courierRepo.EXPECT().Update(ctx, stalled).Return(nil)
+			}
+			if tc.wantReleased {
+				offerRepo := mocks.NewOfferRepository(t)
+				uow.EXPECT().OfferRepository().Return(offerRepo)
+				available := []*courier.Courier{stalled}
+				if tc.otherAvailable {
+					available = append(available, other)
+					offerRepo.EXPECT().Add(ctx, mock.Anything).Return(nil)
+					courierRepo.EXPECT().Update(ctx, other).Return(nil)
+				}
+				for _, o := range released {
+					offerRepo.EXPECT().GetPending(ctx, o.ID()).Return(nil, errs.NewNotFoundError("pending offer", o.ID().String()))
+					orderRepo.EXPECT().Update(ctx, o).Return(nil)
+				}
+				courierRepo.EXPECT().GetAllAvailable(ctx).Return(available, nil)
+			}
+
+			eta, err := service.NewEtaService(time.Second)
+			require.NoError(t, err)
+			handler, err := NewReassignStalledOrdersHandler(uow, service.NewDispatchService(), eta, timeout)
+			require.NoError(t, err)
+			command, err := NewReassignStalledOrdersCommand(start.Add(tc.elapsed))
+			require.NoError(t, err)
+
+			err = handler.Handle(ctx, command)
+
+			assert.NoError(t, err)
+			for n, o := range held {
+				assert.Equal(t, tc.wantStatuses[n], o.Status())
+				if o.Status() == order.PickedUp {
+					assert.Equal(t, &stalledID, o.CourierID())
+					assert.Empty(t, o.UnassignReason())
+				}
+			}
+			for _, o := range released {
+				if !tc.wantReleased {
+					continue
+				}
+				assert.Equal(t, StalledCourierReason, o.UnassignReason())
+				if tc.wantReassigned {
+					otherID := other.ID()
+					assert.Equal(t, &otherID, o.CourierID())
+					assert.NotNil(t, o.Eta())
+				}
+			}
+			var reported []uuid.UUID
+			for _, event := range stalled.GetDomainEvents() {
+				if stall, ok := event.(*courier.StalledDomainEvent); ok {
+					reported = append(reported, stall.OrderIDs...)
+				}
+			}
+			if tc.wantReported {
+				assert.Equal(t, []uuid.UUID{held[0].ID()}, reported)
+			} else {
+				assert.Empty(t, reported)
+			}
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package commands
+
+type RebuildReadModelsCommand struct {
+	isValid bool
+}
+
+func NewRebuildReadModelsCommand() (*RebuildReadModelsCommand, error) {
+	return &RebuildReadModelsCommand{
+		isValid: true,
+	}, nil
+}
+
+func (c *RebuildReadModelsCommand) IsValid() bool {
+	return c.isValid
+}
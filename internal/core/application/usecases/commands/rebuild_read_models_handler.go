@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type RebuildReadModelsHandler interface {
+	Handle(ctx context.Context, command *RebuildReadModelsCommand) error
+}
+
+type rebuildReadModelsHandler struct {
+	uow       ports.UnitOfWork
+	projector ports.ReadModelProjector
+}
+
+// NewRebuildReadModelsHandler regenerates the courier and order views from the write tables, for a new
+// deployment of the views or after they drifted; the queries keep seeing the old views until it commits
+func NewRebuildReadModelsHandler(uow ports.UnitOfWork, projector ports.ReadModelProjector) (RebuildReadModelsHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	if projector == nil {
+		return nil, errs.NewValueIsRequiredError("read model projector")
+	}
+
+	return &rebuildReadModelsHandler{
+		uow:       uow,
+		projector: projector,
+	}, nil
+}
+
+func (h *rebuildReadModelsHandler) Handle(ctx context.Context, command *RebuildReadModelsCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "rebuild read models command is invalid")
+	}
+
+	h.uow.Begin(ctx)
+	if err := h.projector.Rebuild(ctx); err != nil {
+		h.uow.Rollback(ctx)
+		return err
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type UnassignOrderCommand struct {
+	orderID uuid.UUID
+	reason  string
+
+	isValid bool
+}
+
+func NewUnassignOrderCommand(orderID uuid.UUID, reason string) (*UnassignOrderCommand, error) {
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+	if reason == "" {
+		return nil, errs.NewValueIsRequiredError("reason")
+	}
+
+	return &UnassignOrderCommand{
+		orderID: orderID,
+		reason:  reason,
+		isValid: true,
+	}, nil
+}
+
+func (c *UnassignOrderCommand) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+func (c *UnassignOrderCommand) Reason() string {
+	return c.reason
+}
+
+func (c *UnassignOrderCommand) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type UnassignOrderHandler interface {
+	Handle(ctx context.Context, command *UnassignOrderCommand) error
+}
+
+type unassignOrderHandler struct {
+	uow ports.UnitOfWork
+}
+
+func NewUnassignOrderHandler(uow ports.UnitOfWork) (UnassignOrderHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+
+	return &unassignOrderHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *unassignOrderHandler) Handle(ctx context.Context, command *UnassignOrderCommand) error {
+	if !command.IsValid() {
+		return errs.NewValidationError("command", "unassign order command is invalid")
+	}
+
+	assigned, carrier, err := getAssignedOrder(ctx, h.uow, command.OrderID())
+	if err != nil {
+		return err
+	}
+
+	if err := carrier.UnassignOrder(assigned, command.Reason()); err != nil {
+		return errs.NewBusinessErrorWithCause("unassign order", "failed to take the order from the courier", err)
+	}
+
+	h.uow.Begin(ctx)
+
+	if err := withdrawOffer(ctx, h.uow, assigned.ID(), command.Reason()); err != nil {
+		return err
+	}
+	if err := h.uow.OrderRepository().Update(ctx, assigned); err != nil {
+		return errs.NewDatabaseError("update", "order", err)
+	}
+	if err := h.uow.CourierRepository().Update(ctx, carrier); err != nil {
+		return errs.NewDatabaseError("update", "courier", err)
+	}
+
+	if err := h.uow.Commit(ctx); err != nil {
+		return errs.NewDatabaseError("commit", "transaction", err)
+	}
+
+	return nil
+}
+
+// getAssignedOrder loads an order not picked up yet together with the courier it is assigned to
+func getAssignedOrder(ctx context.Context, uow ports.UnitOfWork, orderID uuid.UUID) (*order.Order, *courier.Courier, error) {
+	o, err := uow.OrderRepository().Get(ctx, orderID)
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return nil, nil, errs.NewNotFoundError("order", orderID.String())
+		}
+		return nil, nil, errs.NewDatabaseError("get", "order", err)
+	}
+	if !o.Status().WithCourier() || o.CourierID() == nil {
+		return nil, nil, errs.NewBusinessError("get assigned order", "order is not assigned to a courier")
+	}
+	if o.Status() == order.PickedUp {
+		return nil, nil, errs.NewBusinessError("get assigned order", "the courier has picked the order up already")
+	}
+
+	c, err := uow.CourierRepository().Get(ctx, *o.CourierID())
+	if err != nil {
+		if errs.IsNotFound(err) {
+			return nil, nil, errs.NewNotFoundError("courier", o.CourierID().String())
+		}
+		return nil, nil, errs.NewDatabaseError("get", "courier", err)
+	}
+
+	return o, c, nil
+}
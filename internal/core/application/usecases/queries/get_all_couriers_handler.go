@@ -1,6 +1,8 @@
 package queries
 
 import (
+	"context"
+
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/errs"
 )
@@ -10,15 +12,15 @@ type GetAllCouriersHandler interface {
 }
 
 type getAllCouriersHandler struct {
-	uow ports.UnitOfWork
+	views ports.ReadModelRepository
 }
 
-func NewGetAllCouriersHandler(uow ports.UnitOfWork) (GetAllCouriersHandler, error) {
-	if uow == nil {
-		return nil, errs.NewValueIsRequiredError("unit of work")
+func NewGetAllCouriersHandler(views ports.ReadModelRepository) (GetAllCouriersHandler, error) {
+	if views == nil {
+		return nil, errs.NewValueIsRequiredError("read model repository")
 	}
 	return &getAllCouriersHandler{
-		uow: uow,
+		views: views,
 	}, nil
 }
 
@@ -26,12 +28,38 @@ func (h *getAllCouriersHandler) Handle(query GetAllCouriersQuery) (GetAllCourier
 	if !query.IsValid() {
 		return GetAllCouriersResponse{}, errs.NewValidationError("query", "get all couriers query is invalid")
 	}
-	var couriers []CourierResponse
-	if err := h.uow.Db().Find(&couriers).Error; err != nil {
-		return GetAllCouriersResponse{}, errs.NewDatabaseError("get", "couriers", err)
+	// one row more than asked tells whether there is a next page
+	views, total, err := h.views.ListCouriers(context.Background(), query.Filter(), query.SortField(), ports.Page{
+		Limit: query.Limit() + 1,
+		Desc:  query.Desc(),
+		After: query.Cursor(),
+	})
+	if err != nil {
+		return GetAllCouriersResponse{}, err
+	}
+	var nextCursor string
+	if len(views) > query.Limit() {
+		views = views[:query.Limit()]
+		nextCursor = encodeCursor(query.Sort(), views[len(views)-1].Cursor(query.SortField()))
+	}
+
+	couriers := make([]CourierResponse, 0, len(views))
+	for _, view := range views {
+		couriers = append(couriers, CourierResponse{
+			ID:             view.ID,
+			Name:           view.Name,
+			Location:       LocationResponse{X: view.LocationX, Y: view.LocationY},
+			DeclinedOffers: view.DeclinedOffers,
+			HomeZoneID:     view.HomeZoneID,
+			OrdersCount:    view.OrdersCount,
+			UsedVolume:     view.UsedVolume,
+			Capacity:       view.Capacity,
+		})
 	}
 
 	return GetAllCouriersResponse{
-		Couriers: couriers,
+		Couriers:   couriers,
+		Total:      total,
+		NextCursor: nextCursor,
 	}, nil
 }
@@ -1,15 +1,84 @@
 package queries
 
+import (
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
 type GetAllCouriersQuery struct {
+	limit  int
+	cursor *ports.Cursor
+	// sort is as requested, like "-ordersCount", the cursors handed out carry it
+	sort   string
+	field  ports.CourierSort
+	desc   bool
+	filter ports.CourierFilter
+
 	isValid bool
 }
 
-func NewGetAllCouriersQuery() (*GetAllCouriersQuery, error) {
+// NewGetAllCouriersQuery asks for a page of the couriers; the zero values of the arguments
+// take the first page of the default size of all couriers by name
+func NewGetAllCouriersQuery(limit int, cursor string, sort string, name string, bbox []int) (*GetAllCouriersQuery, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	field, desc := parseSort(sort, string(ports.CourierSortName))
+	switch ports.CourierSort(field) {
+	case ports.CourierSortName, ports.CourierSortOrdersCount, ports.CourierSortDeclinedOffers:
+	default:
+		return nil, errs.NewValidationErrorWithValue("sort", sort, "couriers can't be sorted by it")
+	}
+	if sort == "" {
+		sort = field
+	}
+
+	after, err := decodeCursor(cursor, sort)
+	if err != nil {
+		return nil, err
+	}
+	area, err := parseArea(bbox)
+	if err != nil {
+		return nil, err
+	}
+
 	return &GetAllCouriersQuery{
+		limit:   limit,
+		cursor:  after,
+		sort:    sort,
+		field:   ports.CourierSort(field),
+		desc:    desc,
+		filter:  ports.CourierFilter{NamePrefix: name, Area: area},
 		isValid: true,
 	}, nil
 }
 
+func (c *GetAllCouriersQuery) Limit() int {
+	return c.limit
+}
+
+func (c *GetAllCouriersQuery) Cursor() *ports.Cursor {
+	return c.cursor
+}
+
+func (c *GetAllCouriersQuery) Sort() string {
+	return c.sort
+}
+
+func (c *GetAllCouriersQuery) SortField() ports.CourierSort {
+	return c.field
+}
+
+func (c *GetAllCouriersQuery) Desc() bool {
+	return c.desc
+}
+
+func (c *GetAllCouriersQuery) Filter() ports.CourierFilter {
+	return c.filter
+}
+
 func (c *GetAllCouriersQuery) IsValid() bool {
 	return c.isValid
 }
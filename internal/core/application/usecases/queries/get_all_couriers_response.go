@@ -4,14 +4,22 @@ import "github.com/google/uuid"
 
 type GetAllCouriersResponse struct {
 	Couriers []CourierResponse
+	// Total counts the couriers matching the filter on every page
+	Total int64
+	// NextCursor is empty on the last page
+	NextCursor string
 }
 
 type CourierResponse struct {
-	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ID       uuid.UUID
 	Name     string
-	Location LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
-}
-
-func (CourierResponse) TableName() string {
-	return "couriers"
+	Location LocationResponse
+	// DeclinedOffers counts the offers the courier turned down
+	DeclinedOffers int
+	// HomeZoneID is nil for a courier that works in every zone
+	HomeZoneID *uuid.UUID
+	// OrdersCount, UsedVolume and Capacity are the load of the courier's storage places
+	OrdersCount int
+	UsedVolume  int
+	Capacity    int
 }
@@ -1,7 +1,8 @@
 package queries
 
 import (
-	"github.com/delivery/internal/core/domain/model/order"
+	"context"
+
 	"github.com/delivery/internal/core/ports"
 	"github.com/delivery/internal/pkg/errs"
 )
@@ -11,15 +12,15 @@ type GetAllUncompletedOrdersHandler interface {
 }
 
 type getAllUncompletedOrdersHandler struct {
-	uow ports.UnitOfWork
+	views ports.ReadModelRepository
 }
 
-func NewGetAllUncompletedOrdersHandler(uow ports.UnitOfWork) (GetAllUncompletedOrdersHandler, error) {
-	if uow == nil {
-		return nil, errs.NewValueIsRequiredError("unit of work")
+func NewGetAllUncompletedOrdersHandler(views ports.ReadModelRepository) (GetAllUncompletedOrdersHandler, error) {
+	if views == nil {
+		return nil, errs.NewValueIsRequiredError("read model repository")
 	}
 	return &getAllUncompletedOrdersHandler{
-		uow: uow,
+		views: views,
 	}, nil
 }
 
@@ -28,15 +29,39 @@ func (h *getAllUncompletedOrdersHandler) Handle(query GetAllUncompletedOrdersQue
 		return GetAllUncompletedOrdersResponse{}, errs.NewValidationError("query", "get all uncompleted orders query is invalid")
 	}
 
-	var orders []OrderResponse
-	result := h.uow.Db().Where("status IN ?", []string{order.Created.String(), order.Assigned.String()}).
-		Find(&orders)
+	// one row more than asked tells whether there is a next page
+	views, total, err := h.views.ListUncompletedOrders(context.Background(), query.Filter(), query.SortField(), ports.Page{
+		Limit: query.Limit() + 1,
+		Desc:  query.Desc(),
+		After: query.Cursor(),
+	})
+	if err != nil {
+		return GetAllUncompletedOrdersResponse{}, err
+	}
+	var nextCursor string
+	if len(views) > query.Limit() {
+		views = views[:query.Limit()]
+		nextCursor = encodeCursor(query.Sort(), views[len(views)-1].Cursor(query.SortField()))
+	}
 
-	if result.Error != nil {
-		return GetAllUncompletedOrdersResponse{}, errs.NewDatabaseError("get", "orders", result.Error)
+	orders := make([]OrderResponse, 0, len(views))
+	for _, view := range views {
+		orders = append(orders, OrderResponse{
+			ID:          view.ID,
+			Location:    LocationResponse{X: view.LocationX, Y: view.LocationY},
+			Volume:      view.Volume,
+			Status:      view.Status,
+			Priority:    view.Priority,
+			Eta:         view.Eta,
+			ZoneID:      view.ZoneID,
+			CourierID:   view.CourierID,
+			CourierName: view.CourierName,
+		})
 	}
 
 	return GetAllUncompletedOrdersResponse{
-		Orders: orders,
+		Orders:     orders,
+		Total:      total,
+		NextCursor: nextCursor,
 	}, nil
 }
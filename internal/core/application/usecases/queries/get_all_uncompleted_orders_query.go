@@ -1,15 +1,92 @@
 package queries
 
+import (
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
 type GetAllUncompletedOrdersQuery struct {
+	limit  int
+	cursor *ports.Cursor
+	// sort is as requested, like "-createdAt", the cursors handed out carry it
+	sort   string
+	field  ports.OrderSort
+	desc   bool
+	filter ports.OrderFilter
+
 	isValid bool
 }
 
-func NewGetAllUncompletedOrdersQuery() (*GetAllUncompletedOrdersQuery, error) {
+// NewGetAllUncompletedOrdersQuery asks for a page of the uncompleted orders; the zero values of
+// the arguments take the first page of the default size of all of them, the oldest first
+func NewGetAllUncompletedOrdersQuery(limit int, cursor string, sort string, statuses []order.Status,
+	courierName string, bbox []int) (*GetAllUncompletedOrdersQuery, error) {
+	limit, err := pageLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	field, desc := parseSort(sort, string(ports.OrderSortCreatedAt))
+	switch ports.OrderSort(field) {
+	case ports.OrderSortCreatedAt, ports.OrderSortVolume:
+	default:
+		return nil, errs.NewValidationErrorWithValue("sort", sort, "orders can't be sorted by it")
+	}
+	if sort == "" {
+		sort = field
+	}
+
+	for _, status := range statuses {
+		if status != order.Created && !status.WithCourier() {
+			return nil, errs.NewValidationErrorWithValue("status", status, "is not a status of an uncompleted order")
+		}
+	}
+
+	after, err := decodeCursor(cursor, sort)
+	if err != nil {
+		return nil, err
+	}
+	area, err := parseArea(bbox)
+	if err != nil {
+		return nil, err
+	}
+
 	return &GetAllUncompletedOrdersQuery{
+		limit:   limit,
+		cursor:  after,
+		sort:    sort,
+		field:   ports.OrderSort(field),
+		desc:    desc,
+		filter:  ports.OrderFilter{Statuses: statuses, CourierNamePrefix: courierName, Area: area},
 		isValid: true,
 	}, nil
 }
 
+func (c *GetAllUncompletedOrdersQuery) Limit() int {
+	return c.limit
+}
+
+func (c *GetAllUncompletedOrdersQuery) Cursor() *ports.Cursor {
+	return c.cursor
+}
+
+func (c *GetAllUncompletedOrdersQuery) Sort() string {
+	return c.sort
+}
+
+func (c *GetAllUncompletedOrdersQuery) SortField() ports.OrderSort {
+	return c.field
+}
+
+func (c *GetAllUncompletedOrdersQuery) Desc() bool {
+	return c.desc
+}
+
+func (c *GetAllUncompletedOrdersQuery) Filter() ports.OrderFilter {
+	return c.filter
+}
+
 func (c *GetAllUncompletedOrdersQuery) IsValid() bool {
 	return c.isValid
 }
@@ -1,16 +1,29 @@
 package queries
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/google/uuid"
+)
 
 type GetAllUncompletedOrdersResponse struct {
 	Orders []OrderResponse
+	// Total counts the orders matching the filter on every page
+	Total int64
+	// NextCursor is empty on the last page
+	NextCursor string
 }
 
 type OrderResponse struct {
-	ID       uuid.UUID        `gorm:"type:uuid;primaryKey"`
-	Location LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
-}
-
-func (OrderResponse) TableName() string {
-	return "orders"
+	ID       uuid.UUID
+	Location LocationResponse
+	Volume   int
+	Status   order.Status
+	Priority order.Priority
+	Eta      *time.Time
+	ZoneID   *uuid.UUID
+	// CourierID and CourierName are nil while no courier holds the order
+	CourierID   *uuid.UUID
+	CourierName *string
 }
@@ -0,0 +1,37 @@
+package queries
+
+import (
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type GetAllZonesHandler interface {
+	Handle(query GetAllZonesQuery) (GetAllZonesResponse, error)
+}
+
+type getAllZonesHandler struct {
+	uow ports.UnitOfWork
+}
+
+func NewGetAllZonesHandler(uow ports.UnitOfWork) (GetAllZonesHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &getAllZonesHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *getAllZonesHandler) Handle(query GetAllZonesQuery) (GetAllZonesResponse, error) {
+	if !query.IsValid() {
+		return GetAllZonesResponse{}, errs.NewValidationError("query", "get all zones query is invalid")
+	}
+	var zones []ZoneResponse
+	if err := h.uow.Db().Order("name, id").Find(&zones).Error; err != nil {
+		return GetAllZonesResponse{}, errs.NewDatabaseError("get", "zones", err)
+	}
+
+	return GetAllZonesResponse{
+		Zones: zones,
+	}, nil
+}
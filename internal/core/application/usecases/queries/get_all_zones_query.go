@@ -0,0 +1,15 @@
+package queries
+
+type GetAllZonesQuery struct {
+	isValid bool
+}
+
+func NewGetAllZonesQuery() (*GetAllZonesQuery, error) {
+	return &GetAllZonesQuery{
+		isValid: true,
+	}, nil
+}
+
+func (c *GetAllZonesQuery) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,18 @@
+package queries
+
+import "github.com/google/uuid"
+
+type GetAllZonesResponse struct {
+	Zones []ZoneResponse
+}
+
+type ZoneResponse struct {
+	ID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Name string
+	From LocationResponse `gorm:"embedded;embeddedPrefix:from_"`
+	To   LocationResponse `gorm:"embedded;embeddedPrefix:to_"`
+}
+
+func (ZoneResponse) TableName() string {
+	return "zones"
+}
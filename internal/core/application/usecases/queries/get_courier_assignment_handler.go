@@ -0,0 +1,55 @@
+package queries
+
+import (
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"gorm.io/gorm/clause"
+)
+
+type GetCourierAssignmentHandler interface {
+	Handle(query GetCourierAssignmentQuery) (GetCourierAssignmentResponse, error)
+}
+
+type getCourierAssignmentHandler struct {
+	uow ports.UnitOfWork
+}
+
+// NewGetCourierAssignmentHandler lists the orders the courier holds in the sequence it delivers them,
+// an order still on offer carries the time the courier has to accept it by
+func NewGetCourierAssignmentHandler(uow ports.UnitOfWork) (GetCourierAssignmentHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &getCourierAssignmentHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *getCourierAssignmentHandler) Handle(query GetCourierAssignmentQuery) (GetCourierAssignmentResponse, error) {
+	if !query.IsValid() {
+		return GetCourierAssignmentResponse{}, errs.NewValidationError("query", "get courier assignment query is invalid")
+	}
+
+	var orders []AssignedOrderResponse
+	result := h.uow.Db().
+		Select("orders.id, orders.location_x, orders.location_y, orders.volume, orders.status, "+
+			"orders.priority, orders.eta, offers.expires_at AS accept_by").
+		Joins("LEFT JOIN offers ON offers.order_id = orders.id AND offers.status = ?", offer.Pending).
+		Where("orders.courier_id = ? AND orders.status IN ?", query.CourierID(), order.WithCourierStatuses).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "CASE WHEN orders.priority = ? THEN 0 ELSE 1 END, orders.created_at, orders.id",
+			Vars:               []interface{}{order.Express},
+			WithoutParentheses: true,
+		}}).
+		Find(&orders)
+
+	if result.Error != nil {
+		return GetCourierAssignmentResponse{}, errs.NewDatabaseError("get", "orders", result.Error)
+	}
+
+	return GetCourierAssignmentResponse{
+		Orders: orders,
+	}, nil
+}
@@ -0,0 +1,31 @@
+package queries
+
+import (
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type GetCourierAssignmentQuery struct {
+	courierID uuid.UUID
+
+	isValid bool
+}
+
+func NewGetCourierAssignmentQuery(courierID uuid.UUID) (*GetCourierAssignmentQuery, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+
+	return &GetCourierAssignmentQuery{
+		courierID: courierID,
+		isValid:   true,
+	}, nil
+}
+
+func (c *GetCourierAssignmentQuery) CourierID() uuid.UUID {
+	return c.courierID
+}
+
+func (c *GetCourierAssignmentQuery) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,27 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/google/uuid"
+)
+
+type GetCourierAssignmentResponse struct {
+	Orders []AssignedOrderResponse
+}
+
+type AssignedOrderResponse struct {
+	ID       uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	Location LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
+	Volume   int
+	Status   order.Status
+	Priority order.Priority
+	Eta      *time.Time
+	// AcceptBy is when the pending offer of the order expires
+	AcceptBy *time.Time
+}
+
+func (AssignedOrderResponse) TableName() string {
+	return "orders"
+}
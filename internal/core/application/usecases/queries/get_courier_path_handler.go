@@ -0,0 +1,63 @@
+package queries
+
+import (
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/lib/pq"
+)
+
+type GetCourierPathHandler interface {
+	Handle(query GetCourierPathQuery) (GetCourierPathResponse, error)
+}
+
+type getCourierPathHandler struct {
+	uow ports.UnitOfWork
+}
+
+// NewGetCourierPathHandler follows a courier through the audit log: where it was created
+// and every cell it moved to, the oldest first
+func NewGetCourierPathHandler(uow ports.UnitOfWork) (GetCourierPathHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &getCourierPathHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *getCourierPathHandler) Handle(query GetCourierPathQuery) (GetCourierPathResponse, error) {
+	if !query.IsValid() {
+		return GetCourierPathResponse{}, errs.NewValidationError("query", "get courier path query is invalid")
+	}
+
+	var couriers int64
+	if err := h.uow.Db().Table("couriers").Where("id = ?", query.CourierID()).Count(&couriers).Error; err != nil {
+		return GetCourierPathResponse{}, errs.NewDatabaseError("get", "courier", err)
+	}
+	if couriers == 0 {
+		return GetCourierPathResponse{}, errs.NewNotFoundError("courier", query.CourierID().String())
+	}
+
+	db := h.uow.Db().
+		Table("audit_log").
+		Select("(after->'Location'->>'X')::int AS location_x, (after->'Location'->>'Y')::int AS location_y, "+
+			"recorded_at AS at").
+		Where("aggregate_type = ? AND aggregate_id = ? AND events && ?", "courier", query.CourierID(),
+			pq.StringArray{courier.CreatedEventName, courier.MovedEventName})
+	if query.From() != nil {
+		db = db.Where("recorded_at >= ?", *query.From())
+	}
+	if query.To() != nil {
+		db = db.Where("recorded_at <= ?", *query.To())
+	}
+
+	var points []PathPointResponse
+	if err := db.Order("recorded_at, version").Find(&points).Error; err != nil {
+		return GetCourierPathResponse{}, errs.NewDatabaseError("get", "audit log", err)
+	}
+
+	return GetCourierPathResponse{
+		Points: points,
+	}, nil
+}
@@ -0,0 +1,49 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type GetCourierPathQuery struct {
+	courierID uuid.UUID
+	// from and to bound the path in time, either may be nil
+	from *time.Time
+	to   *time.Time
+
+	isValid bool
+}
+
+func NewGetCourierPathQuery(courierID uuid.UUID, from *time.Time, to *time.Time) (*GetCourierPathQuery, error) {
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+	if from != nil && to != nil && to.Before(*from) {
+		return nil, errs.NewValidationErrorWithValue("to", *to, "must not be before from")
+	}
+
+	return &GetCourierPathQuery{
+		courierID: courierID,
+		from:      from,
+		to:        to,
+		isValid:   true,
+	}, nil
+}
+
+func (c *GetCourierPathQuery) CourierID() uuid.UUID {
+	return c.courierID
+}
+
+func (c *GetCourierPathQuery) From() *time.Time {
+	return c.from
+}
+
+func (c *GetCourierPathQuery) To() *time.Time {
+	return c.to
+}
+
+func (c *GetCourierPathQuery) IsValid() bool {
+	return c.isValid
+}
@@ -0,0 +1,13 @@
+package queries
+
+import "time"
+
+type GetCourierPathResponse struct {
+	Points []PathPointResponse
+}
+
+// PathPointResponse is where the courier was at the time
+type PathPointResponse struct {
+	Location LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
+	At       time.Time
+}
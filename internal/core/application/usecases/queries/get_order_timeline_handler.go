@@ -0,0 +1,55 @@
+package queries
+
+import (
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type GetOrderTimelineHandler interface {
+	Handle(query GetOrderTimelineQuery) (GetOrderTimelineResponse, error)
+}
+
+type getOrderTimelineHandler struct {
+	uow ports.UnitOfWork
+}
+
+// NewGetOrderTimelineHandler replays the audit log of an order together with the offers made for it,
+// the oldest change first
+func NewGetOrderTimelineHandler(uow ports.UnitOfWork) (GetOrderTimelineHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	return &getOrderTimelineHandler{
+		uow: uow,
+	}, nil
+}
+
+func (h *getOrderTimelineHandler) Handle(query GetOrderTimelineQuery) (GetOrderTimelineResponse, error) {
+	if !query.IsValid() {
+		return GetOrderTimelineResponse{}, errs.NewValidationError("query", "get order timeline query is invalid")
+	}
+
+	var orders int64
+	if err := h.uow.Db().Table("orders").Where("id = ?", query.OrderID()).Count(&orders).Error; err != nil {
+		return GetOrderTimelineResponse{}, errs.NewDatabaseError("get", "order", err)
+	}
+	if orders == 0 {
+		return GetOrderTimelineResponse{}, errs.NewNotFoundError("order", query.OrderID().String())
+	}
+
+	var entries []AuditEntryResponse
+	offers := h.uow.Db().Table("offers").Select("id").Where("order_id = ?", query.OrderID())
+	result := h.uow.Db().
+		Where("aggregate_type = ? AND aggregate_id = ?", "order", query.OrderID()).
+		Or("aggregate_type = ? AND aggregate_id IN (?)", "offer", offers).
+		Order("recorded_at, version").
+		Find(&entries)
+
+	if result.Error != nil {
+		return GetOrderTimelineResponse{}, errs.NewDatabaseError("get", "audit log", result.Error)
+	}
+
+	return GetOrderTimelineResponse{
+		Entries: entries,
+	}, nil
+}
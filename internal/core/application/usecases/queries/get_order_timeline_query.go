@@ -0,0 +1,31 @@
+package queries
+
+import (
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type GetOrderTimelineQuery struct {
+	orderID uuid.UUID
+
+	isValid bool
+}
+
+func NewGetOrderTimelineQuery(orderID uuid.UUID) (*GetOrderTimelineQuery, error) {
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+
+	return &GetOrderTimelineQuery{
+		orderID: orderID,
+		isValid: true,
+	}, nil
+}
+
+func (c *GetOrderTimelineQuery) OrderID() uuid.UUID {
+	return c.orderID
+}
+
+func (c *GetOrderTimelineQuery) IsValid() bool {
+	return c.isValid
+}
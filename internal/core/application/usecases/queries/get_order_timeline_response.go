@@ -0,0 +1,30 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type GetOrderTimelineResponse struct {
+	Entries []AuditEntryResponse
+}
+
+// AuditEntryResponse is a change of an aggregate, Before and After are its JSON snapshots
+type AuditEntryResponse struct {
+	AggregateType string
+	AggregateID   uuid.UUID
+	Version       int
+	Events        pq.StringArray `gorm:"type:text[]"`
+	Command       string
+	Actor         string
+	CorrelationID string
+	Before        *string
+	After         string
+	RecordedAt    time.Time
+}
+
+func (AuditEntryResponse) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,128 @@
+package queries
+
+import (
+	"github.com/delivery/internal/core/domain/model/analytics"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type GetSupplyDemandHandler interface {
+	Handle(query GetSupplyDemandQuery) (GetSupplyDemandResponse, error)
+}
+
+type getSupplyDemandHandler struct {
+	uow      ports.UnitOfWork
+	rules    analytics.Rules
+	cellSize int
+}
+
+// NewGetSupplyDemandHandler counts supply and demand by service zone, or by grid cells of cellSize
+// while there are no zones
+func NewGetSupplyDemandHandler(uow ports.UnitOfWork, rules analytics.Rules, cellSize int) (GetSupplyDemandHandler, error) {
+	if uow == nil {
+		return nil, errs.NewValueIsRequiredError("unit of work")
+	}
+	if len(rules.Windows()) == 0 {
+		return nil, errs.NewValueIsRequiredError("rules")
+	}
+	if cellSize <= 0 {
+		return nil, errs.NewValidationErrorWithValue("cell size", cellSize, "must be greater than zero")
+	}
+	return &getSupplyDemandHandler{
+		uow:      uow,
+		rules:    rules,
+		cellSize: cellSize,
+	}, nil
+}
+
+func (h *getSupplyDemandHandler) Handle(query GetSupplyDemandQuery) (GetSupplyDemandResponse, error) {
+	if !query.IsValid() {
+		return GetSupplyDemandResponse{}, errs.NewValidationError("query", "get supply demand query is invalid")
+	}
+
+	areas, err := h.areas(query.CellSize())
+	if err != nil {
+		return GetSupplyDemandResponse{}, err
+	}
+
+	var couriers []IdleCourierResponse
+	result := h.uow.Db().
+		Where("NOT EXISTS (SELECT 1 FROM storage_places WHERE storage_places.courier_id = couriers.id " +
+			"AND storage_places.order_id IS NOT NULL)").
+		Find(&couriers)
+	if result.Error != nil {
+		return GetSupplyDemandResponse{}, errs.NewDatabaseError("get", "idle couriers", result.Error)
+	}
+
+	var orders []OrderSampleResponse
+	result = h.uow.Db().
+		Where("status = ? OR created_at >= ?", order.Created, h.rules.Since(query.TakenAt())).
+		Find(&orders)
+	if result.Error != nil {
+		return GetSupplyDemandResponse{}, errs.NewDatabaseError("get", "orders", result.Error)
+	}
+
+	idleCouriers := make([]kernel.Location, 0, len(couriers))
+	for _, c := range couriers {
+		location, err := kernel.NewLocation(c.Location.X, c.Location.Y)
+		if err != nil {
+			return GetSupplyDemandResponse{}, err
+		}
+		idleCouriers = append(idleCouriers, location)
+	}
+	samples := make([]analytics.OrderSample, 0, len(orders))
+	for _, o := range orders {
+		location, err := kernel.NewLocation(o.Location.X, o.Location.Y)
+		if err != nil {
+			return GetSupplyDemandResponse{}, err
+		}
+		samples = append(samples, analytics.OrderSample{
+			Location:   location,
+			CreatedAt:  o.CreatedAt,
+			AssignedAt: o.AssignedAt,
+			Waiting:    o.Status == order.Created,
+		})
+	}
+
+	return GetSupplyDemandResponse{
+		Snapshot: analytics.NewSnapshot(query.TakenAt(), h.rules, areas, idleCouriers, samples),
+	}, nil
+}
+
+func (h *getSupplyDemandHandler) areas(cellSize int) ([]analytics.Area, error) {
+	if cellSize > 0 {
+		return analytics.CellAreas(cellSize)
+	}
+
+	var zones []ZoneResponse
+	if err := h.uow.Db().Order("name, id").Find(&zones).Error; err != nil {
+		return nil, errs.NewDatabaseError("get", "zones", err)
+	}
+	if len(zones) == 0 {
+		return analytics.CellAreas(h.cellSize)
+	}
+
+	areas := make([]analytics.Area, 0, len(zones))
+	for _, z := range zones {
+		from, err := kernel.NewLocation(z.From.X, z.From.Y)
+		if err != nil {
+			return nil, err
+		}
+		to, err := kernel.NewLocation(z.To.X, z.To.Y)
+		if err != nil {
+			return nil, err
+		}
+		bounds, err := kernel.NewRectangle(from, to)
+		if err != nil {
+			return nil, err
+		}
+		area, err := analytics.NewZoneArea(z.ID, z.Name, bounds)
+		if err != nil {
+			return nil, err
+		}
+		areas = append(areas, area)
+	}
+	return areas, nil
+}
@@ -0,0 +1,42 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type GetSupplyDemandQuery struct {
+	takenAt time.Time
+	// cellSize groups by grid cells of the size, 0 groups by the service zones
+	cellSize int
+
+	isValid bool
+}
+
+func NewGetSupplyDemandQuery(takenAt time.Time, cellSize int) (*GetSupplyDemandQuery, error) {
+	if takenAt.IsZero() {
+		return nil, errs.NewValueIsRequiredError("taken at")
+	}
+	if cellSize < 0 {
+		return nil, errs.NewValidationErrorWithValue("cell size", cellSize, "must not be negative")
+	}
+
+	return &GetSupplyDemandQuery{
+		takenAt:  takenAt,
+		cellSize: cellSize,
+		isValid:  true,
+	}, nil
+}
+
+func (c *GetSupplyDemandQuery) TakenAt() time.Time {
+	return c.takenAt
+}
+
+func (c *GetSupplyDemandQuery) CellSize() int {
+	return c.cellSize
+}
+
+func (c *GetSupplyDemandQuery) IsValid() bool {
+	return c.isValid
+}
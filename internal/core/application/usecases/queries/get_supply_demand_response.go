@@ -0,0 +1,32 @@
+package queries
+
+import (
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/analytics"
+	"github.com/delivery/internal/core/domain/model/order"
+)
+
+type GetSupplyDemandResponse struct {
+	Snapshot analytics.Snapshot
+}
+
+// IdleCourierResponse is a courier none of whose storage places holds an order
+type IdleCourierResponse struct {
+	Location LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
+}
+
+func (IdleCourierResponse) TableName() string {
+	return "couriers"
+}
+
+type OrderSampleResponse struct {
+	Location   LocationResponse `gorm:"embedded;embeddedPrefix:location_"`
+	Status     order.Status
+	CreatedAt  time.Time
+	AssignedAt *time.Time
+}
+
+func (OrderSampleResponse) TableName() string {
+	return "orders"
+}
@@ -0,0 +1,91 @@
+package queries
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultPageLimit = 100
+	MaxPageLimit     = 500
+)
+
+// pageCursor is what the opaque cursor of a list carries, the sort it was cut by included,
+// so it is not applied to the list ordered another way
+type pageCursor struct {
+	Sort  string    `json:"s"`
+	Value string    `json:"v"`
+	ID    uuid.UUID `json:"id"`
+}
+
+// pageLimit applies the default to a missing limit
+func pageLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultPageLimit, nil
+	}
+	if limit < 0 || limit > MaxPageLimit {
+		return 0, errs.NewValidationErrorWithValue("limit", limit, "must be between 1 and 500")
+	}
+	return limit, nil
+}
+
+// parseSort splits a sort like "-name" into the field and the descending direction
+func parseSort(sort string, fallback string) (field string, desc bool) {
+	if sort == "" {
+		sort = fallback
+	}
+	return strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+}
+
+// parseArea reads a bounding box given as the x and y of one corner followed by the x and y of the other
+func parseArea(bbox []int) (*kernel.Rectangle, error) {
+	if len(bbox) == 0 {
+		return nil, nil
+	}
+	if len(bbox) != 4 {
+		return nil, errs.NewValidationErrorWithValue("bbox", bbox, "must be x1,y1,x2,y2")
+	}
+	from, err := kernel.NewLocation(min(bbox[0], bbox[2]), min(bbox[1], bbox[3]))
+	if err != nil {
+		return nil, errs.NewValidationErrorWithCause("bbox", "corner is out of the grid", err)
+	}
+	to, err := kernel.NewLocation(max(bbox[0], bbox[2]), max(bbox[1], bbox[3]))
+	if err != nil {
+		return nil, errs.NewValidationErrorWithCause("bbox", "corner is out of the grid", err)
+	}
+	area, err := kernel.NewRectangle(from, to)
+	if err != nil {
+		return nil, errs.NewValidationErrorWithCause("bbox", "is not an area", err)
+	}
+	return &area, nil
+}
+
+func encodeCursor(sort string, cursor ports.Cursor) string {
+	data, _ := json.Marshal(pageCursor{Sort: sort, Value: cursor.Value, ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reads the cursor a previous page handed out for the same sort, an empty one starts the list
+func decodeCursor(token string, sort string) (*ports.Cursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errs.NewValidationErrorWithCause("cursor", "is malformed", err)
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, errs.NewValidationErrorWithCause("cursor", "is malformed", err)
+	}
+	if cursor.Sort != sort {
+		return nil, errs.NewValidationErrorWithValue("cursor", token, "was made for the sort "+cursor.Sort)
+	}
+	return &ports.Cursor{Value: cursor.Value, ID: cursor.ID}, nil
+}
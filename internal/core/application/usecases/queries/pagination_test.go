@@ -0,0 +1,115 @@
+package queries
+
+import (
+	"testing"
+
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/core/ports"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGetAllCouriersQuery(t *testing.T) {
+	cursor := encodeCursor("-ordersCount", ports.Cursor{Value: "3", ID: uuid.New()})
+
+	tests := map[string]struct {
+		limit      int
+		cursor     string
+		sort       string
+		bbox       []int
+		wantLimit  int
+		wantSort   ports.CourierSort
+		wantDesc   bool
+		wantCursor bool
+		wantErr    bool
+	}{
+		"defaults": {
+			wantLimit: DefaultPageLimit,
+			wantSort:  ports.CourierSortName,
+		},
+		"descending with the cursor of the same sort": {
+			limit:      10,
+			cursor:     cursor,
+			sort:       "-ordersCount",
+			wantLimit:  10,
+			wantSort:   ports.CourierSortOrdersCount,
+			wantDesc:   true,
+			wantCursor: true,
+		},
+		"cursor of another sort": {
+			cursor:  cursor,
+			sort:    "ordersCount",
+			wantErr: true,
+		},
+		"malformed cursor": {
+			cursor:  "not a cursor",
+			wantErr: true,
+		},
+		"unknown sort": {
+			sort:    "speed",
+			wantErr: true,
+		},
+		"limit above the max": {
+			limit:   MaxPageLimit + 1,
+			wantErr: true,
+		},
+		"bbox is not four numbers": {
+			bbox:    []int{1, 1, 5},
+			wantErr: true,
+		},
+		"bbox out of the grid": {
+			bbox:    []int{0, 1, 5, 5},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			query, err := NewGetAllCouriersQuery(tc.limit, tc.cursor, tc.sort, "", tc.bbox)
+
+			if tc.wantErr {
+				assert.True(t, errs.IsValidation(err), "got %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantLimit, query.Limit())
+			assert.Equal(t, tc.wantSort, query.SortField())
+			assert.Equal(t, tc.wantDesc, query.Desc())
+			assert.Equal(t, tc.wantCursor, query.Cursor() != nil)
+		})
+	}
+}
+
+func TestNewGetAllUncompletedOrdersQuery(t *testing.T) {
+	t.Run("takes the corners of the bbox in any order", func(t *testing.T) {
+		query, err := NewGetAllUncompletedOrdersQuery(0, "", "-volume", []order.Status{order.Created}, "bo",
+			[]int{5, 7, 2, 3})
+
+		require.NoError(t, err)
+		area := query.Filter().Area
+		require.NotNil(t, area)
+		assert.Equal(t, 2, area.From().X())
+		assert.Equal(t, 3, area.From().Y())
+		assert.Equal(t, 5, area.To().X())
+		assert.Equal(t, 7, area.To().Y())
+		assert.Equal(t, "bo", query.Filter().CourierNamePrefix)
+		assert.Equal(t, ports.OrderSortVolume, query.SortField())
+	})
+
+	t.Run("rejects the status of a completed order", func(t *testing.T) {
+		_, err := NewGetAllUncompletedOrdersQuery(0, "", "", []order.Status{order.Completed}, "", nil)
+
+		assert.True(t, errs.IsValidation(err), "got %v", err)
+	})
+
+	t.Run("a cursor made for the default sort is valid for the sort named explicitly", func(t *testing.T) {
+		cursor := encodeCursor("createdAt", ports.Cursor{Value: "2025-03-01T12:00:00Z", ID: uuid.New()})
+
+		query, err := NewGetAllUncompletedOrdersQuery(0, cursor, "createdAt", nil, "", nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2025-03-01T12:00:00Z", query.Cursor().Value)
+	})
+}
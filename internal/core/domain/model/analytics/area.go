@@ -0,0 +1,56 @@
+package analytics
+
+import (
+	"fmt"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// Area is a part of the grid supply and demand are counted in: a service zone or a grid cell
+type Area struct {
+	zoneID *uuid.UUID
+	name   string
+	bounds kernel.Rectangle
+}
+
+func NewZoneArea(zoneID uuid.UUID, name string, bounds kernel.Rectangle) (Area, error) {
+	if zoneID == uuid.Nil {
+		return Area{}, errs.NewValueIsRequiredError("zone id")
+	}
+	if name == "" {
+		return Area{}, errs.NewValueIsRequiredError("zone name")
+	}
+	return Area{zoneID: &zoneID, name: name, bounds: bounds}, nil
+}
+
+// CellAreas splits the grid into cells of the given size, a cell is named by its corners
+func CellAreas(size int) ([]Area, error) {
+	cells, err := kernel.GridCells(size)
+	if err != nil {
+		return nil, errs.NewValidationErrorWithValue("cell size", size, "must be greater than zero")
+	}
+
+	areas := make([]Area, len(cells))
+	for i, cell := range cells {
+		areas[i] = Area{
+			name:   fmt.Sprintf("%d:%d-%d:%d", cell.From().X(), cell.From().Y(), cell.To().X(), cell.To().Y()),
+			bounds: cell,
+		}
+	}
+	return areas, nil
+}
+
+// ZoneID is nil for a grid cell
+func (a Area) ZoneID() *uuid.UUID {
+	return a.zoneID
+}
+
+func (a Area) Name() string {
+	return a.name
+}
+
+func (a Area) Bounds() kernel.Rectangle {
+	return a.bounds
+}
@@ -0,0 +1,54 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+// Rules name the sliding windows the waits are averaged over and the demand to supply ratio that is a surge
+type Rules struct {
+	windows    []time.Duration
+	surgeRatio float64
+}
+
+func NewRules(windows []time.Duration, surgeRatio float64) (Rules, error) {
+	if len(windows) == 0 {
+		return Rules{}, errs.NewValueIsRequiredError("windows")
+	}
+	for _, window := range windows {
+		if window <= 0 {
+			return Rules{}, errs.NewValidationErrorWithValue("windows", window, "must be greater than zero")
+		}
+	}
+	if surgeRatio <= 0 {
+		return Rules{}, errs.NewValidationErrorWithValue("surge ratio", surgeRatio, "must be greater than zero")
+	}
+
+	return Rules{
+		windows:    append([]time.Duration(nil), windows...),
+		surgeRatio: surgeRatio,
+	}, nil
+}
+
+func (r Rules) Windows() []time.Duration {
+	return r.windows
+}
+
+func (r Rules) SurgeRatio() float64 {
+	return r.surgeRatio
+}
+
+// longestWindow is how far back the orders of a snapshot go
+func (r Rules) longestWindow() time.Duration {
+	var longest time.Duration
+	for _, window := range r.windows {
+		longest = max(longest, window)
+	}
+	return longest
+}
+
+// Since is the creation time of the oldest order a snapshot taken at the given time needs
+func (r Rules) Since(takenAt time.Time) time.Time {
+	return takenAt.Add(-r.longestWindow())
+}
@@ -0,0 +1,163 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+)
+
+// OrderSample is what a snapshot needs to know of an order
+type OrderSample struct {
+	Location   kernel.Location
+	CreatedAt  time.Time
+	AssignedAt *time.Time
+	// Waiting is true while the order is Created, whether it was never assigned or came back to the queue
+	Waiting bool
+}
+
+// Snapshot is the supply and demand of every area at one moment
+type Snapshot struct {
+	takenAt time.Time
+	areas   []AreaSnapshot
+}
+
+// AreaSnapshot counts the idle couriers and the waiting orders of an area
+type AreaSnapshot struct {
+	area       Area
+	supply     int
+	demand     int
+	surgeRatio float64
+	surge      bool
+	windows    []WindowSnapshot
+}
+
+// WindowSnapshot covers the orders of an area created within the window before the snapshot
+type WindowSnapshot struct {
+	window      time.Duration
+	orders      int
+	averageWait time.Duration
+}
+
+// NewSnapshot places the idle couriers and the orders in the areas by location; a waiting order waits
+// until the snapshot is taken, any other one until it was assigned
+func NewSnapshot(takenAt time.Time, rules Rules, areas []Area, idleCouriers []kernel.Location,
+	orders []OrderSample) Snapshot {
+	snapshot := Snapshot{
+		takenAt: takenAt,
+		areas:   make([]AreaSnapshot, len(areas)),
+	}
+
+	for i, area := range areas {
+		s := AreaSnapshot{
+			area:    area,
+			windows: make([]WindowSnapshot, len(rules.windows)),
+		}
+		for _, location := range idleCouriers {
+			if area.bounds.Contains(location) {
+				s.supply++
+			}
+		}
+
+		waits := make([]time.Duration, len(rules.windows))
+		for _, o := range orders {
+			if !area.bounds.Contains(o.Location) {
+				continue
+			}
+			if o.Waiting {
+				s.demand++
+			}
+			wait, ok := o.wait(takenAt)
+			if !ok {
+				continue
+			}
+			for w, window := range rules.windows {
+				if !o.CreatedAt.Before(takenAt.Add(-window)) {
+					s.windows[w].orders++
+					waits[w] += wait
+				}
+			}
+		}
+
+		for w, window := range rules.windows {
+			s.windows[w].window = window
+			if s.windows[w].orders > 0 {
+				s.windows[w].averageWait = waits[w] / time.Duration(s.windows[w].orders)
+			}
+		}
+		s.surgeRatio = surgeRatio(s.demand, s.supply)
+		s.surge = s.demand > 0 && (s.supply == 0 || s.surgeRatio >= rules.surgeRatio)
+
+		snapshot.areas[i] = s
+	}
+
+	return snapshot
+}
+
+// surgeRatio is the number of waiting orders per idle courier, without couriers every order counts in full
+func surgeRatio(demand, supply int) float64 {
+	if supply == 0 {
+		return float64(demand)
+	}
+	return float64(demand) / float64(supply)
+}
+
+func (o OrderSample) wait(takenAt time.Time) (time.Duration, bool) {
+	switch {
+	case o.Waiting:
+		return takenAt.Sub(o.CreatedAt), true
+	case o.AssignedAt != nil:
+		return o.AssignedAt.Sub(o.CreatedAt), true
+	default:
+		return 0, false
+	}
+}
+
+func (s Snapshot) TakenAt() time.Time {
+	return s.takenAt
+}
+
+func (s Snapshot) Areas() []AreaSnapshot {
+	return s.areas
+}
+
+func (s AreaSnapshot) Area() Area {
+	return s.area
+}
+
+// Supply is the number of couriers in the area carrying no order
+func (s AreaSnapshot) Supply() int {
+	return s.supply
+}
+
+// Demand is the number of orders in the area no courier took yet
+func (s AreaSnapshot) Demand() int {
+	return s.demand
+}
+
+func (s AreaSnapshot) SurgeRatio() float64 {
+	return s.surgeRatio
+}
+
+// Surge reports that the area has waiting orders and no idle courier or at least the surge ratio
+// of waiting orders per idle courier
+func (s AreaSnapshot) Surge() bool {
+	return s.surge
+}
+
+func (s AreaSnapshot) Windows() []WindowSnapshot {
+	return s.windows
+}
+
+func (w WindowSnapshot) Window() time.Duration {
+	return w.window
+}
+
+// Orders is the number of orders created within the window
+func (w WindowSnapshot) Orders() int {
+	return w.orders
+}
+
+// AverageWait is how long the orders of the window waited for a courier on average
+func (w WindowSnapshot) AverageWait() time.Duration {
+	return w.averageWait
+}
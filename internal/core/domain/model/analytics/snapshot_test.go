@@ -0,0 +1,134 @@
+package analytics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	mustCreateLocation := func(x, y int) kernel.Location {
+		location, err := kernel.NewLocation(x, y)
+		require.NoError(t, err)
+		return location
+	}
+	ago := func(d time.Duration) *time.Time {
+		at := now.Add(-d)
+		return &at
+	}
+
+	bounds, err := kernel.NewRectangle(mustCreateLocation(1, 1), mustCreateLocation(5, 5))
+	require.NoError(t, err)
+	north, err := NewZoneArea(uuid.New(), "north", bounds)
+	require.NoError(t, err)
+	rules, err := NewRules([]time.Duration{5 * time.Minute, 30 * time.Minute}, 2)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		idleCouriers    []kernel.Location
+		orders          []OrderSample
+		wantSupply      int
+		wantDemand      int
+		wantSurgeRatio  float64
+		wantSurge       bool
+		wantOrders      []int
+		wantAverageWait []time.Duration
+	}{
+		"quiet area": {
+			idleCouriers:    []kernel.Location{mustCreateLocation(1, 1), mustCreateLocation(5, 5), mustCreateLocation(6, 6)},
+			wantSupply:      2,
+			wantOrders:      []int{0, 0},
+			wantAverageWait: []time.Duration{0, 0},
+		},
+		"more waiting orders than idle couriers": {
+			idleCouriers: []kernel.Location{mustCreateLocation(2, 2)},
+			orders: []OrderSample{
+				{Location: mustCreateLocation(3, 3), CreatedAt: *ago(4 * time.Minute), Waiting: true},
+				{Location: mustCreateLocation(3, 4), CreatedAt: *ago(20 * time.Minute), Waiting: true},
+				{Location: mustCreateLocation(4, 4), CreatedAt: *ago(10 * time.Minute), AssignedAt: ago(8 * time.Minute)},
+				{Location: mustCreateLocation(9, 9), CreatedAt: *ago(time.Minute), Waiting: true},
+			},
+			wantSupply:      1,
+			wantDemand:      2,
+			wantSurgeRatio:  2,
+			wantSurge:       true,
+			wantOrders:      []int{1, 3},
+			wantAverageWait: []time.Duration{4 * time.Minute, (4 + 20 + 2) * time.Minute / 3},
+		},
+		"waiting order without idle couriers": {
+			orders: []OrderSample{
+				{Location: mustCreateLocation(1, 5), CreatedAt: *ago(time.Minute), Waiting: true},
+			},
+			wantDemand:      1,
+			wantSurgeRatio:  1,
+			wantSurge:       true,
+			wantOrders:      []int{1, 1},
+			wantAverageWait: []time.Duration{time.Minute, time.Minute},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			snapshot := NewSnapshot(now, rules, []Area{north}, tc.idleCouriers, tc.orders)
+
+			require.Len(t, snapshot.Areas(), 1)
+			area := snapshot.Areas()[0]
+			assert.Equal(t, now, snapshot.TakenAt())
+			assert.Equal(t, north, area.Area())
+			assert.Equal(t, tc.wantSupply, area.Supply())
+			assert.Equal(t, tc.wantDemand, area.Demand())
+			assert.Equal(t, tc.wantSurgeRatio, area.SurgeRatio())
+			assert.Equal(t, tc.wantSurge, area.Surge())
+			for i, window := range area.Windows() {
+				assert.Equal(t, rules.Windows()[i], window.Window())
+				assert.Equal(t, tc.wantOrders[i], window.Orders())
+				assert.Equal(t, tc.wantAverageWait[i], window.AverageWait())
+			}
+		})
+	}
+}
+
+func TestCellAreas(t *testing.T) {
+	areas, err := CellAreas(5)
+
+	require.NoError(t, err)
+	require.Len(t, areas, 4)
+	assert.Nil(t, areas[0].ZoneID())
+	assert.Equal(t, "1:1-5:5", areas[0].Name())
+	assert.Equal(t, "6:6-10:10", areas[3].Name())
+
+	_, err = CellAreas(0)
+	assert.Error(t, err)
+}
+
+func TestNewRules(t *testing.T) {
+	tests := map[string]struct {
+		windows    []time.Duration
+		surgeRatio float64
+		wantErr    bool
+	}{
+		"windows and a ratio": {windows: []time.Duration{time.Minute, time.Hour}, surgeRatio: 1.5},
+		"no windows":          {surgeRatio: 2, wantErr: true},
+		"empty window":        {windows: []time.Duration{0}, surgeRatio: 2, wantErr: true},
+		"no ratio":            {windows: []time.Duration{time.Minute}, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rules, err := NewRules(tc.windows, tc.surgeRatio)
+
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			assert.Equal(t, now.Add(-time.Hour), rules.Since(now))
+		})
+	}
+}
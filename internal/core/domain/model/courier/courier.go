@@ -2,6 +2,7 @@ package courier
 
 import (
 	"math"
+	"time"
 
 	"github.com/delivery/internal/core/domain/model/kernel"
 	"github.com/delivery/internal/core/domain/model/order"
@@ -16,6 +17,16 @@ type Courier struct {
 	speed         int
 	location      kernel.Location
 	storagePlaces []*StoragePlace
+	movedAt       time.Time
+	moveProgress  float64
+	// progressedAt is when the courier last got closer to its orders
+	progressedAt time.Time
+	// declinedOffers counts the offers the courier turned down
+	declinedOffers int
+	// homeZoneID is the service zone the courier is based in, nil for a courier working everywhere
+	homeZoneID *uuid.UUID
+	// stallReportedAt is when the operators were last told the courier stalled
+	stallReportedAt time.Time
 }
 
 func NewCourier(name string, speed int, location kernel.Location) (*Courier, error) {
@@ -28,22 +39,33 @@ func NewCourier(name string, speed int, location kernel.Location) (*Courier, err
 	}
 
 	courierID := uuid.New()
-	return &Courier{
+	c := &Courier{
 		BaseAggregate: ddd.NewBaseAggregate[uuid.UUID](courierID),
 		name:          name,
 		speed:         speed,
 		location:      location,
 		storagePlaces: make([]*StoragePlace, 0),
-	}, nil
+	}
+	c.RaiseDomainEvent(NewCreatedDomainEvent(c))
+
+	return c, nil
 }
 
-func RestoreCourier(id uuid.UUID, name string, speed int, location kernel.Location, storagePlaces []*StoragePlace) *Courier {
+func RestoreCourier(id uuid.UUID, name string, speed int, location kernel.Location, storagePlaces []*StoragePlace,
+	movedAt time.Time, moveProgress float64, progressedAt time.Time, declinedOffers int, homeZoneID *uuid.UUID,
+	stallReportedAt time.Time, version int) *Courier {
 	return &Courier{
-		BaseAggregate: ddd.NewBaseAggregate[uuid.UUID](id),
-		name:          name,
-		speed:         speed,
-		location:      location,
-		storagePlaces: storagePlaces,
+		BaseAggregate:   ddd.RestoreBaseAggregate[uuid.UUID](id, version),
+		name:            name,
+		speed:           speed,
+		location:        location,
+		storagePlaces:   storagePlaces,
+		movedAt:         movedAt,
+		moveProgress:    moveProgress,
+		progressedAt:    progressedAt,
+		declinedOffers:  declinedOffers,
+		homeZoneID:      homeZoneID,
+		stallReportedAt: stallReportedAt,
 	}
 }
 
@@ -61,10 +83,32 @@ func (c *Courier) AddStoragePlace(name string, volume int) error {
 	}
 
 	c.storagePlaces = append(c.storagePlaces, storagePlace)
+	c.RaiseDomainEvent(NewStoragePlaceAddedDomainEvent(c, storagePlace))
 
 	return nil
 }
 
+// AssignHomeZone bases the courier in the service zone, dispatch prefers it for the orders there
+func (c *Courier) AssignHomeZone(zoneID uuid.UUID) error {
+	if zoneID == uuid.Nil {
+		return errs.NewValueIsRequiredError("zone id")
+	}
+	if c.homeZoneID != nil && *c.homeZoneID == zoneID {
+		return nil
+	}
+
+	c.homeZoneID = &zoneID
+	c.RaiseDomainEvent(NewHomeZoneAssignedDomainEvent(c))
+
+	return nil
+}
+
+// InZone reports whether the courier serves the zone as its own, a courier without
+// a home zone and an order without a zone belong everywhere
+func (c *Courier) InZone(zoneID *uuid.UUID) bool {
+	return c.homeZoneID == nil || zoneID == nil || *c.homeZoneID == *zoneID
+}
+
 func (c *Courier) CanTakeOrder(order *order.Order) (bool, error) {
 	if order == nil {
 		return false, errs.NewValueIsRequiredError("order")
@@ -110,17 +154,103 @@ func (c *Courier) TakeOrder(order *order.Order) error {
 	return errs.NewBusinessError("courier can't take order", "all storage places are occupied")
 }
 
-func (c *Courier) CompleteOrder(order *order.Order) error {
+// CompleteOrder hands the order over, the courier has to be at the customer for that
+func (c *Courier) CompleteOrder(order *order.Order, proof *order.ProofOfDelivery) error {
 	if order == nil {
 		return errs.NewValueIsRequiredError("order")
 	}
 
-	if err := order.Complete(); err != nil {
+	if _, err := c.findStoragePlaceByOrderID(order.ID()); err != nil {
 		return err
 	}
 
-	orderID := order.ID()
+	if !c.location.Equals(order.Location()) {
+		return errs.NewBusinessError("complete order", "the courier has not reached the customer yet")
+	}
+
+	if err := order.Complete(proof); err != nil {
+		return err
+	}
+
+	return c.releaseStoragePlace(order.ID())
+}
+
+// DeclineOrder turns down an order the courier was offered, frees the room kept for it
+// and counts the declined offer
+func (c *Courier) DeclineOrder(order *order.Order, reason string) error {
+	if order == nil {
+		return errs.NewValueIsRequiredError("order")
+	}
+
+	if _, err := c.findStoragePlaceByOrderID(order.ID()); err != nil {
+		return err
+	}
+
+	if err := order.Decline(c.ID(), reason); err != nil {
+		return err
+	}
+
+	c.declinedOffers++
+	return c.releaseStoragePlace(order.ID())
+}
+
+// UnassignOrder gives up an order the courier can't deliver, e.g. after a breakdown
+func (c *Courier) UnassignOrder(order *order.Order, reason string) error {
+	if order == nil {
+		return errs.NewValueIsRequiredError("order")
+	}
+
+	if _, err := c.findStoragePlaceByOrderID(order.ID()); err != nil {
+		return err
+	}
+
+	if err := order.Unassign(reason); err != nil {
+		return err
+	}
+
+	return c.releaseStoragePlace(order.ID())
+}
 
+// Stalled reports whether the courier holds the orders but has made no progress with them for the timeout:
+// it has not got closer to the picked up ones, nor picked up the others since it was given them.
+// A courier waiting at the customer to hand an order over is not stalled.
+func (c *Courier) Stalled(orders []*order.Order, now time.Time, timeout time.Duration) bool {
+	if !c.hasOrders() {
+		return false
+	}
+
+	// the oldest order waiting for the pickup, an order given later does not restart the clock
+	var waitingSince time.Time
+	for _, o := range orders {
+		if o.Status() == order.PickedUp || o.AssignedAt().IsZero() {
+			continue
+		}
+		if waitingSince.IsZero() || o.AssignedAt().Before(waitingSince) {
+			waitingSince = o.AssignedAt()
+		}
+	}
+	since := c.progressedAt
+	if waitingSince.After(since) {
+		since = waitingSince
+	}
+	if since.IsZero() {
+		return false
+	}
+	return now.Sub(since) >= timeout
+}
+
+// ReportStall flags the picked up orders of a stalled courier for the operators, the orders are in its bag
+// and can't go to another courier. A stall is reported once, until the courier gets closer to its orders again.
+func (c *Courier) ReportStall(orderIDs []uuid.UUID, now time.Time) bool {
+	if len(orderIDs) == 0 || c.stallReportedAt.After(c.progressedAt) {
+		return false
+	}
+	c.stallReportedAt = now
+	c.RaiseDomainEvent(NewStalledDomainEvent(c, orderIDs))
+	return true
+}
+
+func (c *Courier) releaseStoragePlace(orderID uuid.UUID) error {
 	storagePlace, err := c.findStoragePlaceByOrderID(orderID)
 	if err != nil {
 		return err
@@ -130,6 +260,14 @@ func (c *Courier) CompleteOrder(order *order.Order) error {
 		return err
 	}
 
+	if !c.hasOrders() {
+		// an idle courier is not on a route, the next one starts from scratch
+		c.movedAt = time.Time{}
+		c.moveProgress = 0
+		c.progressedAt = time.Time{}
+		c.stallReportedAt = time.Time{}
+	}
+
 	return nil
 }
 
@@ -142,18 +280,45 @@ func (c *Courier) CalculateTimeToLocation(target kernel.Location) float64 {
 	return float64(distance) / float64(courierSpeed)
 }
 
-func (c *Courier) Move(target kernel.Location) error {
+// Move advances the courier towards the target by the time elapsed since the previous move
+// times its speed in cells per second. The fraction of a cell left over is kept for the next move.
+// maxElapsed caps the time a single move can account for, so a courier resumes its route after
+// a pause instead of jumping over the time the moves were not made.
+func (c *Courier) Move(target kernel.Location, now time.Time, maxElapsed time.Duration) error {
+	if now.IsZero() {
+		return errs.NewValueIsRequiredError("now")
+	}
+	if maxElapsed <= 0 {
+		return errs.NewValueIsRequiredError("max elapsed")
+	}
+
+	if c.movedAt.IsZero() || now.Before(c.movedAt) {
+		// the route starts now
+		c.movedAt = now
+		c.progressedAt = now
+		return nil
+	}
+
+	elapsed := now.Sub(c.movedAt)
+	if elapsed > maxElapsed {
+		elapsed = maxElapsed
+	}
+
+	distance := c.moveProgress + elapsed.Seconds()*float64(c.speed)
+	// tolerate float rounding so that e.g. 3 x 1/3 of a cell makes a whole cell
+	cells := math.Floor(distance + 1e-9)
+
 	x := float64(target.X() - c.location.X())
 	y := float64(target.Y() - c.location.Y())
-	speed := float64(c.speed)
+	left := cells
 
-	if math.Abs(x) > speed {
-		x = math.Copysign(speed, x)
+	if math.Abs(x) > left {
+		x = math.Copysign(left, x)
 	}
-	speed -= math.Abs(x)
+	left -= math.Abs(x)
 
-	if math.Abs(y) > speed {
-		y = math.Copysign(speed, y)
+	if math.Abs(y) > left {
+		y = math.Copysign(left, y)
 	}
 
 	newX := c.location.X() + int(x)
@@ -163,10 +328,32 @@ func (c *Courier) Move(target kernel.Location) error {
 	if err != nil {
 		return err
 	}
+	from := c.location
 	c.location = newLocation
+	c.movedAt = now
+	if !newLocation.Equals(from) {
+		c.progressedAt = now
+		c.RaiseDomainEvent(NewMovedDomainEvent(c, from))
+	}
+	if newLocation.Equals(target) {
+		// waiting at the target for the handover is progress too
+		c.progressedAt = now
+		c.moveProgress = 0
+	} else {
+		c.moveProgress = math.Max(0, distance-cells)
+	}
 	return nil
 }
 
+func (c *Courier) hasOrders() bool {
+	for _, v := range c.storagePlaces {
+		if v.OrderID() != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *Courier) findStoragePlaceByOrderID(orderID uuid.UUID) (*StoragePlace, error) {
 	for _, v := range c.storagePlaces {
 		if v.OrderID() != nil && *v.OrderID() == orderID {
@@ -196,3 +383,27 @@ func (c *Courier) Location() kernel.Location {
 func (c *Courier) StoragePlaces() []*StoragePlace {
 	return c.storagePlaces
 }
+
+func (c *Courier) MovedAt() time.Time {
+	return c.movedAt
+}
+
+func (c *Courier) MoveProgress() float64 {
+	return c.moveProgress
+}
+
+func (c *Courier) ProgressedAt() time.Time {
+	return c.progressedAt
+}
+
+func (c *Courier) DeclinedOffers() int {
+	return c.declinedOffers
+}
+
+func (c *Courier) HomeZoneID() *uuid.UUID {
+	return c.homeZoneID
+}
+
+func (c *Courier) StallReportedAt() time.Time {
+	return c.stallReportedAt
+}
@@ -0,0 +1,31 @@
+package courier
+
+import (
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/pkg/ddd"
+)
+
+const CreatedEventName = "CourierCreated"
+
+var _ ddd.DomainEvent = &CreatedDomainEvent{}
+
+type CreatedDomainEvent struct {
+	ddd.BaseEvent
+
+	Name     string
+	Speed    int
+	Location kernel.Location
+}
+
+func NewCreatedDomainEvent(payload *Courier) *CreatedDomainEvent {
+	return &CreatedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(CreatedEventName, payload.ID()),
+		Name:      payload.Name(),
+		Speed:     payload.Speed(),
+		Location:  payload.Location(),
+	}
+}
+
+func NewCreatedDomainEventWithoutData() *CreatedDomainEvent {
+	return &CreatedDomainEvent{BaseEvent: ddd.BaseEvent{Name: CreatedEventName}}
+}
@@ -0,0 +1,100 @@
+package courier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCourier_TransitionsRaiseOneEvent(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		transition func(t *testing.T, c *Courier)
+		expected   ddd.DomainEvent
+	}{
+		"add storage place": {
+			transition: func(t *testing.T, c *Courier) {
+				require.NoError(t, c.AddStoragePlace("bag", 10))
+			},
+			expected: NewStoragePlaceAddedDomainEventWithoutData(),
+		},
+		"move a cell": {
+			transition: func(t *testing.T, c *Courier) {
+				require.NoError(t, c.Move(mustCreateLocation(5, 5), start, testMaxElapsed))
+				require.NoError(t, c.Move(mustCreateLocation(5, 5), start.Add(time.Second), testMaxElapsed))
+			},
+			expected: NewMovedDomainEventWithoutData(),
+		},
+		"assign home zone": {
+			transition: func(t *testing.T, c *Courier) {
+				zoneID := uuid.New()
+				require.NoError(t, c.AssignHomeZone(zoneID))
+				require.NoError(t, c.AssignHomeZone(zoneID))
+			},
+			expected: NewHomeZoneAssignedDomainEventWithoutData(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c, err := NewCourier("courier", 1, mustCreateLocation(1, 1))
+			require.NoError(t, err)
+			c.ClearDomainEvents()
+
+			tc.transition(t, c)
+
+			events := c.GetDomainEvents()
+			require.Len(t, events, 1)
+			assert.IsType(t, tc.expected, events[0])
+			assert.Equal(t, tc.expected.GetName(), events[0].GetName())
+			assert.Equal(t, c.ID(), events[0].GetAggregateID())
+			assert.Equal(t, c.Version(), events[0].GetVersion())
+			assert.False(t, events[0].GetOccurredAt().IsZero())
+		})
+	}
+}
+
+func TestNewCourier_RaisesCreated(t *testing.T) {
+	c, err := NewCourier("courier", 2, mustCreateLocation(1, 1))
+	require.NoError(t, err)
+
+	events := c.GetDomainEvents()
+	require.Len(t, events, 1)
+	created, ok := events[0].(*CreatedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, CreatedEventName, created.GetName())
+	assert.Equal(t, 1, created.GetVersion())
+	assert.Equal(t, 2, created.Speed)
+}
+
+func TestCourier_Move_StandingStillRaisesNothing(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c, err := NewCourier("courier", 1, mustCreateLocation(1, 1))
+	require.NoError(t, err)
+	c.ClearDomainEvents()
+
+	// the first move only starts the route and the second one is already at the target
+	require.NoError(t, c.Move(mustCreateLocation(1, 1), start, testMaxElapsed))
+	require.NoError(t, c.Move(mustCreateLocation(1, 1), start.Add(time.Second), testMaxElapsed))
+
+	assert.Empty(t, c.GetDomainEvents())
+}
+
+func TestCourier_Move_RaisesFromAndTo(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c, err := NewCourier("courier", 1, mustCreateLocation(1, 1))
+	require.NoError(t, err)
+	require.NoError(t, c.Move(mustCreateLocation(5, 5), start, testMaxElapsed))
+
+	require.NoError(t, c.Move(mustCreateLocation(5, 5), start.Add(time.Second), testMaxElapsed))
+
+	events := c.GetDomainEvents()
+	moved, ok := events[len(events)-1].(*MovedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, mustCreateLocation(1, 1), moved.From)
+	assert.Equal(t, mustCreateLocation(2, 1), moved.To)
+}
@@ -0,0 +1,27 @@
+package courier
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const HomeZoneAssignedEventName = "CourierHomeZoneAssigned"
+
+var _ ddd.DomainEvent = &HomeZoneAssignedDomainEvent{}
+
+type HomeZoneAssignedDomainEvent struct {
+	ddd.BaseEvent
+
+	ZoneID uuid.UUID
+}
+
+func NewHomeZoneAssignedDomainEvent(payload *Courier) *HomeZoneAssignedDomainEvent {
+	return &HomeZoneAssignedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(HomeZoneAssignedEventName, payload.ID()),
+		ZoneID:    *payload.HomeZoneID(),
+	}
+}
+
+func NewHomeZoneAssignedDomainEventWithoutData() *HomeZoneAssignedDomainEvent {
+	return &HomeZoneAssignedDomainEvent{BaseEvent: ddd.BaseEvent{Name: HomeZoneAssignedEventName}}
+}
@@ -0,0 +1,29 @@
+package courier
+
+import (
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/pkg/ddd"
+)
+
+const MovedEventName = "CourierMoved"
+
+var _ ddd.DomainEvent = &MovedDomainEvent{}
+
+type MovedDomainEvent struct {
+	ddd.BaseEvent
+
+	From kernel.Location
+	To   kernel.Location
+}
+
+func NewMovedDomainEvent(payload *Courier, from kernel.Location) *MovedDomainEvent {
+	return &MovedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(MovedEventName, payload.ID()),
+		From:      from,
+		To:        payload.Location(),
+	}
+}
+
+func NewMovedDomainEventWithoutData() *MovedDomainEvent {
+	return &MovedDomainEvent{BaseEvent: ddd.BaseEvent{Name: MovedEventName}}
+}
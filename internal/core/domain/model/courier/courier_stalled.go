@@ -0,0 +1,33 @@
+package courier
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const StalledEventName = "CourierStalled"
+
+var _ ddd.DomainEvent = &StalledDomainEvent{}
+
+// StalledDomainEvent flags the orders a stalled courier has picked up for the operators
+type StalledDomainEvent struct {
+	ddd.BaseEvent
+
+	OrderIDs []uuid.UUID
+	// Since is when the courier last got closer to its orders
+	Since time.Time
+}
+
+func NewStalledDomainEvent(payload *Courier, orderIDs []uuid.UUID) *StalledDomainEvent {
+	return &StalledDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(StalledEventName, payload.ID()),
+		OrderIDs:  orderIDs,
+		Since:     payload.ProgressedAt(),
+	}
+}
+
+func NewStalledDomainEventWithoutData() *StalledDomainEvent {
+	return &StalledDomainEvent{BaseEvent: ddd.BaseEvent{Name: StalledEventName}}
+}
@@ -2,14 +2,21 @@ package courier
 
 import (
 	"testing"
+	"time"
 
 	"github.com/delivery/internal/core/domain/model/kernel"
 	"github.com/delivery/internal/core/domain/model/order"
 	"github.com/delivery/internal/pkg/errs"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// testMaxElapsed is the cap of a move of a job running every second
+const testMaxElapsed = 5 * time.Second
+
 func TestNewCourier(t *testing.T) {
 	validLocation := mustCreateLocation(1, 1)
 	tests := map[string]struct {
@@ -169,50 +176,132 @@ func TestCourier_TakeOrder(t *testing.T) {
 }
 
 func TestCourier_CompleteOrder(t *testing.T) {
-	ord := mustCreateOrder(uuid.New())
+	pin, err := order.NewProofOfDelivery("", "1234")
+	assert.NoError(t, err)
 	tests := map[string]struct {
-		order   *order.Order
-		wantErr bool
-		err     error
+		nilOrder bool
+		location kernel.Location
+		stored   bool
+		pickedUp bool
+		wantErr  bool
+		err      error
 	}{
-		"can successfully complete order": {
-			order:   ord,
-			wantErr: false,
-			err:     nil,
+		"courier hands the order over at the customer": {
+			location: mustCreateLocation(1, 1),
+			stored:   true,
+			pickedUp: true,
+		},
+		"courier has not reached the customer": {
+			location: mustCreateLocation(3, 3),
+			stored:   true,
+			pickedUp: true,
+			wantErr:  true,
+			err:      errs.ErrBusiness,
+		},
+		"order was not picked up": {
+			location: mustCreateLocation(1, 1),
+			stored:   true,
+			wantErr:  true,
+			err:      errs.ErrBusiness,
+		},
+		"order is not carried by the courier": {
+			location: mustCreateLocation(1, 1),
+			wantErr:  true,
+			err:      errs.ErrBusiness,
 		},
 		"cant complete order, invalid order": {
-			order:   nil,
-			wantErr: true,
-			err:     errs.ErrValueIsRequired,
+			nilOrder: true,
+			location: mustCreateLocation(1, 1),
+			wantErr:  true,
+			err:      errs.ErrValueIsRequired,
 		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			courier, err := NewCourier("courier12", 2, mustCreateLocation(1, 1))
+			courier, err := NewCourier("courier12", 2, tc.location)
 			assert.NoError(t, err)
+			assert.NoError(t, courier.AddStoragePlace("storage place", 10))
+			ord := mustCreateOrder(uuid.New())
+			courierID := courier.ID()
+			assert.NoError(t, ord.Assign(&courierID, testNow))
+			assert.NoError(t, ord.Accept(courierID))
+			if tc.stored {
+				assert.NoError(t, courier.TakeOrder(ord))
+			}
+			if tc.pickedUp {
+				assert.NoError(t, ord.PickUp(courierID))
+			}
+			if tc.nilOrder {
+				ord = nil
+			}
 
-			if !tc.wantErr {
-				courierID := courier.ID()
-				err = ord.Assign(&courierID)
-				assert.NoError(t, err)
+			err = courier.CompleteOrder(ord, &pin)
 
-				err = courier.AddStoragePlace("storage place", 10)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				if ord != nil {
+					assert.NotEqual(t, order.Completed, ord.Status())
+				}
+			} else {
 				assert.NoError(t, err)
-				assert.GreaterOrEqual(t, len(courier.storagePlaces), 1)
+				assert.Equal(t, order.Completed, ord.Status())
+				assert.Equal(t, &pin, ord.Proof())
+				assert.Nil(t, courier.StoragePlaces()[0].OrderID())
+			}
+		})
+	}
+}
 
-				err = courier.TakeOrder(tc.order)
-				assert.NoError(t, err)
+func TestCourier_DeclineOrder(t *testing.T) {
+	tests := map[string]struct {
+		accepted bool
+		reason   string
+		wantErr  bool
+		err      error
+	}{
+		"courier turns the offer down": {
+			reason: "too far away",
+		},
+		"accepted order can not be declined": {
+			accepted: true,
+			reason:   "too far away",
+			wantErr:  true,
+			err:      errs.ErrBusiness,
+		},
+		"empty reason": {
+			reason:  "",
+			wantErr: true,
+			err:     errs.ErrValueIsRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			courier, err := NewCourier("test-courier", 1, mustCreateLocation(1, 1))
+			assert.NoError(t, err)
+			assert.NoError(t, courier.AddStoragePlace("bag", 10))
+			ord := mustCreateOrder(uuid.New())
+			courierID := courier.ID()
+			assert.NoError(t, ord.Assign(&courierID, testNow))
+			assert.NoError(t, courier.TakeOrder(ord))
+			if tc.accepted {
+				assert.NoError(t, ord.Accept(courierID))
 			}
 
-			err = courier.CompleteOrder(tc.order)
+			err = courier.DeclineOrder(ord, tc.reason)
 
 			if tc.wantErr {
-				assert.Error(t, err)
 				assert.ErrorIs(t, err, tc.err)
+				assert.NotNil(t, courier.StoragePlaces()[0].OrderID())
+				assert.Zero(t, courier.DeclinedOffers())
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, ord.Status(), order.Completed)
+				assert.Equal(t, order.Created, ord.Status())
+				assert.Nil(t, ord.CourierID())
+				assert.Equal(t, tc.reason, ord.UnassignReason())
+				assert.Nil(t, courier.StoragePlaces()[0].OrderID())
+				assert.Equal(t, 1, courier.DeclinedOffers())
 			}
 		})
 	}
@@ -237,8 +326,12 @@ func TestCourier_Move(t *testing.T) {
 		courierSpeed int
 		targetX      int
 		targetY      int
+		elapsed      time.Duration
+		// maxElapsed is testMaxElapsed when not set
+		maxElapsed   time.Duration
 		expectedX    int
 		expectedY    int
+		wantProgress float64
 		wantErr      bool
 	}{
 		"move within speed limit": {
@@ -247,6 +340,7 @@ func TestCourier_Move(t *testing.T) {
 			courierSpeed: 5,
 			targetX:      6,
 			targetY:      5,
+			elapsed:      time.Second,
 			expectedX:    6,
 			expectedY:    5,
 			wantErr:      false,
@@ -257,6 +351,7 @@ func TestCourier_Move(t *testing.T) {
 			courierSpeed: 3,
 			targetX:      10,
 			targetY:      10,
+			elapsed:      time.Second,
 			expectedX:    5,
 			expectedY:    2,
 			wantErr:      false,
@@ -267,6 +362,7 @@ func TestCourier_Move(t *testing.T) {
 			courierSpeed: 5,
 			targetX:      4,
 			targetY:      10,
+			elapsed:      time.Second,
 			expectedX:    4,
 			expectedY:    5,
 			wantErr:      false,
@@ -277,10 +373,67 @@ func TestCourier_Move(t *testing.T) {
 			courierSpeed: 10,
 			targetX:      5,
 			targetY:      5,
+			elapsed:      time.Second,
 			expectedX:    5,
 			expectedY:    5,
 			wantErr:      false,
 		},
+		"part of a cell is kept as progress": {
+			initialX:     2,
+			initialY:     2,
+			courierSpeed: 1,
+			targetX:      10,
+			targetY:      2,
+			elapsed:      1500 * time.Millisecond,
+			expectedX:    3,
+			expectedY:    2,
+			wantProgress: 0.5,
+			wantErr:      false,
+		},
+		"missed tick is caught up": {
+			initialX:     1,
+			initialY:     1,
+			courierSpeed: 2,
+			targetX:      10,
+			targetY:      1,
+			elapsed:      2 * time.Second,
+			expectedX:    5,
+			expectedY:    1,
+			wantErr:      false,
+		},
+		"long pause does not teleport": {
+			initialX:     1,
+			initialY:     1,
+			courierSpeed: 1,
+			targetX:      10,
+			targetY:      10,
+			elapsed:      time.Hour,
+			expectedX:    6,
+			expectedY:    1,
+			wantErr:      false,
+		},
+		"job running every 10 seconds moves the courier the whole tick": {
+			initialX:     1,
+			initialY:     1,
+			courierSpeed: 1,
+			targetX:      10,
+			targetY:      10,
+			elapsed:      10 * time.Second,
+			maxElapsed:   50 * time.Second,
+			expectedX:    10,
+			expectedY:    2,
+			wantErr:      false,
+		},
+		"no cap": {
+			initialX:     1,
+			initialY:     1,
+			courierSpeed: 1,
+			targetX:      10,
+			targetY:      1,
+			elapsed:      time.Second,
+			maxElapsed:   -time.Second,
+			wantErr:      true,
+		},
 	}
 
 	for name, tc := range tests {
@@ -294,7 +447,13 @@ func TestCourier_Move(t *testing.T) {
 			targetLocation, err := kernel.NewLocation(tc.targetX, tc.targetY)
 			assert.NoError(t, err)
 
-			err = courier.Move(targetLocation)
+			start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			maxElapsed := tc.maxElapsed
+			if maxElapsed == 0 {
+				maxElapsed = testMaxElapsed
+			}
+			assert.NoError(t, courier.Move(targetLocation, start, testMaxElapsed))
+			err = courier.Move(targetLocation, start.Add(tc.elapsed), maxElapsed)
 
 			if tc.wantErr {
 				assert.Error(t, err)
@@ -302,6 +461,271 @@ func TestCourier_Move(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.expectedX, courier.location.X())
 				assert.Equal(t, tc.expectedY, courier.location.Y())
+				assert.InDelta(t, tc.wantProgress, courier.MoveProgress(), 1e-9)
+			}
+		})
+	}
+}
+
+func TestCourier_Move_StartsRoute(t *testing.T) {
+	courier, err := NewCourier("test-courier", 2, mustCreateLocation(1, 1))
+	assert.NoError(t, err)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	err = courier.Move(mustCreateLocation(5, 5), now, testMaxElapsed)
+
+	assert.NoError(t, err)
+	assert.Equal(t, mustCreateLocation(1, 1), courier.Location())
+	assert.Equal(t, now, courier.MovedAt())
+}
+
+func TestCourier_Move_AccumulatesProgress(t *testing.T) {
+	courier, err := NewCourier("test-courier", 1, mustCreateLocation(1, 1))
+	assert.NoError(t, err)
+	target := mustCreateLocation(5, 1)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, courier.Move(target, now, testMaxElapsed))
+
+	for i := 0; i < 6; i++ {
+		now = now.Add(time.Second / 3)
+		assert.NoError(t, courier.Move(target, now, testMaxElapsed))
+	}
+
+	assert.Equal(t, mustCreateLocation(3, 1), courier.Location())
+	assert.InDelta(t, 0, courier.MoveProgress(), 1e-9)
+}
+
+func TestCourier_Move_InvalidTime(t *testing.T) {
+	courier, err := NewCourier("test-courier", 1, mustCreateLocation(1, 1))
+	assert.NoError(t, err)
+
+	err = courier.Move(mustCreateLocation(5, 1), time.Time{}, testMaxElapsed)
+
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
+
+func TestCourier_CompleteOrder_EndsRoute(t *testing.T) {
+	courier, err := NewCourier("test-courier", 1, mustCreateLocation(1, 1))
+	assert.NoError(t, err)
+	assert.NoError(t, courier.AddStoragePlace("bag", 10))
+	ord := mustCreateOrder(uuid.New())
+	courierID := courier.ID()
+	assert.NoError(t, ord.Assign(&courierID, testNow))
+	assert.NoError(t, ord.Accept(courierID))
+	assert.NoError(t, ord.PickUp(courierID))
+	assert.NoError(t, courier.TakeOrder(ord))
+	assert.NoError(t, courier.Move(ord.Location(), testNow, testMaxElapsed))
+
+	err = courier.CompleteOrder(ord, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, courier.MovedAt().IsZero())
+	assert.Zero(t, courier.MoveProgress())
+}
+
+func TestCourier_UnassignOrder(t *testing.T) {
+	tests := map[string]struct {
+		stored  bool
+		reason  string
+		wantErr bool
+		err     error
+	}{
+		"order is given up": {
+			stored: true,
+			reason: "bike broke down",
+		},
+		"order is not carried by the courier": {
+			stored:  false,
+			reason:  "bike broke down",
+			wantErr: true,
+			err:     errs.ErrBusiness,
+		},
+		"empty reason": {
+			stored:  true,
+			reason:  "",
+			wantErr: true,
+			err:     errs.ErrValueIsRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			courier, err := NewCourier("test-courier", 1, mustCreateLocation(1, 1))
+			assert.NoError(t, err)
+			assert.NoError(t, courier.AddStoragePlace("bag", 10))
+			ord := mustCreateOrder(uuid.New())
+			courierID := courier.ID()
+			assert.NoError(t, ord.Assign(&courierID, testNow))
+			if tc.stored {
+				assert.NoError(t, courier.TakeOrder(ord))
+				assert.NoError(t, courier.Move(mustCreateLocation(5, 5), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), testMaxElapsed))
+			}
+
+			err = courier.UnassignOrder(ord, tc.reason)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, order.Assigned, ord.Status())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, order.Created, ord.Status())
+				assert.Nil(t, courier.StoragePlaces()[0].OrderID())
+				assert.True(t, courier.ProgressedAt().IsZero())
+			}
+		})
+	}
+}
+
+func TestCourier_Stalled(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	timeout := 30 * time.Second
+	tests := map[string]struct {
+		held       []order.Status
+		atCustomer bool
+		moves      []time.Duration
+		now        time.Duration
+		expected   bool
+	}{
+		"courier keeps moving": {
+			held:     []order.Status{order.PickedUp},
+			moves:    []time.Duration{0, time.Second, 2 * time.Second},
+			now:      timeout + time.Second,
+			expected: false,
+		},
+		"courier has not moved for the timeout": {
+			held:     []order.Status{order.PickedUp},
+			moves:    []time.Duration{0, time.Second},
+			now:      timeout + time.Second,
+			expected: true,
+		},
+		"courier waits at the customer to hand the order over": {
+			held:       []order.Status{order.PickedUp},
+			atCustomer: true,
+			moves:      []time.Duration{0, 20 * time.Second},
+			now:        timeout + time.Second,
+			expected:   false,
+		},
+		"route has not started": {
+			held:     []order.Status{order.PickedUp},
+			now:      timeout,
+			expected: false,
+		},
+		"courier has not picked the accepted orders up for the timeout": {
+			held:     []order.Status{order.Accepted, order.Assigned},
+			now:      timeout,
+			expected: true,
+		},
+		"courier was given the orders lately": {
+			held:     []order.Status{order.Accepted},
+			now:      timeout - time.Second,
+			expected: false,
+		},
+		"courier on the move with the picked up orders is not held up by the others": {
+			held:     []order.Status{order.PickedUp, order.Accepted},
+			moves:    []time.Duration{0, timeout},
+			now:      timeout + time.Second,
+			expected: false,
+		},
+		"idle courier": {
+			now:      timeout,
+			expected: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			courier, err := NewCourier("test-courier", 1, mustCreateLocation(1, 1))
+			assert.NoError(t, err)
+			courierID := courier.ID()
+			var held []*order.Order
+			for _, status := range tc.held {
+				assert.NoError(t, courier.AddStoragePlace("bag", 10))
+				ord := mustCreateOrder(uuid.New())
+				assert.NoError(t, ord.Assign(&courierID, start))
+				if status != order.Assigned {
+					assert.NoError(t, ord.Accept(courierID))
+				}
+				if status == order.PickedUp {
+					assert.NoError(t, ord.PickUp(courierID))
+				}
+				assert.NoError(t, courier.TakeOrder(ord))
+				held = append(held, ord)
+			}
+			target := mustCreateLocation(10, 1)
+			if tc.atCustomer {
+				target = courier.Location()
+			}
+			for _, move := range tc.moves {
+				assert.NoError(t, courier.Move(target, start.Add(move), testMaxElapsed))
+			}
+
+			assert.Equal(t, tc.expected, courier.Stalled(held, start.Add(tc.now), timeout))
+		})
+	}
+}
+
+func TestCourier_ReportStall(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		reportedAt []time.Duration
+		movedAt    []time.Duration
+		noOrders   bool
+		expected   bool
+	}{
+		"first stall is reported": {
+			expected: true,
+		},
+		"stall reported already": {
+			reportedAt: []time.Duration{40 * time.Second},
+			expected:   false,
+		},
+		"courier got closer since the last report": {
+			reportedAt: []time.Duration{40 * time.Second},
+			movedAt:    []time.Duration{50 * time.Second},
+			expected:   true,
+		},
+		"no picked up orders to report": {
+			noOrders: true,
+			expected: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			courier, err := NewCourier("test-courier", 1, mustCreateLocation(1, 1))
+			require.NoError(t, err)
+			require.NoError(t, courier.AddStoragePlace("bag", 10))
+			ord := mustCreateOrder(uuid.New())
+			courierID := courier.ID()
+			require.NoError(t, ord.Assign(&courierID, testNow))
+			require.NoError(t, courier.TakeOrder(ord))
+			target := mustCreateLocation(10, 1)
+			require.NoError(t, courier.Move(target, start, testMaxElapsed))
+			require.NoError(t, courier.Move(target, start.Add(time.Second), testMaxElapsed))
+			orderIDs := []uuid.UUID{ord.ID()}
+			for _, at := range tc.reportedAt {
+				require.True(t, courier.ReportStall(orderIDs, start.Add(at)))
+			}
+			for _, at := range tc.movedAt {
+				require.NoError(t, courier.Move(target, start.Add(at), testMaxElapsed))
+				require.NoError(t, courier.Move(target, start.Add(at+time.Second), testMaxElapsed))
+			}
+			courier.ClearDomainEvents()
+			if tc.noOrders {
+				orderIDs = nil
+			}
+
+			reported := courier.ReportStall(orderIDs, start.Add(time.Minute))
+
+			assert.Equal(t, tc.expected, reported)
+			if tc.expected {
+				require.Len(t, courier.GetDomainEvents(), 1)
+				stall, ok := courier.GetDomainEvents()[0].(*StalledDomainEvent)
+				require.True(t, ok)
+				assert.Equal(t, orderIDs, stall.OrderIDs)
+				assert.Equal(t, courier.ProgressedAt(), stall.Since)
+			} else {
+				assert.Empty(t, courier.GetDomainEvents())
 			}
 		})
 	}
@@ -309,7 +733,7 @@ func TestCourier_Move(t *testing.T) {
 
 func mustCreateOrder(orderID uuid.UUID) *order.Order {
 	location := mustCreateLocation(1, 1)
-	ord, err := order.NewOrder(orderID, location, 1)
+	ord, err := order.NewOrder(orderID, location, 1, order.Standard, time.Now())
 	if err != nil {
 		panic(err)
 	}
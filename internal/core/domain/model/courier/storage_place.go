@@ -74,7 +74,7 @@ func (s *StoragePlace) Clear(orderID uuid.UUID) error {
 		return ErrWrongOrderId
 	}
 
-	*s.orderID = uuid.Nil
+	s.orderID = nil
 
 	return nil
 }
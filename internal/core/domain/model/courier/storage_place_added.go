@@ -0,0 +1,31 @@
+package courier
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const StoragePlaceAddedEventName = "StoragePlaceAdded"
+
+var _ ddd.DomainEvent = &StoragePlaceAddedDomainEvent{}
+
+type StoragePlaceAddedDomainEvent struct {
+	ddd.BaseEvent
+
+	StoragePlaceID uuid.UUID
+	Name           string
+	TotalVolume    int
+}
+
+func NewStoragePlaceAddedDomainEvent(payload *Courier, storagePlace *StoragePlace) *StoragePlaceAddedDomainEvent {
+	return &StoragePlaceAddedDomainEvent{
+		BaseEvent:      ddd.NewBaseEvent(StoragePlaceAddedEventName, payload.ID()),
+		StoragePlaceID: storagePlace.ID(),
+		Name:           storagePlace.Name(),
+		TotalVolume:    storagePlace.TotalVolume(),
+	}
+}
+
+func NewStoragePlaceAddedDomainEventWithoutData() *StoragePlaceAddedDomainEvent {
+	return &StoragePlaceAddedDomainEvent{BaseEvent: ddd.BaseEvent{Name: StoragePlaceAddedEventName}}
+}
@@ -31,6 +31,17 @@ func CreateRandomLocation() Location {
 	return location
 }
 
+// CreateRandomLocationFrom draws the location from the given source, so the result is reproducible
+func CreateRandomLocationFrom(rnd *rand.Rand) Location {
+	x := rnd.Intn(maxX-minX+1) + minX
+	y := rnd.Intn(maxY-minY+1) + minY
+	location, err := NewLocation(x, y)
+	if err != nil {
+		panic(err)
+	}
+	return location
+}
+
 func NewLocation(x, y int) (Location, error) {
 	if (x < minX || y < minY) || (x > maxX || y > maxY) {
 		return Location{}, ErrInvalidLocation
@@ -1,6 +1,7 @@
 package kernel
 
 import (
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -76,6 +77,19 @@ func TestCreateRandomLocation(t *testing.T) {
 	}
 }
 
+func TestCreateRandomLocationFrom(t *testing.T) {
+	first := rand.New(rand.NewSource(42))
+	second := rand.New(rand.NewSource(42))
+	for i := 0; i < 100; i++ {
+		loc := CreateRandomLocationFrom(first)
+		assert.GreaterOrEqual(t, loc.X(), 1)
+		assert.LessOrEqual(t, loc.X(), 10)
+		assert.GreaterOrEqual(t, loc.Y(), 1)
+		assert.LessOrEqual(t, loc.Y(), 10)
+		assert.Equal(t, loc, CreateRandomLocationFrom(second))
+	}
+}
+
 func TestLocation_DistanceTo(t *testing.T) {
 	tests := map[string]struct {
 		loc      Location
@@ -0,0 +1,66 @@
+package kernel
+
+import (
+	"errors"
+)
+
+var ErrInvalidRectangle = errors.New("rectangle is invalid")
+
+// Rectangle is an area of the grid, both corners belong to it
+type Rectangle struct {
+	from Location
+	to   Location
+}
+
+// NewRectangle spans the area between the corner with the lowest coordinates and the one with the highest
+func NewRectangle(from Location, to Location) (Rectangle, error) {
+	if from.x > to.x || from.y > to.y {
+		return Rectangle{}, ErrInvalidRectangle
+	}
+
+	return Rectangle{
+		from: from,
+		to:   to,
+	}, nil
+}
+
+func (r Rectangle) Contains(l Location) bool {
+	return l.x >= r.from.x && l.x <= r.to.x && l.y >= r.from.y && l.y <= r.to.y
+}
+
+// Overlaps reports whether the rectangles share at least one location
+func (r Rectangle) Overlaps(other Rectangle) bool {
+	return r.from.x <= other.to.x && other.from.x <= r.to.x &&
+		r.from.y <= other.to.y && other.from.y <= r.to.y
+}
+
+func (r Rectangle) Equals(other Rectangle) bool {
+	return r == other
+}
+
+func (r Rectangle) From() Location {
+	return r.from
+}
+
+func (r Rectangle) To() Location {
+	return r.to
+}
+
+// GridCells splits the whole grid into squares of the given size row by row, the last ones of a row
+// or a column are cut by the edge of the grid
+func GridCells(size int) ([]Rectangle, error) {
+	if size <= 0 {
+		return nil, ErrInvalidRectangle
+	}
+
+	var cells []Rectangle
+	for y := minY; y <= maxY; y += size {
+		for x := minX; x <= maxX; x += size {
+			cells = append(cells, Rectangle{
+				from: Location{x: x, y: y},
+				to:   Location{x: min(x+size-1, maxX), y: min(y+size-1, maxY)},
+			})
+		}
+	}
+	return cells, nil
+}
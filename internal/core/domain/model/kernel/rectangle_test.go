@@ -0,0 +1,133 @@
+package kernel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRectangle(t *testing.T) {
+	tests := map[string]struct {
+		from, to Location
+		wantErr  bool
+	}{
+		"area": {
+			from: Location{x: 1, y: 1},
+			to:   Location{x: 5, y: 3},
+		},
+		"single location": {
+			from: Location{x: 4, y: 4},
+			to:   Location{x: 4, y: 4},
+		},
+		"corners swapped": {
+			from:    Location{x: 5, y: 3},
+			to:      Location{x: 1, y: 1},
+			wantErr: true,
+		},
+		"corners of the other diagonal": {
+			from:    Location{x: 1, y: 3},
+			to:      Location{x: 5, y: 1},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := NewRectangle(tc.from, tc.to)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRectangle)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.from, r.From())
+				assert.Equal(t, tc.to, r.To())
+			}
+		})
+	}
+}
+
+func TestRectangle_Contains(t *testing.T) {
+	r := Rectangle{from: Location{x: 2, y: 2}, to: Location{x: 5, y: 4}}
+	tests := map[string]struct {
+		location Location
+		expected bool
+	}{
+		"inside":       {location: Location{x: 3, y: 3}, expected: true},
+		"lower corner": {location: Location{x: 2, y: 2}, expected: true},
+		"upper corner": {location: Location{x: 5, y: 4}, expected: true},
+		"left of it":   {location: Location{x: 1, y: 3}, expected: false},
+		"above it":     {location: Location{x: 3, y: 5}, expected: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, r.Contains(tc.location))
+		})
+	}
+}
+
+func TestRectangle_Overlaps(t *testing.T) {
+	r := Rectangle{from: Location{x: 1, y: 1}, to: Location{x: 5, y: 5}}
+	tests := map[string]struct {
+		other    Rectangle
+		expected bool
+	}{
+		"inside":        {other: Rectangle{from: Location{x: 2, y: 2}, to: Location{x: 3, y: 3}}, expected: true},
+		"sharing edge":  {other: Rectangle{from: Location{x: 5, y: 1}, to: Location{x: 8, y: 5}}, expected: true},
+		"next to it":    {other: Rectangle{from: Location{x: 6, y: 1}, to: Location{x: 8, y: 5}}, expected: false},
+		"above it":      {other: Rectangle{from: Location{x: 1, y: 6}, to: Location{x: 5, y: 9}}, expected: false},
+		"covers it":     {other: Rectangle{from: Location{x: 1, y: 1}, to: Location{x: 10, y: 10}}, expected: true},
+		"crossing it":   {other: Rectangle{from: Location{x: 3, y: 2}, to: Location{x: 4, y: 9}}, expected: true},
+		"diagonal only": {other: Rectangle{from: Location{x: 6, y: 6}, to: Location{x: 7, y: 7}}, expected: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, r.Overlaps(tc.other))
+			assert.Equal(t, tc.expected, tc.other.Overlaps(r))
+		})
+	}
+}
+
+func TestGridCells(t *testing.T) {
+	tests := map[string]struct {
+		size      int
+		wantCells int
+		wantLast  Rectangle
+		wantErr   bool
+	}{
+		"size dividing the grid": {
+			size:      5,
+			wantCells: 4,
+			wantLast:  Rectangle{from: Location{x: 6, y: 6}, to: Location{x: 10, y: 10}},
+		},
+		"cells cut by the edge": {
+			size:      3,
+			wantCells: 16,
+			wantLast:  Rectangle{from: Location{x: 10, y: 10}, to: Location{x: 10, y: 10}},
+		},
+		"the whole grid": {
+			size:      10,
+			wantCells: 1,
+			wantLast:  Rectangle{from: Location{x: 1, y: 1}, to: Location{x: 10, y: 10}},
+		},
+		"no size": {
+			size:    0,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cells, err := GridCells(tc.size)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRectangle)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, cells, tc.wantCells)
+			assert.Equal(t, tc.wantLast, cells[len(cells)-1])
+		})
+	}
+}
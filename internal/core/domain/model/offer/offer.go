@@ -0,0 +1,174 @@
+package offer
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// DefaultTimeout is how long a courier has to answer an offer before it expires, unless configured otherwise
+const DefaultTimeout = 2 * time.Minute
+
+// Offer proposes an order to one courier. Only a pending offer can change, every answer
+// closes it for good, the next courier gets an offer of its own.
+type Offer struct {
+	*ddd.BaseAggregate[uuid.UUID]
+
+	orderID   uuid.UUID
+	courierID uuid.UUID
+	status    Status
+	createdAt time.Time
+	expiresAt time.Time
+	// reason is why the offer was declined or withdrawn
+	reason string
+}
+
+// NewOffer makes an offer the courier has the timeout to answer
+func NewOffer(offerID uuid.UUID, orderID uuid.UUID, courierID uuid.UUID, now time.Time,
+	timeout time.Duration) (*Offer, error) {
+	if offerID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("offer id")
+	}
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+	if courierID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("courier id")
+	}
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+	if timeout <= 0 {
+		return nil, errs.NewValidationErrorWithValue("timeout", timeout, "must be greater than zero")
+	}
+
+	o := &Offer{
+		BaseAggregate: ddd.NewBaseAggregate[uuid.UUID](offerID),
+		orderID:       orderID,
+		courierID:     courierID,
+		status:        Pending,
+		createdAt:     now,
+		expiresAt:     now.Add(timeout),
+	}
+	o.RaiseDomainEvent(NewMadeDomainEvent(o))
+
+	return o, nil
+}
+
+// RestoreOffer must be used ONLY in a repository layer for mapping
+func RestoreOffer(offerID uuid.UUID, orderID uuid.UUID, courierID uuid.UUID, status Status, createdAt time.Time,
+	expiresAt time.Time, reason string, version int) *Offer {
+	return &Offer{
+		BaseAggregate: ddd.RestoreBaseAggregate[uuid.UUID](offerID, version),
+		orderID:       orderID,
+		courierID:     courierID,
+		status:        status,
+		createdAt:     createdAt,
+		expiresAt:     expiresAt,
+		reason:        reason,
+	}
+}
+
+// Accept takes the offer up, an expired offer can no longer be accepted
+func (o *Offer) Accept(now time.Time) error {
+	if err := o.checkPending("accept offer"); err != nil {
+		return err
+	}
+	if o.ExpiredAt(now) {
+		return errs.NewBusinessError("accept offer", "the offer has expired")
+	}
+
+	o.status = Accepted
+	o.RaiseDomainEvent(NewAcceptedDomainEvent(o))
+
+	return nil
+}
+
+// Decline turns the offer down, the courier owes a reason
+func (o *Offer) Decline(reason string) error {
+	if reason == "" {
+		return errs.NewValueIsRequiredError("reason")
+	}
+	if err := o.checkPending("decline offer"); err != nil {
+		return err
+	}
+
+	o.status = Declined
+	o.reason = reason
+	o.RaiseDomainEvent(NewDeclinedDomainEvent(o))
+
+	return nil
+}
+
+// Expire closes an offer the courier let lapse
+func (o *Offer) Expire(now time.Time) error {
+	if err := o.checkPending("expire offer"); err != nil {
+		return err
+	}
+	if !o.ExpiredAt(now) {
+		return errs.NewBusinessError("expire offer", "the offer has not expired yet")
+	}
+
+	o.status = Expired
+	o.RaiseDomainEvent(NewExpiredDomainEvent(o))
+
+	return nil
+}
+
+// Withdraw closes the offer before the courier answered, the order was taken away from it
+func (o *Offer) Withdraw(reason string) error {
+	if reason == "" {
+		return errs.NewValueIsRequiredError("reason")
+	}
+	if err := o.checkPending("withdraw offer"); err != nil {
+		return err
+	}
+
+	o.status = Withdrawn
+	o.reason = reason
+	o.RaiseDomainEvent(NewWithdrawnDomainEvent(o))
+
+	return nil
+}
+
+// ExpiredAt reports whether the courier let the pending offer lapse by now
+func (o *Offer) ExpiredAt(now time.Time) bool {
+	return o.status == Pending && now.After(o.expiresAt)
+}
+
+func (o *Offer) checkPending(operation string) error {
+	if o.status != Pending {
+		return errs.NewBusinessError(operation, "the offer is "+o.status.String()+", not "+Pending.String())
+	}
+	return nil
+}
+
+func (o *Offer) ID() uuid.UUID {
+	return o.BaseAggregate.ID()
+}
+
+func (o *Offer) OrderID() uuid.UUID {
+	return o.orderID
+}
+
+func (o *Offer) CourierID() uuid.UUID {
+	return o.courierID
+}
+
+func (o *Offer) Status() Status {
+	return o.status
+}
+
+func (o *Offer) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+func (o *Offer) ExpiresAt() time.Time {
+	return o.expiresAt
+}
+
+func (o *Offer) Reason() string {
+	return o.reason
+}
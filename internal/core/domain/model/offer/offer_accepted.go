@@ -0,0 +1,29 @@
+package offer
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const AcceptedEventName = "OfferAccepted"
+
+var _ ddd.DomainEvent = &AcceptedDomainEvent{}
+
+type AcceptedDomainEvent struct {
+	ddd.BaseEvent
+
+	OrderID   uuid.UUID
+	CourierID uuid.UUID
+}
+
+func NewAcceptedDomainEvent(payload *Offer) *AcceptedDomainEvent {
+	return &AcceptedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(AcceptedEventName, payload.ID()),
+		OrderID:   payload.OrderID(),
+		CourierID: payload.CourierID(),
+	}
+}
+
+func NewAcceptedDomainEventWithoutData() *AcceptedDomainEvent {
+	return &AcceptedDomainEvent{BaseEvent: ddd.BaseEvent{Name: AcceptedEventName}}
+}
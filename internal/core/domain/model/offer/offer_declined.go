@@ -0,0 +1,31 @@
+package offer
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const DeclinedEventName = "OfferDeclined"
+
+var _ ddd.DomainEvent = &DeclinedDomainEvent{}
+
+type DeclinedDomainEvent struct {
+	ddd.BaseEvent
+
+	OrderID   uuid.UUID
+	CourierID uuid.UUID
+	Reason    string
+}
+
+func NewDeclinedDomainEvent(payload *Offer) *DeclinedDomainEvent {
+	return &DeclinedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(DeclinedEventName, payload.ID()),
+		OrderID:   payload.OrderID(),
+		CourierID: payload.CourierID(),
+		Reason:    payload.Reason(),
+	}
+}
+
+func NewDeclinedDomainEventWithoutData() *DeclinedDomainEvent {
+	return &DeclinedDomainEvent{BaseEvent: ddd.BaseEvent{Name: DeclinedEventName}}
+}
@@ -0,0 +1,29 @@
+package offer
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const ExpiredEventName = "OfferExpired"
+
+var _ ddd.DomainEvent = &ExpiredDomainEvent{}
+
+type ExpiredDomainEvent struct {
+	ddd.BaseEvent
+
+	OrderID   uuid.UUID
+	CourierID uuid.UUID
+}
+
+func NewExpiredDomainEvent(payload *Offer) *ExpiredDomainEvent {
+	return &ExpiredDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(ExpiredEventName, payload.ID()),
+		OrderID:   payload.OrderID(),
+		CourierID: payload.CourierID(),
+	}
+}
+
+func NewExpiredDomainEventWithoutData() *ExpiredDomainEvent {
+	return &ExpiredDomainEvent{BaseEvent: ddd.BaseEvent{Name: ExpiredEventName}}
+}
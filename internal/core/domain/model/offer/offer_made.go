@@ -0,0 +1,29 @@
+package offer
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const MadeEventName = "OfferMade"
+
+var _ ddd.DomainEvent = &MadeDomainEvent{}
+
+type MadeDomainEvent struct {
+	ddd.BaseEvent
+
+	OrderID   uuid.UUID
+	CourierID uuid.UUID
+}
+
+func NewMadeDomainEvent(payload *Offer) *MadeDomainEvent {
+	return &MadeDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(MadeEventName, payload.ID()),
+		OrderID:   payload.OrderID(),
+		CourierID: payload.CourierID(),
+	}
+}
+
+func NewMadeDomainEventWithoutData() *MadeDomainEvent {
+	return &MadeDomainEvent{BaseEvent: ddd.BaseEvent{Name: MadeEventName}}
+}
@@ -0,0 +1,16 @@
+package offer
+
+type Status string
+
+const (
+	Empty     Status = ""
+	Pending   Status = "Pending"
+	Accepted  Status = "Accepted"
+	Declined  Status = "Declined"
+	Expired   Status = "Expired"
+	Withdrawn Status = "Withdrawn"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
@@ -0,0 +1,176 @@
+package offer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func TestNewOffer(t *testing.T) {
+	tests := map[string]struct {
+		offerID   uuid.UUID
+		orderID   uuid.UUID
+		courierID uuid.UUID
+		now       time.Time
+		timeout   time.Duration
+		wantErr   bool
+		err       error
+	}{
+		"valid offer": {
+			offerID:   uuid.New(),
+			orderID:   uuid.New(),
+			courierID: uuid.New(),
+			now:       testNow,
+			timeout:   DefaultTimeout,
+		},
+		"empty order id": {
+			offerID:   uuid.New(),
+			orderID:   uuid.Nil,
+			courierID: uuid.New(),
+			now:       testNow,
+			timeout:   DefaultTimeout,
+			wantErr:   true,
+			err:       errs.ErrValueIsRequired,
+		},
+		"empty courier id": {
+			offerID:   uuid.New(),
+			orderID:   uuid.New(),
+			courierID: uuid.Nil,
+			now:       testNow,
+			timeout:   DefaultTimeout,
+			wantErr:   true,
+			err:       errs.ErrValueIsRequired,
+		},
+		"empty now": {
+			offerID:   uuid.New(),
+			orderID:   uuid.New(),
+			courierID: uuid.New(),
+			timeout:   DefaultTimeout,
+			wantErr:   true,
+			err:       errs.ErrValueIsRequired,
+		},
+		"configured timeout": {
+			offerID:   uuid.New(),
+			orderID:   uuid.New(),
+			courierID: uuid.New(),
+			now:       testNow,
+			timeout:   30 * time.Second,
+		},
+		"no timeout": {
+			offerID:   uuid.New(),
+			orderID:   uuid.New(),
+			courierID: uuid.New(),
+			now:       testNow,
+			wantErr:   true,
+			err:       errs.ErrValidation,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o, err := NewOffer(tc.offerID, tc.orderID, tc.courierID, tc.now, tc.timeout)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, o)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, Pending, o.Status())
+				assert.Equal(t, tc.now.Add(tc.timeout), o.ExpiresAt())
+				require.Len(t, o.GetDomainEvents(), 1)
+				assert.IsType(t, &MadeDomainEvent{}, o.GetDomainEvents()[0])
+			}
+		})
+	}
+}
+
+func TestOffer_Transitions(t *testing.T) {
+	tests := map[string]struct {
+		prepare    func(t *testing.T, o *Offer)
+		transition func(o *Offer) error
+		status     Status
+		reason     string
+		event      string
+		wantErr    bool
+		err        error
+	}{
+		"accept in time": {
+			transition: func(o *Offer) error { return o.Accept(testNow.Add(DefaultTimeout)) },
+			status:     Accepted,
+			event:      AcceptedEventName,
+		},
+		"accept too late": {
+			transition: func(o *Offer) error { return o.Accept(testNow.Add(DefaultTimeout + time.Second)) },
+			wantErr:    true,
+			err:        errs.ErrBusiness,
+		},
+		"decline": {
+			transition: func(o *Offer) error { return o.Decline("too far away") },
+			status:     Declined,
+			reason:     "too far away",
+			event:      DeclinedEventName,
+		},
+		"decline without a reason": {
+			transition: func(o *Offer) error { return o.Decline("") },
+			wantErr:    true,
+			err:        errs.ErrValueIsRequired,
+		},
+		"expire": {
+			transition: func(o *Offer) error { return o.Expire(testNow.Add(DefaultTimeout + time.Second)) },
+			status:     Expired,
+			event:      ExpiredEventName,
+		},
+		"expire before the timeout": {
+			transition: func(o *Offer) error { return o.Expire(testNow.Add(DefaultTimeout)) },
+			wantErr:    true,
+			err:        errs.ErrBusiness,
+		},
+		"withdraw": {
+			transition: func(o *Offer) error { return o.Withdraw("order reassigned") },
+			status:     Withdrawn,
+			reason:     "order reassigned",
+			event:      WithdrawnEventName,
+		},
+		"answered offer is closed": {
+			prepare: func(t *testing.T, o *Offer) {
+				require.NoError(t, o.Decline("too far away"))
+			},
+			transition: func(o *Offer) error { return o.Accept(testNow) },
+			wantErr:    true,
+			err:        errs.ErrBusiness,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o, err := NewOffer(uuid.New(), uuid.New(), uuid.New(), testNow, DefaultTimeout)
+			require.NoError(t, err)
+			if tc.prepare != nil {
+				tc.prepare(t, o)
+			}
+			o.ClearDomainEvents()
+			before := o.Status()
+
+			err = tc.transition(o)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, before, o.Status())
+				assert.Empty(t, o.GetDomainEvents())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.status, o.Status())
+				assert.Equal(t, tc.reason, o.Reason())
+				assert.False(t, o.ExpiredAt(testNow.Add(time.Hour)), "only a pending offer expires")
+				require.Len(t, o.GetDomainEvents(), 1)
+				assert.Equal(t, tc.event, o.GetDomainEvents()[0].GetName())
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package offer
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const WithdrawnEventName = "OfferWithdrawn"
+
+var _ ddd.DomainEvent = &WithdrawnDomainEvent{}
+
+type WithdrawnDomainEvent struct {
+	ddd.BaseEvent
+
+	OrderID   uuid.UUID
+	CourierID uuid.UUID
+	Reason    string
+}
+
+func NewWithdrawnDomainEvent(payload *Offer) *WithdrawnDomainEvent {
+	return &WithdrawnDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(WithdrawnEventName, payload.ID()),
+		OrderID:   payload.OrderID(),
+		CourierID: payload.CourierID(),
+		Reason:    payload.Reason(),
+	}
+}
+
+func NewWithdrawnDomainEventWithoutData() *WithdrawnDomainEvent {
+	return &WithdrawnDomainEvent{BaseEvent: ddd.BaseEvent{Name: WithdrawnEventName}}
+}
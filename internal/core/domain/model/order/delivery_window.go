@@ -0,0 +1,54 @@
+package order
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+// DeliveryWindow is the time span the customer expects the order to arrive in
+type DeliveryWindow struct {
+	from time.Time
+	to   time.Time
+}
+
+func NewDeliveryWindow(from, to time.Time) (DeliveryWindow, error) {
+	if from.IsZero() {
+		return DeliveryWindow{}, errs.NewValueIsRequiredError("delivery window from")
+	}
+	if to.IsZero() {
+		return DeliveryWindow{}, errs.NewValueIsRequiredError("delivery window to")
+	}
+	if !to.After(from) {
+		return DeliveryWindow{}, errs.NewValidationErrorWithValue("delivery window to", to, "must be after from")
+	}
+
+	return DeliveryWindow{from: from.UTC(), to: to.UTC()}, nil
+}
+
+func (w DeliveryWindow) From() time.Time {
+	return w.from
+}
+
+func (w DeliveryWindow) To() time.Time {
+	return w.to
+}
+
+// ScheduleDelivery sets the window the order is expected in, it can be changed until a courier takes the order
+func (o *Order) ScheduleDelivery(window DeliveryWindow) error {
+	if window.from.IsZero() || window.to.IsZero() {
+		return errs.NewValueIsRequiredError("delivery window")
+	}
+	if o.status != Created {
+		return errs.NewBusinessError("schedule delivery", "only a created order can be scheduled")
+	}
+
+	o.deliveryWindow = &window
+	o.RaiseDomainEvent(NewDeliveryScheduledDomainEvent(o))
+
+	return nil
+}
+
+func (o *Order) DeliveryWindow() *DeliveryWindow {
+	return o.deliveryWindow
+}
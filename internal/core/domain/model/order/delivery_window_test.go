@@ -0,0 +1,99 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeliveryWindow(t *testing.T) {
+	tests := map[string]struct {
+		from time.Time
+		to   time.Time
+		err  error
+	}{
+		"window of an hour": {
+			from: testCreatedAt,
+			to:   testCreatedAt.Add(time.Hour),
+		},
+		"no start": {
+			to:  testCreatedAt,
+			err: errs.ErrValueIsRequired,
+		},
+		"no end": {
+			from: testCreatedAt,
+			err:  errs.ErrValueIsRequired,
+		},
+		"ends before it starts": {
+			from: testCreatedAt,
+			to:   testCreatedAt.Add(-time.Hour),
+			err:  errs.ErrValidation,
+		},
+		"empty window": {
+			from: testCreatedAt,
+			to:   testCreatedAt,
+			err:  errs.ErrValidation,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			window, err := NewDeliveryWindow(tc.from, tc.to)
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.from, window.From())
+				assert.Equal(t, tc.to, window.To())
+			}
+		})
+	}
+}
+
+func TestOrder_ScheduleDelivery(t *testing.T) {
+	window, err := NewDeliveryWindow(testCreatedAt.Add(time.Hour), testCreatedAt.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		assigned bool
+		window   DeliveryWindow
+		err      error
+	}{
+		"created order": {
+			window: window,
+		},
+		"no window": {
+			err: errs.ErrValueIsRequired,
+		},
+		"order already has a courier": {
+			assigned: true,
+			window:   window,
+			err:      errs.ErrBusiness,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := mustCreateOrderWithPriority(Standard, testCreatedAt)
+			if tc.assigned {
+				courierID := uuid.New()
+				require.NoError(t, o.Assign(&courierID, testCreatedAt))
+			}
+
+			err := o.ScheduleDelivery(tc.window)
+
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, o.DeliveryWindow())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &tc.window, o.DeliveryWindow())
+			}
+		})
+	}
+}
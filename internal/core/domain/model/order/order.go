@@ -1,6 +1,8 @@
 package order
 
 import (
+	"time"
+
 	"github.com/delivery/internal/core/domain/model/kernel"
 	"github.com/delivery/internal/pkg/ddd"
 	"github.com/delivery/internal/pkg/errs"
@@ -14,9 +16,23 @@ type Order struct {
 	location  kernel.Location
 	volume    int
 	status    Status
+	priority  Priority
+	createdAt time.Time
+	eta       *time.Time
+	// assignedAt is when the current courier was offered the order
+	assignedAt time.Time
+	// deliveryWindow is nil when the customer did not ask for a time
+	deliveryWindow *DeliveryWindow
+	// unassignReason is why the order was last taken away from a courier
+	unassignReason string
+	// proof is what the courier handed the order over with, nil before the delivery or without one
+	proof *ProofOfDelivery
+	// zoneID is the service zone of the delivery address, nil while no zones are set up
+	zoneID *uuid.UUID
 }
 
-func NewOrder(orderID uuid.UUID, location kernel.Location, volume int) (*Order, error) {
+func NewOrder(orderID uuid.UUID, location kernel.Location, volume int, priority Priority,
+	createdAt time.Time) (*Order, error) {
 	if orderID == uuid.Nil {
 		return nil, errs.NewValueIsRequiredError("order id")
 	}
@@ -25,53 +41,213 @@ func NewOrder(orderID uuid.UUID, location kernel.Location, volume int) (*Order,
 		return nil, errs.NewValueIsRequiredError("volume")
 	}
 
-	return &Order{
+	if priority != Standard && priority != Express {
+		return nil, errs.NewValidationErrorWithValue("priority", priority, "must be standard or express")
+	}
+
+	if createdAt.IsZero() {
+		return nil, errs.NewValueIsRequiredError("created at")
+	}
+
+	o := &Order{
 		BaseAggregate: ddd.NewBaseAggregate[uuid.UUID](orderID),
 		courierID:     nil,
 		location:      location,
 		volume:        volume,
 		status:        Created,
-	}, nil
+		priority:      priority,
+		createdAt:     createdAt,
+	}
+	o.RaiseDomainEvent(NewCreatedDomainEvent(o))
+
+	return o, nil
 }
 
 // RestoreOrder must be used ONLY in a repository layer for mapping
-func RestoreOrder(orderID uuid.UUID, courierID *uuid.UUID, location kernel.Location, volume int, status Status) *Order {
+func RestoreOrder(orderID uuid.UUID, courierID *uuid.UUID, location kernel.Location, volume int, status Status,
+	priority Priority, createdAt time.Time, eta *time.Time, assignedAt time.Time, deliveryWindow *DeliveryWindow,
+	unassignReason string, proof *ProofOfDelivery, zoneID *uuid.UUID, version int) *Order {
 	return &Order{
-		BaseAggregate: ddd.NewBaseAggregate[uuid.UUID](orderID),
-		courierID:     courierID,
-		location:      location,
-		volume:        volume,
-		status:        status,
+		BaseAggregate:  ddd.RestoreBaseAggregate[uuid.UUID](orderID, version),
+		courierID:      courierID,
+		location:       location,
+		volume:         volume,
+		status:         status,
+		priority:       priority,
+		createdAt:      createdAt,
+		eta:            eta,
+		assignedAt:     assignedAt,
+		deliveryWindow: deliveryWindow,
+		unassignReason: unassignReason,
+		proof:          proof,
+		zoneID:         zoneID,
+	}
+}
+
+// TagZone records the service zone the order is delivered in
+func (o *Order) TagZone(zoneID uuid.UUID) error {
+	if zoneID == uuid.Nil {
+		return errs.NewValueIsRequiredError("zone id")
+	}
+	if o.status != Created {
+		return errs.NewBusinessError("tag zone", "only a created order can be tagged")
 	}
+
+	o.zoneID = &zoneID
+	o.RaiseDomainEvent(NewZoneTaggedDomainEvent(o))
+
+	return nil
 }
 
-func (o *Order) Assign(courierId *uuid.UUID) error {
+// Assign reserves the order for the courier it is offered to, see offer.Offer
+func (o *Order) Assign(courierId *uuid.UUID, now time.Time) error {
 	if courierId == nil {
 		return errs.NewValueIsRequiredError("courier id")
 	}
+	if now.IsZero() {
+		return errs.NewValueIsRequiredError("now")
+	}
+	if o.status != Created {
+		return errs.NewBusinessError("assign order", "only a created order can be assigned, the order is "+o.status.String())
+	}
 
 	o.courierID = courierId
 	o.status = Assigned
+	o.eta = nil
+	o.assignedAt = now
+	o.RaiseDomainEvent(NewAssignedDomainEvent(o))
+
+	return nil
+}
+
+// Accept records that the courier took the offer up
+func (o *Order) Accept(courierID uuid.UUID) error {
+	if err := o.checkCourier("accept order", courierID, Assigned); err != nil {
+		return err
+	}
+
+	o.status = Accepted
+	o.RaiseDomainEvent(NewAcceptedDomainEvent(o))
+
+	return nil
+}
+
+// Decline turns the offer down and returns the order to the dispatch queue
+func (o *Order) Decline(courierID uuid.UUID, reason string) error {
+	if reason == "" {
+		return errs.NewValueIsRequiredError("reason")
+	}
+	if err := o.checkCourier("decline order", courierID, Assigned); err != nil {
+		return err
+	}
+
+	return o.Unassign(reason)
+}
+
+// PickUp records that the courier collected the order, from now on it heads to the customer
+func (o *Order) PickUp(courierID uuid.UUID) error {
+	if err := o.checkCourier("pick up order", courierID, Accepted); err != nil {
+		return err
+	}
+
+	o.status = PickedUp
+	o.RaiseDomainEvent(NewPickedUpDomainEvent(o))
 
 	return nil
 }
 
-func (o *Order) Complete() error {
+// Unassign takes the order away from its courier and returns it to the dispatch queue,
+// a picked up order is in the bag of the courier and stays with it
+func (o *Order) Unassign(reason string) error {
+	if reason == "" {
+		return errs.NewValueIsRequiredError("reason")
+	}
+	if !o.status.WithCourier() {
+		return errs.NewBusinessError("unassign order", "only an assigned order can be unassigned")
+	}
+	if o.status == PickedUp {
+		return errs.NewBusinessError("unassign order", "the courier has picked the order up already")
+	}
+
+	courierID := *o.courierID
+	o.courierID = nil
+	o.status = Created
+	o.eta = nil
+	o.assignedAt = time.Time{}
+	o.unassignReason = reason
+	o.RaiseDomainEvent(NewUnassignedDomainEvent(o, courierID))
+
+	return nil
+}
+
+// Complete hands the order over to the customer, the proof is optional
+func (o *Order) Complete(proof *ProofOfDelivery) error {
 	if o.courierID == nil {
 		return errs.NewBusinessError("order is not assigned to courier", "courier id is nil")
 	}
+	if o.status != PickedUp {
+		return errs.NewBusinessError("complete order", "only a picked up order can be delivered")
+	}
 
 	o.status = Completed
+	o.eta = nil
+	o.proof = proof
+	o.RaiseDomainEvent(NewCompletedDomainEvent(o))
 
-	domainEvent, err := NewStatusChangedDomainEvent(o.ID(), "", o)
-	if err != nil {
-		return err
+	return nil
+}
+
+func (o *Order) checkCourier(operation string, courierID uuid.UUID, status Status) error {
+	if o.courierID == nil || *o.courierID != courierID {
+		return errs.NewBusinessError(operation, "the order is not assigned to the courier")
+	}
+	if o.status != status {
+		return errs.NewBusinessError(operation, "the order is "+o.status.String()+", not "+status.String())
+	}
+	return nil
+}
+
+// UpdateEta stores the estimated time of arrival of an assigned order. An assignment or an eta update
+// that is not published yet carries the new estimate, otherwise it is recorded by an EtaUpdated event,
+// so the order replayed from its events has it. An unchanged estimate records nothing.
+func (o *Order) UpdateEta(eta time.Time) error {
+	if eta.IsZero() {
+		return errs.NewValueIsRequiredError("eta")
+	}
+	if !o.status.WithCourier() {
+		return errs.NewBusinessError("update eta", "only an assigned order has an eta")
+	}
+	if o.eta != nil && o.eta.Equal(eta) {
+		return nil
+	}
+
+	o.eta = &eta
+	if !o.carryEtaInPendingEvent() {
+		o.RaiseDomainEvent(NewEtaUpdatedDomainEvent(o))
 	}
-	o.BaseAggregate.RaiseDomainEvent(domainEvent)
 
 	return nil
 }
 
+// carryEtaInPendingEvent puts the eta in the latest pending event setting it, as long as that event is
+// about the current assignment
+func (o *Order) carryEtaInPendingEvent() bool {
+	events := o.GetDomainEvents()
+	for i := len(events) - 1; i >= 0; i-- {
+		switch pending := events[i].(type) {
+		case *AssignedDomainEvent:
+			pending.Eta = o.eta
+			return true
+		case *EtaUpdatedDomainEvent:
+			pending.Eta = *o.eta
+			return true
+		case *UnassignedDomainEvent:
+			return false
+		}
+	}
+	return false
+}
+
 func (o *Order) Equals(other *Order) bool {
 	return o.BaseAggregate.ID() == other.BaseAggregate.ID()
 }
@@ -95,3 +271,31 @@ func (o *Order) Volume() int {
 func (o *Order) Status() Status {
 	return o.status
 }
+
+func (o *Order) Priority() Priority {
+	return o.priority
+}
+
+func (o *Order) CreatedAt() time.Time {
+	return o.createdAt
+}
+
+func (o *Order) Eta() *time.Time {
+	return o.eta
+}
+
+func (o *Order) AssignedAt() time.Time {
+	return o.assignedAt
+}
+
+func (o *Order) UnassignReason() string {
+	return o.unassignReason
+}
+
+func (o *Order) Proof() *ProofOfDelivery {
+	return o.proof
+}
+
+func (o *Order) ZoneID() *uuid.UUID {
+	return o.zoneID
+}
@@ -0,0 +1,27 @@
+package order
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const AcceptedEventName = "OrderAccepted"
+
+var _ ddd.DomainEvent = &AcceptedDomainEvent{}
+
+type AcceptedDomainEvent struct {
+	ddd.BaseEvent
+
+	CourierID uuid.UUID
+}
+
+func NewAcceptedDomainEvent(payload *Order) *AcceptedDomainEvent {
+	return &AcceptedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(AcceptedEventName, payload.ID()),
+		CourierID: *payload.CourierID(),
+	}
+}
+
+func NewAcceptedDomainEventWithoutData() *AcceptedDomainEvent {
+	return &AcceptedDomainEvent{BaseEvent: ddd.BaseEvent{Name: AcceptedEventName}}
+}
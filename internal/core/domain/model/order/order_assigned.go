@@ -0,0 +1,34 @@
+package order
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const AssignedEventName = "OrderAssigned"
+
+var _ ddd.DomainEvent = &AssignedDomainEvent{}
+
+type AssignedDomainEvent struct {
+	ddd.BaseEvent
+
+	CourierID uuid.UUID
+	Eta       *time.Time
+	// AssignedAt is when the courier was offered the order
+	AssignedAt time.Time
+}
+
+func NewAssignedDomainEvent(payload *Order) *AssignedDomainEvent {
+	return &AssignedDomainEvent{
+		BaseEvent:  ddd.NewBaseEvent(AssignedEventName, payload.ID()),
+		CourierID:  *payload.CourierID(),
+		Eta:        payload.Eta(),
+		AssignedAt: payload.AssignedAt(),
+	}
+}
+
+func NewAssignedDomainEventWithoutData() *AssignedDomainEvent {
+	return &AssignedDomainEvent{BaseEvent: ddd.BaseEvent{Name: AssignedEventName}}
+}
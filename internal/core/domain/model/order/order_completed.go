@@ -0,0 +1,30 @@
+package order
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const CompletedEventName = "OrderCompleted"
+
+var _ ddd.DomainEvent = &CompletedDomainEvent{}
+
+type CompletedDomainEvent struct {
+	ddd.BaseEvent
+
+	CourierID uuid.UUID
+	// Proof is nil when the courier confirmed the delivery without one
+	Proof *ProofOfDelivery
+}
+
+func NewCompletedDomainEvent(payload *Order) *CompletedDomainEvent {
+	return &CompletedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(CompletedEventName, payload.ID()),
+		CourierID: *payload.CourierID(),
+		Proof:     payload.Proof(),
+	}
+}
+
+func NewCompletedDomainEventWithoutData() *CompletedDomainEvent {
+	return &CompletedDomainEvent{BaseEvent: ddd.BaseEvent{Name: CompletedEventName}}
+}
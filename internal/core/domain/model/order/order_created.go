@@ -0,0 +1,35 @@
+package order
+
+import (
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/pkg/ddd"
+)
+
+const CreatedEventName = "OrderCreated"
+
+var _ ddd.DomainEvent = &CreatedDomainEvent{}
+
+type CreatedDomainEvent struct {
+	ddd.BaseEvent
+
+	Location  kernel.Location
+	Volume    int
+	Priority  Priority
+	CreatedAt time.Time
+}
+
+func NewCreatedDomainEvent(payload *Order) *CreatedDomainEvent {
+	return &CreatedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(CreatedEventName, payload.ID()),
+		Location:  payload.Location(),
+		Volume:    payload.Volume(),
+		Priority:  payload.Priority(),
+		CreatedAt: payload.CreatedAt(),
+	}
+}
+
+func NewCreatedDomainEventWithoutData() *CreatedDomainEvent {
+	return &CreatedDomainEvent{BaseEvent: ddd.BaseEvent{Name: CreatedEventName}}
+}
@@ -0,0 +1,26 @@
+package order
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+)
+
+const DeliveryScheduledEventName = "OrderDeliveryScheduled"
+
+var _ ddd.DomainEvent = &DeliveryScheduledDomainEvent{}
+
+type DeliveryScheduledDomainEvent struct {
+	ddd.BaseEvent
+
+	Window DeliveryWindow
+}
+
+func NewDeliveryScheduledDomainEvent(payload *Order) *DeliveryScheduledDomainEvent {
+	return &DeliveryScheduledDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(DeliveryScheduledEventName, payload.ID()),
+		Window:    *payload.DeliveryWindow(),
+	}
+}
+
+func NewDeliveryScheduledDomainEventWithoutData() *DeliveryScheduledDomainEvent {
+	return &DeliveryScheduledDomainEvent{BaseEvent: ddd.BaseEvent{Name: DeliveryScheduledEventName}}
+}
@@ -0,0 +1,28 @@
+package order
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/ddd"
+)
+
+const EtaUpdatedEventName = "OrderEtaUpdated"
+
+var _ ddd.DomainEvent = &EtaUpdatedDomainEvent{}
+
+type EtaUpdatedDomainEvent struct {
+	ddd.BaseEvent
+
+	Eta time.Time
+}
+
+func NewEtaUpdatedDomainEvent(payload *Order) *EtaUpdatedDomainEvent {
+	return &EtaUpdatedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(EtaUpdatedEventName, payload.ID()),
+		Eta:       *payload.Eta(),
+	}
+}
+
+func NewEtaUpdatedDomainEventWithoutData() *EtaUpdatedDomainEvent {
+	return &EtaUpdatedDomainEvent{BaseEvent: ddd.BaseEvent{Name: EtaUpdatedEventName}}
+}
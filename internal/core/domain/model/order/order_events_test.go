@@ -0,0 +1,127 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_TransitionsRaiseOneEvent(t *testing.T) {
+	courierID := uuid.New()
+	tests := map[string]struct {
+		transition func(t *testing.T, o *Order)
+		expected   ddd.DomainEvent
+	}{
+		"assign": {
+			transition: func(t *testing.T, o *Order) {
+				require.NoError(t, o.Assign(&courierID, testCreatedAt))
+			},
+			expected: NewAssignedDomainEventWithoutData(),
+		},
+		"unassign": {
+			transition: func(t *testing.T, o *Order) {
+				require.NoError(t, o.Assign(&courierID, testCreatedAt))
+				o.ClearDomainEvents()
+				require.NoError(t, o.Unassign("bike broke down"))
+			},
+			expected: NewUnassignedDomainEventWithoutData(),
+		},
+		"accept": {
+			transition: func(t *testing.T, o *Order) {
+				require.NoError(t, o.Assign(&courierID, testCreatedAt))
+				o.ClearDomainEvents()
+				require.NoError(t, o.Accept(courierID))
+			},
+			expected: NewAcceptedDomainEventWithoutData(),
+		},
+		"decline": {
+			transition: func(t *testing.T, o *Order) {
+				require.NoError(t, o.Assign(&courierID, testCreatedAt))
+				o.ClearDomainEvents()
+				require.NoError(t, o.Decline(courierID, "too far away"))
+			},
+			expected: NewUnassignedDomainEventWithoutData(),
+		},
+		"pick up": {
+			transition: func(t *testing.T, o *Order) {
+				require.NoError(t, o.Assign(&courierID, testCreatedAt))
+				require.NoError(t, o.Accept(courierID))
+				o.ClearDomainEvents()
+				require.NoError(t, o.PickUp(courierID))
+			},
+			expected: NewPickedUpDomainEventWithoutData(),
+		},
+		"complete": {
+			transition: func(t *testing.T, o *Order) {
+				require.NoError(t, o.Assign(&courierID, testCreatedAt))
+				require.NoError(t, o.Accept(courierID))
+				require.NoError(t, o.PickUp(courierID))
+				o.ClearDomainEvents()
+				require.NoError(t, o.Complete(nil))
+			},
+			expected: NewCompletedDomainEventWithoutData(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := mustCreateOrder(uuid.New())
+			o.ClearDomainEvents()
+			before := o.Version()
+
+			tc.transition(t, o)
+
+			events := o.GetDomainEvents()
+			require.Len(t, events, 1)
+			assert.IsType(t, tc.expected, events[0])
+			assert.Equal(t, tc.expected.GetName(), events[0].GetName())
+			assert.Equal(t, o.ID(), events[0].GetAggregateID())
+			assert.Equal(t, o.Version(), events[0].GetVersion())
+			assert.Greater(t, o.Version(), before)
+			assert.False(t, events[0].GetOccurredAt().IsZero())
+		})
+	}
+}
+
+func TestNewOrder_RaisesCreated(t *testing.T) {
+	o := mustCreateOrder(uuid.New())
+
+	events := o.GetDomainEvents()
+	require.Len(t, events, 1)
+	created, ok := events[0].(*CreatedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, CreatedEventName, created.GetName())
+	assert.Equal(t, 1, created.GetVersion())
+	assert.Equal(t, o.Volume(), created.Volume)
+	assert.Equal(t, o.Priority(), created.Priority)
+}
+
+func TestOrder_UnassignedCarriesPreviousCourier(t *testing.T) {
+	courierID := uuid.New()
+	o := mustCreateOrder(uuid.New())
+	require.NoError(t, o.Assign(&courierID, testCreatedAt))
+
+	require.NoError(t, o.Unassign("bike broke down"))
+
+	events := o.GetDomainEvents()
+	unassigned, ok := events[len(events)-1].(*UnassignedDomainEvent)
+	require.True(t, ok)
+	assert.Equal(t, courierID, unassigned.CourierID)
+	assert.Equal(t, "bike broke down", unassigned.Reason)
+	assert.Equal(t, 3, o.Version())
+}
+
+func TestRestoreOrder_ContinuesVersion(t *testing.T) {
+	courierID := uuid.New()
+	o := RestoreOrder(uuid.New(), nil, mustCreateLocation(1, 1), 1, Created, Standard, testCreatedAt, nil,
+		time.Time{}, nil, "", nil, nil, 4)
+
+	require.NoError(t, o.Assign(&courierID, testCreatedAt))
+
+	assert.Equal(t, 5, o.Version())
+	assert.Equal(t, 5, o.GetDomainEvents()[0].GetVersion())
+}
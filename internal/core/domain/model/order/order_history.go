@@ -0,0 +1,90 @@
+package order
+
+import (
+	"time"
+
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// RebuildOrder must be used ONLY in a repository layer, it replays the whole history of an order
+// starting with its OrderCreated event
+func RebuildOrder(orderID uuid.UUID, history []ddd.DomainEvent) (*Order, error) {
+	if orderID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("order id")
+	}
+	if len(history) == 0 {
+		return nil, errs.NewValueIsRequiredError("history")
+	}
+	if _, ok := history[0].(*CreatedDomainEvent); !ok {
+		return nil, errs.NewValidationError("history", "must start with "+CreatedEventName)
+	}
+
+	o := &Order{BaseAggregate: ddd.NewBaseAggregate[uuid.UUID](orderID)}
+	if err := o.Replay(history...); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// Replay applies the events that followed the version the order is at, e.g. those after a snapshot
+// restored with RestoreOrder. The replayed events are not raised again.
+func (o *Order) Replay(history ...ddd.DomainEvent) error {
+	for _, event := range history {
+		if event.GetAggregateID() != o.ID() {
+			return errs.NewValidationErrorWithValue("event", event.GetAggregateID(), "belongs to another order")
+		}
+		if err := o.ReplayDomainEvent(event); err != nil {
+			return errs.NewValidationErrorWithCause("event", "cannot be replayed", err)
+		}
+		if err := o.apply(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply changes the state the way the transition that raised the event did
+func (o *Order) apply(event ddd.DomainEvent) error {
+	switch e := event.(type) {
+	case *CreatedDomainEvent:
+		o.location = e.Location
+		o.volume = e.Volume
+		o.priority = e.Priority
+		o.createdAt = e.CreatedAt
+		o.status = Created
+	case *DeliveryScheduledDomainEvent:
+		window := e.Window
+		o.deliveryWindow = &window
+	case *ZoneTaggedDomainEvent:
+		zoneID := e.ZoneID
+		o.zoneID = &zoneID
+	case *AssignedDomainEvent:
+		courierID := e.CourierID
+		o.courierID = &courierID
+		o.status = Assigned
+		o.eta = e.Eta
+		o.assignedAt = e.AssignedAt
+	case *EtaUpdatedDomainEvent:
+		eta := e.Eta
+		o.eta = &eta
+	case *AcceptedDomainEvent:
+		o.status = Accepted
+	case *PickedUpDomainEvent:
+		o.status = PickedUp
+	case *UnassignedDomainEvent:
+		o.courierID = nil
+		o.status = Created
+		o.eta = nil
+		o.assignedAt = time.Time{}
+		o.unassignReason = e.Reason
+	case *CompletedDomainEvent:
+		o.status = Completed
+		o.eta = nil
+		o.proof = e.Proof
+	default:
+		return errs.NewValidationErrorWithValue("event", event.GetName(), "is not an order event")
+	}
+	return nil
+}
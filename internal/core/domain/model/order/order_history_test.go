@@ -0,0 +1,158 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildOrder_MatchesTheLiveOrder(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	zoneID := uuid.New()
+	window, err := NewDeliveryWindow(testCreatedAt.Add(time.Hour), testCreatedAt.Add(2*time.Hour))
+	require.NoError(t, err)
+	proof, err := NewProofOfDelivery("", "1234")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		transitions func(t *testing.T, o *Order)
+	}{
+		"created": {
+			transitions: func(t *testing.T, o *Order) {},
+		},
+		"scheduled in a zone": {
+			transitions: func(t *testing.T, o *Order) {
+				require.NoError(t, o.ScheduleDelivery(window))
+				require.NoError(t, o.TagZone(zoneID))
+			},
+		},
+		"assigned with an eta": {
+			transitions: func(t *testing.T, o *Order) {
+				require.NoError(t, o.Assign(&first, testCreatedAt.Add(time.Minute)))
+				require.NoError(t, o.UpdateEta(testCreatedAt.Add(10*time.Minute)))
+			},
+		},
+		"declined and delivered by the next courier": {
+			transitions: func(t *testing.T, o *Order) {
+				require.NoError(t, o.Assign(&first, testCreatedAt.Add(time.Minute)))
+				require.NoError(t, o.Decline(first, "too far away"))
+				require.NoError(t, o.Assign(&second, testCreatedAt.Add(2*time.Minute)))
+				require.NoError(t, o.Accept(second))
+				require.NoError(t, o.PickUp(second))
+				require.NoError(t, o.Complete(&proof))
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			live := mustCreateOrder(uuid.New())
+			tc.transitions(t, live)
+
+			rebuilt, err := RebuildOrder(live.ID(), live.GetDomainEvents())
+
+			require.NoError(t, err)
+			assertSameState(t, live, rebuilt)
+			assert.Empty(t, rebuilt.GetDomainEvents(), "replayed events must not be raised again")
+		})
+	}
+}
+
+func TestRebuildOrder_KeepsTheEtaRefreshedAfterTheAssignment(t *testing.T) {
+	courierID := uuid.New()
+	live := mustCreateOrder(uuid.New())
+	require.NoError(t, live.Assign(&courierID, testCreatedAt))
+	require.NoError(t, live.UpdateEta(testCreatedAt.Add(10*time.Minute)))
+	require.NoError(t, live.Accept(courierID))
+	history := live.GetDomainEvents()
+	live.ClearDomainEvents()
+
+	// the courier fell behind after the assignment was stored
+	require.NoError(t, live.UpdateEta(testCreatedAt.Add(15*time.Minute)))
+	require.NoError(t, live.PickUp(courierID))
+	history = append(history, live.GetDomainEvents()...)
+
+	rebuilt, err := RebuildOrder(live.ID(), history)
+
+	require.NoError(t, err)
+	require.NotNil(t, rebuilt.Eta())
+	assert.Equal(t, testCreatedAt.Add(15*time.Minute), *rebuilt.Eta())
+	assertSameState(t, live, rebuilt)
+}
+
+func TestOrder_ReplayAfterSnapshot(t *testing.T) {
+	courierID := uuid.New()
+	live := mustCreateOrder(uuid.New())
+	require.NoError(t, live.Assign(&courierID, testCreatedAt))
+	snapshot := RestoreOrder(live.ID(), live.CourierID(), live.Location(), live.Volume(), live.Status(),
+		live.Priority(), live.CreatedAt(), live.Eta(), live.AssignedAt(), live.DeliveryWindow(),
+		live.UnassignReason(), live.Proof(), live.ZoneID(), live.Version())
+	live.ClearDomainEvents()
+	require.NoError(t, live.Accept(courierID))
+	require.NoError(t, live.PickUp(courierID))
+
+	require.NoError(t, snapshot.Replay(live.GetDomainEvents()...))
+
+	assertSameState(t, live, snapshot)
+}
+
+func TestRebuildOrder_RejectsBrokenHistory(t *testing.T) {
+	courierID := uuid.New()
+	live := mustCreateOrder(uuid.New())
+	require.NoError(t, live.Assign(&courierID, testCreatedAt))
+	require.NoError(t, live.Accept(courierID))
+	events := live.GetDomainEvents()
+	other := mustCreateOrder(uuid.New())
+
+	tests := map[string]struct {
+		history []ddd.DomainEvent
+		err     error
+	}{
+		"no history": {
+			err: errs.ErrValueIsRequired,
+		},
+		"does not start with the creation": {
+			history: events[1:],
+			err:     errs.ErrValidation,
+		},
+		"misses an event": {
+			history: []ddd.DomainEvent{events[0], events[2]},
+			err:     ddd.ErrVersionGap,
+		},
+		"event of another order": {
+			history: []ddd.DomainEvent{events[0], other.GetDomainEvents()[0]},
+			err:     errs.ErrValidation,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := RebuildOrder(live.ID(), tc.history)
+
+			assert.True(t, errs.HasCause(err, tc.err), "got %v", err)
+		})
+	}
+}
+
+func assertSameState(t *testing.T, expected *Order, actual *Order) {
+	t.Helper()
+	assert.Equal(t, expected.ID(), actual.ID())
+	assert.Equal(t, expected.Version(), actual.Version())
+	assert.Equal(t, expected.CourierID(), actual.CourierID())
+	assert.Equal(t, expected.Location(), actual.Location())
+	assert.Equal(t, expected.Volume(), actual.Volume())
+	assert.Equal(t, expected.Status(), actual.Status())
+	assert.Equal(t, expected.Priority(), actual.Priority())
+	assert.Equal(t, expected.CreatedAt(), actual.CreatedAt())
+	assert.Equal(t, expected.Eta(), actual.Eta())
+	assert.Equal(t, expected.AssignedAt(), actual.AssignedAt())
+	assert.Equal(t, expected.DeliveryWindow(), actual.DeliveryWindow())
+	assert.Equal(t, expected.UnassignReason(), actual.UnassignReason())
+	assert.Equal(t, expected.Proof(), actual.Proof())
+	assert.Equal(t, expected.ZoneID(), actual.ZoneID())
+}
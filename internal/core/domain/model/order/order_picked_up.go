@@ -0,0 +1,27 @@
+package order
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const PickedUpEventName = "OrderPickedUp"
+
+var _ ddd.DomainEvent = &PickedUpDomainEvent{}
+
+type PickedUpDomainEvent struct {
+	ddd.BaseEvent
+
+	CourierID uuid.UUID
+}
+
+func NewPickedUpDomainEvent(payload *Order) *PickedUpDomainEvent {
+	return &PickedUpDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(PickedUpEventName, payload.ID()),
+		CourierID: *payload.CourierID(),
+	}
+}
+
+func NewPickedUpDomainEventWithoutData() *PickedUpDomainEvent {
+	return &PickedUpDomainEvent{BaseEvent: ddd.BaseEvent{Name: PickedUpEventName}}
+}
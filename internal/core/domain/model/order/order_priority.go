@@ -0,0 +1,62 @@
+package order
+
+import (
+	"strings"
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+type Priority string
+
+const (
+	Standard Priority = "standard"
+	Express  Priority = "express"
+)
+
+// AgingThreshold is how much longer than an express order a standard one may wait for a courier
+const AgingThreshold = 10 * time.Minute
+
+// ParsePriority accepts any letter case, an empty value means the standard tier
+func ParsePriority(value string) (Priority, error) {
+	switch Priority(strings.ToLower(strings.TrimSpace(value))) {
+	case "", Standard:
+		return Standard, nil
+	case Express:
+		return Express, nil
+	default:
+		return "", errs.NewValidationErrorWithValue("priority", value, "must be standard or express")
+	}
+}
+
+func (p Priority) String() string {
+	return string(p)
+}
+
+// DispatchDeadline is the moment the order is due for a courier. A standard order is due
+// AgingThreshold after creation, so an express order overtakes the standard ones created
+// less than that before it, and a standard order gets ahead of every later express one once it aged.
+func (o *Order) DispatchDeadline() time.Time {
+	if o.priority == Express {
+		return o.createdAt
+	}
+	return o.createdAt.Add(AgingThreshold)
+}
+
+// DispatchBefore reports whether the order goes to a courier before the other one:
+// the earliest deadline first, then the oldest one
+func (o *Order) DispatchBefore(other *Order) bool {
+	mine, theirs := o.DispatchDeadline(), other.DispatchDeadline()
+	if !mine.Equal(theirs) {
+		return mine.Before(theirs)
+	}
+	return o.createdAt.Before(other.createdAt)
+}
+
+// DeliverBefore orders the stops of a courier's route: an express order preempts the planned standard ones
+func (o *Order) DeliverBefore(other *Order) bool {
+	if o.priority != other.priority {
+		return o.priority == Express
+	}
+	return o.createdAt.Before(other.createdAt)
+}
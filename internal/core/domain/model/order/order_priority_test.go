@@ -0,0 +1,155 @@
+package order
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePriority(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected Priority
+		wantErr  bool
+	}{
+		"empty is standard": {
+			value:    "",
+			expected: Standard,
+		},
+		"standard": {
+			value:    "standard",
+			expected: Standard,
+		},
+		"express in upper case": {
+			value:    "EXPRESS",
+			expected: Express,
+		},
+		"unknown tier": {
+			value:   "overnight",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			priority, err := ParsePriority(tc.value)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, errs.ErrValidation)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, priority)
+			}
+		})
+	}
+}
+
+func TestNewOrder_Priority(t *testing.T) {
+	tests := map[string]struct {
+		priority  Priority
+		createdAt time.Time
+		err       error
+	}{
+		"unknown priority": {
+			priority:  "overnight",
+			createdAt: testCreatedAt,
+			err:       errs.ErrValidation,
+		},
+		"no creation time": {
+			priority:  Express,
+			createdAt: time.Time{},
+			err:       errs.ErrValueIsRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewOrder(uuid.New(), mustCreateLocation(1, 1), 1, tc.priority, tc.createdAt)
+
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestOrder_DispatchDeadline(t *testing.T) {
+	tests := map[string]struct {
+		priority Priority
+		expected time.Time
+	}{
+		"standard order is due after aging": {
+			priority: Standard,
+			expected: testCreatedAt.Add(AgingThreshold),
+		},
+		"express order is due right away": {
+			priority: Express,
+			expected: testCreatedAt,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			o := mustCreateOrderWithPriority(tc.priority, testCreatedAt)
+
+			assert.Equal(t, tc.expected, o.DispatchDeadline())
+		})
+	}
+}
+
+func TestOrder_DispatchBefore(t *testing.T) {
+	tests := map[string]struct {
+		order    *Order
+		other    *Order
+		expected bool
+	}{
+		"express goes before a slightly older standard order": {
+			order:    mustCreateOrderWithPriority(Express, testCreatedAt.Add(time.Minute)),
+			other:    mustCreateOrderWithPriority(Standard, testCreatedAt),
+			expected: true,
+		},
+		"aged standard order goes before a newer express one": {
+			order:    mustCreateOrderWithPriority(Standard, testCreatedAt),
+			other:    mustCreateOrderWithPriority(Express, testCreatedAt.Add(AgingThreshold+time.Second)),
+			expected: true,
+		},
+		"older order of the same tier goes first": {
+			order:    mustCreateOrderWithPriority(Standard, testCreatedAt),
+			other:    mustCreateOrderWithPriority(Standard, testCreatedAt.Add(time.Minute)),
+			expected: true,
+		},
+		"newer order of the same tier waits": {
+			order:    mustCreateOrderWithPriority(Express, testCreatedAt.Add(time.Minute)),
+			other:    mustCreateOrderWithPriority(Express, testCreatedAt),
+			expected: false,
+		},
+		"same deadline goes to the older order": {
+			order:    mustCreateOrderWithPriority(Standard, testCreatedAt),
+			other:    mustCreateOrderWithPriority(Express, testCreatedAt.Add(AgingThreshold)),
+			expected: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.order.DispatchBefore(tc.other))
+		})
+	}
+}
+
+func TestOrder_DeliverBefore(t *testing.T) {
+	older := mustCreateOrderWithPriority(Standard, testCreatedAt)
+	express := mustCreateOrderWithPriority(Express, testCreatedAt.Add(AgingThreshold))
+
+	assert.True(t, express.DeliverBefore(older))
+	assert.False(t, older.DeliverBefore(express))
+}
+
+func mustCreateOrderWithPriority(priority Priority, createdAt time.Time) *Order {
+	o, err := NewOrder(uuid.New(), mustCreateLocation(1, 1), 1, priority, createdAt)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
@@ -6,9 +6,14 @@ const (
 	Empty     Status = ""
 	Created   Status = "Created"
 	Assigned  Status = "Assigned"
+	Accepted  Status = "Accepted"
+	PickedUp  Status = "PickedUp"
 	Completed Status = "Completed"
 )
 
+// WithCourierStatuses are the statuses of an order a courier holds, from the offer up to the handover
+var WithCourierStatuses = []Status{Assigned, Accepted, PickedUp}
+
 func (s Status) equals(other Status) bool {
 	return s == other
 }
@@ -17,6 +22,16 @@ func (s Status) isEmpty() bool {
 	return s == Empty
 }
 
+// WithCourier reports whether a courier holds the order
+func (s Status) WithCourier() bool {
+	for _, status := range WithCourierStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
 func (s Status) String() string {
 	return string(s)
 }
@@ -2,13 +2,17 @@ package order
 
 import (
 	"testing"
+	"time"
 
 	"github.com/delivery/internal/core/domain/model/kernel"
 	"github.com/delivery/internal/pkg/errs"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+var testCreatedAt = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
 func TestNewOrder(t *testing.T) {
 	tests := map[string]struct {
 		orderID  uuid.UUID
@@ -48,7 +52,7 @@ func TestNewOrder(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			order, err := NewOrder(tc.orderID, tc.location, tc.volume)
+			order, err := NewOrder(tc.orderID, tc.location, tc.volume, Standard, testCreatedAt)
 
 			if tc.wantErr {
 				assert.Error(t, err)
@@ -67,8 +71,10 @@ func TestNewOrder(t *testing.T) {
 
 func TestOrder_Assign(t *testing.T) {
 	validCourierID := uuid.New()
+	previousCourierID := uuid.New()
 	tests := map[string]struct {
 		courierId *uuid.UUID
+		reached   Status
 		status    Status
 		wantErr   bool
 		err       error
@@ -85,22 +91,52 @@ func TestOrder_Assign(t *testing.T) {
 			wantErr:   true,
 			err:       errs.ErrValueIsRequired,
 		},
+		"order offered to another courier": {
+			courierId: &validCourierID,
+			reached:   Assigned,
+			status:    Assigned,
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+		"order accepted by another courier": {
+			courierId: &validCourierID,
+			reached:   Accepted,
+			status:    Accepted,
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+		"order picked up by another courier": {
+			courierId: &validCourierID,
+			reached:   PickedUp,
+			status:    PickedUp,
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+		"completed order": {
+			courierId: &validCourierID,
+			reached:   Completed,
+			status:    Completed,
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
 	}
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			validOrderID := uuid.New()
 			location := mustCreateLocation(1, 1)
-			order, err := NewOrder(validOrderID, location, 1)
+			order, err := NewOrder(validOrderID, location, 1, Standard, testCreatedAt)
 			assert.NoError(t, err)
+			mustAdvance(t, order, previousCourierID, tc.reached)
+			wantCourierID := order.CourierID()
 
-			err = order.Assign(tc.courierId)
+			err = order.Assign(tc.courierId, testCreatedAt)
 
 			if tc.wantErr {
 				assert.Error(t, err)
 				assert.ErrorIs(t, err, tc.err)
 				assert.Equal(t, tc.status, order.Status())
-				assert.Equal(t, tc.courierId, order.CourierID())
+				assert.Equal(t, wantCourierID, order.CourierID())
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.status, order.Status())
@@ -110,23 +146,26 @@ func TestOrder_Assign(t *testing.T) {
 	}
 }
 
-func TestOrder_Complete(t *testing.T) {
-	validCourierId := uuid.New()
+func TestOrder_Accept(t *testing.T) {
+	courierID := uuid.New()
 	tests := map[string]struct {
-		courierId *uuid.UUID
-		status    Status
+		courierID uuid.UUID
+		assign    bool
 		wantErr   bool
 		err       error
 	}{
-		"valid completion": {
-			courierId: &validCourierId,
-			status:    Completed,
-			wantErr:   false,
-			err:       nil,
+		"courier accepts": {
+			courierID: courierID,
+			assign:    true,
 		},
-		"courier didn't assign": {
-			courierId: nil,
-			status:    Created,
+		"order is offered to another courier": {
+			courierID: uuid.New(),
+			assign:    true,
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+		"order is not offered": {
+			courierID: courierID,
 			wantErr:   true,
 			err:       errs.ErrBusiness,
 		},
@@ -134,30 +173,355 @@ func TestOrder_Complete(t *testing.T) {
 
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			validOrderID := uuid.New()
-			location := mustCreateLocation(1, 1)
-			order, err := NewOrder(validOrderID, location, 1)
-			assert.NoError(t, err)
+			order := mustCreateOrder(uuid.New())
+			if tc.assign {
+				assert.NoError(t, order.Assign(&courierID, testCreatedAt))
+			}
+			before := order.Status()
+
+			err := order.Accept(tc.courierID)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, before, order.Status())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, Accepted, order.Status())
+			}
+		})
+	}
+}
 
-			if !tc.wantErr {
-				err = order.Assign(tc.courierId)
+func TestOrder_Decline(t *testing.T) {
+	courierID := uuid.New()
+	tests := map[string]struct {
+		courierID uuid.UUID
+		accepted  bool
+		reason    string
+		wantErr   bool
+		err       error
+	}{
+		"courier turns the offer down": {
+			courierID: courierID,
+			reason:    "too far away",
+		},
+		"accepted order can not be declined": {
+			courierID: courierID,
+			accepted:  true,
+			reason:    "too far away",
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+		"order is offered to another courier": {
+			courierID: uuid.New(),
+			reason:    "too far away",
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+		"empty reason": {
+			courierID: courierID,
+			reason:    "",
+			wantErr:   true,
+			err:       errs.ErrValueIsRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			order := mustCreateOrder(uuid.New())
+			assert.NoError(t, order.Assign(&courierID, testCreatedAt))
+			if tc.accepted {
+				assert.NoError(t, order.Accept(courierID))
 			}
 
-			err = order.Complete()
+			err := order.Decline(tc.courierID, tc.reason)
 
 			if tc.wantErr {
-				assert.Error(t, err)
 				assert.ErrorIs(t, err, tc.err)
-				assert.Equal(t, tc.status, order.Status())
+				assert.Equal(t, &courierID, order.CourierID())
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tc.courierId, order.CourierID())
-				assert.Equal(t, tc.status, order.Status())
+				assert.Equal(t, Created, order.Status())
+				assert.Nil(t, order.CourierID())
+				assert.True(t, order.AssignedAt().IsZero())
+				assert.Equal(t, tc.reason, order.UnassignReason())
 			}
 		})
 	}
 }
 
+func TestOrder_PickUp(t *testing.T) {
+	courierID := uuid.New()
+	tests := map[string]struct {
+		courierID uuid.UUID
+		accepted  bool
+		wantErr   bool
+		err       error
+	}{
+		"courier picks the accepted order up": {
+			courierID: courierID,
+			accepted:  true,
+		},
+		"order was not accepted": {
+			courierID: courierID,
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+		"order is assigned to another courier": {
+			courierID: uuid.New(),
+			accepted:  true,
+			wantErr:   true,
+			err:       errs.ErrBusiness,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			order := mustCreateOrder(uuid.New())
+			assert.NoError(t, order.Assign(&courierID, testCreatedAt))
+			if tc.accepted {
+				assert.NoError(t, order.Accept(courierID))
+			}
+
+			err := order.PickUp(tc.courierID)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.NotEqual(t, PickedUp, order.Status())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, PickedUp, order.Status())
+			}
+		})
+	}
+}
+
+func TestOrder_Complete(t *testing.T) {
+	courierID := uuid.New()
+	tests := map[string]struct {
+		assign   bool
+		pickedUp bool
+		wantErr  bool
+		err      error
+	}{
+		"valid completion": {
+			assign:   true,
+			pickedUp: true,
+		},
+		"order was not picked up": {
+			assign:  true,
+			wantErr: true,
+			err:     errs.ErrBusiness,
+		},
+		"courier didn't assign": {
+			wantErr: true,
+			err:     errs.ErrBusiness,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			order := mustCreateOrder(uuid.New())
+			if tc.assign {
+				assert.NoError(t, order.Assign(&courierID, testCreatedAt))
+				assert.NoError(t, order.Accept(courierID))
+			}
+			if tc.pickedUp {
+				assert.NoError(t, order.PickUp(courierID))
+			}
+			before := order.Status()
+
+			err := order.Complete(nil)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Equal(t, before, order.Status())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &courierID, order.CourierID())
+				assert.Equal(t, Completed, order.Status())
+				assert.Nil(t, order.Proof())
+			}
+		})
+	}
+}
+
+func TestOrder_UpdateEta(t *testing.T) {
+	eta := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	courierID := uuid.New()
+	tests := map[string]struct {
+		assign  bool
+		eta     time.Time
+		wantErr bool
+		err     error
+	}{
+		"assigned order": {
+			assign:  true,
+			eta:     eta,
+			wantErr: false,
+		},
+		"order is not assigned": {
+			assign:  false,
+			eta:     eta,
+			wantErr: true,
+			err:     errs.ErrBusiness,
+		},
+		"empty eta": {
+			assign:  true,
+			eta:     time.Time{},
+			wantErr: true,
+			err:     errs.ErrValueIsRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			order := mustCreateOrder(uuid.New())
+			if tc.assign {
+				assert.NoError(t, order.Assign(&courierID, testCreatedAt))
+			}
+
+			err := order.UpdateEta(tc.eta)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, order.Eta())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, &tc.eta, order.Eta())
+			}
+		})
+	}
+}
+
+func TestOrder_EtaIsPublishedWithAssignment(t *testing.T) {
+	eta := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	courierID := uuid.New()
+	order := mustCreateOrder(uuid.New())
+	order.ClearDomainEvents()
+
+	assert.NoError(t, order.Assign(&courierID, testCreatedAt))
+	assert.NoError(t, order.UpdateEta(eta))
+
+	events := order.GetDomainEvents()
+	assert.Len(t, events, 1)
+	assigned, ok := events[0].(*AssignedDomainEvent)
+	assert.True(t, ok)
+	assert.Equal(t, courierID, assigned.CourierID)
+	assert.Equal(t, &eta, assigned.Eta)
+
+	assert.NoError(t, order.Accept(courierID))
+	assert.NoError(t, order.PickUp(courierID))
+	assert.NoError(t, order.Complete(nil))
+	assert.Nil(t, order.Eta())
+}
+
+func TestOrder_UpdateEta_RecordsAChangeOfAStoredAssignment(t *testing.T) {
+	courierID := uuid.New()
+	order := mustCreateOrder(uuid.New())
+	assert.NoError(t, order.Assign(&courierID, testCreatedAt))
+	assert.NoError(t, order.UpdateEta(testCreatedAt.Add(10*time.Minute)))
+	order.ClearDomainEvents()
+
+	assert.NoError(t, order.UpdateEta(testCreatedAt.Add(10*time.Minute)))
+	assert.Empty(t, order.GetDomainEvents(), "an unchanged eta records nothing")
+
+	assert.NoError(t, order.UpdateEta(testCreatedAt.Add(12*time.Minute)))
+	assert.NoError(t, order.UpdateEta(testCreatedAt.Add(14*time.Minute)))
+
+	events := order.GetDomainEvents()
+	assert.Len(t, events, 1)
+	updated, ok := events[0].(*EtaUpdatedDomainEvent)
+	assert.True(t, ok)
+	assert.Equal(t, testCreatedAt.Add(14*time.Minute), updated.Eta)
+}
+
+func TestOrder_Unassign(t *testing.T) {
+	courierID := uuid.New()
+	tests := map[string]struct {
+		reached Status
+		reason  string
+		wantErr bool
+		err     error
+	}{
+		"assigned order": {
+			reached: Assigned,
+			reason:  "bike broke down",
+		},
+		"accepted order": {
+			reached: Accepted,
+			reason:  "bike broke down",
+		},
+		"order is not assigned": {
+			reason:  "bike broke down",
+			wantErr: true,
+			err:     errs.ErrBusiness,
+		},
+		"picked up order stays in the bag of the courier": {
+			reached: PickedUp,
+			reason:  "bike broke down",
+			wantErr: true,
+			err:     errs.ErrBusiness,
+		},
+		"empty reason": {
+			reached: Assigned,
+			reason:  "",
+			wantErr: true,
+			err:     errs.ErrValueIsRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			order := mustCreateOrder(uuid.New())
+			mustAdvance(t, order, courierID, tc.reached)
+			if tc.reached != "" {
+				assert.NoError(t, order.UpdateEta(testCreatedAt.Add(time.Minute)))
+			}
+
+			err := order.Unassign(tc.reason)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Empty(t, order.UnassignReason())
+				if tc.reached != "" {
+					assert.Equal(t, tc.reached, order.Status())
+					assert.Equal(t, &courierID, order.CourierID())
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, Created, order.Status())
+				assert.Nil(t, order.CourierID())
+				assert.Nil(t, order.Eta())
+				assert.Equal(t, tc.reason, order.UnassignReason())
+			}
+		})
+	}
+}
+
+// mustAdvance takes a created order through the courier steps up to the status, an empty status leaves it created
+func mustAdvance(t *testing.T, order *Order, courierID uuid.UUID, to Status) {
+	t.Helper()
+	steps := []struct {
+		status Status
+		step   func() error
+	}{
+		{Assigned, func() error { return order.Assign(&courierID, testCreatedAt) }},
+		{Accepted, func() error { return order.Accept(courierID) }},
+		{PickedUp, func() error { return order.PickUp(courierID) }},
+		{Completed, func() error { return order.Complete(nil) }},
+	}
+	if to == "" || to == Created {
+		return
+	}
+	for _, s := range steps {
+		require.NoError(t, s.step())
+		if s.status == to {
+			return
+		}
+	}
+}
+
 func TestOrder_Equals(t *testing.T) {
 	validOrderID := uuid.New()
 	tests := map[string]struct {
@@ -188,7 +552,7 @@ func TestOrder_Equals(t *testing.T) {
 
 func mustCreateOrder(orderID uuid.UUID) *Order {
 	location := mustCreateLocation(1, 1)
-	order, err := NewOrder(orderID, location, 1)
+	order, err := NewOrder(orderID, location, 1, Standard, testCreatedAt)
 	if err != nil {
 		panic(err)
 	}
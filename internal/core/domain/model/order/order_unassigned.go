@@ -0,0 +1,30 @@
+package order
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const UnassignedEventName = "OrderUnassigned"
+
+var _ ddd.DomainEvent = &UnassignedDomainEvent{}
+
+type UnassignedDomainEvent struct {
+	ddd.BaseEvent
+
+	// CourierID is the courier the order was taken away from
+	CourierID uuid.UUID
+	Reason    string
+}
+
+func NewUnassignedDomainEvent(payload *Order, courierID uuid.UUID) *UnassignedDomainEvent {
+	return &UnassignedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(UnassignedEventName, payload.ID()),
+		CourierID: courierID,
+		Reason:    payload.UnassignReason(),
+	}
+}
+
+func NewUnassignedDomainEventWithoutData() *UnassignedDomainEvent {
+	return &UnassignedDomainEvent{BaseEvent: ddd.BaseEvent{Name: UnassignedEventName}}
+}
@@ -0,0 +1,27 @@
+package order
+
+import (
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/google/uuid"
+)
+
+const ZoneTaggedEventName = "OrderZoneTagged"
+
+var _ ddd.DomainEvent = &ZoneTaggedDomainEvent{}
+
+type ZoneTaggedDomainEvent struct {
+	ddd.BaseEvent
+
+	ZoneID uuid.UUID
+}
+
+func NewZoneTaggedDomainEvent(payload *Order) *ZoneTaggedDomainEvent {
+	return &ZoneTaggedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(ZoneTaggedEventName, payload.ID()),
+		ZoneID:    *payload.ZoneID(),
+	}
+}
+
+func NewZoneTaggedDomainEventWithoutData() *ZoneTaggedDomainEvent {
+	return &ZoneTaggedDomainEvent{BaseEvent: ddd.BaseEvent{Name: ZoneTaggedEventName}}
+}
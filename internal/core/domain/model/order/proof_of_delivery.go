@@ -0,0 +1,51 @@
+package order
+
+import (
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+var pinPattern = regexp.MustCompile(`^[0-9]{4,8}$`)
+
+// ProofOfDelivery is what the courier confirms the handover with: the sha256 of a photo
+// of the delivered order, the pin the customer told the courier, or both
+type ProofOfDelivery struct {
+	photoHash string
+	pin       string
+}
+
+func NewProofOfDelivery(photoHash, pin string) (ProofOfDelivery, error) {
+	if photoHash == "" && pin == "" {
+		return ProofOfDelivery{}, errs.NewValueIsRequiredError("photo hash or pin")
+	}
+	if photoHash != "" {
+		if decoded, err := hex.DecodeString(photoHash); err != nil || len(decoded) != 32 {
+			return ProofOfDelivery{}, errs.NewValidationErrorWithValue("photo hash", photoHash,
+				"must be a hex encoded sha256")
+		}
+	}
+	if pin != "" && !pinPattern.MatchString(pin) {
+		return ProofOfDelivery{}, errs.NewValidationError("pin", "must be 4 to 8 digits")
+	}
+
+	return ProofOfDelivery{photoHash: strings.ToLower(photoHash), pin: pin}, nil
+}
+
+// RestoreProofOfDelivery must be used ONLY in a repository layer for mapping
+func RestoreProofOfDelivery(photoHash, pin string) *ProofOfDelivery {
+	if photoHash == "" && pin == "" {
+		return nil
+	}
+	return &ProofOfDelivery{photoHash: photoHash, pin: pin}
+}
+
+func (p ProofOfDelivery) PhotoHash() string {
+	return p.photoHash
+}
+
+func (p ProofOfDelivery) Pin() string {
+	return p.pin
+}
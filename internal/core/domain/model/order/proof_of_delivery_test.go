@@ -0,0 +1,71 @@
+package order
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProofOfDelivery(t *testing.T) {
+	photoHash := strings.Repeat("ab", 32)
+	tests := map[string]struct {
+		photoHash string
+		pin       string
+		wantErr   bool
+		err       error
+	}{
+		"photo hash": {
+			photoHash: photoHash,
+		},
+		"upper case photo hash": {
+			photoHash: strings.ToUpper(photoHash),
+		},
+		"pin": {
+			pin: "0042",
+		},
+		"photo hash and pin": {
+			photoHash: photoHash,
+			pin:       "12345678",
+		},
+		"neither": {
+			wantErr: true,
+			err:     errs.ErrValueIsRequired,
+		},
+		"photo hash is not sha256": {
+			photoHash: "abcd",
+			wantErr:   true,
+			err:       errs.ErrValidation,
+		},
+		"photo hash is not hex": {
+			photoHash: strings.Repeat("zz", 32),
+			wantErr:   true,
+			err:       errs.ErrValidation,
+		},
+		"pin is too short": {
+			pin:     "123",
+			wantErr: true,
+			err:     errs.ErrValidation,
+		},
+		"pin has letters": {
+			pin:     "12a4",
+			wantErr: true,
+			err:     errs.ErrValidation,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			proof, err := NewProofOfDelivery(tc.photoHash, tc.pin)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, strings.ToLower(tc.photoHash), proof.PhotoHash())
+				assert.Equal(t, tc.pin, proof.Pin())
+			}
+		})
+	}
+}
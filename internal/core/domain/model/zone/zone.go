@@ -0,0 +1,79 @@
+package zone
+
+import (
+	"errors"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/pkg/ddd"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+// ErrOutsideServiceZones reports a location no service zone covers
+var ErrOutsideServiceZones = errors.New("location is outside every service zone")
+
+// Zone is a part of the grid the service delivers to, couriers are based in one
+type Zone struct {
+	*ddd.BaseAggregate[uuid.UUID]
+
+	name string
+	area kernel.Rectangle
+}
+
+func NewZone(zoneID uuid.UUID, name string, area kernel.Rectangle) (*Zone, error) {
+	if zoneID == uuid.Nil {
+		return nil, errs.NewValueIsRequiredError("zone id")
+	}
+	if name == "" {
+		return nil, errs.NewValueIsRequiredError("zone name")
+	}
+
+	z := &Zone{
+		BaseAggregate: ddd.NewBaseAggregate[uuid.UUID](zoneID),
+		name:          name,
+		area:          area,
+	}
+	z.RaiseDomainEvent(NewCreatedDomainEvent(z))
+
+	return z, nil
+}
+
+// RestoreZone must be used ONLY in a repository layer for mapping
+func RestoreZone(zoneID uuid.UUID, name string, area kernel.Rectangle, version int) *Zone {
+	return &Zone{
+		BaseAggregate: ddd.RestoreBaseAggregate[uuid.UUID](zoneID, version),
+		name:          name,
+		area:          area,
+	}
+}
+
+func (z *Zone) Contains(location kernel.Location) bool {
+	return z.area.Contains(location)
+}
+
+// Overlaps reports whether the zones share a location, service zones must not
+func (z *Zone) Overlaps(other *Zone) bool {
+	return z.area.Overlaps(other.area)
+}
+
+// Locate returns the zone that covers the location, the service zones do not overlap
+func Locate(zones []*Zone, location kernel.Location) (*Zone, error) {
+	for _, z := range zones {
+		if z.Contains(location) {
+			return z, nil
+		}
+	}
+	return nil, ErrOutsideServiceZones
+}
+
+func (z *Zone) ID() uuid.UUID {
+	return z.BaseAggregate.ID()
+}
+
+func (z *Zone) Name() string {
+	return z.name
+}
+
+func (z *Zone) Area() kernel.Rectangle {
+	return z.area
+}
@@ -0,0 +1,29 @@
+package zone
+
+import (
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/pkg/ddd"
+)
+
+const CreatedEventName = "ZoneCreated"
+
+var _ ddd.DomainEvent = &CreatedDomainEvent{}
+
+type CreatedDomainEvent struct {
+	ddd.BaseEvent
+
+	Name string
+	Area kernel.Rectangle
+}
+
+func NewCreatedDomainEvent(payload *Zone) *CreatedDomainEvent {
+	return &CreatedDomainEvent{
+		BaseEvent: ddd.NewBaseEvent(CreatedEventName, payload.ID()),
+		Name:      payload.Name(),
+		Area:      payload.Area(),
+	}
+}
+
+func NewCreatedDomainEventWithoutData() *CreatedDomainEvent {
+	return &CreatedDomainEvent{BaseEvent: ddd.BaseEvent{Name: CreatedEventName}}
+}
@@ -0,0 +1,111 @@
+package zone
+
+import (
+	"testing"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewZone(t *testing.T) {
+	area := mustCreateArea(1, 1, 5, 5)
+	tests := map[string]struct {
+		zoneID  uuid.UUID
+		name    string
+		wantErr bool
+		err     error
+	}{
+		"valid zone": {
+			zoneID: uuid.New(),
+			name:   "center",
+		},
+		"empty id": {
+			zoneID:  uuid.Nil,
+			name:    "center",
+			wantErr: true,
+			err:     errs.ErrValueIsRequired,
+		},
+		"empty name": {
+			zoneID:  uuid.New(),
+			name:    "",
+			wantErr: true,
+			err:     errs.ErrValueIsRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			z, err := NewZone(tc.zoneID, tc.name, area)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, tc.err)
+				assert.Nil(t, z)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.name, z.Name())
+				assert.Equal(t, area, z.Area())
+				require.Len(t, z.GetDomainEvents(), 1)
+				assert.IsType(t, &CreatedDomainEvent{}, z.GetDomainEvents()[0])
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	west := mustCreateZone("west", mustCreateArea(1, 1, 5, 10))
+	east := mustCreateZone("east", mustCreateArea(6, 1, 8, 10))
+	zones := []*Zone{west, east}
+
+	tests := map[string]struct {
+		x, y     int
+		expected *Zone
+		wantErr  bool
+	}{
+		"in the first zone":  {x: 5, y: 3, expected: west},
+		"in the second zone": {x: 6, y: 3, expected: east},
+		"outside every zone": {x: 9, y: 3, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			location, err := kernel.NewLocation(tc.x, tc.y)
+			require.NoError(t, err)
+
+			z, err := Locate(zones, location)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrOutsideServiceZones)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, z)
+			}
+		})
+	}
+}
+
+func mustCreateZone(name string, area kernel.Rectangle) *Zone {
+	z, err := NewZone(uuid.New(), name, area)
+	if err != nil {
+		panic(err)
+	}
+	return z
+}
+
+func mustCreateArea(fromX, fromY, toX, toY int) kernel.Rectangle {
+	from, err := kernel.NewLocation(fromX, fromY)
+	if err != nil {
+		panic(err)
+	}
+	to, err := kernel.NewLocation(toX, toY)
+	if err != nil {
+		panic(err)
+	}
+	area, err := kernel.NewRectangle(from, to)
+	if err != nil {
+		panic(err)
+	}
+	return area
+}
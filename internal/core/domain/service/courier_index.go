@@ -0,0 +1,176 @@
+package service
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/google/uuid"
+)
+
+// CourierIndex buckets the couriers by the cell of the grid they stand on, so the couriers reaching
+// a location first are found by walking the rings of cells around it instead of every courier.
+// It is safe for concurrent use: the courier events refresh it while the dispatch reads it.
+type CourierIndex struct {
+	mu       sync.RWMutex
+	cells    map[kernel.Location]map[uuid.UUID]int
+	couriers map[uuid.UUID]indexedCourier
+	// speeds counts the couriers of every speed, the fastest bounds how far the search walks
+	speeds map[int]int
+}
+
+type indexedCourier struct {
+	location kernel.Location
+	speed    int
+}
+
+type rankedCourier struct {
+	id   uuid.UUID
+	time float64
+}
+
+func NewCourierIndex() *CourierIndex {
+	return &CourierIndex{
+		cells:    make(map[kernel.Location]map[uuid.UUID]int),
+		couriers: make(map[uuid.UUID]indexedCourier),
+		speeds:   make(map[int]int),
+	}
+}
+
+// Put indexes the courier at the location, a courier indexed already is moved there
+func (i *CourierIndex) Put(courierID uuid.UUID, location kernel.Location, speed int) {
+	if speed <= 0 {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.remove(courierID)
+	i.couriers[courierID] = indexedCourier{location: location, speed: speed}
+	if i.cells[location] == nil {
+		i.cells[location] = make(map[uuid.UUID]int)
+	}
+	i.cells[location][courierID] = speed
+	i.speeds[speed]++
+}
+
+// Move refreshes the location of an indexed courier, it reports false for a courier the index does not know
+func (i *CourierIndex) Move(courierID uuid.UUID, to kernel.Location) bool {
+	i.mu.RLock()
+	indexed, ok := i.couriers[courierID]
+	i.mu.RUnlock()
+	if !ok {
+		return false
+	}
+	i.Put(courierID, to, indexed.speed)
+	return true
+}
+
+// Nearest returns up to k couriers that reach the location first, the fastest first and couriers arriving
+// at once by id. The rings are walked until even the fastest courier can't beat the k found so far.
+func (i *CourierIndex) Nearest(location kernel.Location, k int) []uuid.UUID {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if k <= 0 || len(i.couriers) == 0 {
+		return nil
+	}
+	fastest := float64(i.fastestSpeed())
+
+	var best []rankedCourier
+	seen := 0
+	for distance := 0; seen < len(i.couriers); distance++ {
+		if len(best) == k && float64(distance)/fastest > best[k-1].time {
+			break
+		}
+		onGrid := walkRing(location, distance, func(cell kernel.Location) {
+			for id, speed := range i.cells[cell] {
+				best = insertRanked(best, rankedCourier{id: id, time: float64(distance) / float64(speed)}, k)
+				seen++
+			}
+		})
+		if !onGrid {
+			break
+		}
+	}
+
+	ids := make([]uuid.UUID, len(best))
+	for n, ranked := range best {
+		ids[n] = ranked.id
+	}
+	return ids
+}
+
+func (i *CourierIndex) remove(courierID uuid.UUID) {
+	indexed, ok := i.couriers[courierID]
+	if !ok {
+		return
+	}
+	delete(i.cells[indexed.location], courierID)
+	if len(i.cells[indexed.location]) == 0 {
+		delete(i.cells, indexed.location)
+	}
+	i.speeds[indexed.speed]--
+	if i.speeds[indexed.speed] == 0 {
+		delete(i.speeds, indexed.speed)
+	}
+	delete(i.couriers, courierID)
+}
+
+func (i *CourierIndex) fastestSpeed() int {
+	fastest := 0
+	for speed := range i.speeds {
+		fastest = max(fastest, speed)
+	}
+	return fastest
+}
+
+// walkRing visits the cells of the grid at the distance from the center, it reports false once the ring lies off the grid
+func walkRing(center kernel.Location, distance int, visit func(cell kernel.Location)) bool {
+	onGrid := false
+	add := func(x, y int) {
+		if cell, err := kernel.NewLocation(x, y); err == nil {
+			onGrid = true
+			visit(cell)
+		}
+	}
+	for dx := -distance; dx <= distance; dx++ {
+		dy := distance - abs(dx)
+		add(center.X()+dx, center.Y()+dy)
+		if dy != 0 {
+			add(center.X()+dx, center.Y()-dy)
+		}
+	}
+	return onGrid
+}
+
+// insertRanked keeps the k best couriers ordered by the time they arrive in and then by id
+func insertRanked(best []rankedCourier, candidate rankedCourier, k int) []rankedCourier {
+	at := sort.Search(len(best), func(n int) bool {
+		return rankedBefore(candidate, best[n])
+	})
+	if at == k {
+		return best
+	}
+	if len(best) < k {
+		best = append(best, rankedCourier{})
+	}
+	copy(best[at+1:], best[at:])
+	best[at] = candidate
+	return best
+}
+
+func rankedBefore(a, b rankedCourier) bool {
+	if a.time != b.time {
+		return a.time < b.time
+	}
+	return bytes.Compare(a.id[:], b.id[:]) < 0
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
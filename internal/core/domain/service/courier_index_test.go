@@ -0,0 +1,144 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCourierIndex_Nearest(t *testing.T) {
+	slow, fast, near, moved := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	tests := map[string]struct {
+		put  func(index *CourierIndex)
+		k    int
+		want []uuid.UUID
+	}{
+		"the courier arriving first comes first, not the closest one": {
+			put: func(index *CourierIndex) {
+				index.Put(slow, mustCreateLocation(2, 1), 1)
+				index.Put(fast, mustCreateLocation(3, 2), 4)
+			},
+			k:    2,
+			want: []uuid.UUID{fast, slow},
+		},
+		"k caps the couriers returned": {
+			put: func(index *CourierIndex) {
+				index.Put(slow, mustCreateLocation(10, 10), 1)
+				index.Put(near, mustCreateLocation(1, 1), 1)
+			},
+			k:    1,
+			want: []uuid.UUID{near},
+		},
+		"a moved courier is ranked by where it is now": {
+			put: func(index *CourierIndex) {
+				index.Put(near, mustCreateLocation(2, 2), 1)
+				index.Put(moved, mustCreateLocation(10, 10), 1)
+				index.Move(moved, mustCreateLocation(1, 1))
+			},
+			k:    2,
+			want: []uuid.UUID{moved, near},
+		},
+		"a move of a courier the index does not know is ignored": {
+			put: func(index *CourierIndex) {
+				index.Move(moved, mustCreateLocation(1, 1))
+			},
+			k: 1,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			index := NewCourierIndex()
+			tc.put(index)
+
+			got := index.Nearest(mustCreateLocation(1, 1), tc.k)
+
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestCourierIndex_Nearest_MatchesFullScan(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	index := NewCourierIndex()
+	indexed := make(map[uuid.UUID]indexedCourier)
+	for range 500 {
+		id, location, speed := uuid.New(), kernel.CreateRandomLocationFrom(rnd), rnd.Intn(3)+1
+		index.Put(id, location, speed)
+		indexed[id] = indexedCourier{location: location, speed: speed}
+	}
+
+	for range 50 {
+		target := kernel.CreateRandomLocationFrom(rnd)
+		var all []rankedCourier
+		for id, c := range indexed {
+			all = append(all, rankedCourier{id: id, time: float64(c.location.DistanceTo(target)) / float64(c.speed)})
+		}
+		sort.Slice(all, func(i, j int) bool {
+			return rankedBefore(all[i], all[j])
+		})
+		var want []uuid.UUID
+		for _, ranked := range all[:20] {
+			want = append(want, ranked.id)
+		}
+
+		require.Equal(t, want, index.Nearest(target, 20))
+	}
+}
+
+// BenchmarkNearestCourier compares the scan of every courier with the index narrowing the scan down to
+// the couriers arriving first, the fleet spread over the grid at random
+func BenchmarkNearestCourier(b *testing.B) {
+	for _, size := range []int{10, 100, 1_000, 10_000, 100_000} {
+		rnd := rand.New(rand.NewSource(1))
+		couriers := make([]*courier.Courier, size)
+		byID := make(map[uuid.UUID]*courier.Courier, size)
+		index := NewCourierIndex()
+		for n := range couriers {
+			c := mustCreateCourier(fmt.Sprintf("courier%d", n), rnd.Intn(3)+1, kernel.CreateRandomLocationFrom(rnd))
+			mustAddStoragePlace(c, "bag", 10)
+			couriers[n] = c
+			byID[c.ID()] = c
+			index.Put(c.ID(), c.Location(), c.Speed())
+		}
+		orders := make([]*order.Order, 100)
+		for n := range orders {
+			o, err := order.NewOrder(uuid.New(), kernel.CreateRandomLocationFrom(rnd), 1, order.Standard, time.Now())
+			if err != nil {
+				b.Fatal(err)
+			}
+			orders[n] = o
+		}
+
+		b.Run(fmt.Sprintf("scan/%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				if _, err := findNearestSuitableCourier(orders[n%len(orders)], couriers); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("index/%d", size), func(b *testing.B) {
+			candidates := make([]*courier.Courier, 0, 20)
+			for n := 0; n < b.N; n++ {
+				o := orders[n%len(orders)]
+				candidates = candidates[:0]
+				for _, id := range index.Nearest(o.Location(), 20) {
+					candidates = append(candidates, byID[id])
+				}
+				if _, err := findNearestSuitableCourier(o, candidates); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,51 +1,114 @@
 package service
 
 import (
+	"errors"
+	"time"
+
 	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/offer"
 	"github.com/delivery/internal/core/domain/model/order"
 	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidOrder    = errors.New("order is not created")
+	ErrInvalidCouriers = errors.New("couriers not found")
+	ErrCourierNotFound = errors.New("no suitable couriers found")
 )
 
 type DispatchService interface {
-	Dispatch(order *order.Order, couriers []*courier.Courier) (*courier.Courier, error)
+	// Dispatch offers the order to the best of the couriers at now, the courier keeps room
+	// for the order until it answers the offer or the offer expires
+	Dispatch(order *order.Order, couriers []*courier.Courier, now time.Time) (*courier.Courier, *offer.Offer, error)
 }
 
 type dispatchService struct {
+	rules ZoneRules
+	// offerTimeout is how long the courier has to answer the offer
+	offerTimeout time.Duration
 }
 
+// NewDispatchService dispatches by the DefaultZoneRules with offers of the offer.DefaultTimeout
 func NewDispatchService() DispatchService {
-	return &dispatchService{}
+	return &dispatchService{rules: DefaultZoneRules, offerTimeout: offer.DefaultTimeout}
+}
+
+// NewZonedDispatchService offers an order to the nearest courier of its zone first, the rules
+// decide whether and how far the couriers of the other zones are considered
+func NewZonedDispatchService(rules ZoneRules, offerTimeout time.Duration) (DispatchService, error) {
+	if err := rules.Validate(); err != nil {
+		return nil, err
+	}
+	if offerTimeout <= 0 {
+		return nil, errs.NewValidationErrorWithValue("offer timeout", offerTimeout, "must be greater than zero")
+	}
+	return &dispatchService{rules: rules, offerTimeout: offerTimeout}, nil
 }
 
-func (d *dispatchService) Dispatch(orderParam *order.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+func (d *dispatchService) Dispatch(orderParam *order.Order, couriers []*courier.Courier,
+	now time.Time) (*courier.Courier, *offer.Offer, error) {
 	if orderParam == nil || orderParam.Status() != order.Created {
-		return nil, errs.NewValidationError("order", "order is not created")
+		return nil, nil, errs.NewValidationErrorWithCause("order", "order is not created", ErrInvalidOrder)
 	}
 
 	if couriers == nil || len(couriers) == 0 {
-		return nil, errs.NewValidationError("couriers", "couriers not found")
+		return nil, nil, errs.NewValidationErrorWithCause("couriers", "couriers not found", ErrInvalidCouriers)
 	}
 
-	bestCourier, err := findNearestSuitableCourier(orderParam, couriers)
+	bestCourier, err := d.findBestCourier(orderParam, couriers)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if bestCourier == nil {
-		return nil, errs.NewValidationError("couriers", "no suitable couriers found")
+		return nil, nil, errs.NewValidationErrorWithCause("couriers", "no suitable couriers found", ErrCourierNotFound)
 	}
 
 	courierID := bestCourier.ID()
+	newOffer, err := offer.NewOffer(uuid.New(), orderParam.ID(), courierID, now, d.offerTimeout)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if err := orderParam.Assign(&courierID); err != nil {
-		return nil, err
+	if err := orderParam.Assign(&courierID, now); err != nil {
+		return nil, nil, err
 	}
 
 	if err := bestCourier.TakeOrder(orderParam); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	return bestCourier, newOffer, nil
+}
+
+// findBestCourier picks the nearest courier of the order's zone, and then as far as the
+// rules allow the nearest courier of another zone
+func (d *dispatchService) findBestCourier(orderParam *order.Order, couriers []*courier.Courier) (*courier.Courier, error) {
+	if d.rules.CrossZone == CrossZoneAnywhere {
+		return findNearestSuitableCourier(orderParam, couriers)
+	}
+
+	var inZone, crossZone []*courier.Courier
+	for _, c := range couriers {
+		switch {
+		case c.InZone(orderParam.ZoneID()):
+			inZone = append(inZone, c)
+		case d.withinCrossZoneDistance(orderParam, c):
+			crossZone = append(crossZone, c)
+		}
 	}
 
-	return bestCourier, nil
+	best, err := findNearestSuitableCourier(orderParam, inZone)
+	if err != nil || best != nil || d.rules.CrossZone == CrossZoneNever {
+		return best, err
+	}
+	return findNearestSuitableCourier(orderParam, crossZone)
+}
+
+func (d *dispatchService) withinCrossZoneDistance(orderParam *order.Order, c *courier.Courier) bool {
+	return d.rules.MaxCrossZoneDistance == 0 ||
+		c.Location().DistanceTo(orderParam.Location()) <= d.rules.MaxCrossZoneDistance
 }
 
 func findNearestSuitableCourier(orderParam *order.Order, couriers []*courier.Courier) (*courier.Courier, error) {
@@ -2,15 +2,19 @@ package service
 
 import (
 	"testing"
+	"time"
 
 	"github.com/delivery/internal/core/domain/model/courier"
 	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/offer"
 	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/errs"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestDispatchService_Dispatch(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
 	couriers := createCouriers()
 	courier1 := couriers[0]
 	courier2 := couriers[1]
@@ -19,7 +23,7 @@ func TestDispatchService_Dispatch(t *testing.T) {
 	validOrder2 := mustCreateOrder(uuid.New())
 	invalidOrder := mustCreateOrder(uuid.New())
 	courierID := courier1.ID()
-	err := invalidOrder.Assign(&courierID)
+	err := invalidOrder.Assign(&courierID, now)
 	assert.NoError(t, err)
 
 	tests := map[string]struct {
@@ -69,14 +73,149 @@ func TestDispatchService_Dispatch(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			service := NewDispatchService()
-			dispatch, err := service.Dispatch(tc.orderParam, tc.couriers)
+			dispatch, newOffer, err := service.Dispatch(tc.orderParam, tc.couriers, now)
 
 			if tc.wantErr {
 				assert.Error(t, err)
-				assert.ErrorIs(t, err, tc.err)
+				assert.True(t, errs.HasCause(err, tc.err), "got %v", err)
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.result, dispatch)
+				assert.Equal(t, order.Assigned, tc.orderParam.Status())
+				assert.Equal(t, tc.orderParam.ID(), newOffer.OrderID())
+				assert.Equal(t, tc.result.ID(), newOffer.CourierID())
+				assert.Equal(t, offer.Pending, newOffer.Status())
+				assert.Equal(t, now.Add(offer.DefaultTimeout), newOffer.ExpiresAt())
+			}
+		})
+	}
+}
+
+func TestDispatchService_Dispatch_Zones(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	home := uuid.New()
+	other := uuid.New()
+
+	tests := map[string]struct {
+		rules ZoneRules
+		// orderZone is nil for an order placed while no zones were set up
+		orderZone *uuid.UUID
+		// inZoneFull leaves the courier of the order's zone without room
+		inZoneFull bool
+		// roaming adds a courier without a home zone far away
+		roaming  bool
+		expected string
+		err      error
+	}{
+		"courier of the zone goes before a nearer one of another zone": {
+			rules:     DefaultZoneRules,
+			orderZone: &home,
+			expected:  "in zone",
+		},
+		"courier of another zone takes over when the zone has nobody": {
+			rules:      DefaultZoneRules,
+			orderZone:  &home,
+			inZoneFull: true,
+			expected:   "cross zone",
+		},
+		"order stays in its zone": {
+			rules:      ZoneRules{CrossZone: CrossZoneNever},
+			orderZone:  &home,
+			inZoneFull: true,
+			err:        ErrCourierNotFound,
+		},
+		"courier of another zone is too far away": {
+			rules:      ZoneRules{CrossZone: CrossZoneFallback, MaxCrossZoneDistance: 1},
+			orderZone:  &home,
+			inZoneFull: true,
+			err:        ErrCourierNotFound,
+		},
+		"courier of another zone is close enough": {
+			rules:      ZoneRules{CrossZone: CrossZoneFallback, MaxCrossZoneDistance: 2},
+			orderZone:  &home,
+			inZoneFull: true,
+			expected:   "cross zone",
+		},
+		"zones are ignored": {
+			rules:     ZoneRules{CrossZone: CrossZoneAnywhere},
+			orderZone: &home,
+			expected:  "cross zone",
+		},
+		"order without a zone goes to the nearest courier": {
+			rules:    ZoneRules{CrossZone: CrossZoneNever},
+			expected: "cross zone",
+		},
+		"courier without a home zone works everywhere": {
+			rules:      ZoneRules{CrossZone: CrossZoneNever},
+			orderZone:  &home,
+			inZoneFull: true,
+			roaming:    true,
+			expected:   "roaming",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			couriers := map[string]*courier.Courier{
+				"in zone":    mustCreateCourier("in zone", 1, mustCreateLocation(9, 9)),
+				"cross zone": mustCreateCourier("cross zone", 1, mustCreateLocation(3, 3)),
+			}
+			assert.NoError(t, couriers["in zone"].AssignHomeZone(home))
+			assert.NoError(t, couriers["cross zone"].AssignHomeZone(other))
+			mustAddStoragePlace(couriers["cross zone"], "bag", 10)
+			if !tc.inZoneFull {
+				mustAddStoragePlace(couriers["in zone"], "bag", 10)
+			}
+			if tc.roaming {
+				couriers["roaming"] = mustCreateCourier("roaming", 1, mustCreateLocation(10, 10))
+				mustAddStoragePlace(couriers["roaming"], "bag", 10)
+			}
+			candidates := make([]*courier.Courier, 0, len(couriers))
+			for _, c := range couriers {
+				candidates = append(candidates, c)
+			}
+			o := mustCreateOrder(uuid.New())
+			if tc.orderZone != nil {
+				assert.NoError(t, o.TagZone(*tc.orderZone))
+			}
+
+			service, err := NewZonedDispatchService(tc.rules, 30*time.Second)
+			assert.NoError(t, err)
+			best, made, err := service.Dispatch(o, candidates, now)
+
+			if tc.err != nil {
+				assert.True(t, errs.HasCause(err, tc.err), "got %v", err)
+				assert.Equal(t, order.Created, o.Status())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, couriers[tc.expected], best)
+				assert.Equal(t, now.Add(30*time.Second), made.ExpiresAt())
+			}
+		})
+	}
+}
+
+func TestNewZonedDispatchService(t *testing.T) {
+	tests := map[string]struct {
+		rules        ZoneRules
+		offerTimeout time.Duration
+		wantErr      bool
+	}{
+		"default rules":     {rules: DefaultZoneRules, offerTimeout: offer.DefaultTimeout},
+		"capped fallback":   {rules: ZoneRules{CrossZone: CrossZoneFallback, MaxCrossZoneDistance: 3}, offerTimeout: time.Minute},
+		"unknown rule":      {rules: ZoneRules{CrossZone: "sometimes"}, offerTimeout: time.Minute, wantErr: true},
+		"negative distance": {rules: ZoneRules{CrossZone: CrossZoneNever, MaxCrossZoneDistance: -1}, offerTimeout: time.Minute, wantErr: true},
+		"no offer timeout":  {rules: DefaultZoneRules, wantErr: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewZonedDispatchService(tc.rules, tc.offerTimeout)
+
+			if tc.wantErr {
+				assert.ErrorIs(t, err, errs.ErrValidation)
+			} else {
+				assert.NoError(t, err)
 			}
 		})
 	}
@@ -84,7 +223,7 @@ func TestDispatchService_Dispatch(t *testing.T) {
 
 func mustCreateOrder(orderID uuid.UUID) *order.Order {
 	location := mustCreateLocation(4, 4)
-	ord, err := order.NewOrder(orderID, location, 1)
+	ord, err := order.NewOrder(orderID, location, 1, order.Standard, time.Now())
 	if err != nil {
 		panic(err)
 	}
@@ -97,7 +236,7 @@ func createOccupiedCouriers() []*courier.Courier {
 
 	order1 := mustCreateOrder(uuid.New())
 	courier1ID := courier1.ID()
-	err := order1.Assign(&courier1ID)
+	err := order1.Assign(&courier1ID, time.Now())
 	if err != nil {
 		panic(err)
 	}
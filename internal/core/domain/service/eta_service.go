@@ -0,0 +1,63 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+)
+
+type EtaService interface {
+	// Estimate returns the arrival time of every order on the route, visited in the given sequence
+	Estimate(courier *courier.Courier, route []*order.Order, now time.Time) (map[uuid.UUID]time.Time, error)
+}
+
+type etaService struct {
+	tick time.Duration
+}
+
+// NewEtaService takes the interval the couriers are moved at: a courier only moves on a tick,
+// so it arrives on the first tick that covers the distance; the handover is up to the courier
+func NewEtaService(tick time.Duration) (EtaService, error) {
+	if tick <= 0 {
+		return nil, errs.NewValueIsRequiredError("tick")
+	}
+	return &etaService{tick: tick}, nil
+}
+
+func (s *etaService) Estimate(c *courier.Courier, route []*order.Order, now time.Time) (map[uuid.UUID]time.Time, error) {
+	if c == nil {
+		return nil, errs.NewValueIsRequiredError("courier")
+	}
+	if now.IsZero() {
+		return nil, errs.NewValueIsRequiredError("now")
+	}
+
+	cellsPerTick := float64(c.Speed()) * s.tick.Seconds()
+	// the first move of a route only starts it
+	startTicks := 0
+	if c.MovedAt().IsZero() {
+		startTicks = 1
+	}
+
+	etas := make(map[uuid.UUID]time.Time, len(route))
+	position := c.Location()
+	distance := 0
+	for _, stop := range route {
+		if stop == nil {
+			return nil, errs.NewValueIsRequiredError("order")
+		}
+
+		distance += position.DistanceTo(stop.Location())
+		position = stop.Location()
+
+		left := math.Max(0, float64(distance)-c.MoveProgress())
+		ticks := startTicks + int(math.Ceil(left/cellsPerTick-1e-9))
+		etas[stop.ID()] = now.Add(time.Duration(ticks) * s.tick)
+	}
+
+	return etas, nil
+}
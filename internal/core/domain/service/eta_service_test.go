@@ -0,0 +1,120 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/delivery/internal/pkg/errs"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEtaService_Estimate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := map[string]struct {
+		speed    int
+		tick     time.Duration
+		started  bool
+		stops    []kernel.Location
+		expected []time.Duration
+	}{
+		"single stop": {
+			speed:    2,
+			tick:     time.Second,
+			started:  true,
+			stops:    []kernel.Location{mustCreateLocation(5, 1)},
+			expected: []time.Duration{2 * time.Second},
+		},
+		"route not started yet": {
+			speed:    2,
+			tick:     time.Second,
+			started:  false,
+			stops:    []kernel.Location{mustCreateLocation(5, 1)},
+			expected: []time.Duration{3 * time.Second},
+		},
+		"other stops come first": {
+			speed:    1,
+			tick:     time.Second,
+			started:  true,
+			stops:    []kernel.Location{mustCreateLocation(3, 1), mustCreateLocation(3, 4)},
+			expected: []time.Duration{2 * time.Second, 5 * time.Second},
+		},
+		"slower tick rate": {
+			speed:    1,
+			tick:     2 * time.Second,
+			started:  true,
+			stops:    []kernel.Location{mustCreateLocation(4, 1)},
+			expected: []time.Duration{4 * time.Second},
+		},
+		"courier already there": {
+			speed:    1,
+			tick:     time.Second,
+			started:  true,
+			stops:    []kernel.Location{mustCreateLocation(1, 1)},
+			expected: []time.Duration{0},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			etaService, err := NewEtaService(tc.tick)
+			assert.NoError(t, err)
+
+			c := mustCreateCourier("courier", tc.speed, mustCreateLocation(1, 1))
+			if tc.started {
+				assert.NoError(t, c.Move(tc.stops[0], now.Add(-tc.tick), time.Minute))
+			}
+
+			route := make([]*order.Order, 0, len(tc.stops))
+			for _, stop := range tc.stops {
+				o, err := order.NewOrder(uuid.New(), stop, 1, order.Standard, now)
+				assert.NoError(t, err)
+				route = append(route, o)
+			}
+
+			etas, err := etaService.Estimate(c, route, now)
+
+			assert.NoError(t, err)
+			assert.Len(t, etas, len(route))
+			for i, o := range route {
+				assert.Equal(t, now.Add(tc.expected[i]), etas[o.ID()])
+			}
+		})
+	}
+}
+
+func TestEtaService_EstimateKeepsProgress(t *testing.T) {
+	etaService, err := NewEtaService(time.Second)
+	assert.NoError(t, err)
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	target := mustCreateLocation(4, 1)
+	c := mustCreateCourier("courier", 1, mustCreateLocation(1, 1))
+	assert.NoError(t, c.Move(target, start, time.Minute))
+	now := start.Add(1500 * time.Millisecond)
+	assert.NoError(t, c.Move(target, now, time.Minute))
+	o, err := order.NewOrder(uuid.New(), target, 1, order.Standard, now)
+	assert.NoError(t, err)
+
+	etas, err := etaService.Estimate(c, []*order.Order{o}, now)
+
+	// 2 cells left with half a cell already covered take 2 ticks
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Second), etas[o.ID()])
+}
+
+func TestEtaService_InvalidArguments(t *testing.T) {
+	_, err := NewEtaService(0)
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	etaService, err := NewEtaService(time.Second)
+	assert.NoError(t, err)
+
+	_, err = etaService.Estimate(nil, nil, time.Now())
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+
+	_, err = etaService.Estimate(mustCreateCourier("courier", 1, mustCreateLocation(1, 1)), nil, time.Time{})
+	assert.ErrorIs(t, err, errs.ErrValueIsRequired)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"github.com/delivery/internal/pkg/errs"
+)
+
+// CrossZoneRule says when dispatch may give an order to a courier based in another zone
+type CrossZoneRule string
+
+const (
+	// CrossZoneFallback lets a courier of another zone take the order when no courier of its own zone can
+	CrossZoneFallback CrossZoneRule = "fallback"
+	// CrossZoneNever keeps the order with the couriers of its zone
+	CrossZoneNever CrossZoneRule = "never"
+	// CrossZoneAnywhere ignores the zones, the nearest courier gets the order
+	CrossZoneAnywhere CrossZoneRule = "anywhere"
+)
+
+// ZoneRules decide which couriers dispatch considers for an order of a zone
+type ZoneRules struct {
+	CrossZone CrossZoneRule
+	// MaxCrossZoneDistance caps how far a courier of another zone may be from the order, 0 means no cap
+	MaxCrossZoneDistance int
+}
+
+// DefaultZoneRules prefer the couriers of the zone and fall back to the nearest courier of any zone
+var DefaultZoneRules = ZoneRules{CrossZone: CrossZoneFallback}
+
+func (r ZoneRules) Validate() error {
+	switch r.CrossZone {
+	case CrossZoneFallback, CrossZoneNever, CrossZoneAnywhere:
+	default:
+		return errs.NewValidationErrorWithValue("cross zone", r.CrossZone, "must be fallback, never or anywhere")
+	}
+	if r.MaxCrossZoneDistance < 0 {
+		return errs.NewValidationErrorWithValue("max cross zone distance", r.MaxCrossZoneDistance, "must not be negative")
+	}
+	return nil
+}
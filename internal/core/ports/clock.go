@@ -0,0 +1,9 @@
+package ports
+
+import (
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
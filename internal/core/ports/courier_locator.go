@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
+)
+
+// CourierLocator finds the free couriers near a location without loading the whole fleet
+type CourierLocator interface {
+	// GetNearestAvailable returns up to limit free couriers that reach the location first, the fastest first
+	GetNearestAvailable(ctx context.Context, location kernel.Location, limit int) ([]*courier.Courier, error)
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/delivery/internal/pkg/ddd"
+)
+
+type CourierProducer interface {
+	Publish(ctx context.Context, domainEvent ddd.DomainEvent) error
+	Close() error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/delivery/internal/core/domain/model/courier"
+	"github.com/delivery/internal/core/domain/model/kernel"
 	"github.com/google/uuid"
 )
 
@@ -11,5 +12,10 @@ type CourierRepository interface {
 	Add(ctx context.Context, courier *courier.Courier) error
 	Update(ctx context.Context, courier *courier.Courier) error
 	Get(ctx context.Context, courierID uuid.UUID) (*courier.Courier, error)
+	GetAll(ctx context.Context) ([]*courier.Courier, error)
 	GetAllAvailable(ctx context.Context) ([]*courier.Courier, error)
+	// GetAvailableByIDs returns the free couriers among the ids, in no particular order
+	GetAvailableByIDs(ctx context.Context, courierIDs []uuid.UUID) ([]*courier.Courier, error)
+	// GetNearestAvailable lets the repository serve as the CourierLocator, ranking the couriers where they are stored
+	GetNearestAvailable(ctx context.Context, location kernel.Location, limit int) ([]*courier.Courier, error)
 }
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProcessedMessage is a message the service has handled, keyed by its id and by the basket it was about
+type ProcessedMessage struct {
+	MessageID string
+	BasketID  uuid.UUID
+	// RequestHash fingerprints the request the message carried, empty for messages processed before it was kept
+	RequestHash string
+	ProcessedAt time.Time
+}
+
+// InboxRepository remembers the processed messages, so a redelivered or replayed one is a no-op
+type InboxRepository interface {
+	// Add records the message in the current transaction, it fails with errs.ErrConflict
+	// when the message or the basket was processed before
+	Add(ctx context.Context, message ProcessedMessage) error
+	// Processed tells whether the message or the basket was processed before
+	Processed(ctx context.Context, messageID string, basketID uuid.UUID) (bool, error)
+	// Get returns the processed message with the id, it fails with errs.ErrNotFound for an unknown one
+	Get(ctx context.Context, messageID string) (ProcessedMessage, error)
+}
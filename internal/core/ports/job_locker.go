@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+)
+
+// JobLocker elects a single replica to run a scheduled job
+type JobLocker interface {
+	// TryLock reports whether this replica holds the lock for the job, acquiring it if possible.
+	// Once acquired the lock is kept between runs until Unlock or Close.
+	TryLock(ctx context.Context, job string) (bool, error)
+	Unlock(ctx context.Context, job string) error
+	Close() error
+}
@@ -0,0 +1,19 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/offer"
+	"github.com/google/uuid"
+)
+
+type OfferRepository interface {
+	Add(ctx context.Context, offer *offer.Offer) error
+	Update(ctx context.Context, offer *offer.Offer) error
+	// GetPending returns the offer of the order waiting for the courier's answer
+	GetPending(ctx context.Context, orderID uuid.UUID) (*offer.Offer, error)
+	// GetAllPending returns the offers waiting for an answer, the earliest to expire first
+	GetAllPending(ctx context.Context) ([]*offer.Offer, error)
+	// GetAllByOrder returns every offer made for the order, the first made first
+	GetAllByOrder(ctx context.Context, orderID uuid.UUID) ([]*offer.Offer, error)
+}
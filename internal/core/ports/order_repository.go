@@ -11,6 +11,9 @@ type OrderRepository interface {
 	Add(ctx context.Context, order *order.Order) error
 	Update(ctx context.Context, order *order.Order) error
 	Get(ctx context.Context, orderID uuid.UUID) (*order.Order, error)
+	// GetFirstInStatusCreate returns the next order to dispatch, see order.DispatchBefore
 	GetFirstInStatusCreate(ctx context.Context) (*order.Order, error)
-	GetAllInStatusAssigned(ctx context.Context) ([]*order.Order, error)
+	// GetAllWithCourier returns the orders couriers hold, see order.WithCourierStatuses,
+	// in the sequence couriers deliver them, see order.DeliverBefore
+	GetAllWithCourier(ctx context.Context) ([]*order.Order, error)
 }
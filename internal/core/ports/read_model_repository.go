@@ -0,0 +1,123 @@
+package ports
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/delivery/internal/core/domain/model/kernel"
+	"github.com/delivery/internal/core/domain/model/order"
+	"github.com/google/uuid"
+)
+
+// CourierView is a courier as the queries show it, with the load of its storage places
+type CourierView struct {
+	ID             uuid.UUID
+	Name           string
+	LocationX      int
+	LocationY      int
+	DeclinedOffers int
+	HomeZoneID     *uuid.UUID
+	// OrdersCount is the number of orders the courier holds, from the offer up to the handover
+	OrdersCount int
+	// UsedVolume is the volume of those orders, Capacity the volume of all storage places
+	UsedVolume int
+	Capacity   int
+}
+
+// Cursor is the position of the courier in the list ordered by sort
+func (v CourierView) Cursor(sort CourierSort) Cursor {
+	value := v.Name
+	switch sort {
+	case CourierSortOrdersCount:
+		value = strconv.Itoa(v.OrdersCount)
+	case CourierSortDeclinedOffers:
+		value = strconv.Itoa(v.DeclinedOffers)
+	}
+	return Cursor{Value: value, ID: v.ID}
+}
+
+// OrderView is an order as the queries show it, with the name of the courier holding it
+type OrderView struct {
+	ID          uuid.UUID
+	LocationX   int
+	LocationY   int
+	Volume      int
+	Status      order.Status
+	Priority    order.Priority
+	CreatedAt   time.Time
+	Eta         *time.Time
+	ZoneID      *uuid.UUID
+	CourierID   *uuid.UUID
+	CourierName *string
+}
+
+// Cursor is the position of the order in the list ordered by sort
+func (v OrderView) Cursor(sort OrderSort) Cursor {
+	value := v.CreatedAt.Format(time.RFC3339Nano)
+	if sort == OrderSortVolume {
+		value = strconv.Itoa(v.Volume)
+	}
+	return Cursor{Value: value, ID: v.ID}
+}
+
+// CourierSort and OrderSort name the fields a list can be ordered by, the id breaks the ties
+type CourierSort string
+
+const (
+	CourierSortName           CourierSort = "name"
+	CourierSortOrdersCount    CourierSort = "ordersCount"
+	CourierSortDeclinedOffers CourierSort = "declinedOffers"
+)
+
+type OrderSort string
+
+const (
+	OrderSortCreatedAt OrderSort = "createdAt"
+	OrderSortVolume    OrderSort = "volume"
+)
+
+// Cursor is the sort value and the id of the last row of the previous page
+type Cursor struct {
+	Value string
+	ID    uuid.UUID
+}
+
+// Page is up to Limit rows following the cursor in the sort direction, a nil cursor starts the list
+type Page struct {
+	Limit int
+	Desc  bool
+	After *Cursor
+}
+
+// CourierFilter narrows the courier list, its zero value matches every courier
+type CourierFilter struct {
+	// NamePrefix matches the beginning of the name ignoring the case
+	NamePrefix string
+	Area       *kernel.Rectangle
+}
+
+// OrderFilter narrows the list of uncompleted orders, its zero value matches all of them
+type OrderFilter struct {
+	Statuses []order.Status
+	// CourierNamePrefix matches the beginning of the name of the courier holding the order ignoring the case
+	CourierNamePrefix string
+	Area              *kernel.Rectangle
+}
+
+// ReadModelRepository reads the denormalised views the projectors keep, it never writes
+type ReadModelRepository interface {
+	// ListCouriers returns a page of the couriers matching the filter and how many match it in total
+	ListCouriers(ctx context.Context, filter CourierFilter, sort CourierSort, page Page) ([]CourierView, int64, error)
+	// ListUncompletedOrders returns a page of the uncompleted orders matching the filter and how many match it in total
+	ListUncompletedOrders(ctx context.Context, filter OrderFilter, sort OrderSort, page Page) ([]OrderView, int64, error)
+}
+
+// ReadModelProjector brings the views in line with the write model in the current transaction
+type ReadModelProjector interface {
+	// ProjectCourier refreshes the courier and the orders it holds
+	ProjectCourier(ctx context.Context, courierID uuid.UUID) error
+	ProjectOrder(ctx context.Context, orderID uuid.UUID) error
+	// Rebuild regenerates every view from the write tables
+	Rebuild(ctx context.Context) error
+}
@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/analytics"
+)
+
+type SupplyDemandProducer interface {
+	Publish(ctx context.Context, snapshot analytics.Snapshot) error
+	Close() error
+}
@@ -15,7 +15,12 @@ type UnitOfWork interface {
 
 	Begin(ctx context.Context)
 	Commit(ctx context.Context) error
+	// Rollback abandons the transaction and the domain events raised in it
+	Rollback(ctx context.Context)
 
 	CourierRepository() CourierRepository
 	OrderRepository() OrderRepository
+	OfferRepository() OfferRepository
+	ZoneRepository() ZoneRepository
+	InboxRepository() InboxRepository
 }
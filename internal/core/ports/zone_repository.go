@@ -0,0 +1,14 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/delivery/internal/core/domain/model/zone"
+	"github.com/google/uuid"
+)
+
+type ZoneRepository interface {
+	Add(ctx context.Context, zone *zone.Zone) error
+	Get(ctx context.Context, zoneID uuid.UUID) (*zone.Zone, error)
+	GetAll(ctx context.Context) ([]*zone.Zone, error)
+}
@@ -14,30 +14,284 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/runtime"
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	ApiKeyScopes     = "apiKey.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for AssignedOrderPriority.
+const (
+	AssignedOrderPriorityExpress  AssignedOrderPriority = "express"
+	AssignedOrderPriorityStandard AssignedOrderPriority = "standard"
+)
+
+// Defines values for AssignedOrderStatus.
+const (
+	AssignedOrderStatusAccepted AssignedOrderStatus = "Accepted"
+	AssignedOrderStatusAssigned AssignedOrderStatus = "Assigned"
+	AssignedOrderStatusPickedUp AssignedOrderStatus = "PickedUp"
+)
+
+// Defines values for AuditEntryAggregateType.
+const (
+	AuditEntryAggregateTypeCourier AuditEntryAggregateType = "courier"
+	AuditEntryAggregateTypeOffer   AuditEntryAggregateType = "offer"
+	AuditEntryAggregateTypeOrder   AuditEntryAggregateType = "order"
+	AuditEntryAggregateTypeZone    AuditEntryAggregateType = "zone"
+)
+
+// Defines values for NewOrderPriority.
+const (
+	NewOrderPriorityExpress  NewOrderPriority = "express"
+	NewOrderPriorityStandard NewOrderPriority = "standard"
+)
+
+// Defines values for OrderPriority.
+const (
+	Express  OrderPriority = "express"
+	Standard OrderPriority = "standard"
+)
+
+// Defines values for OrderStatus.
+const (
+	OrderStatusAccepted OrderStatus = "Accepted"
+	OrderStatusAssigned OrderStatus = "Assigned"
+	OrderStatusCreated  OrderStatus = "Created"
+	OrderStatusPickedUp OrderStatus = "PickedUp"
+)
+
+// Defines values for GetCouriersParamsSort.
+const (
+	DeclinedOffers      GetCouriersParamsSort = "declinedOffers"
+	MinusDeclinedOffers GetCouriersParamsSort = "-declinedOffers"
+	MinusName           GetCouriersParamsSort = "-name"
+	MinusOrdersCount    GetCouriersParamsSort = "-ordersCount"
+	Name                GetCouriersParamsSort = "name"
+	OrdersCount         GetCouriersParamsSort = "ordersCount"
+)
+
+// Defines values for GetOrdersParamsSort.
+const (
+	CreatedAt      GetOrdersParamsSort = "createdAt"
+	MinusCreatedAt GetOrdersParamsSort = "-createdAt"
+	MinusVolume    GetOrdersParamsSort = "-volume"
+	Volume         GetOrdersParamsSort = "volume"
+)
+
+// Defines values for GetOrdersParamsStatus.
+const (
+	Accepted GetOrdersParamsStatus = "Accepted"
+	Assigned GetOrdersParamsStatus = "Assigned"
+	Created  GetOrdersParamsStatus = "Created"
+	PickedUp GetOrdersParamsStatus = "PickedUp"
+)
+
+// Address defines model for Address.
+type Address struct {
+	// Apartment Квартира
+	Apartment *string `json:"apartment,omitempty"`
+
+	// City Город
+	City *string `json:"city,omitempty"`
+
+	// Country Страна
+	Country *string `json:"country,omitempty"`
+
+	// House Дом
+	House *string `json:"house,omitempty"`
+
+	// Street Улица
+	Street string `json:"street"`
+}
+
+// AreaSupplyDemand defines model for AreaSupplyDemand.
+type AreaSupplyDemand struct {
+	// Demand Заказы, которые еще не взял ни один курьер
+	Demand int      `json:"demand"`
+	From   Location `json:"from"`
+
+	// Name Название зоны или углы клетки
+	Name string `json:"name"`
+
+	// Supply Курьеры без заказов
+	Supply int `json:"supply"`
+
+	// Surge Заказы ждут, а свободных курьеров нет или их не хватает
+	Surge bool `json:"surge"`
+
+	// SurgeRatio Ожидающие заказы на одного свободного курьера
+	SurgeRatio float64  `json:"surgeRatio"`
+	To         Location `json:"to"`
+
+	// Windows Скользящие окна до момента среза
+	Windows []WindowSupplyDemand `json:"windows"`
+
+	// ZoneId Зона обслуживания, у клетки сетки отсутствует
+	ZoneId *openapi_types.UUID `json:"zoneId,omitempty"`
+}
+
+// AssignHomeZone defines model for AssignHomeZone.
+type AssignHomeZone struct {
+	// ZoneId Идентификатор зоны
+	ZoneId openapi_types.UUID `json:"zoneId"`
+}
+
+// AssignedOrder defines model for AssignedOrder.
+type AssignedOrder struct {
+	// AcceptBy Срок, до которого курьер должен принять предложенный заказ
+	AcceptBy *time.Time `json:"acceptBy,omitempty"`
+
+	// Eta Ожидаемое время доставки
+	Eta *time.Time `json:"eta,omitempty"`
+
+	// Id Идентификатор
+	Id       openapi_types.UUID `json:"id"`
+	Location Location           `json:"location"`
+
+	// Priority Тариф доставки
+	Priority AssignedOrderPriority `json:"priority"`
+
+	// Status Статус заказа у курьера
+	Status AssignedOrderStatus `json:"status"`
+
+	// Volume Объем
+	Volume int `json:"volume"`
+}
+
+// AssignedOrderPriority Тариф доставки
+type AssignedOrderPriority string
+
+// AssignedOrderStatus Статус заказа у курьера
+type AssignedOrderStatus string
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// Actor Кто изменил агрегат
+	Actor string `json:"actor"`
+
+	// After Агрегат после изменения
+	After map[string]interface{} `json:"after"`
+
+	// AggregateId Идентификатор агрегата
+	AggregateId openapi_types.UUID `json:"aggregateId"`
+
+	// AggregateType Тип агрегата
+	AggregateType AuditEntryAggregateType `json:"aggregateType"`
+
+	// Before Агрегат до изменения, у нового агрегата отсутствует
+	Before *map[string]interface{} `json:"before,omitempty"`
+
+	// Command Команда, которая изменила агрегат
+	Command string `json:"command"`
+
+	// CorrelationId Идентификатор запроса или сообщения
+	CorrelationId string `json:"correlationId"`
+
+	// Events Доменные события изменения
+	Events []string `json:"events"`
+
+	// RecordedAt Момент изменения
+	RecordedAt time.Time `json:"recordedAt"`
+
+	// Version Версия агрегата после изменения
+	Version int `json:"version"`
+}
+
+// AuditEntryAggregateType Тип агрегата
+type AuditEntryAggregateType string
+
 // Courier defines model for Courier.
 type Courier struct {
+	// Capacity Общий объем мест хранения курьера
+	Capacity int `json:"capacity"`
+
+	// DeclinedOffers Сколько предложений заказов курьер отклонил
+	DeclinedOffers int `json:"declinedOffers"`
+
+	// HomeZoneId Зона курьера, без нее курьер работает во всех зонах
+	HomeZoneId *openapi_types.UUID `json:"homeZoneId,omitempty"`
+
 	// Id Идентификатор
 	Id       openapi_types.UUID `json:"id"`
 	Location Location           `json:"location"`
 
 	// Name Имя
 	Name string `json:"name"`
+
+	// OrdersCount Сколько заказов у курьера, от предложения до передачи клиенту
+	OrdersCount int `json:"ordersCount"`
+
+	// UsedVolume Объем заказов курьера
+	UsedVolume int `json:"usedVolume"`
+}
+
+// CourierAssignment defines model for CourierAssignment.
+type CourierAssignment struct {
+	// Orders Заказы курьера в порядке доставки
+	Orders []AssignedOrder `json:"orders"`
+}
+
+// CourierPath defines model for CourierPath.
+type CourierPath struct {
+	// Points Точки пути, самая ранняя первой
+	Points []PathPoint `json:"points"`
+}
+
+// DeclineOrder defines model for DeclineOrder.
+type DeclineOrder struct {
+	// Reason Причина отказа
+	Reason string `json:"reason"`
 }
 
-// Error defines model for Error.
+// DeliveryWindow defines model for DeliveryWindow.
+type DeliveryWindow struct {
+	// From Начало интервала доставки
+	From time.Time `json:"from"`
+
+	// To Конец интервала доставки
+	To time.Time `json:"to"`
+}
+
+// Error Описание ошибки в формате RFC 7807
 type Error struct {
-	// Code Код ошибки
-	Code int32 `json:"code"`
+	// Detail Текст ошибки
+	Detail *string `json:"detail,omitempty"`
+
+	// Errors Невалидные поля запроса
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance Путь запроса
+	Instance *string `json:"instance,omitempty"`
+
+	// Status HTTP статус
+	Status int `json:"status"`
+
+	// Title Краткое описание типа ошибки
+	Title string `json:"title"`
+
+	// TraceId Идентификатор запроса для поиска в логах
+	TraceId *string `json:"traceId,omitempty"`
 
-	// Message Текст ошибки
-	Message string `json:"message"`
+	// Type URI типа ошибки
+	Type string `json:"type"`
+}
+
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Detail Что не так со значением
+	Detail string `json:"detail"`
+
+	// Field Поле запроса
+	Field string `json:"field"`
 }
 
 // Location defines model for Location.
@@ -58,30 +312,280 @@ type NewCourier struct {
 	Speed int `json:"speed"`
 }
 
+// NewOrder defines model for NewOrder.
+type NewOrder struct {
+	Address        Address         `json:"address"`
+	DeliveryWindow *DeliveryWindow `json:"deliveryWindow,omitempty"`
+
+	// OrderId Идентификатор заказа
+	OrderId openapi_types.UUID `json:"orderId"`
+
+	// Priority Тариф доставки
+	Priority *NewOrderPriority `json:"priority,omitempty"`
+
+	// Volume Объем
+	Volume int `json:"volume"`
+}
+
+// NewOrderPriority Тариф доставки
+type NewOrderPriority string
+
+// NewZone defines model for NewZone.
+type NewZone struct {
+	From Location `json:"from"`
+
+	// Name Название
+	Name string   `json:"name"`
+	To   Location `json:"to"`
+}
+
 // Order defines model for Order.
 type Order struct {
+	// CourierId Курьер, у которого заказ
+	CourierId *openapi_types.UUID `json:"courierId,omitempty"`
+
+	// CourierName Имя курьера, у которого заказ
+	CourierName *string `json:"courierName,omitempty"`
+
+	// Eta Ожидаемое время доставки
+	Eta *time.Time `json:"eta,omitempty"`
+
 	// Id Идентификатор
 	Id       openapi_types.UUID `json:"id"`
 	Location Location           `json:"location"`
+
+	// Priority Тариф доставки
+	Priority OrderPriority `json:"priority"`
+
+	// Status Статус заказа
+	Status OrderStatus `json:"status"`
+
+	// Volume Объем
+	Volume int `json:"volume"`
+
+	// ZoneId Зона обслуживания заказа
+	ZoneId *openapi_types.UUID `json:"zoneId,omitempty"`
+}
+
+// OrderPriority Тариф доставки
+type OrderPriority string
+
+// OrderStatus Статус заказа
+type OrderStatus string
+
+// PathPoint defines model for PathPoint.
+type PathPoint struct {
+	// At Когда курьер оказался в точке
+	At       time.Time `json:"at"`
+	Location Location  `json:"location"`
+}
+
+// ProofOfDelivery defines model for ProofOfDelivery.
+type ProofOfDelivery struct {
+	// PhotoHash SHA-256 фотографии переданного заказа в hex
+	PhotoHash *string `json:"photoHash,omitempty"`
+
+	// Pin Пин-код, названный клиентом
+	Pin *string `json:"pin,omitempty"`
+}
+
+// ReassignOrder defines model for ReassignOrder.
+type ReassignOrder struct {
+	// CourierId Идентификатор нового курьера
+	CourierId openapi_types.UUID `json:"courierId"`
+
+	// Reason Причина
+	Reason string `json:"reason"`
+}
+
+// SupplyDemand defines model for SupplyDemand.
+type SupplyDemand struct {
+	// Areas Зоны обслуживания или клетки сетки
+	Areas []AreaSupplyDemand `json:"areas"`
+
+	// TakenAt Момент среза
+	TakenAt time.Time `json:"takenAt"`
+}
+
+// UnassignOrder defines model for UnassignOrder.
+type UnassignOrder struct {
+	// Reason Причина
+	Reason string `json:"reason"`
+}
+
+// WindowSupplyDemand defines model for WindowSupplyDemand.
+type WindowSupplyDemand struct {
+	// AverageWaitSeconds Среднее ожидание курьера заказами окна в секундах
+	AverageWaitSeconds float64 `json:"averageWaitSeconds"`
+
+	// Orders Заказы, созданные в окне
+	Orders int `json:"orders"`
+
+	// Window Длина окна, например 15m0s
+	Window string `json:"window"`
+}
+
+// Zone defines model for Zone.
+type Zone struct {
+	From Location `json:"from"`
+
+	// Id Идентификатор
+	Id openapi_types.UUID `json:"id"`
+
+	// Name Название
+	Name string   `json:"name"`
+	To   Location `json:"to"`
+}
+
+// GetSupplyDemandParams defines parameters for GetSupplyDemand.
+type GetSupplyDemandParams struct {
+	// CellSize Размер клетки сетки; без него считается по зонам, а пока зон нет, по клеткам из настроек
+	CellSize *int `form:"cellSize,omitempty" json:"cellSize,omitempty"`
+}
+
+// GetCouriersParams defines parameters for GetCouriers.
+type GetCouriersParams struct {
+	// Limit Сколько записей вернуть на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы из заголовка X-Next-Cursor предыдущего ответа
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort Поле сортировки, с минусом по убыванию
+	Sort *GetCouriersParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Name Начало имени курьера без учета регистра
+	Name *string `form:"name,omitempty" json:"name,omitempty"`
+
+	// Bbox Область x1,y1,x2,y2, углы входят в нее
+	Bbox *[]int `form:"bbox,omitempty" json:"bbox,omitempty"`
+}
+
+// GetCouriersParamsSort defines parameters for GetCouriers.
+type GetCouriersParamsSort string
+
+// GetCourierPathParams defines parameters for GetCourierPath.
+type GetCourierPathParams struct {
+	// From Начало периода
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// CreateOrderParams defines parameters for CreateOrder.
+type CreateOrderParams struct {
+	// IdempotencyKey Ключ идемпотентности, повторный запрос с тем же ключом не создает новый заказ
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// GetOrdersParams defines parameters for GetOrders.
+type GetOrdersParams struct {
+	// Limit Сколько записей вернуть на странице
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Курсор следующей страницы из заголовка X-Next-Cursor предыдущего ответа
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Sort Поле сортировки, с минусом по убыванию
+	Sort *GetOrdersParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Status Статусы заказов
+	Status *[]GetOrdersParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// CourierName Начало имени курьера, у которого заказ, без учета регистра
+	CourierName *string `form:"courierName,omitempty" json:"courierName,omitempty"`
+
+	// Bbox Область x1,y1,x2,y2, углы входят в нее
+	Bbox *[]int `form:"bbox,omitempty" json:"bbox,omitempty"`
 }
 
+// GetOrdersParamsSort defines parameters for GetOrders.
+type GetOrdersParamsSort string
+
+// GetOrdersParamsStatus defines parameters for GetOrders.
+type GetOrdersParamsStatus string
+
 // CreateCourierJSONRequestBody defines body for CreateCourier for application/json ContentType.
 type CreateCourierJSONRequestBody = NewCourier
 
+// AssignHomeZoneJSONRequestBody defines body for AssignHomeZone for application/json ContentType.
+type AssignHomeZoneJSONRequestBody = AssignHomeZone
+
+// DeclineOrderJSONRequestBody defines body for DeclineOrder for application/json ContentType.
+type DeclineOrderJSONRequestBody = DeclineOrder
+
+// DeliverOrderJSONRequestBody defines body for DeliverOrder for application/json ContentType.
+type DeliverOrderJSONRequestBody = ProofOfDelivery
+
+// CreateOrderJSONRequestBody defines body for CreateOrder for application/json ContentType.
+type CreateOrderJSONRequestBody = NewOrder
+
+// ReassignOrderJSONRequestBody defines body for ReassignOrder for application/json ContentType.
+type ReassignOrderJSONRequestBody = ReassignOrder
+
+// UnassignOrderJSONRequestBody defines body for UnassignOrder for application/json ContentType.
+type UnassignOrderJSONRequestBody = UnassignOrder
+
+// CreateZoneJSONRequestBody defines body for CreateZone for application/json ContentType.
+type CreateZoneJSONRequestBody = NewZone
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Получить спрос и предложение
+	// (GET /api/v1/analytics/supply-demand)
+	GetSupplyDemand(ctx echo.Context, params GetSupplyDemandParams) error
 	// Получить всех курьеров
 	// (GET /api/v1/couriers)
-	GetCouriers(ctx echo.Context) error
+	GetCouriers(ctx echo.Context, params GetCouriersParams) error
 	// Добавить курьера
 	// (POST /api/v1/couriers)
 	CreateCourier(ctx echo.Context) error
+	// Получить текущее задание курьера
+	// (GET /api/v1/couriers/{courierId}/assignment)
+	GetCourierAssignment(ctx echo.Context, courierId openapi_types.UUID) error
+	// Закрепить курьера за зоной
+	// (PUT /api/v1/couriers/{courierId}/home-zone)
+	AssignHomeZone(ctx echo.Context, courierId openapi_types.UUID) error
+	// Принять заказ
+	// (POST /api/v1/couriers/{courierId}/orders/{orderId}/accept)
+	AcceptOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error
+	// Отказаться от заказа
+	// (POST /api/v1/couriers/{courierId}/orders/{orderId}/decline)
+	DeclineOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error
+	// Доставить заказ
+	// (POST /api/v1/couriers/{courierId}/orders/{orderId}/deliver)
+	DeliverOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error
+	// Забрать заказ
+	// (POST /api/v1/couriers/{courierId}/orders/{orderId}/pickup)
+	PickUpOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error
+	// Получить путь курьера
+	// (GET /api/v1/couriers/{courierId}/path)
+	GetCourierPath(ctx echo.Context, courierId openapi_types.UUID, params GetCourierPathParams) error
 	// Создать заказ
 	// (POST /api/v1/orders)
-	CreateOrder(ctx echo.Context) error
+	CreateOrder(ctx echo.Context, params CreateOrderParams) error
 	// Получить все незавершенные заказы
 	// (GET /api/v1/orders/active)
-	GetOrders(ctx echo.Context) error
+	GetOrders(ctx echo.Context, params GetOrdersParams) error
+	// Передать заказ другому курьеру
+	// (POST /api/v1/orders/{orderId}/reassign)
+	ReassignOrder(ctx echo.Context, orderId openapi_types.UUID) error
+	// Получить историю заказа
+	// (GET /api/v1/orders/{orderId}/timeline)
+	GetOrderTimeline(ctx echo.Context, orderId openapi_types.UUID) error
+	// Снять заказ с курьера
+	// (POST /api/v1/orders/{orderId}/unassign)
+	UnassignOrder(ctx echo.Context, orderId openapi_types.UUID) error
+	// Перестроить представления
+	// (POST /api/v1/read-models/rebuild)
+	RebuildReadModels(ctx echo.Context) error
+	// Получить все зоны обслуживания
+	// (GET /api/v1/zones)
+	GetZones(ctx echo.Context) error
+	// Добавить зону обслуживания
+	// (POST /api/v1/zones)
+	CreateZone(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -89,12 +593,75 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
+// GetSupplyDemand converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSupplyDemand(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher", "reader"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher", "reader"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSupplyDemandParams
+	// ------------- Optional query parameter "cellSize" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cellSize", ctx.QueryParams(), &params.CellSize)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cellSize: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSupplyDemand(ctx, params)
+	return err
+}
+
 // GetCouriers converts echo context to params.
 func (w *ServerInterfaceWrapper) GetCouriers(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(BearerAuthScopes, []string{"dispatcher", "reader"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher", "reader"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCouriersParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "name", ctx.QueryParams(), &params.Name)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter name: %s", err))
+	}
+
+	// ------------- Optional query parameter "bbox" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "bbox", ctx.QueryParams(), &params.Bbox)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bbox: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetCouriers(ctx)
+	err = w.Handler.GetCouriers(ctx, params)
 	return err
 }
 
@@ -102,313 +669,2140 @@ func (w *ServerInterfaceWrapper) GetCouriers(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) CreateCourier(ctx echo.Context) error {
 	var err error
 
+	ctx.Set(BearerAuthScopes, []string{"dispatcher"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher"})
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.CreateCourier(ctx)
 	return err
 }
 
-// CreateOrder converts echo context to params.
-func (w *ServerInterfaceWrapper) CreateOrder(ctx echo.Context) error {
+// GetCourierAssignment converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCourierAssignment(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"courier", "dispatcher"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateOrder(ctx)
+	err = w.Handler.GetCourierAssignment(ctx, courierId)
 	return err
 }
 
-// GetOrders converts echo context to params.
-func (w *ServerInterfaceWrapper) GetOrders(ctx echo.Context) error {
+// AssignHomeZone converts echo context to params.
+func (w *ServerInterfaceWrapper) AssignHomeZone(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher"})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetOrders(ctx)
+	err = w.Handler.AssignHomeZone(ctx, courierId)
 	return err
 }
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+// AcceptOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) AcceptOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"courier"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.AcceptOrder(ctx, courierId, orderId)
+	return err
 }
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+// DeclineOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) DeclineOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"courier"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeclineOrder(ctx, courierId, orderId)
+	return err
 }
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+// DeliverOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) DeliverOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
 	}
 
-	router.GET(baseURL+"/api/v1/couriers", wrapper.GetCouriers)
-	router.POST(baseURL+"/api/v1/couriers", wrapper.CreateCourier)
-	router.POST(baseURL+"/api/v1/orders", wrapper.CreateOrder)
-	router.GET(baseURL+"/api/v1/orders/active", wrapper.GetOrders)
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"courier"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeliverOrder(ctx, courierId, orderId)
+	return err
+}
+
+// PickUpOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) PickUpOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"courier"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PickUpOrder(ctx, courierId, orderId)
+	return err
+}
+
+// GetCourierPath converts echo context to params.
+func (w *ServerInterfaceWrapper) GetCourierPath(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "courierId" -------------
+	var courierId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "courierId", ctx.Param("courierId"), &courierId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher", "reader"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher", "reader"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCourierPathParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetCourierPath(ctx, courierId, params)
+	return err
+}
+
+// CreateOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateOrder(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params CreateOrderParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Idempotency-Key, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Idempotency-Key: %s", err))
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateOrder(ctx, params)
+	return err
+}
+
+// GetOrders converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrders(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher", "reader"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher", "reader"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetOrdersParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", ctx.QueryParams(), &params.Status)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter status: %s", err))
+	}
+
+	// ------------- Optional query parameter "courierName" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "courierName", ctx.QueryParams(), &params.CourierName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter courierName: %s", err))
+	}
+
+	// ------------- Optional query parameter "bbox" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "bbox", ctx.QueryParams(), &params.Bbox)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter bbox: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrders(ctx, params)
+	return err
+}
+
+// ReassignOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) ReassignOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.ReassignOrder(ctx, orderId)
+	return err
+}
+
+// GetOrderTimeline converts echo context to params.
+func (w *ServerInterfaceWrapper) GetOrderTimeline(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher", "reader"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher", "reader"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetOrderTimeline(ctx, orderId)
+	return err
+}
+
+// UnassignOrder converts echo context to params.
+func (w *ServerInterfaceWrapper) UnassignOrder(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "orderId" -------------
+	var orderId openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "orderId", ctx.Param("orderId"), &orderId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter orderId: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UnassignOrder(ctx, orderId)
+	return err
+}
+
+// RebuildReadModels converts echo context to params.
+func (w *ServerInterfaceWrapper) RebuildReadModels(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RebuildReadModels(ctx)
+	return err
+}
+
+// GetZones converts echo context to params.
+func (w *ServerInterfaceWrapper) GetZones(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher", "reader"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher", "reader"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetZones(ctx)
+	return err
+}
+
+// CreateZone converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateZone(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{"dispatcher"})
+
+	ctx.Set(ApiKeyScopes, []string{"dispatcher"})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateZone(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/api/v1/analytics/supply-demand", wrapper.GetSupplyDemand)
+	router.GET(baseURL+"/api/v1/couriers", wrapper.GetCouriers)
+	router.POST(baseURL+"/api/v1/couriers", wrapper.CreateCourier)
+	router.GET(baseURL+"/api/v1/couriers/:courierId/assignment", wrapper.GetCourierAssignment)
+	router.PUT(baseURL+"/api/v1/couriers/:courierId/home-zone", wrapper.AssignHomeZone)
+	router.POST(baseURL+"/api/v1/couriers/:courierId/orders/:orderId/accept", wrapper.AcceptOrder)
+	router.POST(baseURL+"/api/v1/couriers/:courierId/orders/:orderId/decline", wrapper.DeclineOrder)
+	router.POST(baseURL+"/api/v1/couriers/:courierId/orders/:orderId/deliver", wrapper.DeliverOrder)
+	router.POST(baseURL+"/api/v1/couriers/:courierId/orders/:orderId/pickup", wrapper.PickUpOrder)
+	router.GET(baseURL+"/api/v1/couriers/:courierId/path", wrapper.GetCourierPath)
+	router.POST(baseURL+"/api/v1/orders", wrapper.CreateOrder)
+	router.GET(baseURL+"/api/v1/orders/active", wrapper.GetOrders)
+	router.POST(baseURL+"/api/v1/orders/:orderId/reassign", wrapper.ReassignOrder)
+	router.GET(baseURL+"/api/v1/orders/:orderId/timeline", wrapper.GetOrderTimeline)
+	router.POST(baseURL+"/api/v1/orders/:orderId/unassign", wrapper.UnassignOrder)
+	router.POST(baseURL+"/api/v1/read-models/rebuild", wrapper.RebuildReadModels)
+	router.GET(baseURL+"/api/v1/zones", wrapper.GetZones)
+	router.POST(baseURL+"/api/v1/zones", wrapper.CreateZone)
+
+}
+
+type GetSupplyDemandRequestObject struct {
+	Params GetSupplyDemandParams
+}
+
+type GetSupplyDemandResponseObject interface {
+	VisitGetSupplyDemandResponse(w http.ResponseWriter) error
+}
+
+type GetSupplyDemand200JSONResponse SupplyDemand
+
+func (response GetSupplyDemand200JSONResponse) VisitGetSupplyDemandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSupplyDemand400ApplicationProblemPlusJSONResponse Error
+
+func (response GetSupplyDemand400ApplicationProblemPlusJSONResponse) VisitGetSupplyDemandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSupplyDemand401ApplicationProblemPlusJSONResponse Error
+
+func (response GetSupplyDemand401ApplicationProblemPlusJSONResponse) VisitGetSupplyDemandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSupplyDemand403ApplicationProblemPlusJSONResponse Error
+
+func (response GetSupplyDemand403ApplicationProblemPlusJSONResponse) VisitGetSupplyDemandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSupplyDemanddefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetSupplyDemanddefaultApplicationProblemPlusJSONResponse) VisitGetSupplyDemandResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCouriersRequestObject struct {
+	Params GetCouriersParams
+}
+
+type GetCouriersResponseObject interface {
+	VisitGetCouriersResponse(w http.ResponseWriter) error
+}
+
+type GetCouriers200ResponseHeaders struct {
+	XNextCursor string
+	XTotalCount int
+}
+
+type GetCouriers200JSONResponse struct {
+	Body    []Courier
+	Headers GetCouriers200ResponseHeaders
+}
+
+func (response GetCouriers200JSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCouriers400ApplicationProblemPlusJSONResponse Error
+
+func (response GetCouriers400ApplicationProblemPlusJSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCouriers401ApplicationProblemPlusJSONResponse Error
+
+func (response GetCouriers401ApplicationProblemPlusJSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCouriers403ApplicationProblemPlusJSONResponse Error
+
+func (response GetCouriers403ApplicationProblemPlusJSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCouriersdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetCouriersdefaultApplicationProblemPlusJSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateCourierRequestObject struct {
+	Body *CreateCourierJSONRequestBody
+}
+
+type CreateCourierResponseObject interface {
+	VisitCreateCourierResponse(w http.ResponseWriter) error
+}
+
+type CreateCourier201Response struct {
+}
+
+func (response CreateCourier201Response) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.WriteHeader(201)
+	return nil
+}
+
+type CreateCourier400ApplicationProblemPlusJSONResponse Error
+
+func (response CreateCourier400ApplicationProblemPlusJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCourier401ApplicationProblemPlusJSONResponse Error
+
+func (response CreateCourier401ApplicationProblemPlusJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCourier403ApplicationProblemPlusJSONResponse Error
+
+func (response CreateCourier403ApplicationProblemPlusJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCourier409ApplicationProblemPlusJSONResponse Error
+
+func (response CreateCourier409ApplicationProblemPlusJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateCourierdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateCourierdefaultApplicationProblemPlusJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCourierAssignmentRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+}
+
+type GetCourierAssignmentResponseObject interface {
+	VisitGetCourierAssignmentResponse(w http.ResponseWriter) error
+}
+
+type GetCourierAssignment200JSONResponse CourierAssignment
+
+func (response GetCourierAssignment200JSONResponse) VisitGetCourierAssignmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierAssignment401ApplicationProblemPlusJSONResponse Error
+
+func (response GetCourierAssignment401ApplicationProblemPlusJSONResponse) VisitGetCourierAssignmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierAssignment403ApplicationProblemPlusJSONResponse Error
+
+func (response GetCourierAssignment403ApplicationProblemPlusJSONResponse) VisitGetCourierAssignmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierAssignmentdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetCourierAssignmentdefaultApplicationProblemPlusJSONResponse) VisitGetCourierAssignmentResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AssignHomeZoneRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+	Body      *AssignHomeZoneJSONRequestBody
+}
+
+type AssignHomeZoneResponseObject interface {
+	VisitAssignHomeZoneResponse(w http.ResponseWriter) error
+}
+
+type AssignHomeZone200Response struct {
+}
+
+func (response AssignHomeZone200Response) VisitAssignHomeZoneResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type AssignHomeZone400ApplicationProblemPlusJSONResponse Error
+
+func (response AssignHomeZone400ApplicationProblemPlusJSONResponse) VisitAssignHomeZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignHomeZone401ApplicationProblemPlusJSONResponse Error
+
+func (response AssignHomeZone401ApplicationProblemPlusJSONResponse) VisitAssignHomeZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignHomeZone403ApplicationProblemPlusJSONResponse Error
+
+func (response AssignHomeZone403ApplicationProblemPlusJSONResponse) VisitAssignHomeZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignHomeZone404ApplicationProblemPlusJSONResponse Error
+
+func (response AssignHomeZone404ApplicationProblemPlusJSONResponse) VisitAssignHomeZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AssignHomeZonedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response AssignHomeZonedefaultApplicationProblemPlusJSONResponse) VisitAssignHomeZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type AcceptOrderRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+	OrderId   openapi_types.UUID `json:"orderId"`
+}
+
+type AcceptOrderResponseObject interface {
+	VisitAcceptOrderResponse(w http.ResponseWriter) error
+}
+
+type AcceptOrder200Response struct {
+}
+
+func (response AcceptOrder200Response) VisitAcceptOrderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type AcceptOrder401ApplicationProblemPlusJSONResponse Error
+
+func (response AcceptOrder401ApplicationProblemPlusJSONResponse) VisitAcceptOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptOrder403ApplicationProblemPlusJSONResponse Error
+
+func (response AcceptOrder403ApplicationProblemPlusJSONResponse) VisitAcceptOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptOrder404ApplicationProblemPlusJSONResponse Error
+
+func (response AcceptOrder404ApplicationProblemPlusJSONResponse) VisitAcceptOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptOrder409ApplicationProblemPlusJSONResponse Error
+
+func (response AcceptOrder409ApplicationProblemPlusJSONResponse) VisitAcceptOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AcceptOrderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response AcceptOrderdefaultApplicationProblemPlusJSONResponse) VisitAcceptOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeclineOrderRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+	OrderId   openapi_types.UUID `json:"orderId"`
+	Body      *DeclineOrderJSONRequestBody
+}
+
+type DeclineOrderResponseObject interface {
+	VisitDeclineOrderResponse(w http.ResponseWriter) error
+}
+
+type DeclineOrder200Response struct {
+}
+
+func (response DeclineOrder200Response) VisitDeclineOrderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type DeclineOrder400ApplicationProblemPlusJSONResponse Error
+
+func (response DeclineOrder400ApplicationProblemPlusJSONResponse) VisitDeclineOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineOrder401ApplicationProblemPlusJSONResponse Error
+
+func (response DeclineOrder401ApplicationProblemPlusJSONResponse) VisitDeclineOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineOrder403ApplicationProblemPlusJSONResponse Error
+
+func (response DeclineOrder403ApplicationProblemPlusJSONResponse) VisitDeclineOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineOrder404ApplicationProblemPlusJSONResponse Error
+
+func (response DeclineOrder404ApplicationProblemPlusJSONResponse) VisitDeclineOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineOrder409ApplicationProblemPlusJSONResponse Error
+
+func (response DeclineOrder409ApplicationProblemPlusJSONResponse) VisitDeclineOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeclineOrderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response DeclineOrderdefaultApplicationProblemPlusJSONResponse) VisitDeclineOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type DeliverOrderRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+	OrderId   openapi_types.UUID `json:"orderId"`
+	Body      *DeliverOrderJSONRequestBody
+}
+
+type DeliverOrderResponseObject interface {
+	VisitDeliverOrderResponse(w http.ResponseWriter) error
+}
+
+type DeliverOrder200Response struct {
+}
+
+func (response DeliverOrder200Response) VisitDeliverOrderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type DeliverOrder400ApplicationProblemPlusJSONResponse Error
+
+func (response DeliverOrder400ApplicationProblemPlusJSONResponse) VisitDeliverOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeliverOrder401ApplicationProblemPlusJSONResponse Error
+
+func (response DeliverOrder401ApplicationProblemPlusJSONResponse) VisitDeliverOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeliverOrder403ApplicationProblemPlusJSONResponse Error
+
+func (response DeliverOrder403ApplicationProblemPlusJSONResponse) VisitDeliverOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeliverOrder404ApplicationProblemPlusJSONResponse Error
+
+func (response DeliverOrder404ApplicationProblemPlusJSONResponse) VisitDeliverOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeliverOrder409ApplicationProblemPlusJSONResponse Error
+
+func (response DeliverOrder409ApplicationProblemPlusJSONResponse) VisitDeliverOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeliverOrderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response DeliverOrderdefaultApplicationProblemPlusJSONResponse) VisitDeliverOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PickUpOrderRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+	OrderId   openapi_types.UUID `json:"orderId"`
+}
+
+type PickUpOrderResponseObject interface {
+	VisitPickUpOrderResponse(w http.ResponseWriter) error
+}
+
+type PickUpOrder200Response struct {
+}
+
+func (response PickUpOrder200Response) VisitPickUpOrderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type PickUpOrder401ApplicationProblemPlusJSONResponse Error
+
+func (response PickUpOrder401ApplicationProblemPlusJSONResponse) VisitPickUpOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PickUpOrder403ApplicationProblemPlusJSONResponse Error
+
+func (response PickUpOrder403ApplicationProblemPlusJSONResponse) VisitPickUpOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PickUpOrder404ApplicationProblemPlusJSONResponse Error
+
+func (response PickUpOrder404ApplicationProblemPlusJSONResponse) VisitPickUpOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PickUpOrder409ApplicationProblemPlusJSONResponse Error
+
+func (response PickUpOrder409ApplicationProblemPlusJSONResponse) VisitPickUpOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PickUpOrderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response PickUpOrderdefaultApplicationProblemPlusJSONResponse) VisitPickUpOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetCourierPathRequestObject struct {
+	CourierId openapi_types.UUID `json:"courierId"`
+	Params    GetCourierPathParams
+}
+
+type GetCourierPathResponseObject interface {
+	VisitGetCourierPathResponse(w http.ResponseWriter) error
+}
+
+type GetCourierPath200JSONResponse CourierPath
+
+func (response GetCourierPath200JSONResponse) VisitGetCourierPathResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierPath400ApplicationProblemPlusJSONResponse Error
+
+func (response GetCourierPath400ApplicationProblemPlusJSONResponse) VisitGetCourierPathResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierPath401ApplicationProblemPlusJSONResponse Error
+
+func (response GetCourierPath401ApplicationProblemPlusJSONResponse) VisitGetCourierPathResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierPath403ApplicationProblemPlusJSONResponse Error
+
+func (response GetCourierPath403ApplicationProblemPlusJSONResponse) VisitGetCourierPathResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierPath404ApplicationProblemPlusJSONResponse Error
+
+func (response GetCourierPath404ApplicationProblemPlusJSONResponse) VisitGetCourierPathResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetCourierPathdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetCourierPathdefaultApplicationProblemPlusJSONResponse) VisitGetCourierPathResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateOrderRequestObject struct {
+	Params CreateOrderParams
+	Body   *CreateOrderJSONRequestBody
+}
+
+type CreateOrderResponseObject interface {
+	VisitCreateOrderResponse(w http.ResponseWriter) error
+}
+
+type CreateOrder201ResponseHeaders struct {
+	Location string
+}
+
+type CreateOrder201Response struct {
+	Headers CreateOrder201ResponseHeaders
+}
+
+func (response CreateOrder201Response) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Location", fmt.Sprint(response.Headers.Location))
+	w.WriteHeader(201)
+	return nil
+}
+
+type CreateOrder400ApplicationProblemPlusJSONResponse Error
+
+func (response CreateOrder400ApplicationProblemPlusJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder401ApplicationProblemPlusJSONResponse Error
+
+func (response CreateOrder401ApplicationProblemPlusJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder403ApplicationProblemPlusJSONResponse Error
+
+func (response CreateOrder403ApplicationProblemPlusJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrder409ApplicationProblemPlusJSONResponse Error
+
+func (response CreateOrder409ApplicationProblemPlusJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateOrderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateOrderdefaultApplicationProblemPlusJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrdersRequestObject struct {
+	Params GetOrdersParams
+}
+
+type GetOrdersResponseObject interface {
+	VisitGetOrdersResponse(w http.ResponseWriter) error
+}
+
+type GetOrders200ResponseHeaders struct {
+	XNextCursor string
+	XTotalCount int
+}
+
+type GetOrders200JSONResponse struct {
+	Body    []Order
+	Headers GetOrders200ResponseHeaders
+}
+
+func (response GetOrders200JSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Next-Cursor", fmt.Sprint(response.Headers.XNextCursor))
+	w.Header().Set("X-Total-Count", fmt.Sprint(response.Headers.XTotalCount))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrders400ApplicationProblemPlusJSONResponse Error
+
+func (response GetOrders400ApplicationProblemPlusJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrders401ApplicationProblemPlusJSONResponse Error
+
+func (response GetOrders401ApplicationProblemPlusJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrders403ApplicationProblemPlusJSONResponse Error
+
+func (response GetOrders403ApplicationProblemPlusJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrdersdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetOrdersdefaultApplicationProblemPlusJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type ReassignOrderRequestObject struct {
+	OrderId openapi_types.UUID `json:"orderId"`
+	Body    *ReassignOrderJSONRequestBody
+}
+
+type ReassignOrderResponseObject interface {
+	VisitReassignOrderResponse(w http.ResponseWriter) error
+}
+
+type ReassignOrder200Response struct {
+}
+
+func (response ReassignOrder200Response) VisitReassignOrderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type ReassignOrder400ApplicationProblemPlusJSONResponse Error
+
+func (response ReassignOrder400ApplicationProblemPlusJSONResponse) VisitReassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignOrder401ApplicationProblemPlusJSONResponse Error
+
+func (response ReassignOrder401ApplicationProblemPlusJSONResponse) VisitReassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignOrder403ApplicationProblemPlusJSONResponse Error
+
+func (response ReassignOrder403ApplicationProblemPlusJSONResponse) VisitReassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignOrder404ApplicationProblemPlusJSONResponse Error
+
+func (response ReassignOrder404ApplicationProblemPlusJSONResponse) VisitReassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignOrder409ApplicationProblemPlusJSONResponse Error
+
+func (response ReassignOrder409ApplicationProblemPlusJSONResponse) VisitReassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReassignOrderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response ReassignOrderdefaultApplicationProblemPlusJSONResponse) VisitReassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetOrderTimelineRequestObject struct {
+	OrderId openapi_types.UUID `json:"orderId"`
+}
+
+type GetOrderTimelineResponseObject interface {
+	VisitGetOrderTimelineResponse(w http.ResponseWriter) error
+}
+
+type GetOrderTimeline200JSONResponse []AuditEntry
+
+func (response GetOrderTimeline200JSONResponse) VisitGetOrderTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderTimeline400ApplicationProblemPlusJSONResponse Error
+
+func (response GetOrderTimeline400ApplicationProblemPlusJSONResponse) VisitGetOrderTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderTimeline401ApplicationProblemPlusJSONResponse Error
+
+func (response GetOrderTimeline401ApplicationProblemPlusJSONResponse) VisitGetOrderTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderTimeline403ApplicationProblemPlusJSONResponse Error
+
+func (response GetOrderTimeline403ApplicationProblemPlusJSONResponse) VisitGetOrderTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderTimeline404ApplicationProblemPlusJSONResponse Error
+
+func (response GetOrderTimeline404ApplicationProblemPlusJSONResponse) VisitGetOrderTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetOrderTimelinedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetOrderTimelinedefaultApplicationProblemPlusJSONResponse) VisitGetOrderTimelineResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UnassignOrderRequestObject struct {
+	OrderId openapi_types.UUID `json:"orderId"`
+	Body    *UnassignOrderJSONRequestBody
+}
+
+type UnassignOrderResponseObject interface {
+	VisitUnassignOrderResponse(w http.ResponseWriter) error
+}
+
+type UnassignOrder200Response struct {
+}
+
+func (response UnassignOrder200Response) VisitUnassignOrderResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type UnassignOrder400ApplicationProblemPlusJSONResponse Error
+
+func (response UnassignOrder400ApplicationProblemPlusJSONResponse) VisitUnassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignOrder401ApplicationProblemPlusJSONResponse Error
+
+func (response UnassignOrder401ApplicationProblemPlusJSONResponse) VisitUnassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignOrder403ApplicationProblemPlusJSONResponse Error
+
+func (response UnassignOrder403ApplicationProblemPlusJSONResponse) VisitUnassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignOrder404ApplicationProblemPlusJSONResponse Error
+
+func (response UnassignOrder404ApplicationProblemPlusJSONResponse) VisitUnassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignOrder409ApplicationProblemPlusJSONResponse Error
+
+func (response UnassignOrder409ApplicationProblemPlusJSONResponse) VisitUnassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UnassignOrderdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response UnassignOrderdefaultApplicationProblemPlusJSONResponse) VisitUnassignOrderResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type RebuildReadModelsRequestObject struct {
+}
+
+type RebuildReadModelsResponseObject interface {
+	VisitRebuildReadModelsResponse(w http.ResponseWriter) error
+}
+
+type RebuildReadModels204Response struct {
+}
+
+func (response RebuildReadModels204Response) VisitRebuildReadModelsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RebuildReadModels401ApplicationProblemPlusJSONResponse Error
+
+func (response RebuildReadModels401ApplicationProblemPlusJSONResponse) VisitRebuildReadModelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RebuildReadModels403ApplicationProblemPlusJSONResponse Error
+
+func (response RebuildReadModels403ApplicationProblemPlusJSONResponse) VisitRebuildReadModelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RebuildReadModelsdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response RebuildReadModelsdefaultApplicationProblemPlusJSONResponse) VisitRebuildReadModelsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetZonesRequestObject struct {
+}
+
+type GetZonesResponseObject interface {
+	VisitGetZonesResponse(w http.ResponseWriter) error
+}
+
+type GetZones200JSONResponse []Zone
+
+func (response GetZones200JSONResponse) VisitGetZonesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetZones401ApplicationProblemPlusJSONResponse Error
+
+func (response GetZones401ApplicationProblemPlusJSONResponse) VisitGetZonesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetZones403ApplicationProblemPlusJSONResponse Error
+
+func (response GetZones403ApplicationProblemPlusJSONResponse) VisitGetZonesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetZonesdefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response GetZonesdefaultApplicationProblemPlusJSONResponse) VisitGetZonesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type CreateZoneRequestObject struct {
+	Body *CreateZoneJSONRequestBody
+}
+
+type CreateZoneResponseObject interface {
+	VisitCreateZoneResponse(w http.ResponseWriter) error
+}
+
+type CreateZone201JSONResponse Zone
+
+func (response CreateZone201JSONResponse) VisitCreateZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateZone400ApplicationProblemPlusJSONResponse Error
+
+func (response CreateZone400ApplicationProblemPlusJSONResponse) VisitCreateZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateZone401ApplicationProblemPlusJSONResponse Error
+
+func (response CreateZone401ApplicationProblemPlusJSONResponse) VisitCreateZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateZone403ApplicationProblemPlusJSONResponse Error
+
+func (response CreateZone403ApplicationProblemPlusJSONResponse) VisitCreateZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateZone409ApplicationProblemPlusJSONResponse Error
+
+func (response CreateZone409ApplicationProblemPlusJSONResponse) VisitCreateZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreateZonedefaultApplicationProblemPlusJSONResponse struct {
+	Body       Error
+	StatusCode int
+}
+
+func (response CreateZonedefaultApplicationProblemPlusJSONResponse) VisitCreateZoneResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Получить спрос и предложение
+	// (GET /api/v1/analytics/supply-demand)
+	GetSupplyDemand(ctx context.Context, request GetSupplyDemandRequestObject) (GetSupplyDemandResponseObject, error)
+	// Получить всех курьеров
+	// (GET /api/v1/couriers)
+	GetCouriers(ctx context.Context, request GetCouriersRequestObject) (GetCouriersResponseObject, error)
+	// Добавить курьера
+	// (POST /api/v1/couriers)
+	CreateCourier(ctx context.Context, request CreateCourierRequestObject) (CreateCourierResponseObject, error)
+	// Получить текущее задание курьера
+	// (GET /api/v1/couriers/{courierId}/assignment)
+	GetCourierAssignment(ctx context.Context, request GetCourierAssignmentRequestObject) (GetCourierAssignmentResponseObject, error)
+	// Закрепить курьера за зоной
+	// (PUT /api/v1/couriers/{courierId}/home-zone)
+	AssignHomeZone(ctx context.Context, request AssignHomeZoneRequestObject) (AssignHomeZoneResponseObject, error)
+	// Принять заказ
+	// (POST /api/v1/couriers/{courierId}/orders/{orderId}/accept)
+	AcceptOrder(ctx context.Context, request AcceptOrderRequestObject) (AcceptOrderResponseObject, error)
+	// Отказаться от заказа
+	// (POST /api/v1/couriers/{courierId}/orders/{orderId}/decline)
+	DeclineOrder(ctx context.Context, request DeclineOrderRequestObject) (DeclineOrderResponseObject, error)
+	// Доставить заказ
+	// (POST /api/v1/couriers/{courierId}/orders/{orderId}/deliver)
+	DeliverOrder(ctx context.Context, request DeliverOrderRequestObject) (DeliverOrderResponseObject, error)
+	// Забрать заказ
+	// (POST /api/v1/couriers/{courierId}/orders/{orderId}/pickup)
+	PickUpOrder(ctx context.Context, request PickUpOrderRequestObject) (PickUpOrderResponseObject, error)
+	// Получить путь курьера
+	// (GET /api/v1/couriers/{courierId}/path)
+	GetCourierPath(ctx context.Context, request GetCourierPathRequestObject) (GetCourierPathResponseObject, error)
+	// Создать заказ
+	// (POST /api/v1/orders)
+	CreateOrder(ctx context.Context, request CreateOrderRequestObject) (CreateOrderResponseObject, error)
+	// Получить все незавершенные заказы
+	// (GET /api/v1/orders/active)
+	GetOrders(ctx context.Context, request GetOrdersRequestObject) (GetOrdersResponseObject, error)
+	// Передать заказ другому курьеру
+	// (POST /api/v1/orders/{orderId}/reassign)
+	ReassignOrder(ctx context.Context, request ReassignOrderRequestObject) (ReassignOrderResponseObject, error)
+	// Получить историю заказа
+	// (GET /api/v1/orders/{orderId}/timeline)
+	GetOrderTimeline(ctx context.Context, request GetOrderTimelineRequestObject) (GetOrderTimelineResponseObject, error)
+	// Снять заказ с курьера
+	// (POST /api/v1/orders/{orderId}/unassign)
+	UnassignOrder(ctx context.Context, request UnassignOrderRequestObject) (UnassignOrderResponseObject, error)
+	// Перестроить представления
+	// (POST /api/v1/read-models/rebuild)
+	RebuildReadModels(ctx context.Context, request RebuildReadModelsRequestObject) (RebuildReadModelsResponseObject, error)
+	// Получить все зоны обслуживания
+	// (GET /api/v1/zones)
+	GetZones(ctx context.Context, request GetZonesRequestObject) (GetZonesResponseObject, error)
+	// Добавить зону обслуживания
+	// (POST /api/v1/zones)
+	CreateZone(ctx context.Context, request CreateZoneRequestObject) (CreateZoneResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetSupplyDemand operation middleware
+func (sh *strictHandler) GetSupplyDemand(ctx echo.Context, params GetSupplyDemandParams) error {
+	var request GetSupplyDemandRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSupplyDemand(ctx.Request().Context(), request.(GetSupplyDemandRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSupplyDemand")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetSupplyDemandResponseObject); ok {
+		return validResponse.VisitGetSupplyDemandResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetCouriers operation middleware
+func (sh *strictHandler) GetCouriers(ctx echo.Context, params GetCouriersParams) error {
+	var request GetCouriersRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCouriers(ctx.Request().Context(), request.(GetCouriersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCouriers")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetCouriersResponseObject); ok {
+		return validResponse.VisitGetCouriersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateCourier operation middleware
+func (sh *strictHandler) CreateCourier(ctx echo.Context) error {
+	var request CreateCourierRequestObject
+
+	var body CreateCourierJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateCourier(ctx.Request().Context(), request.(CreateCourierRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateCourier")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateCourierResponseObject); ok {
+		return validResponse.VisitCreateCourierResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetCourierAssignment operation middleware
+func (sh *strictHandler) GetCourierAssignment(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request GetCourierAssignmentRequestObject
+
+	request.CourierId = courierId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCourierAssignment(ctx.Request().Context(), request.(GetCourierAssignmentRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCourierAssignment")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetCourierAssignmentResponseObject); ok {
+		return validResponse.VisitGetCourierAssignmentResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// AssignHomeZone operation middleware
+func (sh *strictHandler) AssignHomeZone(ctx echo.Context, courierId openapi_types.UUID) error {
+	var request AssignHomeZoneRequestObject
+
+	request.CourierId = courierId
+
+	var body AssignHomeZoneJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AssignHomeZone(ctx.Request().Context(), request.(AssignHomeZoneRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AssignHomeZone")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(AssignHomeZoneResponseObject); ok {
+		return validResponse.VisitAssignHomeZoneResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// AcceptOrder operation middleware
+func (sh *strictHandler) AcceptOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error {
+	var request AcceptOrderRequestObject
+
+	request.CourierId = courierId
+	request.OrderId = orderId
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.AcceptOrder(ctx.Request().Context(), request.(AcceptOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AcceptOrder")
+	}
+
+	response, err := handler(ctx, request)
 
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(AcceptOrderResponseObject); ok {
+		return validResponse.VisitAcceptOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type GetCouriersRequestObject struct {
-}
+// DeclineOrder operation middleware
+func (sh *strictHandler) DeclineOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error {
+	var request DeclineOrderRequestObject
 
-type GetCouriersResponseObject interface {
-	VisitGetCouriersResponse(w http.ResponseWriter) error
-}
+	request.CourierId = courierId
+	request.OrderId = orderId
 
-type GetCouriers200JSONResponse []Courier
+	var body DeclineOrderJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
 
-func (response GetCouriers200JSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeclineOrder(ctx.Request().Context(), request.(DeclineOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeclineOrder")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(ctx, request)
 
-type GetCouriersdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeclineOrderResponseObject); ok {
+		return validResponse.VisitDeclineOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-func (response GetCouriersdefaultJSONResponse) VisitGetCouriersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// DeliverOrder operation middleware
+func (sh *strictHandler) DeliverOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error {
+	var request DeliverOrderRequestObject
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	request.CourierId = courierId
+	request.OrderId = orderId
 
-type CreateCourierRequestObject struct {
-	Body *CreateCourierJSONRequestBody
-}
+	var body DeliverOrderJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
 
-type CreateCourierResponseObject interface {
-	VisitCreateCourierResponse(w http.ResponseWriter) error
-}
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeliverOrder(ctx.Request().Context(), request.(DeliverOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeliverOrder")
+	}
 
-type CreateCourier201Response struct {
-}
+	response, err := handler(ctx, request)
 
-func (response CreateCourier201Response) VisitCreateCourierResponse(w http.ResponseWriter) error {
-	w.WriteHeader(201)
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeliverOrderResponseObject); ok {
+		return validResponse.VisitDeliverOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
 	return nil
 }
 
-type CreateCourier400JSONResponse Error
-
-func (response CreateCourier400JSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+// PickUpOrder operation middleware
+func (sh *strictHandler) PickUpOrder(ctx echo.Context, courierId openapi_types.UUID, orderId openapi_types.UUID) error {
+	var request PickUpOrderRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.CourierId = courierId
+	request.OrderId = orderId
 
-type CreateCourier409JSONResponse Error
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PickUpOrder(ctx.Request().Context(), request.(PickUpOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PickUpOrder")
+	}
 
-func (response CreateCourier409JSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(409)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PickUpOrderResponseObject); ok {
+		return validResponse.VisitPickUpOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type CreateCourierdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+// GetCourierPath operation middleware
+func (sh *strictHandler) GetCourierPath(ctx echo.Context, courierId openapi_types.UUID, params GetCourierPathParams) error {
+	var request GetCourierPathRequestObject
 
-func (response CreateCourierdefaultJSONResponse) VisitCreateCourierResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+	request.CourierId = courierId
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCourierPath(ctx.Request().Context(), request.(GetCourierPathRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCourierPath")
+	}
 
-type CreateOrderRequestObject struct {
-}
+	response, err := handler(ctx, request)
 
-type CreateOrderResponseObject interface {
-	VisitCreateOrderResponse(w http.ResponseWriter) error
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetCourierPathResponseObject); ok {
+		return validResponse.VisitGetCourierPathResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type CreateOrder201Response struct {
-}
+// CreateOrder operation middleware
+func (sh *strictHandler) CreateOrder(ctx echo.Context, params CreateOrderParams) error {
+	var request CreateOrderRequestObject
 
-func (response CreateOrder201Response) VisitCreateOrderResponse(w http.ResponseWriter) error {
-	w.WriteHeader(201)
-	return nil
-}
+	request.Params = params
 
-type CreateOrderdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
-}
+	var body CreateOrderJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
 
-func (response CreateOrderdefaultJSONResponse) VisitCreateOrderResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateOrder(ctx.Request().Context(), request.(CreateOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateOrder")
+	}
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	response, err := handler(ctx, request)
 
-type GetOrdersRequestObject struct {
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateOrderResponseObject); ok {
+		return validResponse.VisitCreateOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type GetOrdersResponseObject interface {
-	VisitGetOrdersResponse(w http.ResponseWriter) error
-}
+// GetOrders operation middleware
+func (sh *strictHandler) GetOrders(ctx echo.Context, params GetOrdersParams) error {
+	var request GetOrdersRequestObject
 
-type GetOrders200JSONResponse []Order
+	request.Params = params
 
-func (response GetOrders200JSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrders(ctx.Request().Context(), request.(GetOrdersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrders")
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	response, err := handler(ctx, request)
 
-type GetOrdersdefaultJSONResponse struct {
-	Body       Error
-	StatusCode int
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetOrdersResponseObject); ok {
+		return validResponse.VisitGetOrdersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-func (response GetOrdersdefaultJSONResponse) VisitGetOrdersResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.StatusCode)
+// ReassignOrder operation middleware
+func (sh *strictHandler) ReassignOrder(ctx echo.Context, orderId openapi_types.UUID) error {
+	var request ReassignOrderRequestObject
 
-	return json.NewEncoder(w).Encode(response.Body)
-}
+	request.OrderId = orderId
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// Получить всех курьеров
-	// (GET /api/v1/couriers)
-	GetCouriers(ctx context.Context, request GetCouriersRequestObject) (GetCouriersResponseObject, error)
-	// Добавить курьера
-	// (POST /api/v1/couriers)
-	CreateCourier(ctx context.Context, request CreateCourierRequestObject) (CreateCourierResponseObject, error)
-	// Создать заказ
-	// (POST /api/v1/orders)
-	CreateOrder(ctx context.Context, request CreateOrderRequestObject) (CreateOrderResponseObject, error)
-	// Получить все незавершенные заказы
-	// (GET /api/v1/orders/active)
-	GetOrders(ctx context.Context, request GetOrdersRequestObject) (GetOrdersResponseObject, error)
-}
+	var body ReassignOrderJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
 
-type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
-type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.ReassignOrder(ctx.Request().Context(), request.(ReassignOrderRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReassignOrder")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	response, err := handler(ctx, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ReassignOrderResponseObject); ok {
+		return validResponse.VisitReassignOrderResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-// GetCouriers operation middleware
-func (sh *strictHandler) GetCouriers(ctx echo.Context) error {
-	var request GetCouriersRequestObject
+// GetOrderTimeline operation middleware
+func (sh *strictHandler) GetOrderTimeline(ctx echo.Context, orderId openapi_types.UUID) error {
+	var request GetOrderTimelineRequestObject
+
+	request.OrderId = orderId
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetCouriers(ctx.Request().Context(), request.(GetCouriersRequestObject))
+		return sh.ssi.GetOrderTimeline(ctx.Request().Context(), request.(GetOrderTimelineRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetCouriers")
+		handler = middleware(handler, "GetOrderTimeline")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(GetCouriersResponseObject); ok {
-		return validResponse.VisitGetCouriersResponse(ctx.Response())
+	} else if validResponse, ok := response.(GetOrderTimelineResponseObject); ok {
+		return validResponse.VisitGetOrderTimelineResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
 	return nil
 }
 
-// CreateCourier operation middleware
-func (sh *strictHandler) CreateCourier(ctx echo.Context) error {
-	var request CreateCourierRequestObject
+// UnassignOrder operation middleware
+func (sh *strictHandler) UnassignOrder(ctx echo.Context, orderId openapi_types.UUID) error {
+	var request UnassignOrderRequestObject
 
-	var body CreateCourierJSONRequestBody
+	request.OrderId = orderId
+
+	var body UnassignOrderJSONRequestBody
 	if err := ctx.Bind(&body); err != nil {
 		return err
 	}
 	request.Body = &body
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateCourier(ctx.Request().Context(), request.(CreateCourierRequestObject))
+		return sh.ssi.UnassignOrder(ctx.Request().Context(), request.(UnassignOrderRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateCourier")
+		handler = middleware(handler, "UnassignOrder")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(CreateCourierResponseObject); ok {
-		return validResponse.VisitCreateCourierResponse(ctx.Response())
+	} else if validResponse, ok := response.(UnassignOrderResponseObject); ok {
+		return validResponse.VisitUnassignOrderResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
 	return nil
 }
 
-// CreateOrder operation middleware
-func (sh *strictHandler) CreateOrder(ctx echo.Context) error {
-	var request CreateOrderRequestObject
+// RebuildReadModels operation middleware
+func (sh *strictHandler) RebuildReadModels(ctx echo.Context) error {
+	var request RebuildReadModelsRequestObject
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateOrder(ctx.Request().Context(), request.(CreateOrderRequestObject))
+		return sh.ssi.RebuildReadModels(ctx.Request().Context(), request.(RebuildReadModelsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateOrder")
+		handler = middleware(handler, "RebuildReadModels")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(CreateOrderResponseObject); ok {
-		return validResponse.VisitCreateOrderResponse(ctx.Response())
+	} else if validResponse, ok := response.(RebuildReadModelsResponseObject); ok {
+		return validResponse.VisitRebuildReadModelsResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
 	return nil
 }
 
-// GetOrders operation middleware
-func (sh *strictHandler) GetOrders(ctx echo.Context) error {
-	var request GetOrdersRequestObject
+// GetZones operation middleware
+func (sh *strictHandler) GetZones(ctx echo.Context) error {
+	var request GetZonesRequestObject
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetOrders(ctx.Request().Context(), request.(GetOrdersRequestObject))
+		return sh.ssi.GetZones(ctx.Request().Context(), request.(GetZonesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetOrders")
+		handler = middleware(handler, "GetZones")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(GetOrdersResponseObject); ok {
-		return validResponse.VisitGetOrdersResponse(ctx.Response())
+	} else if validResponse, ok := response.(GetZonesResponseObject); ok {
+		return validResponse.VisitGetZonesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateZone operation middleware
+func (sh *strictHandler) CreateZone(ctx echo.Context) error {
+	var request CreateZoneRequestObject
+
+	var body CreateZoneJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateZone(ctx.Request().Context(), request.(CreateZoneRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateZone")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreateZoneResponseObject); ok {
+		return validResponse.VisitCreateZoneResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
@@ -418,21 +2812,90 @@ func (sh *strictHandler) GetOrders(ctx echo.Context) error {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xVT28bRRT/KqsHx1Vtt1zYIwEhpIgeuIAQh8V+caby7iyz47RWtFLsQBspUXPhgHoo",
-	"KnyBjcnKi0Pcr/DeN0IzEyduPHFcEVURF8u7O/Pm9++92YW2TDKZYqpziHYhb29jEtu/G7KvBCrzN1My",
-	"Q6UF2g+iY347mLeVyLSQKURAv9EpVXTOI6r5Z6ppSiWPaMZ7EMKWVEmsIYJ+X3QgBD3IECLItRJpF4oQ",
-	"erIdu0K78LHCLYjgo8YVsMYFqsbmfF0RQhon6MXxDx8vn1GEoPCnvlDYgeh7sDBshYXDf7jcJX98gm1t",
-	"TvlCKemRoC07vsNf0YxOA5rxAdV0QlOqF9mLVD96eAVNpBq7qMwpCeZ53PVV/IMqmvKQR9erruZn8V3V",
-	"9THbXND8XXLPlnF8a4qJVCT9BKKmj8JgedN3t2y6hvkZmCo+qF/j0xvDeEsMEpFuYtrV2xC1PMHLM0Rf",
-	"mt/Q1GSXZkZ6Plok0rqVyEWuXG0fn8eqc1/7ytcnKxrErBfplvQAf80jGlPFL6ikyuR3QmXA+/zCPU15",
-	"n/f4iCqjMo3DwPDkIb01n+2iParNHn5ONb80n60ZVNKYZjQN330z5X0jgNA9A++bp3G3iyr4HHtiB9UA",
-	"QthBlTtkrQfNB02jjswwjTMBETyyr0LIYr1trWjEmWjstBptFzv7rovaQ/N3mtHEQjrjY0ftrX0wTGsT",
-	"noDGPKSKf1kiDRaDsuJ+1YEIvkS9MT/RGJFnMs1dOB42m27wpBpTCyTOsp5wzjSe5M5kZ6gNk8Ykv833",
-	"eVcVl8bGSsUD5+s1on9emHNA53xIf5t55AwegV28Ffd7+r0grkLm5q4Px+vLMVjauOb9JInVYO7FesIX",
-	"IWQyX9PPU5rRiU3ZRdnFauWSiRsKY41zaV0/Ya4/k53BncmzMBF9Gr26AgjFUpBaHtqr3f3kPcP3n50N",
-	"aEwlnVFNp24CUO1wfPrBcfCha2g6N3OYaj4O6MSOpnMzsAI6oxn9ZSdzfW864dfVkTWr5yNOmsvIJmPt",
-	"juChfWWsscUnVBoQNAl4GPBzquiMj/hlwCOjkL26bNtR6QS8oWXctXgHcb0XFry5QSOP+I24rcUO3sEl",
-	"E9iUTqzzFe/xgc2s0ahagMCHvpvnsQvCh7h3nNP/61tnbSeKoij+DQAA//8JDQamhg0AAA==",
+	"H4sIAAAAAAAC/+xd/3PbxpX/VzC4/nZgJaV2v6g/qW5z8V0u0djOJdeMbgYiVxIakmAB0Jbi4YxFVnFy",
+	"8lg3d5lJJ3N1r+0P9ytNkxYtitS/sPsf3by3uwAWWICgvkU+Y6bTmJSEffv2vc/7ug+PzarbaLlN0gx8",
+	"c/Wx6Vd3SMPGf67Vah7x8Z8tz20RL3AIfrJbthc0SDOADzXiVz2nFThu01w16fd0QPvsCevSMXtC+6Zl",
+	"BnstYq6afuA5zW2zY5lVJ9jT/OV/0Rl7Qmd0qP0bt90MPN2f/YV1YSE61S+247Z9ovmzb+mMnur+wA88",
+	"QnQ7+xud0DH7CpdpOM0PSXM72DFXV1LP6FimR37fdjxSM1c/lw/cCH/P3fwdqQaw1ppH7PvtVqu+92vS",
+	"sJu1NKtr4fcJar6jfXpC+/SYHVoGPaEz1gX+sUM6MuiIfQP/mcL/DegxO6IT+DQ2gL10TKcGPWE99oQ9",
+	"oyP2JOKC0wzINvGAtC3PbcCyP/LIlrlq/t1SJCZLQkaWPnSrNtLTscym3dCx+U9AIogELA/kHNMZnbJD",
+	"g46BnQbr0Vd0Ap9P6ISOWJee0LH2WJBNOoGLNgKPeUlH9BiWEeyhMzrQbtBve9skn7EGfU2HrMe6lkH7",
+	"BtunAzqjL5GHU3bIDhQuwkLIctaVm6NjdsBPgR2gWnRpH34e0bPpunViN0N67gE/NUS9oK/pmA5pnz1n",
+	"30hGxuic0j4/2ymd0Vd0liBWfKmQC2K85XoNOzBXzZrb3qyTiK5mu7HJ2RS4i0jBI6dZcx/5OjUFGaUT",
+	"9gzEUe5hRk846UOg7hRUko7oFPhksH32BM4SCXUC0vDnEfIpLq7oUyfcke159h58/tJtkrt6jZpJPr5k",
+	"+3TCesh0IbvsyDJYTxFTYLL8J+gf2wdZYfusSwesJw46ZHG77dTMeViBWiSUD3kfCr4loUCRFPHBjDiv",
+	"hRnfd7abH7gN8lu3SdIgk8mSP9KhOI8x+wMdg7xxmAn1eOEdirWyySS1j70a8TRWp1olreBXehsA6ndi",
+	"CUEK0VAj9/grdEJfw8YMesaeAB6yI9Zlz/jHER3SCZ3x34A90jcxbVO0xg5IJXAaRAdYJLDzFJmOUN4B",
+	"oHHNU3aEpKH49OlA4GCxtZyFDm/+mVlmXSr1Asrf8hzX09v2v4JPAHTo9kia7QY3lHazZntAD9ltoeux",
+	"oTXQdtD29Z4AbJH12H7cAPS53iagTy4qhc60zDUUMPznulP9gtQ+aWkJeOjW21pj94K+ZP8OZ6kxOAk1",
+	"QK6HTA6fGe4uxk2tqrRrTvAb6REl9SRwPa2h7IIujOkxR1kwUgbt01cof6+AdTpJsLcCro12rebAs+z6",
+	"emy9wGsTK7nUf8QfatAzOHLAzfjqI46qpmZ39va2R7btYGFQUjZD+0UEPVzrAf5EI7ljeqZ7shQgF9EK",
+	"PVTPwX+5W1v4X4A6rQBtki3XIxfj6ZDONNzkNgrs/UBiX4LybEuVOoaq28hwPr9HSw12cUj7cfeT9tlR",
+	"nCzwg/oFhKzqeh6pozIsboj6CNsgYv3QrdynM7Tj36TlLFqVPJRRjy44COF/xB/3kh3i+kd6KQ5dlNQy",
+	"SQ/EI1UQmtqaLsr478gJ0q9TzCI8JJ4v4DuxwH+ip7zPN5ISjmKqmoVqqjKpihzRFDI+EjFLoFZSEiT8",
+	"KEzTAeIdoX0pNKzaLTsj4HyB8jEG8z6TyA1e6AgUw2AHIqwUu0+bkIbTdBoAAsu6+KJGqnUHvBlAg3yP",
+	"+AT0NO16jBXHg8cXiiczQ+dzgn7rmE7mkrQjPMB891fZphVGVMCIkUoA/AKEGDKugWBzBg4N+MUHwkek",
+	"fXZQBIhvhg+TEcb+Efwz3RqI/v4dyE7MP2L1LFNeiYUnqpUE4RyCfo7ET/vsKcQdcP5jziPWmysBbZ/U",
+	"/mWu+5IndXMlX+foiLAm5u8ktENlpEKnFalwjuJzN07mpFQI4I+eF+orezTogGPhE3ZEh/SEjnSOa6Go",
+	"VI1qUuYgwS5Ba85O1+1gJ73HluvoTdlf6Yw95SHqGRh9OrbAnPXBfrMjQ2AcBEBHUrrAdXhTdHtAzjos",
+	"PndrgkTd1n7NpSEj8POI7WtN2Z8xpngKAZxwbKTLv2CKTqygJ63uPCTeHk8upImTebJ03os9pX3QYQMD",
+	"zK5gbZ97ROeN9AI3wx2DxNNXl7hUgkNRRkLHpN94njbkeEHP6BilTWT/ZuxrOqYvuTwODPYHdBpPEdVH",
+	"xr337xg/+/nyz0wrweQaCWynrpXuET1Bcx1/tNbXAxJ97UmNBK/GPK8HdJ4hbB8lvMuiOvG+Q+o1zhON",
+	"++c0IdCtEq1A93geIrls4SD4gwcP1g1+3DwS1uY+AyeoE22ICB58F60WnFbi+AA96Bntz2V24NlVckFH",
+	"fsgPAE4CaDgRoDzBuIb7FBmednLRT+7dLUp6QuoD7sZydoVM12lA7MhXHxeV3v/lEfmUs7ZPTzDQAE5M",
+	"ET64EzjS1ym2YEWdDIHo0lGCn/MVHB9nSWJ1e/ww5k+pO9xN0/HZXF9E45L/62K+xa4JT9GR+hF5lBkR",
+	"zHHxcu2GZfotQmpZrh7nNqhwfCMrczci/CP+7Iz9ZGVFoyJdrhcifg0Dk6RFy/vDhP2TLu95lDuyzXNd",
+	"dzWVuGW364G5Gk8QXmF+sVB6b4HDlfyywrMK18g4an2W/moqcvMlfpEK0Lxyhm7DGYIt8ml3a/k1P1mV",
+	"SWT8tfn6LGkTS32UAwypUG3uqmVB4MYWBGJL3PGIzVP+11UIuEAFckEYvdySQxTopW1QkBGUvALBTiat",
+	"5AbohO3DngYG64ogdVRYwBcXxwQ3Yoyw9SnFdc91tz7ekgYwve3Wjhu4H9j+Tnr39z9Yq7x3+6c8wOkC",
+	"J9CzBq0bK0kciL1T6MFd3R0C/k3LDgLiwTP/7fPlyi/sytZa5f2Nxz+91fmR1nA62kAZ4sIKuvVDC7sF",
+	"QhMg6puxNJJojEksvPH4lvVz3ZodDevuERu16RzQnuNDKGWNzFaGLAArlkVYMHEQ7cTKSyLk9/jY8JdZ",
+	"cMAOc+BAtJnoexIKJ6iSTUiamDWwvyDNufUKpWXjHEkGuYolWKJj5SfNXNG6klPOOVlNz0n6fB8Sz94m",
+	"n9pOcJ9U3WbNz2hhGNGhTLPPQrdAZE4S6ckYVtBTOo610gxQBOD3eXmOHRRr9CmSJbV4JexYIhdPlgzk",
+	"6iOtuXsUxhmpKtskTN1x6gU28aaMU7QXK7cby/5cyRFrhLuwdEzXnd/luNqX724Vdt4v012P5+rzfHbw",
+	"uUi1Da7CfXim7Ap1/oloO/TohD1nTzk0QWISc0oWOLqHIFV0IPraRgjta+t3TcsEO2buEJsX1zk7zM8q",
+	"a+t3K7BKBFF8Vayq2x7x1to8Pc4/vS/5/I+fPtBFjSB32Ac0RKLAMHfZU65kluwOStRhEpooCs5P6Zh7",
+	"m3QEiatfGhgVTGLmHn8Fa6sc1cHvge+xSS8EdXbA8274dPYVFAFNi3flYsMgbiva/k4QtMxOB9OKW9rO",
+	"Qajx4+KiRMcbYnCT3VQDI3ZQjRKcgEIwksKeq9562HIVfnPCemG+bNW8/8je3iaeEbpQsbq0ufLj5R8v",
+	"I/C0SNNuOeaq+RP8Cl2PHRSpJbvlLD1cWbKbdn0vcKr+Eu+Gq0RtsdskyEiCHSOFE3bEd8pTuj1+UOwZ",
+	"SKPIjhm8OJIuv/JEcFjHpKeLmGL4dcUYA8CEHQ7mP5BAsRqwa89ukABB+PPUjv4HNZ9jYobB/6VSq+Vd",
+	"oFIu8Tj32VFyR9jZCt/xBCt+L7pYLfG76pZAGhClMc8FQjOiJ1Jff9/mpyzUtUrq9fvOl0QKMMa/uZmT",
+	"DQAkv+U2fY4p7y0vc4exGYjSnt1q1R2OX0u/E6Y+enge7Kk+TqeTgoO/Cbn/WjrFM6E7XRDTW7mktDx3",
+	"s04af78YSaJGoKHlRZilBpMelic4Iow5PSvXSM+fUIkkbsj259ANYAehBsx4o/k0WVbhNP/k2mmmU1RW",
+	"CB7ehKDM86Air/hDHKpiRFHf4+brc7Pm+C07qO6I3hc0gxsdK7KyWb+yAS3BjYbt7UkcXBT0kDSJvCLA",
+	"8S8EtVFHSMLc6FDxjlxxHiJqmit4uWoEujvANaayoDblHeXyqgjc4xhloFbdaTiBAlmhpKwsL1tmw97l",
+	"AHYbP+UmgjMyl/sY0ApnAG4YcO/nTYJEfk1C3GZ4hZI7wyRZ3/is8hHZDSp32p7veuFBskN8mvSkIgDj",
+	"tUsdROMDlN2mPO3MEhPfh7jqwynD7gJoohoj7yFaOOV2hPWggy40mc8zCPJdL4P50jOVqTvxsSL+qzaQ",
+	"VNSPqXaTSuKbDavAvhNFfdHimPIIuREOsbJviBa7sTzdjK2LjSimMi9G1aUdoeTPK1DG7oq1t2Ltvmft",
+	"vWfF7tkM2AGkgaDj3RA3VlAVyG6r7taIubpl132ip3Bz091VKEw2PcbCvoa9e5f/9BZqSfQhmVzwgz10",
+	"FyEoMi/sAhTKecjiYLpnZSG3wBIRCq6nqGRm0aKw6vNYONaRKZIDb1I4psMH7sLl67X5WeWBG9j1ygLt",
+	"azGEPUMxQmECnBdfGBjrTtgzoDG8miQNQIJ0npxIkRgBaKd0vErH6/+D45XtAkHS3vULelZDvNPXpwP5",
+	"2GQaXHWneHXrTngzAZI9xA9+5db2Li2sirVa6FgfK9WanRS0r+ju2ZZh2LuEBreWf/GDHSg75LFKvMv/",
+	"Jea7wHzuy26zMWZwbgZyRRDzbT4W6KK4pcdhwaqzZCst08WDu1RO9HnKA04FgMcX7LTOihJjbd/zwsWc",
+	"wmISRNH1hSRkLE5SCn0yZc4vakVnPK8gf5XprTRHzpPjKsHs3GAW5YDV28Yj+iZ+006MZZhhvRRVSwTr",
+	"YUB5432k6KpjzBWam3rq8qokhjsiZsmqb84HL7jKVPlSFu/aBbELYQij8TMtaBr0OMyCw9laKnKF5/Um",
+	"NscCE+YR4MmLUOFlCgjxDOxvEU0f7FkK0BIX9N8aKLt8bzLBCZ2g6i+qpTbT0WNt6Wy+287mrWsk5vu4",
+	"IRCsOpbSO+UTevr0jdDmwxvnYX63IFzOh22elV16LHqiO0t8qAe/wVY0CI7RwHrSIV1ghIcVK3hmlH3x",
+	"cDBfC0YLfprGbKT8Y3H7/+YDtnX+dn0NTVFT+6V7w6XH+lZ6rNcLrWFTWgxIY9emEiBR5hmuwPtPOfwq",
+	"DocQcg6rIIpzFzILsdvAQBBvfsm4X69rwY77/mE5OWqjGSR8ekNa9X7y+l7Kcii3nUvTcYOiD+VkdGqS",
+	"7CIuQ47SwJYGtjSw12JgX2RatDjmnsfaYnvuRawt66lXiPqyPaArLOdQBpDKABvWU2iXHdcGmuWJSA6O",
+	"saNIfdxrbn/C2/FJA4sbKg3sjTOwyTt1WhubddLpwlBpcEuDWxrc0uBekcH9NgY344tHtS2n+kW7deGg",
+	"Fu6AdDk9lsGe8hkyWIUC4l6CraITNS2aGmGs2ku45P5JqzSXZSqzBP4S+Evgp99JIF0Y9FtiOuP578qc",
+	"ySsr6Z4qg76G71BCJmAO+qzHIyvaz2mSWudw9zbiunLj4kzcRoWG86w7FOLasmbN3DEEeSMViywbuIsv",
+	"eg3NYHjy5VXHsu3hh2t7SLY5vLMXAPS4rliUaARH4QAhmseRMFXCYPTpEGcP7LNeOn2ivy5QLA6QQx1Q",
+	"5mF02RliCDchU7HQWLRaDLgpiXdiyGuo8L8uHzr9GvsA+XP5vcEpHcV2KB24GR1Ez5ERjXZexN0aabTc",
+	"gDSre2JqROxynb0rL9e9d/v2vIEwG1d2eSK72PRdbHvzCk0reYNblKktsTdvIcPhUuZE/Q3lQGKHJSZD",
+	"xe68xUeCpt5XISQvMTNGU2rNvaZWXgErL31c1CAVwqqQi+NCyYRQgfjgGPFuMznKBWsWQwjYMBhKjNQ/",
+	"vXGtfn/JMCMa+7RkVwPnIbmEiQBcUo/RHkFw+3XslSvxxmtdbPOxHPRUDgkohwREzDerfKDnWhCbFBD/",
+	"rhL/EI7ArKQG8ubsLDZWlB1yvsdfNaklWI7Y1NxTv5RRpMk3L5xvdsG8sbbW+YcbxGfsljMOrmPGQdYL",
+	"R8oJB+WEg9K9LSccXMmEg8IuncavjCqlnpgfvFitNN5aJP05pRk3eREk8tBn9DTxOi7WS3md6lTjCyTU",
+	"3+YOHpUJet0IEzRKMU7MGyvbZ0sovbGp61gtVldP1iWzy2LsOVMOf07CdVFczjUdUGyTd0cunJ5IvQI1",
+	"MaR/bMggOuPtnUp0RU/PVcVFpH0gt/VWmJ2rDm1ir7++YHxTmozSZFx++867XuvkN6dn6O09z76ekALv",
+	"dvMcfj/b11w6xARjclJ7KuErwPsCd/nU11G8ozGByoTy3lyJ4qXjXzr+ObXGInitWAowPJWGWyN1f8kj",
+	"m22nXlvIRuBC4v1RIuEs3l4wVqaGhO0xE/37PvBt6OPU+9HPeB1tXywB+HTKX+kKxQrMA2uuqd3jG7lH",
+	"7No/49bMFA7eyniTUQap8Y2NIq0ukehtz+amoubC8quoEQxM8y8lMj6e824yXSD7W1z9OuJDPjvsEiLD",
+	"UnPKOsg56yBzNeRig5/543uZj7diFEzF3Vk6oa/5N/KVVPimuOg6eUZHqBhKeEUNkPMG/RXtfrwUejKJ",
+	"KaOPskHxCqOPmWyFUPQyHDcU7yacheNHKc7cu+ljqechldkpDtTZ+LzR2ej83wBTcmD1zJoAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
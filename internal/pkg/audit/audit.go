@@ -0,0 +1,39 @@
+package audit
+
+import "context"
+
+// Metadata tells who changed the aggregates, with which command and as part of which request or message
+type Metadata struct {
+	Actor         string
+	Command       string
+	CorrelationID string
+}
+
+type metadataKey struct{}
+
+// WithActor names the caller: the authenticated subject, a job or the topic of a message
+func WithActor(ctx context.Context, actor string) context.Context {
+	metadata := FromContext(ctx)
+	metadata.Actor = actor
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// WithCommand names the command the changes are made by, a nested command replaces the outer one
+func WithCommand(ctx context.Context, command string) context.Context {
+	metadata := FromContext(ctx)
+	metadata.Command = command
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// WithCorrelationID ties the changes to the request id or the message id they come from
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	metadata := FromContext(ctx)
+	metadata.CorrelationID = correlationID
+	return context.WithValue(ctx, metadataKey{}, metadata)
+}
+
+// FromContext returns the metadata set so far, the fields nobody set are empty
+func FromContext(ctx context.Context) Metadata {
+	metadata, _ := ctx.Value(metadataKey{}).(Metadata)
+	return metadata
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromContext(t *testing.T) {
+	ctx := WithCorrelationID(WithActor(context.Background(), "job:assign_order"), "run-1")
+	outer := WithCommand(ctx, "ExpireOffers")
+	inner := WithCommand(outer, "AssignOrder")
+
+	assert.Equal(t, Metadata{}, FromContext(context.Background()))
+	assert.Equal(t, Metadata{Actor: "job:assign_order", Command: "ExpireOffers", CorrelationID: "run-1"}, FromContext(outer))
+	assert.Equal(t, Metadata{Actor: "job:assign_order", Command: "AssignOrder", CorrelationID: "run-1"}, FromContext(inner))
+}
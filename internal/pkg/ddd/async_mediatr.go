@@ -0,0 +1,228 @@
+package ddd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/delivery/internal/pkg/errs"
+)
+
+var (
+	ErrQueueFull       = errors.New("event handler queue is full")
+	ErrMediatrClosed   = errors.New("mediatr is closed")
+	errEventsAbandoned = errors.New("events left unhandled")
+)
+
+type AsyncMediatrConfig struct {
+	Workers   int
+	QueueSize int
+	Retry     RetryPolicy
+	// OnError receives what the AfterCommit handlers failed to handle, it is logged by default
+	OnError func(event DomainEvent, err error)
+}
+
+type queuedEvent struct {
+	ctx   context.Context
+	event DomainEvent
+}
+
+// workerPool gives each worker its own queue and the events of an aggregate always
+// go to the same worker, so a handler sees them in the order they were raised
+type workerPool struct {
+	handler        EventHandler
+	retry          RetryPolicy
+	enqueueTimeout time.Duration
+	queues         []chan queuedEvent
+}
+
+type asyncMediatr struct {
+	config AsyncMediatrConfig
+
+	mu       sync.RWMutex
+	handlers map[string][]subscription
+	pools    map[EventHandler]*workerPool
+	closed   bool
+
+	workers sync.WaitGroup
+	// stop cuts the retries short once Close runs out of time
+	stop       context.Context
+	cancelStop context.CancelFunc
+}
+
+// NewAsyncMediatr runs the AfterCommit handlers on their own workers, so a slow or failing
+// handler neither blocks the publisher nor the other handlers. Handlers must be comparable.
+func NewAsyncMediatr(config AsyncMediatrConfig) (Mediatr, error) {
+	if config.Workers <= 0 {
+		return nil, errs.NewValueIsRequiredError("workers")
+	}
+	if config.QueueSize <= 0 {
+		return nil, errs.NewValueIsRequiredError("queue size")
+	}
+	if config.Retry.MaxAttempts <= 0 {
+		return nil, errs.NewValueIsRequiredError("retry max attempts")
+	}
+	if config.OnError == nil {
+		config.OnError = func(event DomainEvent, err error) {
+			log.Printf("event %s %s was not handled: %v", event.GetName(), event.GetID(), err)
+		}
+	}
+
+	stop, cancelStop := context.WithCancel(context.Background())
+	return &asyncMediatr{
+		config:     config,
+		handlers:   make(map[string][]subscription),
+		pools:      make(map[EventHandler]*workerPool),
+		stop:       stop,
+		cancelStop: cancelStop,
+	}, nil
+}
+
+func (m *asyncMediatr) Subscribe(handler EventHandler, events ...DomainEvent) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	options := optionsOf(handler, HandlerOptions{
+		Workers:   m.config.Workers,
+		QueueSize: m.config.QueueSize,
+		Retry:     m.config.Retry,
+	})
+	if _, ok := m.pools[handler]; !ok && options.Phase == AfterCommit && !m.closed {
+		m.pools[handler] = m.startPool(handler, options)
+	}
+	for _, event := range events {
+		m.handlers[event.GetName()] = append(m.handlers[event.GetName()], subscription{
+			handler: handler,
+			options: options,
+		})
+	}
+}
+
+func (m *asyncMediatr) PublishBeforeCommit(ctx context.Context, event DomainEvent) error {
+	m.mu.RLock()
+	subscriptions := append([]subscription(nil), m.handlers[event.GetName()]...)
+	m.mu.RUnlock()
+
+	var problems []error
+	for _, s := range subscriptions {
+		if s.options.Phase != BeforeCommit {
+			continue
+		}
+		if err := handle(ctx, s.handler, event, s.options.Retry); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return errors.Join(problems...)
+}
+
+// Publish only queues the event, a queue that stays full for the handler's EnqueueTimeout
+// drops it for that handler and is reported. Close waits for a publish that is waiting.
+func (m *asyncMediatr) Publish(ctx context.Context, event DomainEvent) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return ErrMediatrClosed
+	}
+
+	var problems []error
+	for _, s := range m.handlers[event.GetName()] {
+		if s.options.Phase != AfterCommit {
+			continue
+		}
+		if err := m.pools[s.handler].enqueue(ctx, event); err != nil {
+			problems = append(problems, fmt.Errorf("%T: %s %s: %w", s.handler, event.GetName(), event.GetID(), err))
+		}
+	}
+	return errors.Join(problems...)
+}
+
+func (m *asyncMediatr) Close(ctx context.Context) error {
+	m.mu.Lock()
+	if !m.closed {
+		m.closed = true
+		for _, pool := range m.pools {
+			for _, queue := range pool.queues {
+				close(queue)
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		m.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		m.cancelStop()
+		return fmt.Errorf("%w: %w", errEventsAbandoned, ctx.Err())
+	}
+}
+
+func (m *asyncMediatr) startPool(handler EventHandler, options HandlerOptions) *workerPool {
+	pool := &workerPool{
+		handler:        handler,
+		retry:          options.Retry,
+		enqueueTimeout: options.EnqueueTimeout,
+		queues:         make([]chan queuedEvent, options.Workers),
+	}
+	for i := range pool.queues {
+		pool.queues[i] = make(chan queuedEvent, options.QueueSize)
+		m.workers.Add(1)
+		go m.work(pool, pool.queues[i])
+	}
+	return pool
+}
+
+func (m *asyncMediatr) work(pool *workerPool, queue <-chan queuedEvent) {
+	defer m.workers.Done()
+
+	for queued := range queue {
+		// the publisher's request may be over by now, only its values are kept
+		ctx, cancel := context.WithCancel(context.WithoutCancel(queued.ctx))
+		stopWatching := context.AfterFunc(m.stop, cancel)
+
+		if err := handle(ctx, pool.handler, queued.event, pool.retry); err != nil {
+			m.config.OnError(queued.event, err)
+		}
+
+		stopWatching()
+		cancel()
+	}
+}
+
+func (p *workerPool) enqueue(ctx context.Context, event DomainEvent) error {
+	id := event.GetAggregateID()
+	hash := fnv.New32a()
+	_, _ = hash.Write(id[:])
+	queue := p.queues[hash.Sum32()%uint32(len(p.queues))]
+
+	queued := queuedEvent{ctx: ctx, event: event}
+	select {
+	case queue <- queued:
+		return nil
+	default:
+	}
+	if p.enqueueTimeout <= 0 {
+		return ErrQueueFull
+	}
+
+	// the changes are committed already, so the publisher's cancellation does not cut the wait short
+	timer := time.NewTimer(p.enqueueTimeout)
+	defer timer.Stop()
+	select {
+	case queue <- queued:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%w after waiting %s", ErrQueueFull, p.enqueueTimeout)
+	}
+}
@@ -1,7 +1,16 @@
 package ddd
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrVersionGap is returned when the history of an aggregate misses an event
+var ErrVersionGap = errors.New("the history of the aggregate has a gap")
+
 type BaseAggregate[ID comparable] struct {
 	*BaseEntity[ID]
+	version      int
 	domainEvents []DomainEvent
 }
 
@@ -12,6 +21,13 @@ func NewBaseAggregate[ID comparable](id ID) *BaseAggregate[ID] {
 	}
 }
 
+// RestoreBaseAggregate must be used ONLY in a repository layer for mapping
+func RestoreBaseAggregate[ID comparable](id ID, version int) *BaseAggregate[ID] {
+	aggregate := NewBaseAggregate[ID](id)
+	aggregate.version = version
+	return aggregate
+}
+
 func (a *BaseAggregate[ID]) ClearDomainEvents() {
 	a.domainEvents = nil
 }
@@ -20,6 +36,27 @@ func (a *BaseAggregate[ID]) GetDomainEvents() []DomainEvent {
 	return a.domainEvents
 }
 
+// RaiseDomainEvent records the event and moves the aggregate to the next version
 func (a *BaseAggregate[ID]) RaiseDomainEvent(event DomainEvent) {
+	a.version++
+	if e, ok := event.(versioned); ok {
+		e.setVersion(a.version)
+	}
 	a.domainEvents = append(a.domainEvents, event)
 }
+
+// Version counts the events the aggregate raised over its lifetime
+func (a *BaseAggregate[ID]) Version() int {
+	return a.version
+}
+
+// ReplayDomainEvent moves an aggregate rebuilt from its history to the version of the event,
+// the events must follow each other without gaps
+func (a *BaseAggregate[ID]) ReplayDomainEvent(event DomainEvent) error {
+	if event.GetVersion() != a.version+1 {
+		return fmt.Errorf("%w: event %s has version %d, the aggregate is at %d",
+			ErrVersionGap, event.GetName(), event.GetVersion(), a.version)
+	}
+	a.version = event.GetVersion()
+	return nil
+}
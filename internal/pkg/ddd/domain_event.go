@@ -1,8 +1,65 @@
 package ddd
 
-import "github.com/google/uuid"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
 
 type DomainEvent interface {
 	GetID() uuid.UUID
 	GetName() string
+	GetAggregateID() uuid.UUID
+	// GetVersion is the version of the aggregate the event brought it to
+	GetVersion() int
+	GetOccurredAt() time.Time
+}
+
+// versioned events get the aggregate version when they are raised
+type versioned interface {
+	setVersion(version int)
+}
+
+var _ versioned = &BaseEvent{}
+
+// BaseEvent is embedded by the domain events, the name routes the event in Mediatr
+type BaseEvent struct {
+	ID          uuid.UUID
+	Name        string
+	AggregateID uuid.UUID
+	Version     int
+	OccurredAt  time.Time
+}
+
+func NewBaseEvent(name string, aggregateID uuid.UUID) BaseEvent {
+	return BaseEvent{
+		ID:          uuid.New(),
+		Name:        name,
+		AggregateID: aggregateID,
+		OccurredAt:  time.Now().UTC(),
+	}
+}
+
+func (e *BaseEvent) GetID() uuid.UUID {
+	return e.ID
+}
+
+func (e *BaseEvent) GetName() string {
+	return e.Name
+}
+
+func (e *BaseEvent) GetAggregateID() uuid.UUID {
+	return e.AggregateID
+}
+
+func (e *BaseEvent) GetVersion() int {
+	return e.Version
+}
+
+func (e *BaseEvent) GetOccurredAt() time.Time {
+	return e.OccurredAt
+}
+
+func (e *BaseEvent) setVersion(version int) {
+	e.Version = version
 }
@@ -0,0 +1,101 @@
+package ddd
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Phase is the point of the unit of work a handler runs at
+type Phase int
+
+const (
+	// AfterCommit handlers learn about the changes once they are stored, their failure can't undo them
+	AfterCommit Phase = iota
+	// BeforeCommit handlers run inside the transaction on the caller goroutine, their error rolls it back
+	BeforeCommit
+)
+
+// RetryPolicy retries a failed handler with a backoff doubling up to MaxBackoff
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// HandlerOptions tune a single handler, zero values fall back to the mediatr defaults
+type HandlerOptions struct {
+	Phase     Phase
+	Workers   int
+	QueueSize int
+	Retry     RetryPolicy
+	// EnqueueTimeout is how long Publish waits for room in a full queue before the event is
+	// dropped for the handler, it is for handlers that must not lose events; zero does not wait
+	EnqueueTimeout time.Duration
+}
+
+// ConfiguredEventHandler is implemented by handlers that declare how they run,
+// the others run after commit with the mediatr defaults
+type ConfiguredEventHandler interface {
+	EventHandler
+	Options() HandlerOptions
+}
+
+func optionsOf(handler EventHandler, defaults HandlerOptions) HandlerOptions {
+	configured, ok := handler.(ConfiguredEventHandler)
+	if !ok {
+		return defaults
+	}
+
+	options := configured.Options()
+	if options.Workers <= 0 {
+		options.Workers = defaults.Workers
+	}
+	if options.QueueSize <= 0 {
+		options.QueueSize = defaults.QueueSize
+	}
+	if options.Retry.MaxAttempts <= 0 {
+		options.Retry = defaults.Retry
+	}
+	if options.Phase == BeforeCommit {
+		// the handler runs in the caller's transaction, a failed statement aborts it and another attempt can't succeed
+		options.Retry = RetryPolicy{MaxAttempts: 1}
+	}
+	return options
+}
+
+// handle runs the handler until it succeeds or the retry policy gives up, a panic counts as a failure
+func handle(ctx context.Context, handler EventHandler, event DomainEvent, retry RetryPolicy) error {
+	backoff := retry.Backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = handleOnce(ctx, handler, event); err == nil {
+			return nil
+		}
+		if attempt >= retry.MaxAttempts {
+			return fmt.Errorf("%T failed to handle %s %s after %d attempt(s): %w",
+				handler, event.GetName(), event.GetID(), attempt, err)
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("%T gave up on %s %s: %w", handler, event.GetName(), event.GetID(), err)
+		case <-timer.C:
+		}
+		backoff *= 2
+		if retry.MaxBackoff > 0 && backoff > retry.MaxBackoff {
+			backoff = retry.MaxBackoff
+		}
+	}
+}
+
+func handleOnce(ctx context.Context, handler EventHandler, event DomainEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return handler.Handle(ctx, event)
+}
@@ -2,6 +2,7 @@ package ddd
 
 import (
 	"context"
+	"errors"
 	"sync"
 )
 
@@ -11,39 +12,71 @@ type EventHandler interface {
 
 type Mediatr interface {
 	Subscribe(handler EventHandler, events ...DomainEvent)
+	// PublishBeforeCommit runs the BeforeCommit handlers, an error must abort the unit of work
+	PublishBeforeCommit(ctx context.Context, event DomainEvent) error
+	// Publish hands the event to the AfterCommit handlers, the errors of all of them are joined
 	Publish(ctx context.Context, event DomainEvent) error
+	// Close waits for the events already published to be handled
+	Close(ctx context.Context) error
+}
+
+type subscription struct {
+	handler EventHandler
+	options HandlerOptions
 }
 
 type mediatr struct {
 	mu       sync.RWMutex
-	handlers map[string][]EventHandler
+	handlers map[string][]subscription
 }
 
+// NewMediatr runs every handler on the caller goroutine, one after another
 func NewMediatr() Mediatr {
-	return &mediatr{handlers: make(map[string][]EventHandler)}
+	return &mediatr{handlers: make(map[string][]subscription)}
 }
 
 func (m *mediatr) Subscribe(handler EventHandler, events ...DomainEvent) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	options := optionsOf(handler, HandlerOptions{Retry: RetryPolicy{MaxAttempts: 1}})
 	for _, event := range events {
-		handlers := m.handlers[event.GetName()]
-		handlers = append(handlers, handler)
-		m.handlers[event.GetName()] = handlers
+		m.handlers[event.GetName()] = append(m.handlers[event.GetName()], subscription{
+			handler: handler,
+			options: options,
+		})
 	}
 }
 
+func (m *mediatr) PublishBeforeCommit(ctx context.Context, event DomainEvent) error {
+	return m.publish(ctx, event, BeforeCommit)
+}
+
 func (m *mediatr) Publish(ctx context.Context, event DomainEvent) error {
+	return m.publish(ctx, event, AfterCommit)
+}
 
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+func (m *mediatr) Close(_ context.Context) error {
+	return nil
+}
 
-	for _, handler := range m.handlers[event.GetName()] {
-		err := handler.Handle(ctx, event)
-		if err != nil {
-			return err
+func (m *mediatr) publish(ctx context.Context, event DomainEvent, phase Phase) error {
+	var problems []error
+	for _, s := range m.subscriptions(event) {
+		if s.options.Phase != phase {
+			continue
+		}
+		if err := handle(ctx, s.handler, event, s.options.Retry); err != nil {
+			problems = append(problems, err)
 		}
 	}
-	return nil
+	return errors.Join(problems...)
+}
+
+// subscriptions copies the handlers, so that a slow one doesn't hold the lock
+func (m *mediatr) subscriptions(event DomainEvent) []subscription {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]subscription(nil), m.handlers[event.GetName()]...)
 }
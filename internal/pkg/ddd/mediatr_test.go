@@ -0,0 +1,320 @@
+package ddd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testEventName = "TestHappened"
+
+type testEvent struct {
+	BaseEvent
+}
+
+func newTestEvent(aggregateID uuid.UUID) *testEvent {
+	return &testEvent{BaseEvent: NewBaseEvent(testEventName, aggregateID)}
+}
+
+// recordingHandler fails the first failures calls and then records the events
+type recordingHandler struct {
+	mu       sync.Mutex
+	failures int
+	panics   bool
+	phase    Phase
+	wait     time.Duration
+	block    chan struct{}
+	calls    int
+	handled  []DomainEvent
+}
+
+func (h *recordingHandler) Handle(_ context.Context, event DomainEvent) error {
+	if h.block != nil {
+		<-h.block
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.calls++
+	if h.calls <= h.failures {
+		if h.panics {
+			panic("boom")
+		}
+		return errors.New("handler failed")
+	}
+	h.handled = append(h.handled, event)
+	return nil
+}
+
+func (h *recordingHandler) Options() HandlerOptions {
+	return HandlerOptions{Phase: h.phase, EnqueueTimeout: h.wait}
+}
+
+func (h *recordingHandler) events() []DomainEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]DomainEvent(nil), h.handled...)
+}
+
+func TestMediatr_Publish_RunsEveryHandlerAndJoinsErrors(t *testing.T) {
+	m := NewMediatr()
+	failing := &recordingHandler{failures: 1}
+	succeeding := &recordingHandler{}
+	m.Subscribe(failing, newTestEvent(uuid.Nil))
+	m.Subscribe(succeeding, newTestEvent(uuid.Nil))
+
+	err := m.Publish(context.Background(), newTestEvent(uuid.New()))
+
+	assert.ErrorContains(t, err, "handler failed")
+	assert.Len(t, succeeding.events(), 1)
+}
+
+func TestMediatr_PhasesAreSeparate(t *testing.T) {
+	tests := map[string]func(t *testing.T) Mediatr{
+		"sync": func(t *testing.T) Mediatr {
+			return NewMediatr()
+		},
+		"async": func(t *testing.T) Mediatr {
+			return mustCreateAsyncMediatr(t, AsyncMediatrConfig{})
+		},
+	}
+
+	for name, newMediatr := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			m := newMediatr(t)
+			before := &recordingHandler{phase: BeforeCommit, failures: 1}
+			after := &recordingHandler{}
+			m.Subscribe(before, newTestEvent(uuid.Nil))
+			m.Subscribe(after, newTestEvent(uuid.Nil))
+
+			assert.Error(t, m.PublishBeforeCommit(ctx, newTestEvent(uuid.New())))
+			assert.NoError(t, m.Publish(ctx, newTestEvent(uuid.New())))
+			require.NoError(t, m.Close(ctx))
+
+			assert.Empty(t, before.events())
+			assert.Len(t, after.events(), 1)
+		})
+	}
+}
+
+func TestMediatr_BeforeCommitIsNotRetried(t *testing.T) {
+	tests := map[string]func(t *testing.T) Mediatr{
+		"sync": func(t *testing.T) Mediatr {
+			return NewMediatr()
+		},
+		"async": func(t *testing.T) Mediatr {
+			return mustCreateAsyncMediatr(t, AsyncMediatrConfig{Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}})
+		},
+	}
+
+	for name, newMediatr := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			m := newMediatr(t)
+			before := &recordingHandler{phase: BeforeCommit, failures: 1}
+			m.Subscribe(before, newTestEvent(uuid.Nil))
+
+			assert.Error(t, m.PublishBeforeCommit(ctx, newTestEvent(uuid.New())))
+			require.NoError(t, m.Close(ctx))
+
+			assert.Equal(t, 1, before.calls)
+			assert.Empty(t, before.events())
+		})
+	}
+}
+
+func TestAsyncMediatr_Publish_DoesNotWaitForHandlers(t *testing.T) {
+	m := mustCreateAsyncMediatr(t, AsyncMediatrConfig{})
+	slow := &recordingHandler{block: make(chan struct{})}
+	m.Subscribe(slow, newTestEvent(uuid.Nil))
+
+	published := make(chan error)
+	go func() {
+		published <- m.Publish(context.Background(), newTestEvent(uuid.New()))
+	}()
+
+	select {
+	case err := <-published:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("publish waited for the handler")
+	}
+	close(slow.block)
+	require.NoError(t, m.Close(context.Background()))
+	assert.Len(t, slow.events(), 1)
+}
+
+func TestAsyncMediatr_Retries(t *testing.T) {
+	tests := map[string]struct {
+		handler    *recordingHandler
+		wantFailed bool
+	}{
+		"error is retried": {
+			handler: &recordingHandler{failures: 2},
+		},
+		"panic is recovered and retried": {
+			handler: &recordingHandler{failures: 2, panics: true},
+		},
+		"handler gives up after the last attempt": {
+			handler:    &recordingHandler{failures: 3},
+			wantFailed: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var failed []error
+			var mu sync.Mutex
+			m := mustCreateAsyncMediatr(t, AsyncMediatrConfig{
+				Retry: RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+				OnError: func(_ DomainEvent, err error) {
+					mu.Lock()
+					defer mu.Unlock()
+					failed = append(failed, err)
+				},
+			})
+			m.Subscribe(tc.handler, newTestEvent(uuid.Nil))
+
+			require.NoError(t, m.Publish(context.Background(), newTestEvent(uuid.New())))
+			require.NoError(t, m.Close(context.Background()))
+
+			if tc.wantFailed {
+				assert.Len(t, failed, 1)
+				assert.Empty(t, tc.handler.events())
+			} else {
+				assert.Empty(t, failed)
+				assert.Len(t, tc.handler.events(), 1)
+			}
+		})
+	}
+}
+
+func TestAsyncMediatr_Publish_FullQueue(t *testing.T) {
+	m := mustCreateAsyncMediatr(t, AsyncMediatrConfig{Workers: 1, QueueSize: 1})
+	slow := &recordingHandler{block: make(chan struct{})}
+	m.Subscribe(slow, newTestEvent(uuid.Nil))
+	ctx := context.Background()
+
+	// the worker holds one event and the queue another one
+	var err error
+	for i := 0; i < 3 && err == nil; i++ {
+		err = m.Publish(ctx, newTestEvent(uuid.New()))
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.ErrorIs(t, err, ErrQueueFull)
+	close(slow.block)
+	require.NoError(t, m.Close(ctx))
+}
+
+func TestAsyncMediatr_Publish_WaitsForRoom(t *testing.T) {
+	tests := map[string]struct {
+		wait        time.Duration
+		unblockedIn time.Duration
+		wantErr     error
+		wantHandled int
+	}{
+		"room frees up within the wait": {
+			wait:        time.Second,
+			unblockedIn: 20 * time.Millisecond,
+			wantHandled: 3,
+		},
+		"queue stays full for the wait": {
+			wait:        20 * time.Millisecond,
+			unblockedIn: time.Second,
+			wantErr:     ErrQueueFull,
+			wantHandled: 2,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := mustCreateAsyncMediatr(t, AsyncMediatrConfig{Workers: 1, QueueSize: 1})
+			slow := &recordingHandler{wait: tc.wait, block: make(chan struct{})}
+			m.Subscribe(slow, newTestEvent(uuid.Nil))
+			ctx := context.Background()
+
+			// the worker holds the first event and the queue the second one
+			require.NoError(t, m.Publish(ctx, newTestEvent(uuid.New())))
+			time.Sleep(10 * time.Millisecond)
+			require.NoError(t, m.Publish(ctx, newTestEvent(uuid.New())))
+			unblock := time.AfterFunc(tc.unblockedIn, func() { close(slow.block) })
+			defer unblock.Stop()
+
+			started := time.Now()
+			err := m.Publish(ctx, newTestEvent(uuid.New()))
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.GreaterOrEqual(t, time.Since(started), tc.wait)
+				unblock.Stop()
+				close(slow.block)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.NoError(t, m.Close(ctx))
+			assert.Len(t, slow.events(), tc.wantHandled)
+		})
+	}
+}
+
+func TestAsyncMediatr_KeepsOrderOfAnAggregate(t *testing.T) {
+	m := mustCreateAsyncMediatr(t, AsyncMediatrConfig{Workers: 8, QueueSize: 200})
+	handler := &recordingHandler{}
+	m.Subscribe(handler, newTestEvent(uuid.Nil))
+	ctx := context.Background()
+	aggregateID := uuid.New()
+
+	var published []uuid.UUID
+	for i := 0; i < 100; i++ {
+		event := newTestEvent(aggregateID)
+		published = append(published, event.GetID())
+		require.NoError(t, m.Publish(ctx, event))
+		require.NoError(t, m.Publish(ctx, newTestEvent(uuid.New())))
+	}
+	require.NoError(t, m.Close(ctx))
+
+	var handled []uuid.UUID
+	for _, event := range handler.events() {
+		if event.GetAggregateID() == aggregateID {
+			handled = append(handled, event.GetID())
+		}
+	}
+	assert.Equal(t, published, handled)
+}
+
+func TestAsyncMediatr_Close(t *testing.T) {
+	m := mustCreateAsyncMediatr(t, AsyncMediatrConfig{})
+	stuck := &recordingHandler{block: make(chan struct{})}
+	m.Subscribe(stuck, newTestEvent(uuid.Nil))
+	require.NoError(t, m.Publish(context.Background(), newTestEvent(uuid.New())))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, m.Close(ctx), context.DeadlineExceeded)
+	assert.ErrorIs(t, m.Publish(context.Background(), newTestEvent(uuid.New())), ErrMediatrClosed)
+	close(stuck.block)
+}
+
+func mustCreateAsyncMediatr(t *testing.T, config AsyncMediatrConfig) Mediatr {
+	if config.Workers == 0 {
+		config.Workers = 2
+	}
+	if config.QueueSize == 0 {
+		config.QueueSize = 100
+	}
+	if config.Retry.MaxAttempts == 0 {
+		config.Retry.MaxAttempts = 1
+	}
+	m, err := NewAsyncMediatr(config)
+	require.NoError(t, err)
+	return m
+}
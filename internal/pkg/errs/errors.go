@@ -12,6 +12,7 @@ var (
 	ErrDatabase        = errors.New("database error")
 	ErrBusiness        = errors.New("business logic error")
 	ErrConflict        = errors.New("conflict error")
+	ErrUnavailable     = errors.New("service unavailable")
 )
 
 type ValueIsRequiredError struct {
@@ -33,9 +34,10 @@ func NewValueIsRequiredErrorWithCause(paramName string, cause error) *ValueIsReq
 }
 
 func (e *ValueIsRequiredError) Error() string {
-	if e.Cause != nil {
-		return fmt.Sprintf("%s: %s (cause: %v)", ErrValueIsRequired, e.ParamName, e.Cause)
-	}
+	return withCause(e.message(), e.Cause)
+}
+
+func (e *ValueIsRequiredError) message() string {
 	return fmt.Sprintf("%s: %s", ErrValueIsRequired, e.ParamName)
 }
 
@@ -65,15 +67,13 @@ func NewNotFoundErrorWithCause(resource, id string, cause error) *NotFoundError
 }
 
 func (e *NotFoundError) Error() string {
+	return withCause(e.message(), e.Cause)
+}
+
+func (e *NotFoundError) message() string {
 	if e.ID != "" {
-		if e.Cause != nil {
-			return fmt.Sprintf("%s: %s with id '%s' (cause: %v)", ErrNotFound, e.Resource, e.ID, e.Cause)
-		}
 		return fmt.Sprintf("%s: %s with id '%s'", ErrNotFound, e.Resource, e.ID)
 	}
-	if e.Cause != nil {
-		return fmt.Sprintf("%s: %s (cause: %v)", ErrNotFound, e.Resource, e.Cause)
-	}
 	return fmt.Sprintf("%s: %s", ErrNotFound, e.Resource)
 }
 
@@ -112,15 +112,13 @@ func NewValidationErrorWithCause(field, message string, cause error) *Validation
 }
 
 func (e *ValidationError) Error() string {
+	return withCause(e.message(), e.Cause)
+}
+
+func (e *ValidationError) message() string {
 	if e.Value != nil {
-		if e.Cause != nil {
-			return fmt.Sprintf("%s: field '%s' with value '%v': %s (cause: %v)", ErrValidation, e.Field, e.Value, e.Message, e.Cause)
-		}
 		return fmt.Sprintf("%s: field '%s' with value '%v': %s", ErrValidation, e.Field, e.Value, e.Message)
 	}
-	if e.Cause != nil {
-		return fmt.Sprintf("%s: field '%s': %s (cause: %v)", ErrValidation, e.Field, e.Message, e.Cause)
-	}
 	return fmt.Sprintf("%s: field '%s': %s", ErrValidation, e.Field, e.Message)
 }
 
@@ -143,10 +141,14 @@ func NewDatabaseError(operation, entity string, cause error) *DatabaseError {
 }
 
 func (e *DatabaseError) Error() string {
+	return fmt.Sprintf("%s (cause: %v)", e.message(), e.Cause)
+}
+
+func (e *DatabaseError) message() string {
 	if e.Entity != "" {
-		return fmt.Sprintf("%s: failed to %s %s (cause: %v)", ErrDatabase, e.Operation, e.Entity, e.Cause)
+		return fmt.Sprintf("%s: failed to %s %s", ErrDatabase, e.Operation, e.Entity)
 	}
-	return fmt.Sprintf("%s: failed to %s (cause: %v)", ErrDatabase, e.Operation, e.Cause)
+	return fmt.Sprintf("%s: failed to %s", ErrDatabase, e.Operation)
 }
 
 func (e *DatabaseError) Unwrap() error {
@@ -175,9 +177,10 @@ func NewBusinessErrorWithCause(operation, reason string, cause error) *BusinessE
 }
 
 func (e *BusinessError) Error() string {
-	if e.Cause != nil {
-		return fmt.Sprintf("%s: %s - %s (cause: %v)", ErrBusiness, e.Operation, e.Reason, e.Cause)
-	}
+	return withCause(e.message(), e.Cause)
+}
+
+func (e *BusinessError) message() string {
 	return fmt.Sprintf("%s: %s - %s", ErrBusiness, e.Operation, e.Reason)
 }
 
@@ -210,15 +213,13 @@ func NewConflictErrorWithCause(resource, id, reason string, cause error) *Confli
 }
 
 func (e *ConflictError) Error() string {
+	return withCause(e.message(), e.Cause)
+}
+
+func (e *ConflictError) message() string {
 	if e.ID != "" {
-		if e.Cause != nil {
-			return fmt.Sprintf("%s: %s with id '%s' - %s (cause: %v)", ErrConflict, e.Resource, e.ID, e.Reason, e.Cause)
-		}
 		return fmt.Sprintf("%s: %s with id '%s' - %s", ErrConflict, e.Resource, e.ID, e.Reason)
 	}
-	if e.Cause != nil {
-		return fmt.Sprintf("%s: %s - %s (cause: %v)", ErrConflict, e.Resource, e.Reason, e.Cause)
-	}
 	return fmt.Sprintf("%s: %s - %s", ErrConflict, e.Resource, e.Reason)
 }
 
@@ -226,6 +227,51 @@ func (e *ConflictError) Unwrap() error {
 	return ErrConflict
 }
 
+// UnavailableError is a failure of a service the operation depends on, e.g. a call that timed out,
+// the operation may succeed when retried later
+type UnavailableError struct {
+	Service   string
+	Operation string
+	Cause     error
+}
+
+func NewUnavailableError(service, operation string, cause error) *UnavailableError {
+	return &UnavailableError{
+		Service:   service,
+		Operation: operation,
+		Cause:     cause,
+	}
+}
+
+func (e *UnavailableError) Error() string {
+	return withCause(e.message(), e.Cause)
+}
+
+func (e *UnavailableError) message() string {
+	return fmt.Sprintf("%s: %s failed to %s", ErrUnavailable, e.Service, e.Operation)
+}
+
+func (e *UnavailableError) Unwrap() error {
+	return ErrUnavailable
+}
+
+func withCause(message string, cause error) string {
+	if cause == nil {
+		return message
+	}
+	return fmt.Sprintf("%s (cause: %v)", message, cause)
+}
+
+// Message is the message of the error of this package err is, without the causes it was made from,
+// e.g. to show a client. Any other error gives its whole text.
+func Message(err error) string {
+	var described interface{ message() string }
+	if errors.As(err, &described) {
+		return described.message()
+	}
+	return err.Error()
+}
+
 func IsValueRequired(err error) bool {
 	return errors.Is(err, ErrValueIsRequired)
 }
@@ -249,3 +295,46 @@ func IsBusiness(err error) bool {
 func IsConflict(err error) bool {
 	return errors.Is(err, ErrConflict)
 }
+
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrUnavailable)
+}
+
+// HasCause tells whether target is err or an error it was made from, following the Cause of the errors
+// of this package. The Is predicates above only see the kind of the error itself.
+func HasCause(err error, target error) bool {
+	for err != nil {
+		if errors.Is(err, target) {
+			return true
+		}
+		err = causeOf(err)
+	}
+	return false
+}
+
+func causeOf(err error) error {
+	var required *ValueIsRequiredError
+	var notFound *NotFoundError
+	var validation *ValidationError
+	var database *DatabaseError
+	var business *BusinessError
+	var conflict *ConflictError
+	var unavailable *UnavailableError
+	switch {
+	case errors.As(err, &required):
+		return required.Cause
+	case errors.As(err, &notFound):
+		return notFound.Cause
+	case errors.As(err, &validation):
+		return validation.Cause
+	case errors.As(err, &database):
+		return database.Cause
+	case errors.As(err, &business):
+		return business.Cause
+	case errors.As(err, &conflict):
+		return conflict.Cause
+	case errors.As(err, &unavailable):
+		return unavailable.Cause
+	}
+	return nil
+}
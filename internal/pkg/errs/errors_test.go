@@ -0,0 +1,86 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasCause(t *testing.T) {
+	sentinel := errors.New("sentinel")
+	notFound := NewNotFoundError("order", "42")
+
+	tests := map[string]struct {
+		err        error
+		target     error
+		want       bool
+		wantIsKind bool
+	}{
+		"the kind of the error itself": {
+			err:        notFound,
+			target:     ErrNotFound,
+			want:       true,
+			wantIsKind: true,
+		},
+		"a kind wrapped in another kind is only a cause": {
+			err:    NewDatabaseError("get", "order", notFound),
+			target: ErrNotFound,
+			want:   true,
+		},
+		"a sentinel cause several errors deep": {
+			err:    NewBusinessErrorWithCause("dispatch", "failed", NewValidationErrorWithCause("couriers", "none", sentinel)),
+			target: sentinel,
+			want:   true,
+		},
+		"an errs error wrapped by fmt": {
+			err:    fmt.Errorf("offset 3: %w", NewConflictErrorWithCause("order", "42", "exists", sentinel)),
+			target: sentinel,
+			want:   true,
+		},
+		"no such cause": {
+			err:    NewDatabaseError("get", "order", sentinel),
+			target: ErrNotFound,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, HasCause(tc.err, tc.target))
+			assert.Equal(t, tc.wantIsKind, errors.Is(tc.err, tc.target))
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	cause := errors.New("rpc error: code = Unavailable desc = connection refused")
+
+	tests := map[string]struct {
+		err  error
+		want string
+	}{
+		"error without a cause": {
+			err:  NewBusinessError("assign order", "order is taken"),
+			want: "business logic error: assign order - order is taken",
+		},
+		"cause is left out": {
+			err:  NewBusinessErrorWithCause("assign order", "order is taken", cause),
+			want: "business logic error: assign order - order is taken",
+		},
+		"errs error wrapped by fmt": {
+			err:  fmt.Errorf("offset 3: %w", NewUnavailableError("geo service", "get location", cause)),
+			want: "service unavailable: geo service failed to get location",
+		},
+		"any other error": {
+			err:  cause,
+			want: cause.Error(),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, Message(tc.err))
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+type ComponentReport struct {
+	Name      string  `json:"name"`
+	Status    Status  `json:"status"`
+	LatencyMs float64 `json:"latencyMs"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status     Status            `json:"status"`
+	Components []ComponentReport `json:"components"`
+}
+
+func (r Report) IsUp() bool {
+	return r.Status == StatusUp
+}
+
+type Service interface {
+	AddLivenessCheck(name string, checker Checker)
+	AddReadinessCheck(name string, checker Checker)
+	Live(ctx context.Context) Report
+	Ready(ctx context.Context) Report
+}
+
+type service struct {
+	mu        sync.RWMutex
+	timeout   time.Duration
+	liveness  map[string]Checker
+	readiness map[string]Checker
+}
+
+func NewService(timeout time.Duration) Service {
+	return &service{
+		timeout:   timeout,
+		liveness:  make(map[string]Checker),
+		readiness: make(map[string]Checker),
+	}
+}
+
+func (s *service) AddLivenessCheck(name string, checker Checker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.liveness[name] = checker
+}
+
+func (s *service) AddReadinessCheck(name string, checker Checker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.readiness[name] = checker
+}
+
+func (s *service) Live(ctx context.Context) Report {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.run(ctx, s.liveness)
+}
+
+func (s *service) Ready(ctx context.Context) Report {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.run(ctx, s.readiness)
+}
+
+// run executes all checkers concurrently, each one bounded by the service timeout
+func (s *service) run(ctx context.Context, checkers map[string]Checker) Report {
+	components := make([]ComponentReport, 0, len(checkers))
+	results := make(chan ComponentReport, len(checkers))
+
+	var wg sync.WaitGroup
+	for name, checker := range checkers {
+		wg.Add(1)
+		go func(name string, checker Checker) {
+			defer wg.Done()
+			results <- s.check(ctx, name, checker)
+		}(name, checker)
+	}
+	wg.Wait()
+	close(results)
+
+	status := StatusUp
+	for component := range results {
+		if component.Status != StatusUp {
+			status = StatusDown
+		}
+		components = append(components, component)
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].Name < components[j].Name
+	})
+
+	return Report{
+		Status:     status,
+		Components: components,
+	}
+}
+
+func (s *service) check(ctx context.Context, name string, checker Checker) ComponentReport {
+	checkCtx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	started := time.Now()
+	err := checker.Check(checkCtx)
+	latency := time.Since(started)
+
+	report := ComponentReport{
+		Name:      name,
+		Status:    StatusUp,
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+	}
+	if err != nil {
+		report.Status = StatusDown
+		report.Error = err.Error()
+	}
+
+	return report
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestService_Ready(t *testing.T) {
+	tests := map[string]struct {
+		checkers map[string]Checker
+		status   Status
+		failed   []string
+	}{
+		"all components are up": {
+			checkers: map[string]Checker{
+				"postgres": CheckerFunc(func(ctx context.Context) error { return nil }),
+				"kafka":    CheckerFunc(func(ctx context.Context) error { return nil }),
+			},
+			status: StatusUp,
+		},
+		"one component is down": {
+			checkers: map[string]Checker{
+				"postgres": CheckerFunc(func(ctx context.Context) error { return errors.New("connection refused") }),
+				"kafka":    CheckerFunc(func(ctx context.Context) error { return nil }),
+			},
+			status: StatusDown,
+			failed: []string{"postgres"},
+		},
+		"component exceeds timeout": {
+			checkers: map[string]Checker{
+				"geo": CheckerFunc(func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}),
+			},
+			status: StatusDown,
+			failed: []string{"geo"},
+		},
+		"no components": {
+			checkers: map[string]Checker{},
+			status:   StatusUp,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			service := NewService(10 * time.Millisecond)
+			for name, checker := range tc.checkers {
+				service.AddReadinessCheck(name, checker)
+			}
+
+			report := service.Ready(context.Background())
+
+			assert.Equal(t, tc.status, report.Status)
+			assert.Len(t, report.Components, len(tc.checkers))
+			var failed []string
+			for _, component := range report.Components {
+				if component.Status == StatusDown {
+					assert.NotEmpty(t, component.Error)
+					failed = append(failed, component.Name)
+				}
+			}
+			assert.Equal(t, tc.failed, failed)
+		})
+	}
+}
+
+func TestService_LiveIgnoresReadinessChecks(t *testing.T) {
+	service := NewService(time.Second)
+	service.AddReadinessCheck("postgres", CheckerFunc(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}))
+	service.AddLivenessCheck("jobs", CheckerFunc(func(ctx context.Context) error { return nil }))
+
+	live := service.Live(context.Background())
+	ready := service.Ready(context.Background())
+
+	assert.True(t, live.IsUp())
+	assert.False(t, ready.IsUp())
+}
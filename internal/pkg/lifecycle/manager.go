@@ -0,0 +1,158 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+type StopFunc func(ctx context.Context) error
+
+type hook struct {
+	name string
+	stop StopFunc
+	// close is set instead of stop for a component closed without a context
+	close func() error
+}
+
+// Manager waits for a termination signal and then stops the registered components
+// one by one in registration order, all within a single shutdown timeout. The components
+// closed without a context are closed even once the timeout is exceeded, each within the close grace.
+type Manager struct {
+	timeout    time.Duration
+	closeGrace time.Duration
+	signals    []os.Signal
+
+	mu    sync.Mutex
+	hooks []hook
+
+	failOnce sync.Once
+	failed   chan error
+}
+
+func NewManager(timeout time.Duration, closeGrace time.Duration) *Manager {
+	return &Manager{
+		timeout:    timeout,
+		closeGrace: closeGrace,
+		signals:    []os.Signal{syscall.SIGINT, syscall.SIGTERM},
+		failed:     make(chan error, 1),
+	}
+}
+
+func (m *Manager) OnStop(name string, stop StopFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.hooks = append(m.hooks, hook{name: name, stop: stop})
+}
+
+// OnClose registers a component that can only be closed without a context, such as a connection pool;
+// it is closed best effort even after the shutdown timeout, so what it holds is released. A close
+// that does not return within the close grace is abandoned.
+func (m *Manager) OnClose(name string, closeFn func() error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.hooks = append(m.hooks, hook{name: name, close: closeFn})
+}
+
+// Go runs a blocking component; its unexpected failure triggers the shutdown
+func (m *Manager) Go(name string, run func() error) {
+	go func() {
+		if err := run(); err != nil {
+			m.Fail(fmt.Errorf("%s: %w", name, err))
+		}
+	}()
+}
+
+func (m *Manager) Fail(err error) {
+	m.failOnce.Do(func() {
+		m.failed <- err
+	})
+}
+
+// Run blocks until a termination signal, a component failure or ctx cancellation and then shuts down
+func (m *Manager) Run(ctx context.Context) error {
+	ctx, stop := signal.NotifyContext(ctx, m.signals...)
+	defer stop()
+
+	var cause error
+	select {
+	case <-ctx.Done():
+		log.Printf("Shutdown signal received")
+	case cause = <-m.failed:
+		log.Printf("Component failed, shutting down: %v", cause)
+	}
+
+	return errors.Join(cause, m.Shutdown())
+}
+
+func (m *Manager) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	m.mu.Lock()
+	hooks := make([]hook, len(m.hooks))
+	copy(hooks, m.hooks)
+	m.mu.Unlock()
+
+	var errs []error
+	for _, h := range hooks {
+		if err := m.runHook(ctx, h); err != nil {
+			log.Printf("Failed to stop %s: %v", h.name, err)
+			errs = append(errs, fmt.Errorf("stop %s: %w", h.name, err))
+			continue
+		}
+		log.Printf("Stopped %s", h.name)
+	}
+
+	if err := ctx.Err(); err != nil && !errors.Is(errors.Join(errs...), err) {
+		errs = append(errs, fmt.Errorf("shutdown: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func (m *Manager) runHook(ctx context.Context, h hook) error {
+	stop := h.stop
+	if h.close != nil {
+		stop = func(_ context.Context) error {
+			return h.close()
+		}
+		if ctx.Err() != nil {
+			// past the timeout the component is still closed, best effort, to release what it holds
+			graceCtx, cancel := context.WithTimeout(context.Background(), m.closeGrace)
+			defer cancel()
+			err := wait(graceCtx, stop)
+			if err != nil && errors.Is(err, graceCtx.Err()) {
+				return fmt.Errorf("abandoned after the close grace of %s: %w", m.closeGrace, err)
+			}
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return wait(ctx, stop)
+}
+
+// wait returns once stop does or ctx is done, a stop that outlives ctx is left running
+func wait(ctx context.Context, stop StopFunc) error {
+	resultCh := make(chan error, 1)
+	go func() {
+		resultCh <- stop(ctx)
+		close(resultCh)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-resultCh:
+		return err
+	}
+}
@@ -0,0 +1,154 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManager_Shutdown(t *testing.T) {
+	errClose := errors.New("close failed")
+
+	tests := map[string]struct {
+		hooks func(stopped *[]string) map[string]StopFunc
+		order []string
+		// closers are registered with OnClose
+		closers []string
+		stopped []string
+		wantErr []error
+	}{
+		"stops components in registration order": {
+			hooks: func(stopped *[]string) map[string]StopFunc {
+				return map[string]StopFunc{
+					"cron":  record(stopped, "cron", nil),
+					"kafka": record(stopped, "kafka", nil),
+					"db":    record(stopped, "db", nil),
+				}
+			},
+			order:   []string{"cron", "kafka", "db"},
+			stopped: []string{"cron", "kafka", "db"},
+		},
+		"continues after a failed component": {
+			hooks: func(stopped *[]string) map[string]StopFunc {
+				return map[string]StopFunc{
+					"kafka": record(stopped, "kafka", errClose),
+					"db":    record(stopped, "db", nil),
+				}
+			},
+			order:   []string{"kafka", "db"},
+			stopped: []string{"kafka", "db"},
+			wantErr: []error{errClose},
+		},
+		"skips the rest once the timeout is exceeded": {
+			hooks: func(stopped *[]string) map[string]StopFunc {
+				return map[string]StopFunc{
+					"http": func(ctx context.Context) error {
+						<-ctx.Done()
+						return ctx.Err()
+					},
+					"db": record(stopped, "db", nil),
+				}
+			},
+			order:   []string{"http", "db"},
+			stopped: nil,
+			wantErr: []error{context.DeadlineExceeded},
+		},
+		"closes the components without a context after the timeout": {
+			hooks: func(stopped *[]string) map[string]StopFunc {
+				return map[string]StopFunc{
+					"http": func(ctx context.Context) error {
+						<-ctx.Done()
+						return ctx.Err()
+					},
+					"kafka":    record(stopped, "kafka", nil),
+					"producer": record(stopped, "producer", errClose),
+					"db":       record(stopped, "db", nil),
+				}
+			},
+			order:   []string{"http", "kafka", "producer", "db"},
+			closers: []string{"producer", "db"},
+			stopped: []string{"producer", "db"},
+			wantErr: []error{context.DeadlineExceeded, errClose},
+		},
+		"abandons a close that does not return within the grace": {
+			hooks: func(stopped *[]string) map[string]StopFunc {
+				return map[string]StopFunc{
+					"http": func(ctx context.Context) error {
+						<-ctx.Done()
+						return ctx.Err()
+					},
+					"kafka": func(_ context.Context) error {
+						// e.g. a consumer waiting for a handler stuck on a call
+						<-make(chan struct{})
+						return nil
+					},
+					"db": record(stopped, "db", nil),
+				}
+			},
+			order:   []string{"http", "kafka", "db"},
+			closers: []string{"kafka", "db"},
+			stopped: []string{"db"},
+			wantErr: []error{context.DeadlineExceeded},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var stopped []string
+			hooks := tc.hooks(&stopped)
+
+			manager := NewManager(20*time.Millisecond, 20*time.Millisecond)
+			for _, name := range tc.order {
+				if slices.Contains(tc.closers, name) {
+					manager.OnClose(name, func() error {
+						return hooks[name](context.Background())
+					})
+					continue
+				}
+				manager.OnStop(name, hooks[name])
+			}
+
+			started := time.Now()
+			err := manager.Shutdown()
+
+			assert.Less(t, time.Since(started), time.Second, "shutdown must not wait for a hanging close")
+			assert.Equal(t, tc.stopped, stopped)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			}
+			for _, wantErr := range tc.wantErr {
+				assert.ErrorIs(t, err, wantErr)
+			}
+		})
+	}
+}
+
+func TestManager_RunStopsOnComponentFailure(t *testing.T) {
+	errServer := errors.New("address already in use")
+	stopped := false
+
+	manager := NewManager(time.Second, time.Second)
+	manager.OnClose("db", func() error {
+		stopped = true
+		return nil
+	})
+	manager.Go("http server", func() error {
+		return errServer
+	})
+
+	err := manager.Run(context.Background())
+
+	assert.ErrorIs(t, err, errServer)
+	assert.True(t, stopped)
+}
+
+func record(stopped *[]string, name string, err error) StopFunc {
+	return func(_ context.Context) error {
+		*stopped = append(*stopped, name)
+		return err
+	}
+}